@@ -0,0 +1,86 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"incident-report/utils"
+	"net/http"
+)
+
+// Health is the response of the health check
+type Health struct {
+	Status  string `json:"status"`
+	Message string `json:"message"`
+}
+
+// Health checks that the server is up
+func (c *Client) Health(ctx context.Context, opts ...CallOption) (*Health, error) {
+	var body []byte
+	if err := c.do(ctx, http.MethodGet, "/health", nil, nil, &body, opts); err != nil {
+		return nil, err
+	}
+
+	// The health check is not wrapped in the success envelope
+	var health Health
+	if err := json.Unmarshal(body, &health); err != nil {
+		return nil, fmt.Errorf("decode response: %w", err)
+	}
+	return &health, nil
+}
+
+// Trash returns a page of the deleted records
+// Iterate over the whole trash with All and a closure that sets query.PaginationQuery.
+func (c *Client) Trash(ctx context.Context, query utils.TrashQuery, opts ...CallOption) (*Page[utils.TrashItemResponse], error) {
+	return list[utils.TrashItemResponse](ctx, c, "/trash", opts, query)
+}
+
+// Audit returns a page of the audit log, newest first
+func (c *Client) Audit(ctx context.Context, query utils.AuditQuery, opts ...CallOption) (*Page[utils.AuditLogResponse], error) {
+	return list[utils.AuditLogResponse](ctx, c, "/audit", opts, query)
+}
+
+// AnalyticsService calls the /analytics endpoints
+type AnalyticsService struct {
+	client *Client
+}
+
+// Volume counts the reports opened and closed per day or week
+func (s *AnalyticsService) Volume(ctx context.Context, query utils.VolumeQuery, opts ...CallOption) ([]utils.VolumeBucket, error) {
+	return getSlice[utils.VolumeBucket](ctx, s.client, "/analytics/volume", opts, query)
+}
+
+// ResolutionTime returns the time taken to resolve reports
+func (s *AnalyticsService) ResolutionTime(ctx context.Context, query utils.AnalyticsQuery, opts ...CallOption) (*utils.ResolutionTimeResponse, error) {
+	return get[utils.ResolutionTimeResponse](ctx, s.client, "/analytics/resolution-time", opts, query)
+}
+
+// TopComponents ranks the components by their number of reports
+func (s *AnalyticsService) TopComponents(ctx context.Context, query utils.TopNQuery, opts ...CallOption) ([]utils.ComponentFailureCount, error) {
+	return getSlice[utils.ComponentFailureCount](ctx, s.client, "/analytics/top-components", opts, query)
+}
+
+// TopCategories ranks the component categories by their number of reports
+func (s *AnalyticsService) TopCategories(ctx context.Context, query utils.TopNQuery, opts ...CallOption) ([]utils.CategoryFailureCount, error) {
+	return getSlice[utils.CategoryFailureCount](ctx, s.client, "/analytics/top-categories", opts, query)
+}
+
+// BuildingHotspots ranks the buildings by their number of reports
+func (s *AnalyticsService) BuildingHotspots(ctx context.Context, query utils.TopNQuery, opts ...CallOption) ([]utils.BuildingHotspot, error) {
+	return getSlice[utils.BuildingHotspot](ctx, s.client, "/analytics/hotspots/buildings", opts, query)
+}
+
+// FloorHotspots ranks the floors by their number of reports
+func (s *AnalyticsService) FloorHotspots(ctx context.Context, query utils.TopNQuery, opts ...CallOption) ([]utils.FloorHotspot, error) {
+	return getSlice[utils.FloorHotspot](ctx, s.client, "/analytics/hotspots/floors", opts, query)
+}
+
+// Technicians returns the throughput of every technician
+func (s *AnalyticsService) Technicians(ctx context.Context, query utils.AnalyticsQuery, opts ...CallOption) ([]utils.TechnicianThroughput, error) {
+	return getSlice[utils.TechnicianThroughput](ctx, s.client, "/analytics/technicians", opts, query)
+}
+
+// Tags counts the reports of every tag
+func (s *AnalyticsService) Tags(ctx context.Context, query utils.AnalyticsQuery, opts ...CallOption) ([]utils.TagCount, error) {
+	return getSlice[utils.TagCount](ctx, s.client, "/analytics/tags", opts, query)
+}
@@ -0,0 +1,356 @@
+// Package client is a Go client of the incident report REST API
+// Its methods take and return the DTOs of the utils package, so other Go services share the
+// request validation rules and response shapes with the server.
+package client
+
+import (
+	"bytes"
+	"context"
+	cryptorand "crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"incident-report/utils"
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// APIPrefix is the path of the API below the base URL
+const APIPrefix = "/api/v1"
+
+// TokenSource returns the bearer token of a request
+// It is called for every attempt, so a source may refresh an expiring token.
+type TokenSource func(ctx context.Context) (string, error)
+
+// RetryPolicy controls how failed requests are retried
+// A request is retried after a network error, 429 or 502-504, waiting BaseDelay doubled for every
+// attempt, with jitter, up to MaxDelay. A Retry-After header sent by the server takes precedence.
+// POSTs are only retried with an Idempotency-Key, which the client generates when retries are on.
+// The server scopes the key to the user ID, or for an anonymous caller to the request itself, so
+// a retry is replayed whether or not the client sends WithUserID. A bearer token does not identify
+// the caller to the server and plays no part in this.
+type RetryPolicy struct {
+	Retries   int
+	BaseDelay time.Duration
+	MaxDelay  time.Duration
+}
+
+// DefaultRetryPolicy retries a request up to three times within a few seconds
+var DefaultRetryPolicy = RetryPolicy{Retries: 3, BaseDelay: 100 * time.Millisecond, MaxDelay: 2 * time.Second}
+
+// Client calls the API of one server
+// The endpoints are grouped by resource, e.g. client.Reports.Create. It is safe for concurrent use.
+type Client struct {
+	Users      *TrashedResource[utils.CreateUserRequest, utils.UpdateUserRequest, utils.UserResponse]
+	Buildings  *BuildingsService
+	Floors     *FloorsService
+	Rooms      *RoomsService
+	Categories *CategoriesService
+	Components *ComponentsService
+	Reports    *ReportsService
+	Tags       *Resource[utils.CreateTagRequest, utils.UpdateTagRequest, utils.TagResponse]
+	Analytics  *AnalyticsService
+
+	baseURL    string
+	httpClient *http.Client
+	userID     uint
+	token      TokenSource
+	retry      RetryPolicy
+	userAgent  string
+}
+
+// Option configures a Client
+type Option func(*Client)
+
+// WithHTTPClient sends the requests through the given HTTP client instead of http.DefaultClient
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(c *Client) {
+		c.httpClient = httpClient
+	}
+}
+
+// WithUserID identifies the caller with X-User-ID, as the API expects
+func WithUserID(userID uint) Option {
+	return func(c *Client) {
+		c.userID = userID
+	}
+}
+
+// WithToken sends a fixed bearer token, for servers behind a gateway that authenticates callers
+func WithToken(token string) Option {
+	return WithTokenSource(func(context.Context) (string, error) {
+		return token, nil
+	})
+}
+
+// WithTokenSource sends the bearer token returned by source with every request
+func WithTokenSource(source TokenSource) Option {
+	return func(c *Client) {
+		c.token = source
+	}
+}
+
+// WithRetry replaces DefaultRetryPolicy; a policy without retries sends every request once
+func WithRetry(policy RetryPolicy) Option {
+	return func(c *Client) {
+		c.retry = policy
+	}
+}
+
+// WithUserAgent sets the User-Agent header of the requests
+func WithUserAgent(userAgent string) Option {
+	return func(c *Client) {
+		c.userAgent = userAgent
+	}
+}
+
+// New returns a client of the server at baseURL, e.g. "http://localhost:8080"
+func New(baseURL string, opts ...Option) (*Client, error) {
+	parsed, err := url.Parse(baseURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid base URL: %w", err)
+	}
+	if parsed.Scheme != "http" && parsed.Scheme != "https" {
+		return nil, fmt.Errorf("invalid base URL %q: the scheme must be http or https", baseURL)
+	}
+
+	c := &Client{
+		baseURL:    strings.TrimSuffix(parsed.String(), "/"),
+		httpClient: http.DefaultClient,
+		retry:      DefaultRetryPolicy,
+		userAgent:  "incident-report-client",
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	c.Users = &TrashedResource[utils.CreateUserRequest, utils.UpdateUserRequest, utils.UserResponse]{resource[utils.CreateUserRequest, utils.UpdateUserRequest, utils.UserResponse](c, "/users")}
+	c.Buildings = &BuildingsService{location[utils.CreateBuildingRequest, utils.UpdateBuildingRequest, utils.BuildingResponse](c, "/buildings")}
+	c.Floors = &FloorsService{location[utils.CreateFloorRequest, utils.UpdateFloorRequest, utils.FloorResponse](c, "/floors")}
+	c.Rooms = &RoomsService{location[utils.CreateRoomRequest, utils.UpdateRoomRequest, utils.RoomResponse](c, "/rooms")}
+	c.Categories = &CategoriesService{location[utils.CreateComponentCategoryRequest, utils.UpdateComponentCategoryRequest, utils.ComponentCategoryResponse](c, "/component-categories")}
+	c.Components = &ComponentsService{TrashedResource[utils.CreateComponentRequest, utils.UpdateComponentRequest, utils.ComponentResponse]{resource[utils.CreateComponentRequest, utils.UpdateComponentRequest, utils.ComponentResponse](c, "/components")}}
+	c.Reports = &ReportsService{TrashedResource[utils.CreateReportRequest, utils.UpdateReportRequest, utils.ReportResponse]{resource[utils.CreateReportRequest, utils.UpdateReportRequest, utils.ReportResponse](c, "/reports")}}
+	c.Tags = &Resource[utils.CreateTagRequest, utils.UpdateTagRequest, utils.TagResponse]{client: c, path: "/tags"}
+	c.Analytics = &AnalyticsService{client: c}
+	return c, nil
+}
+
+// CallOption sets a header of a single call
+type CallOption func(*call)
+
+// call holds the per-call headers
+type call struct {
+	userID         uint
+	ifMatch        string
+	idempotencyKey string
+	requestID      string
+	contentType    string
+}
+
+// IfMatch makes a write fail with ErrStale unless the record is still at the given version
+func IfMatch(version uint) CallOption {
+	return func(c *call) {
+		c.ifMatch = utils.ETag(version)
+	}
+}
+
+// IdempotencyKey runs a POST only once per key; the response to the first call is replayed
+func IdempotencyKey(key string) CallOption {
+	return func(c *call) {
+		c.idempotencyKey = key
+	}
+}
+
+// AsUser makes a single call on behalf of another user
+func AsUser(userID uint) CallOption {
+	return func(c *call) {
+		c.userID = userID
+	}
+}
+
+// WithRequestID sends the ID of the request, to follow it across services
+func WithRequestID(id string) CallOption {
+	return func(c *call) {
+		c.requestID = id
+	}
+}
+
+// mergePatch sends the body as a JSON Merge Patch
+func mergePatch(c *call) {
+	c.contentType = utils.MergePatchContentType
+}
+
+// do sends a request to a path below APIPrefix and decodes the data of the response into out
+// The body is marshalled to JSON. out may be nil to ignore the data, or a *[]byte to read a file
+// export as is. An error response is returned as an *Error.
+func (c *Client) do(ctx context.Context, method, path string, query url.Values, body any, out any, opts []CallOption) error {
+	settings := call{userID: c.userID, contentType: "application/json"}
+	for _, opt := range opts {
+		opt(&settings)
+	}
+
+	var payload []byte
+	if body != nil {
+		var err error
+		if payload, err = json.Marshal(body); err != nil {
+			return fmt.Errorf("encode request: %w", err)
+		}
+	}
+
+	if method == http.MethodPost && settings.idempotencyKey == "" && c.retry.Retries > 0 {
+		settings.idempotencyKey = newKey()
+	}
+	retryable := method != http.MethodPost || settings.idempotencyKey != ""
+
+	for attempt := 0; ; attempt++ {
+		resp, err := c.sendOnce(ctx, method, path, query, payload, &settings)
+		if retryable && attempt < c.retry.Retries && shouldRetry(resp, err) && ctx.Err() == nil {
+			delay := c.retry.delay(attempt, resp)
+			if resp != nil {
+				io.Copy(io.Discard, resp.Body)
+				resp.Body.Close()
+			}
+			select {
+			case <-time.After(delay):
+				continue
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+		if err != nil {
+			return err
+		}
+
+		defer resp.Body.Close()
+		return decodeResponse(resp, out)
+	}
+}
+
+// sendOnce makes a single attempt of a request
+func (c *Client) sendOnce(ctx context.Context, method, path string, query url.Values, payload []byte, settings *call) (*http.Response, error) {
+	target := c.baseURL + APIPrefix + path
+	if len(query) > 0 {
+		target += "?" + query.Encode()
+	}
+
+	var body io.Reader
+	if payload != nil {
+		body = bytes.NewReader(payload)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, target, body)
+	if err != nil {
+		return nil, fmt.Errorf("build request: %w", err)
+	}
+
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("User-Agent", c.userAgent)
+	if payload != nil {
+		req.Header.Set("Content-Type", settings.contentType)
+	}
+	if settings.userID != 0 {
+		req.Header.Set("X-User-ID", strconv.FormatUint(uint64(settings.userID), 10))
+	}
+	if settings.ifMatch != "" {
+		req.Header.Set("If-Match", settings.ifMatch)
+	}
+	if settings.idempotencyKey != "" {
+		req.Header.Set("Idempotency-Key", settings.idempotencyKey)
+	}
+	if settings.requestID != "" {
+		req.Header.Set("X-Request-ID", settings.requestID)
+	}
+	if c.token != nil {
+		token, err := c.token(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("get token: %w", err)
+		}
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	return c.httpClient.Do(req)
+}
+
+// decodeResponse returns the error of an error response or decodes the data of a success response
+func decodeResponse(resp *http.Response, out any) error {
+	if resp.StatusCode >= http.StatusBadRequest {
+		return newError(resp)
+	}
+	if out == nil || resp.StatusCode == http.StatusNoContent {
+		return nil
+	}
+
+	if raw, ok := out.(*[]byte); ok {
+		data, err := io.ReadAll(resp.Body)
+		if err != nil {
+			return fmt.Errorf("read response: %w", err)
+		}
+		*raw = data
+		return nil
+	}
+
+	var envelope struct {
+		Data json.RawMessage `json:"data"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&envelope); err != nil {
+		return fmt.Errorf("decode response: %w", err)
+	}
+	if len(envelope.Data) == 0 {
+		return nil
+	}
+	if err := json.Unmarshal(envelope.Data, out); err != nil {
+		return fmt.Errorf("decode response data: %w", err)
+	}
+	return nil
+}
+
+// shouldRetry reports whether an attempt failed in a way a later attempt may not
+func shouldRetry(resp *http.Response, err error) bool {
+	if err != nil {
+		return true
+	}
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	default:
+		return false
+	}
+}
+
+// delay returns how long to wait before the retry following an attempt
+func (p RetryPolicy) delay(attempt int, resp *http.Response) time.Duration {
+	if resp != nil {
+		if seconds, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil && seconds >= 0 {
+			return time.Duration(seconds) * time.Second
+		}
+	}
+
+	backoff := p.BaseDelay << attempt
+	if p.MaxDelay > 0 && (backoff > p.MaxDelay || backoff <= 0) {
+		backoff = p.MaxDelay
+	}
+	if backoff <= 0 {
+		return 0
+	}
+	// Half of the backoff is fixed and half random, so clients that failed together spread out
+	return backoff/2 + rand.N(backoff/2+1)
+}
+
+// newKey returns a random idempotency key
+func newKey() string {
+	b := make([]byte, 16)
+	if _, err := cryptorand.Read(b); err != nil {
+		return ""
+	}
+	return hex.EncodeToString(b)
+}
+
+// path formats a path below APIPrefix
+func path(format string, args ...any) string {
+	return fmt.Sprintf(format, args...)
+}
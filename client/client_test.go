@@ -0,0 +1,464 @@
+package client_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"incident-report/client"
+	"incident-report/routes"
+	"incident-report/testutil"
+	"incident-report/utils"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// newServer serves the full route table against a freshly migrated database
+// wrap, when set, sits in front of the router to tamper with the traffic.
+func newServer(t *testing.T, wrap func(http.Handler) http.Handler) (*httptest.Server, *gorm.DB) {
+	t.Helper()
+
+	gin.SetMode(gin.TestMode)
+	db := testutil.OpenDB(t)
+	router := gin.New()
+	routes.RegisterRoutes(router, db)
+
+	var handler http.Handler = router
+	if wrap != nil {
+		handler = wrap(router)
+	}
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+	return server, db
+}
+
+// newClient returns a client of the server that retries quickly
+func newClient(t *testing.T, server *httptest.Server, opts ...client.Option) *client.Client {
+	t.Helper()
+
+	opts = append([]client.Option{
+		client.WithHTTPClient(server.Client()),
+		client.WithRetry(client.RetryPolicy{Retries: 2, BaseDelay: time.Millisecond, MaxDelay: 5 * time.Millisecond}),
+	}, opts...)
+	c, err := client.New(server.URL, opts...)
+	if err != nil {
+		t.Fatalf("new client: %v", err)
+	}
+	return c
+}
+
+// must returns the value of a call, failing the test on its error: must(c.Tags.Get(ctx, id))(t)
+func must[T any](value T, err error) func(t *testing.T) T {
+	return func(t *testing.T) T {
+		t.Helper()
+
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		return value
+	}
+}
+
+// fixture is a location hierarchy with one report
+type fixture struct {
+	user      *utils.UserResponse
+	building  *utils.BuildingResponse
+	room      *utils.RoomResponse
+	component *utils.ComponentResponse
+	report    *utils.ReportResponse
+}
+
+// seed creates a user, a building → floor → room → component chain and a pending report
+func seed(t *testing.T, ctx context.Context, c *client.Client) fixture {
+	t.Helper()
+
+	var f fixture
+	f.user = must(c.Users.Create(ctx, utils.CreateUserRequest{Name: "Tech One", Email: "tech@example.com"}))(t)
+	f.building = must(c.Buildings.Create(ctx, utils.CreateBuildingRequest{Code: "B1", Name: "Main Building"}))(t)
+	floor := must(c.Floors.Create(ctx, utils.CreateFloorRequest{BuildingID: f.building.ID, FloorNumber: 1, Name: "Ground"}))(t)
+	f.room = must(c.Rooms.Create(ctx, utils.CreateRoomRequest{FloorID: floor.ID, Code: "R101", Name: "Lab 101"}))(t)
+	category := must(c.Categories.Create(ctx, utils.CreateComponentCategoryRequest{Code: "PRJ", Name: "Projector"}))(t)
+	f.component = must(c.Components.Create(ctx, utils.CreateComponentRequest{
+		RoomID: &f.room.ID, CategoryID: category.ID, Code: "PRJ-1", Name: "Projector 1", ProcurementYear: 2019,
+	}))(t)
+	f.report = must(c.Reports.Create(ctx, utils.CreateReportRequest{
+		Name: "Broken projector", RoomID: f.room.ID, ComponentID: f.component.ID, Status: "PENDING",
+	}))(t)
+	return f
+}
+
+func TestClientEndpoints(t *testing.T) {
+	ctx := context.Background()
+	server, _ := newServer(t, nil)
+	c := newClient(t, server)
+	f := seed(t, ctx, c)
+
+	t.Run("get", func(t *testing.T) {
+		report := must(c.Reports.Get(ctx, f.report.ID))(t)
+		if report.Name != "Broken projector" || report.Status != "PENDING" {
+			t.Errorf("report = %+v", report)
+		}
+	})
+
+	t.Run("update and patch", func(t *testing.T) {
+		building := must(c.Buildings.Update(ctx, f.building.ID, utils.UpdateBuildingRequest{Name: "Main Hall"}, client.IfMatch(f.building.Version)))(t)
+		if building.Name != "Main Hall" || building.Code != "B1" {
+			t.Errorf("updated building = %+v", building)
+		}
+
+		building = must(c.Buildings.Patch(ctx, f.building.ID, map[string]any{"location": "North campus"}))(t)
+		if building.Location != "North campus" || building.Name != "Main Hall" {
+			t.Errorf("patched building = %+v", building)
+		}
+	})
+
+	t.Run("nested lists and tree", func(t *testing.T) {
+		floors := must(c.Buildings.Floors(ctx, f.building.ID, utils.PaginationQuery{}))(t)
+		if len(floors.Items) != 1 || floors.Total == nil || *floors.Total != 1 {
+			t.Errorf("floors = %+v", floors)
+		}
+		components := must(c.Rooms.Components(ctx, f.room.ID, utils.PaginationQuery{}))(t)
+		if len(components.Items) != 1 || components.Items[0].ID != f.component.ID {
+			t.Errorf("room components = %+v", components)
+		}
+		tree := must(c.Tree(ctx, utils.TreeQuery{Depth: 3}))(t)
+		if len(tree) != 1 || len(tree[0].Floors) != 1 {
+			t.Errorf("tree = %+v", tree)
+		}
+	})
+
+	t.Run("filtered list", func(t *testing.T) {
+		must(c.Reports.AssignUser(ctx, f.report.ID, utils.AssignUserRequest{UserID: f.user.ID}))(t)
+
+		reports := must(c.Reports.List(ctx, utils.PaginationQuery{}, utils.ReportFilterQuery{UserID: f.user.ID}))(t)
+		if len(reports.Items) != 1 || reports.Items[0].ID != f.report.ID {
+			t.Errorf("reports of the user = %+v", reports)
+		}
+		reports = must(c.Reports.List(ctx, utils.PaginationQuery{}, utils.ReportFilterQuery{Status: "COMPLETED"}))(t)
+		if len(reports.Items) != 0 {
+			t.Errorf("completed reports = %+v", reports)
+		}
+	})
+
+	t.Run("tags", func(t *testing.T) {
+		tag := must(c.Tags.Create(ctx, utils.CreateTagRequest{Name: "electrical"}))(t)
+		report := must(c.Reports.AddTags(ctx, f.report.ID, utils.ReportTagsRequest{TagIDs: []uint{tag.ID}}))(t)
+		if len(report.Tags) != 1 || report.Tags[0].Name != "electrical" {
+			t.Errorf("tagged report = %+v", report)
+		}
+		report = must(c.Reports.RemoveTag(ctx, f.report.ID, tag.ID))(t)
+		if len(report.Tags) != 0 {
+			t.Errorf("untagged report = %+v", report)
+		}
+	})
+
+	t.Run("export", func(t *testing.T) {
+		file := must(c.Reports.Export(ctx, utils.ExportFormatCSV, utils.ReportFilterQuery{}))(t)
+		if lines := strings.Split(strings.TrimSpace(string(file)), "\n"); len(lines) != 2 || !strings.Contains(lines[1], "Broken projector") {
+			t.Errorf("csv export = %q", file)
+		}
+		pdf := must(c.Reports.ExportPDF(ctx, f.report.ID))(t)
+		if !strings.HasPrefix(string(pdf), "%PDF") {
+			t.Errorf("pdf export starts with %q", pdf[:min(len(pdf), 8)])
+		}
+	})
+
+	t.Run("analytics", func(t *testing.T) {
+		top := must(c.Analytics.TopComponents(ctx, utils.TopNQuery{Limit: 5}))(t)
+		if len(top) != 1 || top[0].Reports != 1 {
+			t.Errorf("top components = %+v", top)
+		}
+		must(c.Analytics.Volume(ctx, utils.VolumeQuery{Interval: "week"}))(t)
+	})
+
+	t.Run("rolled back bulk request", func(t *testing.T) {
+		result, err := c.Reports.Bulk(ctx, utils.BulkReportRequest{
+			Action: utils.BulkActionTransition, IDs: []uint{f.report.ID, 999}, Status: "IN_PROGRESS",
+		})
+		if !errors.Is(err, utils.ErrConflict) {
+			t.Fatalf("err = %v, want a conflict", err)
+		}
+		if result == nil || result.Committed || result.Failed != 1 {
+			t.Errorf("result = %+v, want the rolled back outcome", result)
+		}
+	})
+
+	t.Run("delete and restore", func(t *testing.T) {
+		if err := c.Reports.Delete(ctx, f.report.ID); err != nil {
+			t.Fatalf("delete: %v", err)
+		}
+		trash := must(c.Trash(ctx, utils.TrashQuery{Type: "reports"}))(t)
+		if len(trash.Items) != 1 || trash.Items[0].ID != f.report.ID {
+			t.Errorf("trash = %+v", trash)
+		}
+		item := must(c.Reports.Restore(ctx, f.report.ID))(t)
+		if item.DeletedAt != nil {
+			t.Errorf("restored item = %+v", item)
+		}
+	})
+
+	t.Run("health", func(t *testing.T) {
+		if health := must(c.Health(ctx))(t); health.Status != "healthy" {
+			t.Errorf("health = %+v", health)
+		}
+	})
+}
+
+func TestClientErrors(t *testing.T) {
+	ctx := context.Background()
+	server, _ := newServer(t, nil)
+	c := newClient(t, server)
+	f := seed(t, ctx, c)
+
+	// expectError checks the kind and code of an error response
+	expectError := func(t *testing.T, err error, kind error, code string) *client.Error {
+		t.Helper()
+
+		var apiErr *client.Error
+		if !errors.As(err, &apiErr) {
+			t.Fatalf("err = %v, want a *client.Error", err)
+		}
+		if !errors.Is(err, kind) || apiErr.Code != code {
+			t.Fatalf("err = %v with code %s, want %v (%s)", err, apiErr.Code, kind, code)
+		}
+		return apiErr
+	}
+
+	t.Run("not found", func(t *testing.T) {
+		_, err := c.Buildings.Get(ctx, 999)
+		expectError(t, err, utils.ErrNotFound, utils.CodeNotFound)
+	})
+
+	t.Run("validation details", func(t *testing.T) {
+		_, err := c.Reports.Create(ctx, utils.CreateReportRequest{RoomID: f.room.ID, ComponentID: f.component.ID, Status: "BROKEN"})
+		apiErr := expectError(t, err, utils.ErrValidation, utils.CodeValidation)
+		if detail, ok := apiErr.Field("status"); !ok || detail.Rule != "oneof" {
+			t.Errorf("details = %+v, want status to fail oneof", apiErr.Details)
+		}
+		if _, ok := apiErr.Field("name"); !ok {
+			t.Errorf("details = %+v, want name to be required", apiErr.Details)
+		}
+	})
+
+	t.Run("conflict", func(t *testing.T) {
+		_, err := c.Buildings.Create(ctx, utils.CreateBuildingRequest{Code: "B1", Name: "Annex"})
+		expectError(t, err, utils.ErrConflict, utils.CodeConflict)
+	})
+
+	t.Run("stale version", func(t *testing.T) {
+		_, err := c.Rooms.Update(ctx, f.room.ID, utils.UpdateRoomRequest{Name: "Lab 1"}, client.IfMatch(f.room.Version+1))
+		expectError(t, err, utils.ErrStale, utils.CodePrecondition)
+	})
+
+	t.Run("forbidden", func(t *testing.T) {
+		err := c.Buildings.Purge(ctx, f.building.ID, client.AsUser(f.user.ID))
+		expectError(t, err, utils.ErrForbidden, utils.CodeForbidden)
+	})
+
+	t.Run("unknown user", func(t *testing.T) {
+		_, err := c.Users.List(ctx, utils.PaginationQuery{}, client.AsUser(999))
+		expectError(t, err, client.ErrUnauthorized, utils.CodeUnauthorized)
+	})
+
+	t.Run("not an error envelope", func(t *testing.T) {
+		unrouted, err := client.New(server.URL+"/missing", client.WithHTTPClient(server.Client()))
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, err = unrouted.Health(ctx)
+		expectError(t, err, utils.ErrNotFound, utils.CodeNotFound)
+	})
+}
+
+func TestClientPagination(t *testing.T) {
+	ctx := context.Background()
+	server, _ := newServer(t, nil)
+	c := newClient(t, server)
+
+	for i := range 25 {
+		must(c.Tags.Create(ctx, utils.CreateTagRequest{Name: "tag-" + string(rune('a'+i))}))(t)
+	}
+
+	seen := map[uint]bool{}
+	for tag, err := range c.Tags.All(ctx, utils.PaginationQuery{PageSize: 10}) {
+		if err != nil {
+			t.Fatalf("iterate: %v", err)
+		}
+		if seen[tag.ID] {
+			t.Fatalf("tag %d seen twice", tag.ID)
+		}
+		seen[tag.ID] = true
+	}
+	if len(seen) != 25 {
+		t.Errorf("iterated over %d tags, want 25", len(seen))
+	}
+
+	t.Run("stops early", func(t *testing.T) {
+		count := 0
+		for range c.Tags.All(ctx, utils.PaginationQuery{PageSize: 10}) {
+			if count++; count == 3 {
+				break
+			}
+		}
+		if count != 3 {
+			t.Errorf("count = %d", count)
+		}
+	})
+
+	t.Run("yields the error", func(t *testing.T) {
+		var got error
+		for _, err := range c.Tags.All(ctx, utils.PaginationQuery{PageSize: 500}) {
+			got = err
+		}
+		if !errors.Is(got, utils.ErrValidation) {
+			t.Errorf("err = %v, want the rejected page size", got)
+		}
+	})
+}
+
+func TestClientRetries(t *testing.T) {
+	ctx := context.Background()
+
+	var mu sync.Mutex
+	var keys []string
+	failures := map[string]int{}
+	server, _ := newServer(t, func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			mu.Lock()
+			route := r.Method + " " + r.URL.Path
+			if r.Method == http.MethodPost {
+				keys = append(keys, r.Header.Get("Idempotency-Key"))
+			}
+			fail := failures[route] > 0
+			if fail {
+				failures[route]--
+			}
+			mu.Unlock()
+
+			if !fail {
+				next.ServeHTTP(w, r)
+				return
+			}
+			// The request is handled but its response is lost on the way back
+			next.ServeHTTP(httptest.NewRecorder(), r)
+			w.Header().Set("Retry-After", "0")
+			http.Error(w, "upstream unavailable", http.StatusServiceUnavailable)
+		})
+	})
+	anonymous := newClient(t, server)
+	user := must(anonymous.Users.Create(ctx, utils.CreateUserRequest{Name: "Tech One", Email: "tech@example.com"}))(t)
+	c := newClient(t, server, client.WithUserID(user.ID))
+	keys = nil
+	failNext := func(route string, times int) {
+		mu.Lock()
+		defer mu.Unlock()
+		failures[route] = times
+	}
+
+	t.Run("post is run once", func(t *testing.T) {
+		failNext("POST /api/v1/tags", 1)
+		tag := must(c.Tags.Create(ctx, utils.CreateTagRequest{Name: "electrical"}))(t)
+
+		tags := must(c.Tags.List(ctx, utils.PaginationQuery{}))(t)
+		if len(tags.Items) != 1 || tags.Items[0].ID != tag.ID {
+			t.Errorf("tags = %+v, want the tag created once", tags.Items)
+		}
+		if len(keys) != 2 || keys[0] == "" || keys[0] != keys[1] {
+			t.Errorf("Idempotency-Key of the attempts = %q, want the same generated key", keys)
+		}
+	})
+
+	t.Run("anonymous post is run once", func(t *testing.T) {
+		keys = nil
+		failNext("POST /api/v1/tags", 1)
+		tag := must(anonymous.Tags.Create(ctx, utils.CreateTagRequest{Name: "plumbing"}))(t)
+
+		tags := must(c.Tags.List(ctx, utils.PaginationQuery{}))(t)
+		if len(tags.Items) != 2 || tags.Items[1].ID != tag.ID {
+			t.Errorf("tags = %+v, want the anonymous tag created once", tags.Items)
+		}
+		if len(keys) != 2 || keys[0] == "" || keys[0] != keys[1] {
+			t.Errorf("Idempotency-Key of the attempts = %q, want the same generated key", keys)
+		}
+	})
+
+	t.Run("gives up", func(t *testing.T) {
+		failNext("GET /api/v1/tags", 3)
+		_, err := c.Tags.List(ctx, utils.PaginationQuery{})
+
+		var apiErr *client.Error
+		if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusServiceUnavailable || apiErr.Code != utils.CodeInternal {
+			t.Fatalf("err = %v, want the last 503", err)
+		}
+		if !strings.Contains(apiErr.Detail, "upstream unavailable") {
+			t.Errorf("detail = %q, want the body of the response", apiErr.Detail)
+		}
+	})
+
+	t.Run("without retries", func(t *testing.T) {
+		failNext("GET /api/v1/tags", 1)
+		once := newClient(t, server, client.WithRetry(client.RetryPolicy{}))
+		if _, err := once.Tags.List(ctx, utils.PaginationQuery{}); err == nil {
+			t.Fatal("the failed attempt was retried")
+		}
+	})
+}
+
+func TestClientAuth(t *testing.T) {
+	ctx := context.Background()
+
+	var mu sync.Mutex
+	var authorization []string
+	server, _ := newServer(t, func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			mu.Lock()
+			authorization = append(authorization, r.Header.Get("Authorization"))
+			mu.Unlock()
+			next.ServeHTTP(w, r)
+		})
+	})
+
+	calls := 0
+	c := newClient(t, server, client.WithTokenSource(func(context.Context) (string, error) {
+		calls++
+		return "token-" + string(rune('0'+calls)), nil
+	}))
+	must(c.Health(ctx))(t)
+	must(c.Health(ctx))(t)
+	if strings.Join(authorization, ",") != "Bearer token-1,Bearer token-2" {
+		t.Errorf("Authorization = %q, want a fresh token per request", authorization)
+	}
+
+	t.Run("token source failure", func(t *testing.T) {
+		failing := newClient(t, server, client.WithTokenSource(func(context.Context) (string, error) {
+			return "", errors.New("expired")
+		}))
+		if _, err := failing.Health(ctx); err == nil || !strings.Contains(err.Error(), "expired") {
+			t.Errorf("err = %v, want the token source error", err)
+		}
+	})
+
+	t.Run("caller identity", func(t *testing.T) {
+		admin := newClient(t, server)
+		user := must(admin.Users.Create(ctx, utils.CreateUserRequest{Name: "Tech One", Email: "tech@example.com"}))(t)
+
+		as := newClient(t, server, client.WithUserID(user.ID))
+		if _, err := as.Users.Get(ctx, user.ID); err != nil {
+			t.Errorf("get as the user: %v", err)
+		}
+		unknown := newClient(t, server, client.WithUserID(999))
+		if _, err := unknown.Users.Get(ctx, user.ID); !errors.Is(err, client.ErrUnauthorized) {
+			t.Errorf("err = %v, want unauthorized", err)
+		}
+	})
+
+	if _, err := client.New("localhost:8080"); err == nil {
+		t.Error("a base URL without a scheme was accepted")
+	}
+}
@@ -0,0 +1,69 @@
+package client
+
+import (
+	"context"
+	"incident-report/utils"
+	"iter"
+	"net/http"
+)
+
+// ComponentsService calls the /components endpoints
+type ComponentsService struct {
+	TrashedResource[utils.CreateComponentRequest, utils.UpdateComponentRequest, utils.ComponentResponse]
+}
+
+// List returns a page of the components that match the filter
+func (s *ComponentsService) List(ctx context.Context, page utils.PaginationQuery, filter utils.ComponentFilterQuery, opts ...CallOption) (*Page[utils.ComponentResponse], error) {
+	return list[utils.ComponentResponse](ctx, s.client, "/components", opts, page, filter)
+}
+
+// All iterates over every component that matches the filter, see All
+func (s *ComponentsService) All(ctx context.Context, page utils.PaginationQuery, filter utils.ComponentFilterQuery, opts ...CallOption) iter.Seq2[utils.ComponentResponse, error] {
+	return All(ctx, page, func(ctx context.Context, page utils.PaginationQuery) (*Page[utils.ComponentResponse], error) {
+		return s.List(ctx, page, filter, opts...)
+	})
+}
+
+// Export returns every component that matches the filter as a csv, xlsx or pdf file
+func (s *ComponentsService) Export(ctx context.Context, format string, filter utils.ComponentFilterQuery, opts ...CallOption) ([]byte, error) {
+	var file []byte
+	query := encodeQuery(filter, utils.ExportQuery{Format: format})
+	if err := s.client.do(ctx, http.MethodGet, "/components", query, nil, &file, opts); err != nil {
+		return nil, err
+	}
+	return file, nil
+}
+
+// Reliability returns the reliability of a component
+func (s *ComponentsService) Reliability(ctx context.Context, id uint, opts ...CallOption) (*utils.ComponentReliability, error) {
+	return get[utils.ComponentReliability](ctx, s.client, path("/components/%d/reliability", id), opts)
+}
+
+// ReplacementCandidates ranks the components that should be replaced first
+func (s *ComponentsService) ReplacementCandidates(ctx context.Context, query utils.ReplacementCandidatesQuery, opts ...CallOption) ([]utils.ReplacementCandidate, error) {
+	return getSlice[utils.ReplacementCandidate](ctx, s.client, "/components/replacement-candidates", opts, query)
+}
+
+// AssignRoom moves a component to a room
+func (s *ComponentsService) AssignRoom(ctx context.Context, id uint, req utils.AssignRoomRequest, opts ...CallOption) (*utils.ComponentResponse, error) {
+	return send[utils.ComponentResponse](ctx, s.client, http.MethodPut, path("/components/%d/assign-room", id), req, opts)
+}
+
+// BulkMove moves many components to a room
+// A rolled back atomic request fails with a conflict; its outcome is returned with the error.
+func (s *ComponentsService) BulkMove(ctx context.Context, req utils.BulkMoveComponentsRequest, opts ...CallOption) (*utils.BulkResponse, error) {
+	return bulk(ctx, s.client, "/components/bulk-move", req, opts)
+}
+
+// bulk sends a bulk request and returns its outcome, also when it was rolled back
+func bulk(ctx context.Context, c *Client, bulkPath string, req any, opts []CallOption) (*utils.BulkResponse, error) {
+	result, err := send[utils.BulkResponse](ctx, c, http.MethodPost, bulkPath, req, opts)
+	if err != nil {
+		var rolledBack utils.BulkResponse
+		if errorData(err, &rolledBack) {
+			return &rolledBack, err
+		}
+		return nil, err
+	}
+	return result, nil
+}
@@ -0,0 +1,121 @@
+package client
+
+import (
+	"encoding/json"
+	"errors"
+	"incident-report/utils"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// ErrUnauthorized is the kind of an Error for a caller the server does not know
+var ErrUnauthorized = errors.New("unauthorized")
+
+// maxErrorBody is the most of an error response that is read
+const maxErrorBody = 1 << 20
+
+// Error is an error response of the API
+// It matches the error kinds of the utils package with errors.Is, e.g. errors.Is(err, utils.ErrNotFound),
+// so callers can handle it like an error of the services.
+type Error struct {
+	StatusCode int
+	Code       string
+	Message    string
+	// Detail is the error field of the response, the cause of the failure
+	Detail  string
+	Details []utils.FieldError
+	// RequestID is the X-Request-ID of the response, to find the request in the server logs
+	RequestID string
+
+	data json.RawMessage
+}
+
+// Error returns the message and the cause of the error response
+func (e *Error) Error() string {
+	var b strings.Builder
+	b.WriteString(http.StatusText(e.StatusCode))
+	if e.Message != "" {
+		b.WriteString(": " + e.Message)
+	}
+	if e.Detail != "" {
+		b.WriteString(": " + e.Detail)
+	}
+	return b.String()
+}
+
+// Is reports whether target is the kind of the error code
+func (e *Error) Is(target error) bool {
+	switch e.Code {
+	case utils.CodeValidation:
+		return target == utils.ErrValidation
+	case utils.CodeNotFound:
+		return target == utils.ErrNotFound
+	case utils.CodeConflict:
+		return target == utils.ErrConflict
+	case utils.CodeForbidden:
+		return target == utils.ErrForbidden
+	case utils.CodePrecondition:
+		return target == utils.ErrStale
+	case utils.CodeUnprocessable:
+		return target == utils.ErrUnprocessable
+	case utils.CodeUnauthorized:
+		return target == ErrUnauthorized
+	default:
+		return false
+	}
+}
+
+// Field returns the detail of a rejected request field
+func (e *Error) Field(name string) (utils.FieldError, bool) {
+	for _, detail := range e.Details {
+		if detail.Field == name {
+			return detail, true
+		}
+	}
+	return utils.FieldError{}, false
+}
+
+// newError reads an error response
+// A body that is not an error envelope, such as that of a proxy, is kept as the detail and the
+// code is derived from the status.
+func newError(resp *http.Response) *Error {
+	apiErr := &Error{
+		StatusCode: resp.StatusCode,
+		RequestID:  resp.Header.Get("X-Request-ID"),
+	}
+
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBody))
+	var envelope struct {
+		Message string             `json:"message"`
+		Data    json.RawMessage    `json:"data"`
+		Code    string             `json:"code"`
+		Error   string             `json:"error"`
+		Details []utils.FieldError `json:"details"`
+	}
+	if err := json.Unmarshal(body, &envelope); err != nil {
+		apiErr.Code = utils.CodeForStatus(resp.StatusCode)
+		apiErr.Detail = strings.TrimSpace(string(body))
+		return apiErr
+	}
+
+	apiErr.Code = envelope.Code
+	if apiErr.Code == "" {
+		apiErr.Code = utils.CodeForStatus(resp.StatusCode)
+	}
+	apiErr.Message = envelope.Message
+	apiErr.Detail = envelope.Error
+	apiErr.Details = envelope.Details
+	apiErr.data = envelope.Data
+	return apiErr
+}
+
+// errorData decodes the data an error response carries, such as the outcome of a rolled back
+// bulk request, into out and reports whether there was any
+func errorData(err error, out any) bool {
+	var apiErr *Error
+	if !errors.As(err, &apiErr) || len(apiErr.data) == 0 || string(apiErr.data) == "null" {
+		return false
+	}
+	return json.Unmarshal(apiErr.data, out) == nil
+}
@@ -0,0 +1,61 @@
+package client
+
+import (
+	"context"
+	"incident-report/utils"
+)
+
+// BuildingsService calls the /buildings endpoints
+type BuildingsService struct {
+	LocationResource[utils.CreateBuildingRequest, utils.UpdateBuildingRequest, utils.BuildingResponse]
+}
+
+// Floors returns a page of the floors of a building
+func (s *BuildingsService) Floors(ctx context.Context, id uint, page utils.PaginationQuery, opts ...CallOption) (*Page[utils.FloorResponse], error) {
+	return list[utils.FloorResponse](ctx, s.client, path("/buildings/%d/floors", id), opts, page)
+}
+
+// Tree returns the hierarchy tree of a building
+func (s *BuildingsService) Tree(ctx context.Context, id uint, query utils.TreeQuery, opts ...CallOption) (*utils.BuildingTreeNode, error) {
+	return get[utils.BuildingTreeNode](ctx, s.client, path("/buildings/%d/tree", id), opts, query)
+}
+
+// FloorsService calls the /floors endpoints
+type FloorsService struct {
+	LocationResource[utils.CreateFloorRequest, utils.UpdateFloorRequest, utils.FloorResponse]
+}
+
+// Rooms returns a page of the rooms of a floor
+func (s *FloorsService) Rooms(ctx context.Context, id uint, page utils.PaginationQuery, opts ...CallOption) (*Page[utils.RoomResponse], error) {
+	return list[utils.RoomResponse](ctx, s.client, path("/floors/%d/rooms", id), opts, page)
+}
+
+// RoomsService calls the /rooms endpoints
+type RoomsService struct {
+	LocationResource[utils.CreateRoomRequest, utils.UpdateRoomRequest, utils.RoomResponse]
+}
+
+// Components returns a page of the components of a room
+func (s *RoomsService) Components(ctx context.Context, id uint, page utils.PaginationQuery, opts ...CallOption) (*Page[utils.ComponentResponse], error) {
+	return list[utils.ComponentResponse](ctx, s.client, path("/rooms/%d/components", id), opts, page)
+}
+
+// CategoriesService calls the /component-categories endpoints
+type CategoriesService struct {
+	LocationResource[utils.CreateComponentCategoryRequest, utils.UpdateComponentCategoryRequest, utils.ComponentCategoryResponse]
+}
+
+// Components returns a page of the components of a category
+func (s *CategoriesService) Components(ctx context.Context, id uint, page utils.PaginationQuery, opts ...CallOption) (*Page[utils.ComponentResponse], error) {
+	return list[utils.ComponentResponse](ctx, s.client, path("/component-categories/%d/components", id), opts, page)
+}
+
+// Reliability returns the reliability of the components of a category
+func (s *CategoriesService) Reliability(ctx context.Context, id uint, opts ...CallOption) (*utils.CategoryReliability, error) {
+	return get[utils.CategoryReliability](ctx, s.client, path("/component-categories/%d/reliability", id), opts)
+}
+
+// Tree returns the hierarchy tree of every building
+func (c *Client) Tree(ctx context.Context, query utils.TreeQuery, opts ...CallOption) ([]utils.BuildingTreeNode, error) {
+	return getSlice[utils.BuildingTreeNode](ctx, c, "/tree", opts, query)
+}
@@ -0,0 +1,122 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"incident-report/utils"
+	"iter"
+	"net/url"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// Page is a page of a list, see utils.PaginatedResponse
+// Page is only set when paging by page number, Total and TotalPages only when the list was counted.
+type Page[T any] struct {
+	Items      []T    `json:"data"`
+	Page       int    `json:"page"`
+	PageSize   int    `json:"page_size"`
+	Total      *int64 `json:"total"`
+	TotalPages int    `json:"total_page"`
+	NextCursor string `json:"next_cursor"`
+	PrevCursor string `json:"prev_cursor"`
+}
+
+// HasNext reports whether there is a page after this one
+func (p *Page[T]) HasNext() bool {
+	return p.NextCursor != ""
+}
+
+// ListFunc loads one page of a list
+type ListFunc[T any] func(ctx context.Context, page utils.PaginationQuery) (*Page[T], error)
+
+// All iterates over every item of a list, loading it page by page from page onwards
+// The pages are followed by cursor, so records created or deleted meanwhile neither repeat nor
+// shift items out of the iteration, and the list is not counted. The iteration stops after the
+// first error, which is yielded with the zero item.
+//
+// Lists that take filters besides the pagination are iterated with a closure:
+//
+//	reports := client.All(ctx, utils.PaginationQuery{PageSize: 100}, func(ctx context.Context, page utils.PaginationQuery) (*client.Page[utils.ReportResponse], error) {
+//		return c.Reports.List(ctx, page, utils.ReportFilterQuery{Status: "PENDING"})
+//	})
+func All[T any](ctx context.Context, page utils.PaginationQuery, list ListFunc[T]) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		noTotal := false
+		page.Total = &noTotal
+		for {
+			result, err := list(ctx, page)
+			if err != nil {
+				var zero T
+				yield(zero, err)
+				return
+			}
+			for _, item := range result.Items {
+				if !yield(item, nil) {
+					return
+				}
+			}
+			if !result.HasNext() {
+				return
+			}
+			page.Page, page.After, page.Before = 0, result.NextCursor, ""
+		}
+	}
+}
+
+// encodeQuery encodes query structs by their form tags, as the handlers bind them
+// Embedded structs are flattened and members left at their zero value are not sent.
+func encodeQuery(queries ...any) url.Values {
+	values := url.Values{}
+	for _, query := range queries {
+		v := reflect.ValueOf(query)
+		for v.Kind() == reflect.Pointer {
+			if v.IsNil() {
+				break
+			}
+			v = v.Elem()
+		}
+		if v.Kind() == reflect.Struct {
+			encodeStruct(values, v)
+		}
+	}
+	return values
+}
+
+// encodeStruct adds the members of a query struct to values
+func encodeStruct(values url.Values, v reflect.Value) {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field, value := t.Field(i), v.Field(i)
+		if field.Anonymous && field.Type.Kind() == reflect.Struct {
+			encodeStruct(values, value)
+			continue
+		}
+
+		name, _, _ := strings.Cut(field.Tag.Get("form"), ",")
+		if name == "" || name == "-" || !field.IsExported() || value.IsZero() {
+			continue
+		}
+		if value.Kind() == reflect.Pointer {
+			value = value.Elem()
+		}
+		values.Set(name, formatValue(value))
+	}
+}
+
+// formatValue formats a query member the way gin parses it back
+func formatValue(v reflect.Value) string {
+	switch v.Kind() {
+	case reflect.Bool:
+		return strconv.FormatBool(v.Bool())
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(v.Int(), 10)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(v.Uint(), 10)
+	case reflect.Float32, reflect.Float64:
+		return strconv.FormatFloat(v.Float(), 'f', -1, 64)
+	default:
+		return fmt.Sprint(v.Interface())
+	}
+}
@@ -0,0 +1,66 @@
+package client
+
+import (
+	"context"
+	"incident-report/utils"
+	"iter"
+	"net/http"
+)
+
+// ReportsService calls the /reports endpoints
+type ReportsService struct {
+	TrashedResource[utils.CreateReportRequest, utils.UpdateReportRequest, utils.ReportResponse]
+}
+
+// List returns a page of the reports that match the filter
+func (s *ReportsService) List(ctx context.Context, page utils.PaginationQuery, filter utils.ReportFilterQuery, opts ...CallOption) (*Page[utils.ReportResponse], error) {
+	return list[utils.ReportResponse](ctx, s.client, "/reports", opts, page, filter)
+}
+
+// All iterates over every report that matches the filter, see All
+func (s *ReportsService) All(ctx context.Context, page utils.PaginationQuery, filter utils.ReportFilterQuery, opts ...CallOption) iter.Seq2[utils.ReportResponse, error] {
+	return All(ctx, page, func(ctx context.Context, page utils.PaginationQuery) (*Page[utils.ReportResponse], error) {
+		return s.List(ctx, page, filter, opts...)
+	})
+}
+
+// Export returns every report that matches the filter as a csv, xlsx or pdf file
+func (s *ReportsService) Export(ctx context.Context, format string, filter utils.ReportFilterQuery, opts ...CallOption) ([]byte, error) {
+	var file []byte
+	query := encodeQuery(filter, utils.ExportQuery{Format: format})
+	if err := s.client.do(ctx, http.MethodGet, "/reports", query, nil, &file, opts); err != nil {
+		return nil, err
+	}
+	return file, nil
+}
+
+// ExportPDF returns the printable PDF summary of a report
+func (s *ReportsService) ExportPDF(ctx context.Context, id uint, opts ...CallOption) ([]byte, error) {
+	var file []byte
+	query := encodeQuery(utils.ExportQuery{Format: utils.ExportFormatPDF})
+	if err := s.client.do(ctx, http.MethodGet, path("/reports/%d", id), query, nil, &file, opts); err != nil {
+		return nil, err
+	}
+	return file, nil
+}
+
+// AssignUser assigns a technician to a report
+func (s *ReportsService) AssignUser(ctx context.Context, id uint, req utils.AssignUserRequest, opts ...CallOption) (*utils.ReportResponse, error) {
+	return send[utils.ReportResponse](ctx, s.client, http.MethodPut, path("/reports/%d/assign-user", id), req, opts)
+}
+
+// AddTags attaches tags to a report
+func (s *ReportsService) AddTags(ctx context.Context, id uint, req utils.ReportTagsRequest, opts ...CallOption) (*utils.ReportResponse, error) {
+	return send[utils.ReportResponse](ctx, s.client, http.MethodPost, path("/reports/%d/tags", id), req, opts)
+}
+
+// RemoveTag detaches a tag from a report
+func (s *ReportsService) RemoveTag(ctx context.Context, id uint, tagID uint, opts ...CallOption) (*utils.ReportResponse, error) {
+	return send[utils.ReportResponse](ctx, s.client, http.MethodDelete, path("/reports/%d/tags/%d", id, tagID), nil, opts)
+}
+
+// Bulk assigns, transitions, tags or deletes many reports
+// A rolled back atomic request fails with a conflict; its outcome is returned with the error.
+func (s *ReportsService) Bulk(ctx context.Context, req utils.BulkReportRequest, opts ...CallOption) (*utils.BulkResponse, error) {
+	return bulk(ctx, s.client, "/reports/bulk", req, opts)
+}
@@ -0,0 +1,132 @@
+package client
+
+import (
+	"context"
+	"incident-report/utils"
+	"iter"
+	"net/http"
+)
+
+// Resource calls the CRUD endpoints of a collection, e.g. /tags
+// C, U and R are the create and update requests and the response of a record.
+type Resource[C, U, R any] struct {
+	client *Client
+	path   string
+}
+
+// resource returns the Resource of the collection at a path
+func resource[C, U, R any](c *Client, collection string) Resource[C, U, R] {
+	return Resource[C, U, R]{client: c, path: collection}
+}
+
+// location returns the LocationResource of the collection at a path
+func location[C, U, R any](c *Client, collection string) LocationResource[C, U, R] {
+	return LocationResource[C, U, R]{TrashedResource[C, U, R]{resource[C, U, R](c, collection)}}
+}
+
+// Create creates a record; the client sends it with an Idempotency-Key unless the call names one
+func (r *Resource[C, U, R]) Create(ctx context.Context, req C, opts ...CallOption) (*R, error) {
+	return send[R](ctx, r.client, http.MethodPost, r.path, req, opts)
+}
+
+// Get returns a record
+func (r *Resource[C, U, R]) Get(ctx context.Context, id uint, opts ...CallOption) (*R, error) {
+	return get[R](ctx, r.client, path("%s/%d", r.path, id), opts)
+}
+
+// List returns a page of the records
+func (r *Resource[C, U, R]) List(ctx context.Context, page utils.PaginationQuery, opts ...CallOption) (*Page[R], error) {
+	return list[R](ctx, r.client, r.path, opts, page)
+}
+
+// All iterates over every record, see All
+func (r *Resource[C, U, R]) All(ctx context.Context, page utils.PaginationQuery, opts ...CallOption) iter.Seq2[R, error] {
+	return All(ctx, page, func(ctx context.Context, page utils.PaginationQuery) (*Page[R], error) {
+		return r.List(ctx, page, opts...)
+	})
+}
+
+// Update changes the members of a record that the request sets
+func (r *Resource[C, U, R]) Update(ctx context.Context, id uint, req U, opts ...CallOption) (*R, error) {
+	return send[R](ctx, r.client, http.MethodPut, path("%s/%d", r.path, id), req, opts)
+}
+
+// Patch applies a JSON Merge Patch to a record
+// The patch is a map or struct marshalled to the patch document, or the document itself as a
+// json.RawMessage; a nil member clears a nullable field.
+func (r *Resource[C, U, R]) Patch(ctx context.Context, id uint, patch any, opts ...CallOption) (*R, error) {
+	return send[R](ctx, r.client, http.MethodPatch, path("%s/%d", r.path, id), patch, append(opts, mergePatch))
+}
+
+// Delete deletes a record
+func (r *Resource[C, U, R]) Delete(ctx context.Context, id uint, opts ...CallOption) error {
+	return r.client.do(ctx, http.MethodDelete, path("%s/%d", r.path, id), nil, nil, nil, opts)
+}
+
+// TrashedResource is a Resource whose deleted records go to the trash
+type TrashedResource[C, U, R any] struct {
+	Resource[C, U, R]
+}
+
+// Restore takes a deleted record back out of the trash
+func (r *TrashedResource[C, U, R]) Restore(ctx context.Context, id uint, opts ...CallOption) (*utils.TrashItemResponse, error) {
+	return send[utils.TrashItemResponse](ctx, r.client, http.MethodPost, path("%s/%d/restore", r.path, id), nil, opts)
+}
+
+// Purge removes a deleted record for good; it is reserved to admins
+func (r *TrashedResource[C, U, R]) Purge(ctx context.Context, id uint, opts ...CallOption) error {
+	return r.client.do(ctx, http.MethodDelete, path("%s/%d/purge", r.path, id), nil, nil, nil, opts)
+}
+
+// LocationResource is a TrashedResource of the building hierarchy, whose records have dependents
+type LocationResource[C, U, R any] struct {
+	TrashedResource[C, U, R]
+}
+
+// DeleteCascade deletes a record together with its dependents
+// Delete refuses a record that still has any.
+func (r *LocationResource[C, U, R]) DeleteCascade(ctx context.Context, id uint, opts ...CallOption) error {
+	query := encodeQuery(utils.DeleteQuery{Cascade: true})
+	return r.client.do(ctx, http.MethodDelete, path("%s/%d", r.path, id), query, nil, nil, opts)
+}
+
+// DeleteImpact counts the records DeleteCascade would take with a record
+func (r *LocationResource[C, U, R]) DeleteImpact(ctx context.Context, id uint, opts ...CallOption) (*utils.DeleteImpactResponse, error) {
+	return get[utils.DeleteImpactResponse](ctx, r.client, path("%s/%d/delete-impact", r.path, id), opts)
+}
+
+// list loads a page of the list at a path
+func list[T any](ctx context.Context, c *Client, listPath string, opts []CallOption, queries ...any) (*Page[T], error) {
+	var page Page[T]
+	if err := c.do(ctx, http.MethodGet, listPath, encodeQuery(queries...), nil, &page, opts); err != nil {
+		return nil, err
+	}
+	return &page, nil
+}
+
+// get loads the data at a path
+func get[T any](ctx context.Context, c *Client, getPath string, opts []CallOption, queries ...any) (*T, error) {
+	var data T
+	if err := c.do(ctx, http.MethodGet, getPath, encodeQuery(queries...), nil, &data, opts); err != nil {
+		return nil, err
+	}
+	return &data, nil
+}
+
+// send sends a body to a path and decodes the data of the response
+func send[T any](ctx context.Context, c *Client, method, sendPath string, body any, opts []CallOption) (*T, error) {
+	var data T
+	if err := c.do(ctx, method, sendPath, nil, body, &data, opts); err != nil {
+		return nil, err
+	}
+	return &data, nil
+}
+
+// getSlice loads the rows at a path
+func getSlice[T any](ctx context.Context, c *Client, getPath string, opts []CallOption, queries ...any) ([]T, error) {
+	rows, err := get[[]T](ctx, c, getPath, opts, queries...)
+	if err != nil {
+		return nil, err
+	}
+	return *rows, nil
+}
@@ -3,7 +3,10 @@ package main
 import (
 	"incident-report/config"
 	"incident-report/routes"
+	"incident-report/rpc"
 	"log"
+	"log/slog"
+	"net"
 	"os"
 
 	"github.com/gin-gonic/gin"
@@ -18,8 +21,27 @@ func main() {
 		log.Println("Warning: .env file not found, using system environment variables")
 	}
 
+	// Log JSON lines through log/slog; the standard log package writes through it too
+	config.InitLogging()
+
+	// "migrate" subcommands manage the database schema and exit
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		if err := runMigrate(os.Args[2:]); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+
+	// "user" subcommands administer users and exit
+	if len(os.Args) > 1 && os.Args[1] == "user" {
+		if err := runUser(os.Args[2:]); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+
 	// Initialize database connection
-	// This establishes MySQL connection using GORM and runs auto-migration
+	// This connects to the database selected by DB_DRIVER and checks for pending migrations
 	if err := config.InitDatabase(); err != nil {
 		log.Fatalf("Failed to initialize database: %v", err)
 	}
@@ -39,10 +61,14 @@ func main() {
 	}
 
 	// Create a new Gin router instance
-	router := gin.Default()
+	// RegisterRoutes adds the request logging and panic recovery, so gin's text logger is left out
+	router := gin.New()
+	gin.DebugPrintRouteFunc = func(method, path, handler string, handlers int) {
+		slog.Debug("route", "method", method, "path", path, "handler", handler)
+	}
 
 	// Register all API routes
-	routes.RegisterRoutes(router)
+	routes.RegisterRoutes(router, config.DB)
 
 	// Get server configuration from environment variables
 	host := os.Getenv("SERVER_HOST")
@@ -56,16 +82,26 @@ func main() {
 		port = "8080"
 	}
 
+	// The gRPC API runs on its own port, on the same database and service layer
+	grpcPort := os.Getenv("GRPC_PORT")
+	if grpcPort == "" {
+		grpcPort = "9090"
+	}
+	listener, err := net.Listen("tcp", host+":"+grpcPort)
+	if err != nil {
+		log.Fatalf("Failed to listen for gRPC: %v", err)
+	}
+	grpcServer := rpc.NewServer(config.DB)
+	go func() {
+		if err := grpcServer.Serve(listener); err != nil {
+			log.Fatalf("Failed to start gRPC server: %v", err)
+		}
+	}()
+	defer grpcServer.GracefulStop()
+
 	// Log startup message
-	log.Printf("🚀 Server starting on http://%s:%s", host, port)
-	log.Println("📝 API Documentation:")
-	log.Printf("   🔗 Swagger UI: http://localhost:%s/swagger/index.html", port)
-	log.Println("   POST   /api/v1/users           - Create a new user")
-	log.Println("   GET    /api/v1/users           - Get all users (with pagination)")
-	log.Println("   GET    /api/v1/users/:id       - Get a specific user")
-	log.Println("   PUT    /api/v1/users/:id       - Update a specific user")
-	log.Println("   DELETE /api/v1/users/:id       - Delete a specific user")
-	log.Println("   GET    /api/v1/health         - Health check")
+	slog.Info("server starting", "addr", "http://"+host+":"+port, "grpc_addr", host+":"+grpcPort,
+		"swagger", "http://localhost:"+port+"/swagger", "openapi", "http://localhost:"+port+"/openapi.json")
 
 	// Start the server
 	// ListenAndServe blocks until the server is stopped or encounters an error
@@ -0,0 +1,120 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"incident-report/config"
+	"incident-report/migrations"
+	"os"
+	"strconv"
+	"text/tabwriter"
+)
+
+// migrationsDir is where "migrate create" writes new migration files
+const migrationsDir = "migrations"
+
+// migrateUsage documents the migrate subcommands
+const migrateUsage = `usage: incident-report migrate <command>
+
+commands:
+  up [n]         apply all pending migrations, or the next n
+  down [n]       roll back the last applied migration, or the last n
+  status         list migrations and whether they are applied
+  create <name>  write a new empty migration file`
+
+// runMigrate executes a migrate subcommand
+func runMigrate(args []string) error {
+	if len(args) == 0 {
+		return errors.New(migrateUsage)
+	}
+
+	command, args := args[0], args[1:]
+
+	// create only writes a file and does not need a database
+	if command == "create" {
+		if len(args) != 1 {
+			return errors.New("usage: incident-report migrate create <name>")
+		}
+		path, err := migrations.Create(migrationsDir, args[0])
+		if err != nil {
+			return err
+		}
+		fmt.Printf("✓ Created %s\n", path)
+		return nil
+	}
+
+	if err := config.Connect(); err != nil {
+		return err
+	}
+	defer config.CloseDatabase()
+
+	switch command {
+	case "up":
+		steps, err := migrateSteps(args)
+		if err != nil {
+			return err
+		}
+		applied, err := migrations.Up(config.DB, steps)
+		for _, migration := range applied {
+			fmt.Printf("✓ Applied %06d_%s\n", migration.Version, migration.Name)
+		}
+		if err != nil {
+			return err
+		}
+		if len(applied) == 0 {
+			fmt.Println("No pending migrations")
+		}
+		return nil
+
+	case "down":
+		steps, err := migrateSteps(args)
+		if err != nil {
+			return err
+		}
+		rolledBack, err := migrations.Down(config.DB, steps)
+		for _, migration := range rolledBack {
+			fmt.Printf("✓ Rolled back %06d_%s\n", migration.Version, migration.Name)
+		}
+		if err != nil {
+			return err
+		}
+		if len(rolledBack) == 0 {
+			fmt.Println("No applied migrations")
+		}
+		return nil
+
+	case "status":
+		statuses, err := migrations.Statuses(config.DB)
+		if err != nil {
+			return err
+		}
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "VERSION\tNAME\tSTATUS")
+		for _, status := range statuses {
+			state := "pending"
+			if status.AppliedAt != nil {
+				state = "applied " + status.AppliedAt.Format("2006-01-02 15:04:05")
+			}
+			if status.Missing {
+				state += " (missing from this build)"
+			}
+			fmt.Fprintf(w, "%06d\t%s\t%s\n", status.Version, status.Name, state)
+		}
+		return w.Flush()
+
+	default:
+		return errors.New(migrateUsage)
+	}
+}
+
+// migrateSteps parses the optional step count of up and down
+func migrateSteps(args []string) (int, error) {
+	if len(args) == 0 {
+		return 0, nil
+	}
+	steps, err := strconv.Atoi(args[0])
+	if err != nil || steps < 1 {
+		return 0, fmt.Errorf("invalid step count %q", args[0])
+	}
+	return steps, nil
+}
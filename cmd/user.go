@@ -0,0 +1,51 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"incident-report/config"
+	"incident-report/models"
+	"incident-report/repositories"
+	"incident-report/services"
+	"incident-report/utils"
+	"strconv"
+)
+
+// userUsage documents the user subcommands
+const userUsage = `usage: incident-report user <command>
+
+commands:
+  role <id> <technician|admin>  change the role of a user, e.g. to create the first admin`
+
+// runUser executes a user subcommand
+func runUser(args []string) error {
+	if len(args) != 3 || args[0] != "role" {
+		return errors.New(userUsage)
+	}
+
+	id, err := strconv.ParseUint(args[1], 10, 32)
+	if err != nil {
+		return fmt.Errorf("invalid user ID %q", args[1])
+	}
+	role := args[2]
+	if role != models.RoleTechnician && role != models.RoleAdmin {
+		return fmt.Errorf("invalid role %q: use %s or %s", role, models.RoleTechnician, models.RoleAdmin)
+	}
+
+	if err := config.Connect(); err != nil {
+		return err
+	}
+	defer config.CloseDatabase()
+
+	// The command line has full access, so it acts as an admin
+	ctx := utils.WithActor(context.Background(), utils.Actor{Role: models.RoleAdmin})
+	user, err := services.NewUserService(repositories.New(config.DB)).
+		UpdateUser(ctx, uint(id), &utils.UpdateUserRequest{Role: role})
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("✓ %s <%s> is now %s\n", user.Name, user.Email, user.Role)
+	return nil
+}
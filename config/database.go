@@ -2,90 +2,71 @@ package config
 
 import (
 	"fmt"
-	"incident-report/models"
-	"log"
+	"incident-report/migrations"
+	"log/slog"
 	"os"
 
-	"gorm.io/driver/mysql"
 	"gorm.io/gorm"
 )
 
 // Database holds the GORM database instance
 var DB *gorm.DB
 
-// InitDatabase initializes the MySQL database connection using GORM
-// It reads configuration from environment variables and establishes connection
+// InitDatabase initializes the database connection and checks the schema version
 func InitDatabase() error {
-	// Get environment variables for database configuration
-	host := os.Getenv("DB_HOST")
-	port := os.Getenv("DB_PORT")
-	user := os.Getenv("DB_USER")
-	password := os.Getenv("DB_PASSWORD")
-	dbName := os.Getenv("DB_NAME")
-
-	// Build DSN (Data Source Name) for MySQL connection
-	// Format: user:password@tcp(host:port)/dbname?charset=utf8mb4&parseTime=True&loc=Local
-	dsn := fmt.Sprintf("%s:%s@tcp(%s:%s)/%s?charset=utf8mb4&parseTime=True&loc=Local",
-		user, password, host, port, dbName)
-
-	// Open database connection with MySQL dialect
-	var err error
-	DB, err = gorm.Open(mysql.Open(dsn), &gorm.Config{})
-	if err != nil {
-		log.Printf("Failed to connect to database: %v", err)
+	if err := Connect(); err != nil {
 		return err
 	}
 
-	log.Println("Database connection established successfully")
-
-	// Run auto-migration for all models
-	// This will create tables if they don't exist
-	if err := AutoMigrate(); err != nil {
-		log.Printf("Auto migration failed: %v", err)
+	// Schema changes are applied with the migrate command
+	// DB_AUTO_MIGRATE=true applies pending migrations at startup instead
+	if os.Getenv("DB_AUTO_MIGRATE") == "true" {
+		if err := Migrate(); err != nil {
+			slog.Error("migration failed", "error", err.Error())
+			return err
+		}
+	} else if pending, err := migrations.Pending(DB); err != nil {
+		slog.Error("failed to check the migration status", "error", err.Error())
 		return err
+	} else if pending > 0 {
+		slog.Warn("pending migrations, run \"migrate up\" to apply them", "pending", pending)
 	}
 
 	return nil
 }
 
-// AutoMigrate runs migrations for all models
-// Each model's schema will be created/updated in the database
-func AutoMigrate() error {
-	if DB == nil {
-		return fmt.Errorf("database not initialized")
-	}
-
-	// Migrate the User model - this creates the users table with all columns
-	if err := DB.AutoMigrate(&models.User{}); err != nil {
-		return err
-	}
-
-	// Migrate building management models
-	if err := DB.AutoMigrate(&models.Building{}); err != nil {
-		return err
-	}
-
-	if err := DB.AutoMigrate(&models.Floor{}); err != nil {
+// Connect opens the database connection using GORM
+// The driver is selected by DB_DRIVER, see Dialector
+func Connect() error {
+	dialector, err := Dialector()
+	if err != nil {
+		slog.Error("failed to configure the database", "error", err.Error())
 		return err
 	}
 
-	if err := DB.AutoMigrate(&models.Room{}); err != nil {
+	// Open database connection with the configured dialect
+	DB, err = gorm.Open(dialector, &gorm.Config{Logger: NewGormLogger()})
+	if err != nil {
+		slog.Error("failed to connect to the database", "error", err.Error())
 		return err
 	}
 
-	if err := DB.AutoMigrate(&models.ComponentCategory{}); err != nil {
-		return err
-	}
+	slog.Info("database connection established", "driver", DB.Dialector.Name())
+	return nil
+}
 
-	if err := DB.AutoMigrate(&models.Component{}); err != nil {
-		return err
+// Migrate applies every pending migration
+func Migrate() error {
+	if DB == nil {
+		return fmt.Errorf("database not initialized")
 	}
 
-	if err := DB.AutoMigrate(&models.Report{}); err != nil {
+	applied, err := migrations.Up(DB, 0)
+	if err != nil {
 		return err
 	}
 
-	log.Println("Database migration completed successfully")
+	slog.Info("database migration completed", "applied", len(applied))
 	return nil
 }
 
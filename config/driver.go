@@ -0,0 +1,74 @@
+package config
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/glebarez/sqlite"
+	"gorm.io/driver/mysql"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+// Supported values of DB_DRIVER
+// They match the Dialector.Name() of the corresponding GORM driver
+const (
+	DriverMySQL    = "mysql"
+	DriverPostgres = "postgres"
+	DriverSQLite   = "sqlite"
+)
+
+// defaultSQLitePath is the database file used when DB_DRIVER=sqlite and DB_NAME is empty
+const defaultSQLitePath = "incident_report.db"
+
+// Dialector builds the GORM dialector selected by DB_DRIVER (mysql by default)
+// DB_DSN, when set, is passed to the driver unchanged; otherwise the DSN is built
+// from DB_HOST, DB_PORT, DB_USER, DB_PASSWORD and DB_NAME
+func Dialector() (gorm.Dialector, error) {
+	driver := os.Getenv("DB_DRIVER")
+	if driver == "" {
+		driver = DriverMySQL
+	}
+	dsn := os.Getenv("DB_DSN")
+
+	host := os.Getenv("DB_HOST")
+	port := os.Getenv("DB_PORT")
+	user := os.Getenv("DB_USER")
+	password := os.Getenv("DB_PASSWORD")
+	dbName := os.Getenv("DB_NAME")
+
+	switch driver {
+	case DriverMySQL:
+		if dsn == "" {
+			// Format: user:password@tcp(host:port)/dbname?charset=utf8mb4&parseTime=True&loc=Local
+			dsn = fmt.Sprintf("%s:%s@tcp(%s:%s)/%s?charset=utf8mb4&parseTime=True&loc=Local",
+				user, password, host, port, dbName)
+		}
+		return mysql.Open(dsn), nil
+
+	case DriverPostgres:
+		if dsn == "" {
+			sslMode := os.Getenv("DB_SSLMODE")
+			if sslMode == "" {
+				sslMode = "disable"
+			}
+			dsn = fmt.Sprintf("host=%s port=%s user=%s password=%s dbname=%s sslmode=%s",
+				host, port, user, password, dbName, sslMode)
+		}
+		return postgres.Open(dsn), nil
+
+	case DriverSQLite:
+		if dsn == "" {
+			path := dbName
+			if path == "" {
+				path = defaultSQLitePath
+			}
+			// Foreign keys are off by default in SQLite; the cascades rely on them
+			dsn = path + "?_pragma=foreign_keys(1)&_pragma=busy_timeout(5000)"
+		}
+		return sqlite.Open(dsn), nil
+
+	default:
+		return nil, fmt.Errorf("unsupported DB_DRIVER %q (use mysql, postgres or sqlite)", driver)
+	}
+}
@@ -0,0 +1,26 @@
+package config
+
+import (
+	"log/slog"
+	"os"
+	"time"
+)
+
+// DefaultIdempotencyTTL is how long responses are kept for replay when IDEMPOTENCY_TTL is not set
+const DefaultIdempotencyTTL = 24 * time.Hour
+
+// IdempotencyTTL returns how long the response to a request with an Idempotency-Key is replayed
+// IDEMPOTENCY_TTL takes a Go duration such as "30m" or "48h".
+func IdempotencyTTL() time.Duration {
+	value := os.Getenv("IDEMPOTENCY_TTL")
+	if value == "" {
+		return DefaultIdempotencyTTL
+	}
+
+	ttl, err := time.ParseDuration(value)
+	if err != nil || ttl <= 0 {
+		slog.Warn("invalid IDEMPOTENCY_TTL, using the default", "value", value, "default", DefaultIdempotencyTTL.String())
+		return DefaultIdempotencyTTL
+	}
+	return ttl
+}
@@ -0,0 +1,111 @@
+package config
+
+import (
+	"context"
+	"errors"
+	"incident-report/utils"
+	"io"
+	"log/slog"
+	"os"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// SlowQueryThreshold is how long a query may take before it is logged as slow
+const SlowQueryThreshold = 200 * time.Millisecond
+
+// InitLogging makes a JSON logger writing to stdout the default logger
+// The standard log package writes through it too, so every line of the server is JSON.
+func InitLogging() {
+	slog.SetDefault(NewLogger(os.Stdout))
+}
+
+// NewLogger returns a JSON logger at the level named by LOG_LEVEL (debug, info, warn or error)
+func NewLogger(w io.Writer) *slog.Logger {
+	return slog.New(slog.NewJSONHandler(w, &slog.HandlerOptions{Level: logLevel()}))
+}
+
+// logLevel reads LOG_LEVEL, info by default
+func logLevel() slog.Level {
+	value := os.Getenv("LOG_LEVEL")
+	if value == "" {
+		return slog.LevelInfo
+	}
+
+	var level slog.Level
+	if err := level.UnmarshalText([]byte(value)); err != nil {
+		slog.Warn("invalid LOG_LEVEL, logging at info", "value", value)
+		return slog.LevelInfo
+	}
+	return level
+}
+
+// gormLogger logs the queries of GORM with the logger of the request they run for
+// Failed queries are logged as errors and slow ones as warnings, tagged with the request ID, so
+// a database error can be found from the request that hit it. A record that was not found is
+// not an error: the repositories report it.
+type gormLogger struct {
+	level logger.LogLevel
+}
+
+// NewGormLogger returns the GORM logger of the server
+func NewGormLogger() logger.Interface {
+	return &gormLogger{level: logger.Warn}
+}
+
+// LogMode returns a copy of the logger at the given level
+func (l *gormLogger) LogMode(level logger.LogLevel) logger.Interface {
+	return &gormLogger{level: level}
+}
+
+// Info logs a message of GORM
+func (l *gormLogger) Info(ctx context.Context, msg string, args ...any) {
+	if l.level >= logger.Info {
+		utils.Logger(ctx).InfoContext(ctx, strings.TrimSpace(msg), "args", args)
+	}
+}
+
+// Warn logs a warning of GORM
+func (l *gormLogger) Warn(ctx context.Context, msg string, args ...any) {
+	if l.level >= logger.Warn {
+		utils.Logger(ctx).WarnContext(ctx, strings.TrimSpace(msg), "args", args)
+	}
+}
+
+// Error logs an error of GORM
+func (l *gormLogger) Error(ctx context.Context, msg string, args ...any) {
+	if l.level >= logger.Error {
+		utils.Logger(ctx).ErrorContext(ctx, strings.TrimSpace(msg), "args", args)
+	}
+}
+
+// Trace logs a query once it has run
+func (l *gormLogger) Trace(ctx context.Context, begin time.Time, fc func() (sql string, rowsAffected int64), err error) {
+	if l.level <= logger.Silent {
+		return
+	}
+
+	elapsed := time.Since(begin)
+	switch {
+	case err != nil && !errors.Is(err, gorm.ErrRecordNotFound) && l.level >= logger.Error:
+		sql, rows := fc()
+		utils.Logger(ctx).ErrorContext(ctx, "database error",
+			"error", err.Error(), "sql", sql, "rows", rows, "duration_ms", durationMillis(elapsed))
+	case elapsed > SlowQueryThreshold && l.level >= logger.Warn:
+		sql, rows := fc()
+		utils.Logger(ctx).WarnContext(ctx, "slow query",
+			"sql", sql, "rows", rows, "duration_ms", durationMillis(elapsed))
+	case l.level >= logger.Info:
+		sql, rows := fc()
+		utils.Logger(ctx).DebugContext(ctx, "query",
+			"sql", sql, "rows", rows, "duration_ms", durationMillis(elapsed))
+	}
+}
+
+// durationMillis returns a duration in milliseconds, with microsecond precision
+func durationMillis(d time.Duration) float64 {
+	return float64(d.Microseconds()) / 1000
+}
@@ -0,0 +1,23 @@
+package config
+
+import (
+	"log/slog"
+	"os"
+	"strconv"
+)
+
+// ValidateRequests reports whether requests are checked against the OpenAPI spec before they reach the handlers
+// VALIDATE_REQUESTS takes a boolean such as "true" or "1"; validation is off by default.
+func ValidateRequests() bool {
+	value := os.Getenv("VALIDATE_REQUESTS")
+	if value == "" {
+		return false
+	}
+
+	enabled, err := strconv.ParseBool(value)
+	if err != nil {
+		slog.Warn("invalid VALIDATE_REQUESTS, not validating requests", "value", value)
+		return false
+	}
+	return enabled
+}
@@ -0,0 +1,204 @@
+package controllers
+
+import (
+	"net/http"
+
+	"incident-report/services"
+	"incident-report/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// AnalyticsController handles HTTP requests for incident metrics
+// Every endpoint accepts the from, to (YYYY-MM-DD, inclusive) and building_id filters
+type AnalyticsController struct {
+	service *services.AnalyticsService
+}
+
+// NewAnalyticsController creates a new instance of AnalyticsController
+func NewAnalyticsController(service *services.AnalyticsService) *AnalyticsController {
+	return &AnalyticsController{
+		service: service,
+	}
+}
+
+// GetVolume handles GET /api/v1/analytics/volume
+// @Summary Reports opened and closed per period
+// @Produce json
+// @Param interval query string false "day or week" default(day)
+// @Success 200 {array} utils.VolumeBucket
+// @Failure 400 {object} map[string]interface{}
+// @Router /api/v1/analytics/volume [get]
+func (ac *AnalyticsController) GetVolume(c *gin.Context) {
+	var query utils.VolumeQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		utils.BindingError(c, "Invalid query parameters", err)
+		return
+	}
+
+	volume, err := ac.service.GetVolume(c.Request.Context(), &query)
+	if err != nil {
+		utils.HandleError(c, "Failed to compute report volume", err)
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Report volume retrieved successfully", volume)
+}
+
+// GetResolutionTime handles GET /api/v1/analytics/resolution-time
+// @Summary Mean and median time to resolve
+// @Produce json
+// @Success 200 {object} utils.ResolutionTimeResponse
+// @Failure 400 {object} map[string]interface{}
+// @Router /api/v1/analytics/resolution-time [get]
+func (ac *AnalyticsController) GetResolutionTime(c *gin.Context) {
+	var query utils.AnalyticsQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		utils.BindingError(c, "Invalid query parameters", err)
+		return
+	}
+
+	resolution, err := ac.service.GetResolutionTime(c.Request.Context(), &query)
+	if err != nil {
+		utils.HandleError(c, "Failed to compute resolution time", err)
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Resolution time retrieved successfully", resolution)
+}
+
+// GetTopComponents handles GET /api/v1/analytics/top-components
+// @Summary Components with the most reports
+// @Produce json
+// @Param limit query int false "Number of entries" default(10)
+// @Success 200 {array} utils.ComponentFailureCount
+// @Failure 400 {object} map[string]interface{}
+// @Router /api/v1/analytics/top-components [get]
+func (ac *AnalyticsController) GetTopComponents(c *gin.Context) {
+	var query utils.TopNQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		utils.BindingError(c, "Invalid query parameters", err)
+		return
+	}
+
+	components, err := ac.service.GetTopComponents(c.Request.Context(), &query)
+	if err != nil {
+		utils.HandleError(c, "Failed to rank components", err)
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Top components retrieved successfully", components)
+}
+
+// GetTopCategories handles GET /api/v1/analytics/top-categories
+// @Summary Component categories with the most reports
+// @Produce json
+// @Param limit query int false "Number of entries" default(10)
+// @Success 200 {array} utils.CategoryFailureCount
+// @Failure 400 {object} map[string]interface{}
+// @Router /api/v1/analytics/top-categories [get]
+func (ac *AnalyticsController) GetTopCategories(c *gin.Context) {
+	var query utils.TopNQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		utils.BindingError(c, "Invalid query parameters", err)
+		return
+	}
+
+	categories, err := ac.service.GetTopCategories(c.Request.Context(), &query)
+	if err != nil {
+		utils.HandleError(c, "Failed to rank categories", err)
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Top categories retrieved successfully", categories)
+}
+
+// GetBuildingHotspots handles GET /api/v1/analytics/hotspots/buildings
+// @Summary Buildings ranked by number of reports
+// @Produce json
+// @Param limit query int false "Number of entries" default(10)
+// @Success 200 {array} utils.BuildingHotspot
+// @Failure 400 {object} map[string]interface{}
+// @Router /api/v1/analytics/hotspots/buildings [get]
+func (ac *AnalyticsController) GetBuildingHotspots(c *gin.Context) {
+	var query utils.TopNQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		utils.BindingError(c, "Invalid query parameters", err)
+		return
+	}
+
+	hotspots, err := ac.service.GetBuildingHotspots(c.Request.Context(), &query)
+	if err != nil {
+		utils.HandleError(c, "Failed to rank buildings", err)
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Building hotspots retrieved successfully", hotspots)
+}
+
+// GetFloorHotspots handles GET /api/v1/analytics/hotspots/floors
+// @Summary Floors ranked by number of reports
+// @Produce json
+// @Param limit query int false "Number of entries" default(10)
+// @Success 200 {array} utils.FloorHotspot
+// @Failure 400 {object} map[string]interface{}
+// @Router /api/v1/analytics/hotspots/floors [get]
+func (ac *AnalyticsController) GetFloorHotspots(c *gin.Context) {
+	var query utils.TopNQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		utils.BindingError(c, "Invalid query parameters", err)
+		return
+	}
+
+	hotspots, err := ac.service.GetFloorHotspots(c.Request.Context(), &query)
+	if err != nil {
+		utils.HandleError(c, "Failed to rank floors", err)
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Floor hotspots retrieved successfully", hotspots)
+}
+
+// GetTechnicianThroughput handles GET /api/v1/analytics/technicians
+// @Summary Assigned and completed reports per technician
+// @Produce json
+// @Success 200 {array} utils.TechnicianThroughput
+// @Failure 400 {object} map[string]interface{}
+// @Router /api/v1/analytics/technicians [get]
+func (ac *AnalyticsController) GetTechnicianThroughput(c *gin.Context) {
+	var query utils.AnalyticsQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		utils.BindingError(c, "Invalid query parameters", err)
+		return
+	}
+
+	throughput, err := ac.service.GetTechnicianThroughput(c.Request.Context(), &query)
+	if err != nil {
+		utils.HandleError(c, "Failed to compute technician throughput", err)
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Technician throughput retrieved successfully", throughput)
+}
+
+// GetTagCounts handles GET /api/v1/analytics/tags
+// @Summary Reports per tag
+// @Produce json
+// @Success 200 {array} utils.TagCount
+// @Failure 400 {object} map[string]interface{}
+// @Router /api/v1/analytics/tags [get]
+func (ac *AnalyticsController) GetTagCounts(c *gin.Context) {
+	var query utils.AnalyticsQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		utils.BindingError(c, "Invalid query parameters", err)
+		return
+	}
+
+	counts, err := ac.service.GetTagCounts(c.Request.Context(), &query)
+	if err != nil {
+		utils.HandleError(c, "Failed to count tags", err)
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Tag counts retrieved successfully", counts)
+}
@@ -0,0 +1,65 @@
+package controllers
+
+import (
+	"net/http"
+
+	"incident-report/services"
+	"incident-report/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// AuditController handles HTTP requests for the audit log
+type AuditController struct {
+	service *services.AuditService
+}
+
+// NewAuditController creates a new instance of AuditController
+func NewAuditController(service *services.AuditService) *AuditController {
+	return &AuditController{
+		service: service,
+	}
+}
+
+// ListAuditLogs handles GET /api/v1/audit
+// @Summary List audit log entries
+// @Description Lists the recorded creates, updates and deletes, most recent first
+// @Produce json
+// @Param entity query string false "Record type" Enums(users, buildings, floors, rooms, component-categories, components, reports, tags)
+// @Param entity_id query int false "Record ID"
+// @Param actor query int false "ID of the user who made the change"
+// @Param from query string false "First day (YYYY-MM-DD)"
+// @Param to query string false "Last day (YYYY-MM-DD)"
+// @Param page query int false "Page number" default(1)
+// @Param page_size query int false "Records per page" default(10)
+// @Param after query string false "Continue after this cursor (next_cursor of the previous page)"
+// @Param before query string false "Continue before this cursor (prev_cursor of the next page)"
+// @Param total query bool false "Count the matching records; defaults to true with page, false with a cursor"
+// @Success 200 {object} utils.PaginatedResponse
+// @Failure 400 {object} map[string]interface{}
+// @Router /api/v1/audit [get]
+func (ac *AuditController) ListAuditLogs(c *gin.Context) {
+	var query utils.AuditQuery
+
+	if err := c.ShouldBindQuery(&query); err != nil {
+		utils.BindingError(c, "Invalid query parameters", err)
+		return
+	}
+
+	if query.Page == 0 {
+		query.Page = 1
+	}
+	if query.PageSize == 0 {
+		query.PageSize = 10
+	}
+
+	entries, info, err := ac.service.ListAuditLogs(c.Request.Context(), &query)
+	if err != nil {
+		utils.HandleError(c, "Failed to fetch audit log", err)
+		return
+	}
+
+	response := utils.NewPaginatedResponse(entries, &query.PaginationQuery, info)
+
+	utils.SuccessResponse(c, http.StatusOK, "Audit log retrieved successfully", response)
+}
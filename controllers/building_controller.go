@@ -16,9 +16,9 @@ type BuildingController struct {
 }
 
 // NewBuildingController creates a new instance of BuildingController
-func NewBuildingController() *BuildingController {
+func NewBuildingController(service *services.BuildingService) *BuildingController {
 	return &BuildingController{
-		service: services.NewBuildingService(),
+		service: service,
 	}
 }
 
@@ -35,13 +35,13 @@ func (bc *BuildingController) CreateBuilding(c *gin.Context) {
 	var req utils.CreateBuildingRequest
 
 	if err := c.ShouldBindJSON(&req); err != nil {
-		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid request format", err.Error())
+		utils.BindingError(c, "Invalid request format", err)
 		return
 	}
 
-	building, err := bc.service.CreateBuilding(&req)
+	building, err := bc.service.CreateBuilding(c.Request.Context(), &req)
 	if err != nil {
-		utils.ErrorResponse(c, http.StatusBadRequest, "Failed to create building", err.Error())
+		utils.HandleError(c, "Failed to create building", err)
 		return
 	}
 
@@ -54,6 +54,7 @@ func (bc *BuildingController) CreateBuilding(c *gin.Context) {
 // @Produce json
 // @Param id path int true "Building ID"
 // @Success 200 {object} utils.BuildingResponse
+// @Header 200 {string} ETag "Version of the record, for If-Match"
 // @Failure 404 {object} map[string]interface{}
 // @Router /api/v1/buildings/{id} [get]
 func (bc *BuildingController) GetBuilding(c *gin.Context) {
@@ -63,12 +64,13 @@ func (bc *BuildingController) GetBuilding(c *gin.Context) {
 		return
 	}
 
-	building, err := bc.service.GetBuildingByID(uint(id))
+	building, err := bc.service.GetBuildingByID(c.Request.Context(), uint(id))
 	if err != nil {
-		utils.ErrorResponse(c, http.StatusNotFound, "Building not found", err.Error())
+		utils.HandleError(c, "Failed to retrieve building", err)
 		return
 	}
 
+	utils.SetETag(c, building.Version)
 	utils.SuccessResponse(c, http.StatusOK, "Building retrieved successfully", building)
 }
 
@@ -78,6 +80,9 @@ func (bc *BuildingController) GetBuilding(c *gin.Context) {
 // @Produce json
 // @Param page query int false "Page number" default(1)
 // @Param pageSize query int false "Page size" default(10)
+// @Param after query string false "Continue after this cursor (next_cursor of the previous page)"
+// @Param before query string false "Continue before this cursor (prev_cursor of the next page)"
+// @Param total query bool false "Count the matching records; defaults to true with page, false with a cursor"
 // @Success 200 {object} utils.PaginatedResponse
 // @Failure 400 {object} map[string]interface{}
 // @Router /api/v1/buildings [get]
@@ -85,33 +90,24 @@ func (bc *BuildingController) GetAllBuildings(c *gin.Context) {
 	var query utils.PaginationQuery
 
 	if err := c.ShouldBindQuery(&query); err != nil {
-		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid query parameters", err.Error())
+		utils.BindingError(c, "Invalid query parameters", err)
 		return
 	}
 
-	// Apply same defaults as service to avoid division by zero in controller
-	page := query.Page
-	pageSize := query.PageSize
-	if page <= 0 {
-		page = 1
+	if query.Page == 0 {
+		query.Page = 1
 	}
-	if pageSize <= 0 || pageSize > 100 {
-		pageSize = 10
+	if query.PageSize == 0 {
+		query.PageSize = 10
 	}
 
-	buildings, total, err := bc.service.GetAllBuildings(page, pageSize)
+	buildings, info, err := bc.service.GetAllBuildings(c.Request.Context(), &query)
 	if err != nil {
-		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to retrieve buildings", err.Error())
+		utils.HandleError(c, "Failed to retrieve buildings", err)
 		return
 	}
 
-	response := utils.PaginatedResponse{
-		Data:      buildings,
-		Total:     total,
-		Page:      page,
-		PageSize:  pageSize,
-		TotalPage: int((total + int64(pageSize) - 1) / int64(pageSize)),
-	}
+	response := utils.NewPaginatedResponse(buildings, &query, info)
 
 	utils.SuccessResponse(c, http.StatusOK, "Buildings retrieved successfully", response)
 }
@@ -122,10 +118,13 @@ func (bc *BuildingController) GetAllBuildings(c *gin.Context) {
 // @Accept json
 // @Produce json
 // @Param id path int true "Building ID"
+// @Param If-Match header string false "ETag of the version being changed"
 // @Param request body utils.UpdateBuildingRequest true "Building details to update"
 // @Success 200 {object} utils.BuildingResponse
+// @Header 200 {string} ETag "Version of the record, for If-Match"
 // @Failure 400 {object} map[string]interface{}
 // @Failure 404 {object} map[string]interface{}
+// @Failure 412 {object} map[string]interface{}
 // @Router /api/v1/buildings/{id} [put]
 func (bc *BuildingController) UpdateBuilding(c *gin.Context) {
 	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
@@ -136,26 +135,97 @@ func (bc *BuildingController) UpdateBuilding(c *gin.Context) {
 
 	var req utils.UpdateBuildingRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BindingError(c, "Invalid request format", err)
+		return
+	}
+
+	building, err := bc.service.UpdateBuilding(c.Request.Context(), uint(id), &req)
+	if err != nil {
+		utils.HandleError(c, "Failed to update building", err)
+		return
+	}
+
+	utils.SetETag(c, building.Version)
+	utils.SuccessResponse(c, http.StatusOK, "Building updated successfully", building)
+}
+
+// PatchBuilding handles PATCH /api/v1/buildings/:id
+// @Summary Patch a building
+// @Description Applies a JSON Merge Patch to an existing building; a null member clears the field
+// @Accept application/merge-patch+json,json
+// @Produce json
+// @Param id path int true "Building ID"
+// @Param If-Match header string false "ETag of the version being changed"
+// @Param request body utils.PatchBuildingRequest true "Members to change, null to clear"
+// @Success 200 {object} utils.BuildingResponse
+// @Header 200 {string} ETag "Version of the record, for If-Match"
+// @Failure 400 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 412 {object} map[string]interface{}
+// @Failure 415 {object} map[string]interface{}
+// @Router /api/v1/buildings/{id} [patch]
+func (bc *BuildingController) PatchBuilding(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid building ID", err.Error())
+		return
+	}
+
+	if !utils.IsMergePatch(c.ContentType()) {
+		utils.ErrorResponse(c, http.StatusUnsupportedMediaType, "Unsupported patch format", "send a JSON Merge Patch as "+utils.MergePatchContentType)
+		return
+	}
+	patch, err := c.GetRawData()
+	if err != nil {
 		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid request format", err.Error())
 		return
 	}
 
-	building, err := bc.service.UpdateBuilding(uint(id), &req)
+	building, err := bc.service.PatchBuilding(c.Request.Context(), uint(id), patch)
 	if err != nil {
-		utils.ErrorResponse(c, http.StatusBadRequest, "Failed to update building", err.Error())
+		utils.HandleError(c, "Failed to update building", err)
 		return
 	}
 
+	utils.SetETag(c, building.Version)
 	utils.SuccessResponse(c, http.StatusOK, "Building updated successfully", building)
 }
 
+// GetBuildingDeleteImpact handles GET /api/v1/buildings/:id/delete-impact
+// @Summary Preview the impact of deleting a building
+// @Description Counts the floors, rooms, components and reports a cascading delete would remove
+// @Produce json
+// @Param id path int true "Building ID"
+// @Success 200 {object} utils.DeleteImpactResponse
+// @Failure 404 {object} map[string]interface{}
+// @Router /api/v1/buildings/{id}/delete-impact [get]
+func (bc *BuildingController) GetBuildingDeleteImpact(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid building ID", err.Error())
+		return
+	}
+
+	impact, err := bc.service.GetBuildingDeleteImpact(c.Request.Context(), uint(id))
+	if err != nil {
+		utils.HandleError(c, "Failed to compute delete impact", err)
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Delete impact retrieved successfully", impact)
+}
+
 // DeleteBuilding handles DELETE /api/v1/buildings/:id
 // @Summary Delete a building
 // @Description Deletes (soft delete) an existing building
 // @Produce json
 // @Param id path int true "Building ID"
+// @Param If-Match header string false "ETag of the version being changed"
+// @Param cascade query bool false "Delete the dependents too"
 // @Success 204 {object} map[string]interface{}
 // @Failure 404 {object} map[string]interface{}
+// @Failure 409 {object} map[string]interface{}
+// @Failure 412 {object} map[string]interface{}
 // @Router /api/v1/buildings/{id} [delete]
 func (bc *BuildingController) DeleteBuilding(c *gin.Context) {
 	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
@@ -164,9 +234,15 @@ func (bc *BuildingController) DeleteBuilding(c *gin.Context) {
 		return
 	}
 
-	err = bc.service.DeleteBuilding(uint(id))
+	var query utils.DeleteQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		utils.BindingError(c, "Invalid query parameters", err)
+		return
+	}
+
+	err = bc.service.DeleteBuilding(c.Request.Context(), uint(id), query.Cascade)
 	if err != nil {
-		utils.ErrorResponse(c, http.StatusNotFound, "Building not found", err.Error())
+		utils.HandleError(c, "Failed to delete building", err)
 		return
 	}
 
@@ -12,13 +12,15 @@ import (
 
 // ComponentCategoryController handles HTTP requests for component category operations
 type ComponentCategoryController struct {
-	service *services.ComponentCategoryService
+	service            *services.ComponentCategoryService
+	reliabilityService *services.ReliabilityService
 }
 
 // NewComponentCategoryController creates a new instance of ComponentCategoryController
-func NewComponentCategoryController() *ComponentCategoryController {
+func NewComponentCategoryController(service *services.ComponentCategoryService, reliabilityService *services.ReliabilityService) *ComponentCategoryController {
 	return &ComponentCategoryController{
-		service: services.NewComponentCategoryService(),
+		service:            service,
+		reliabilityService: reliabilityService,
 	}
 }
 
@@ -35,13 +37,13 @@ func (ccc *ComponentCategoryController) CreateComponentCategory(c *gin.Context)
 	var req utils.CreateComponentCategoryRequest
 
 	if err := c.ShouldBindJSON(&req); err != nil {
-		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid request format", err.Error())
+		utils.BindingError(c, "Invalid request format", err)
 		return
 	}
 
-	category, err := ccc.service.CreateComponentCategory(&req)
+	category, err := ccc.service.CreateComponentCategory(c.Request.Context(), &req)
 	if err != nil {
-		utils.ErrorResponse(c, http.StatusBadRequest, "Failed to create component category", err.Error())
+		utils.HandleError(c, "Failed to create component category", err)
 		return
 	}
 
@@ -54,6 +56,7 @@ func (ccc *ComponentCategoryController) CreateComponentCategory(c *gin.Context)
 // @Produce json
 // @Param id path int true "Category ID"
 // @Success 200 {object} utils.ComponentCategoryResponse
+// @Header 200 {string} ETag "Version of the record, for If-Match"
 // @Failure 404 {object} map[string]interface{}
 // @Router /api/v1/component-categories/{id} [get]
 func (ccc *ComponentCategoryController) GetComponentCategory(c *gin.Context) {
@@ -63,12 +66,13 @@ func (ccc *ComponentCategoryController) GetComponentCategory(c *gin.Context) {
 		return
 	}
 
-	category, err := ccc.service.GetComponentCategoryByID(uint(id))
+	category, err := ccc.service.GetComponentCategoryByID(c.Request.Context(), uint(id))
 	if err != nil {
-		utils.ErrorResponse(c, http.StatusNotFound, "Component category not found", err.Error())
+		utils.HandleError(c, "Failed to retrieve component category", err)
 		return
 	}
 
+	utils.SetETag(c, category.Version)
 	utils.SuccessResponse(c, http.StatusOK, "Component category retrieved successfully", category)
 }
 
@@ -78,6 +82,9 @@ func (ccc *ComponentCategoryController) GetComponentCategory(c *gin.Context) {
 // @Produce json
 // @Param page query int false "Page number" default(1)
 // @Param pageSize query int false "Page size" default(10)
+// @Param after query string false "Continue after this cursor (next_cursor of the previous page)"
+// @Param before query string false "Continue before this cursor (prev_cursor of the next page)"
+// @Param total query bool false "Count the matching records; defaults to true with page, false with a cursor"
 // @Success 200 {object} utils.PaginatedResponse
 // @Failure 400 {object} map[string]interface{}
 // @Router /api/v1/component-categories [get]
@@ -85,32 +92,24 @@ func (ccc *ComponentCategoryController) GetAllComponentCategories(c *gin.Context
 	var query utils.PaginationQuery
 
 	if err := c.ShouldBindQuery(&query); err != nil {
-		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid query parameters", err.Error())
+		utils.BindingError(c, "Invalid query parameters", err)
 		return
 	}
 
-	page := query.Page
-	pageSize := query.PageSize
-	if page <= 0 {
-		page = 1
+	if query.Page == 0 {
+		query.Page = 1
 	}
-	if pageSize <= 0 || pageSize > 100 {
-		pageSize = 10
+	if query.PageSize == 0 {
+		query.PageSize = 10
 	}
 
-	categories, total, err := ccc.service.GetAllComponentCategories(page, pageSize)
+	categories, info, err := ccc.service.GetAllComponentCategories(c.Request.Context(), &query)
 	if err != nil {
-		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to retrieve component categories", err.Error())
+		utils.HandleError(c, "Failed to retrieve component categories", err)
 		return
 	}
 
-	response := utils.PaginatedResponse{
-		Data:      categories,
-		Total:     total,
-		Page:      page,
-		PageSize:  pageSize,
-		TotalPage: int((total + int64(pageSize) - 1) / int64(pageSize)),
-	}
+	response := utils.NewPaginatedResponse(categories, &query, info)
 
 	utils.SuccessResponse(c, http.StatusOK, "Component categories retrieved successfully", response)
 }
@@ -121,10 +120,13 @@ func (ccc *ComponentCategoryController) GetAllComponentCategories(c *gin.Context
 // @Accept json
 // @Produce json
 // @Param id path int true "Category ID"
+// @Param If-Match header string false "ETag of the version being changed"
 // @Param request body utils.UpdateComponentCategoryRequest true "Category details to update"
 // @Success 200 {object} utils.ComponentCategoryResponse
+// @Header 200 {string} ETag "Version of the record, for If-Match"
 // @Failure 400 {object} map[string]interface{}
 // @Failure 404 {object} map[string]interface{}
+// @Failure 412 {object} map[string]interface{}
 // @Router /api/v1/component-categories/{id} [put]
 func (ccc *ComponentCategoryController) UpdateComponentCategory(c *gin.Context) {
 	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
@@ -135,26 +137,97 @@ func (ccc *ComponentCategoryController) UpdateComponentCategory(c *gin.Context)
 
 	var req utils.UpdateComponentCategoryRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BindingError(c, "Invalid request format", err)
+		return
+	}
+
+	category, err := ccc.service.UpdateComponentCategory(c.Request.Context(), uint(id), &req)
+	if err != nil {
+		utils.HandleError(c, "Failed to update component category", err)
+		return
+	}
+
+	utils.SetETag(c, category.Version)
+	utils.SuccessResponse(c, http.StatusOK, "Component category updated successfully", category)
+}
+
+// PatchComponentCategory handles PATCH /api/v1/component-categories/:id
+// @Summary Patch a component category
+// @Description Applies a JSON Merge Patch to an existing component category; a null member clears the field
+// @Accept application/merge-patch+json,json
+// @Produce json
+// @Param id path int true "Category ID"
+// @Param If-Match header string false "ETag of the version being changed"
+// @Param request body utils.PatchComponentCategoryRequest true "Members to change, null to clear"
+// @Success 200 {object} utils.ComponentCategoryResponse
+// @Header 200 {string} ETag "Version of the record, for If-Match"
+// @Failure 400 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 412 {object} map[string]interface{}
+// @Failure 415 {object} map[string]interface{}
+// @Router /api/v1/component-categories/{id} [patch]
+func (ccc *ComponentCategoryController) PatchComponentCategory(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid category ID", err.Error())
+		return
+	}
+
+	if !utils.IsMergePatch(c.ContentType()) {
+		utils.ErrorResponse(c, http.StatusUnsupportedMediaType, "Unsupported patch format", "send a JSON Merge Patch as "+utils.MergePatchContentType)
+		return
+	}
+	patch, err := c.GetRawData()
+	if err != nil {
 		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid request format", err.Error())
 		return
 	}
 
-	category, err := ccc.service.UpdateComponentCategory(uint(id), &req)
+	category, err := ccc.service.PatchComponentCategory(c.Request.Context(), uint(id), patch)
 	if err != nil {
-		utils.ErrorResponse(c, http.StatusBadRequest, "Failed to update component category", err.Error())
+		utils.HandleError(c, "Failed to update component category", err)
 		return
 	}
 
+	utils.SetETag(c, category.Version)
 	utils.SuccessResponse(c, http.StatusOK, "Component category updated successfully", category)
 }
 
+// GetComponentCategoryDeleteImpact handles GET /api/v1/component-categories/:id/delete-impact
+// @Summary Preview the impact of deleting a component category
+// @Description Counts the floors, rooms, components and reports a cascading delete would remove
+// @Produce json
+// @Param id path int true "Category ID"
+// @Success 200 {object} utils.DeleteImpactResponse
+// @Failure 404 {object} map[string]interface{}
+// @Router /api/v1/component-categories/{id}/delete-impact [get]
+func (ccc *ComponentCategoryController) GetComponentCategoryDeleteImpact(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid category ID", err.Error())
+		return
+	}
+
+	impact, err := ccc.service.GetComponentCategoryDeleteImpact(c.Request.Context(), uint(id))
+	if err != nil {
+		utils.HandleError(c, "Failed to compute delete impact", err)
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Delete impact retrieved successfully", impact)
+}
+
 // DeleteComponentCategory handles DELETE /api/v1/component-categories/:id
 // @Summary Delete a component category
 // @Description Deletes (soft delete) an existing component category
 // @Produce json
 // @Param id path int true "Category ID"
+// @Param If-Match header string false "ETag of the version being changed"
+// @Param cascade query bool false "Delete the dependents too"
 // @Success 204 {object} map[string]interface{}
 // @Failure 404 {object} map[string]interface{}
+// @Failure 409 {object} map[string]interface{}
+// @Failure 412 {object} map[string]interface{}
 // @Router /api/v1/component-categories/{id} [delete]
 func (ccc *ComponentCategoryController) DeleteComponentCategory(c *gin.Context) {
 	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
@@ -163,11 +236,41 @@ func (ccc *ComponentCategoryController) DeleteComponentCategory(c *gin.Context)
 		return
 	}
 
-	err = ccc.service.DeleteComponentCategory(uint(id))
+	var query utils.DeleteQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		utils.BindingError(c, "Invalid query parameters", err)
+		return
+	}
+
+	err = ccc.service.DeleteComponentCategory(c.Request.Context(), uint(id), query.Cascade)
 	if err != nil {
-		utils.ErrorResponse(c, http.StatusNotFound, "Component category not found", err.Error())
+		utils.HandleError(c, "Failed to delete component category", err)
 		return
 	}
 
 	c.JSON(http.StatusNoContent, gin.H{})
 }
+
+// GetCategoryReliability handles GET /api/v1/component-categories/:id/reliability
+// @Summary Get component category reliability
+// @Description Retrieves failure count, MTBF, average age and repair cost across a category
+// @Produce json
+// @Param id path int true "Category ID"
+// @Success 200 {object} utils.CategoryReliability
+// @Failure 404 {object} map[string]interface{}
+// @Router /api/v1/component-categories/{id}/reliability [get]
+func (ccc *ComponentCategoryController) GetCategoryReliability(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid category ID", err.Error())
+		return
+	}
+
+	reliability, err := ccc.reliabilityService.GetCategoryReliability(c.Request.Context(), uint(id))
+	if err != nil {
+		utils.HandleError(c, "Failed to retrieve component category reliability", err)
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Component category reliability retrieved successfully", reliability)
+}
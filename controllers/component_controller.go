@@ -12,13 +12,17 @@ import (
 
 // ComponentController handles HTTP requests for component operations
 type ComponentController struct {
-	service *services.ComponentService
+	service            *services.ComponentService
+	exportService      *services.ExportService
+	reliabilityService *services.ReliabilityService
 }
 
 // NewComponentController creates a new instance of ComponentController
-func NewComponentController() *ComponentController {
+func NewComponentController(service *services.ComponentService, exportService *services.ExportService, reliabilityService *services.ReliabilityService) *ComponentController {
 	return &ComponentController{
-		service: services.NewComponentService(),
+		service:            service,
+		exportService:      exportService,
+		reliabilityService: reliabilityService,
 	}
 }
 
@@ -35,13 +39,13 @@ func (cc *ComponentController) CreateComponent(c *gin.Context) {
 	var req utils.CreateComponentRequest
 
 	if err := c.ShouldBindJSON(&req); err != nil {
-		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid request format", err.Error())
+		utils.BindingError(c, "Invalid request format", err)
 		return
 	}
 
-	component, err := cc.service.CreateComponent(&req)
+	component, err := cc.service.CreateComponent(c.Request.Context(), &req)
 	if err != nil {
-		utils.ErrorResponse(c, http.StatusBadRequest, "Failed to create component", err.Error())
+		utils.HandleError(c, "Failed to create component", err)
 		return
 	}
 
@@ -54,6 +58,7 @@ func (cc *ComponentController) CreateComponent(c *gin.Context) {
 // @Produce json
 // @Param id path int true "Component ID"
 // @Success 200 {object} utils.ComponentResponse
+// @Header 200 {string} ETag "Version of the record, for If-Match"
 // @Failure 404 {object} map[string]interface{}
 // @Router /api/v1/components/{id} [get]
 func (cc *ComponentController) GetComponent(c *gin.Context) {
@@ -63,12 +68,13 @@ func (cc *ComponentController) GetComponent(c *gin.Context) {
 		return
 	}
 
-	component, err := cc.service.GetComponentByID(uint(id))
+	component, err := cc.service.GetComponentByID(c.Request.Context(), uint(id))
 	if err != nil {
-		utils.ErrorResponse(c, http.StatusNotFound, "Component not found", err.Error())
+		utils.HandleError(c, "Failed to retrieve component", err)
 		return
 	}
 
+	utils.SetETag(c, component.Version)
 	utils.SuccessResponse(c, http.StatusOK, "Component retrieved successfully", component)
 }
 
@@ -79,11 +85,14 @@ func (cc *ComponentController) GetComponent(c *gin.Context) {
 // @Param roomId path int true "Room ID"
 // @Param page query int false "Page number" default(1)
 // @Param pageSize query int false "Page size" default(10)
+// @Param after query string false "Continue after this cursor (next_cursor of the previous page)"
+// @Param before query string false "Continue before this cursor (prev_cursor of the next page)"
+// @Param total query bool false "Count the matching records; defaults to true with page, false with a cursor"
 // @Success 200 {object} utils.PaginatedResponse
 // @Failure 400 {object} map[string]interface{}
 // @Router /api/v1/rooms/{roomId}/components [get]
 func (cc *ComponentController) GetComponentsByRoom(c *gin.Context) {
-	roomID, err := strconv.ParseUint(c.Param("roomId"), 10, 32)
+	roomID, err := strconv.ParseUint(c.Param("id"), 10, 32)
 	if err != nil {
 		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid room ID", err.Error())
 		return
@@ -91,34 +100,24 @@ func (cc *ComponentController) GetComponentsByRoom(c *gin.Context) {
 
 	var query utils.PaginationQuery
 	if err := c.ShouldBindQuery(&query); err != nil {
-		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid query parameters", err.Error())
+		utils.BindingError(c, "Invalid query parameters", err)
 		return
 	}
 
-	page := query.Page
-	pageSize := query.PageSize
-	if page == 0 {
-		page = 1
+	if query.Page == 0 {
+		query.Page = 1
 	}
-	if pageSize == 0 {
-		pageSize = 10
+	if query.PageSize == 0 {
+		query.PageSize = 10
 	}
 
-	components, total, err := cc.service.GetComponentsByRoomID(uint(roomID), page, pageSize)
+	components, info, err := cc.service.GetComponentsByRoomID(c.Request.Context(), uint(roomID), &query)
 	if err != nil {
-		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to retrieve components", err.Error())
+		utils.HandleError(c, "Failed to retrieve components", err)
 		return
 	}
 
-	totalPage := (int(total) + pageSize - 1) / pageSize
-
-	response := utils.PaginatedResponse{
-		Data:      components,
-		Page:      page,
-		PageSize:  pageSize,
-		Total:     total,
-		TotalPage: totalPage,
-	}
+	response := utils.NewPaginatedResponse(components, &query, info)
 
 	utils.SuccessResponse(c, http.StatusOK, "Components retrieved successfully", response)
 }
@@ -130,11 +129,14 @@ func (cc *ComponentController) GetComponentsByRoom(c *gin.Context) {
 // @Param categoryId path int true "Category ID"
 // @Param page query int false "Page number" default(1)
 // @Param pageSize query int false "Page size" default(10)
+// @Param after query string false "Continue after this cursor (next_cursor of the previous page)"
+// @Param before query string false "Continue before this cursor (prev_cursor of the next page)"
+// @Param total query bool false "Count the matching records; defaults to true with page, false with a cursor"
 // @Success 200 {object} utils.PaginatedResponse
 // @Failure 400 {object} map[string]interface{}
 // @Router /api/v1/component-categories/{categoryId}/components [get]
 func (cc *ComponentController) GetComponentsByCategory(c *gin.Context) {
-	categoryID, err := strconv.ParseUint(c.Param("categoryId"), 10, 32)
+	categoryID, err := strconv.ParseUint(c.Param("id"), 10, 32)
 	if err != nil {
 		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid category ID", err.Error())
 		return
@@ -142,34 +144,24 @@ func (cc *ComponentController) GetComponentsByCategory(c *gin.Context) {
 
 	var query utils.PaginationQuery
 	if err := c.ShouldBindQuery(&query); err != nil {
-		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid query parameters", err.Error())
+		utils.BindingError(c, "Invalid query parameters", err)
 		return
 	}
 
-	page := query.Page
-	pageSize := query.PageSize
-	if page == 0 {
-		page = 1
+	if query.Page == 0 {
+		query.Page = 1
 	}
-	if pageSize == 0 {
-		pageSize = 10
+	if query.PageSize == 0 {
+		query.PageSize = 10
 	}
 
-	components, total, err := cc.service.GetComponentsByCategoryID(uint(categoryID), page, pageSize)
+	components, info, err := cc.service.GetComponentsByCategoryID(c.Request.Context(), uint(categoryID), &query)
 	if err != nil {
-		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to retrieve components", err.Error())
+		utils.HandleError(c, "Failed to retrieve components", err)
 		return
 	}
 
-	totalPage := (int(total) + pageSize - 1) / pageSize
-
-	response := utils.PaginatedResponse{
-		Data:      components,
-		Page:      page,
-		PageSize:  pageSize,
-		Total:     total,
-		TotalPage: totalPage,
-	}
+	response := utils.NewPaginatedResponse(components, &query, info)
 
 	utils.SuccessResponse(c, http.StatusOK, "Components retrieved successfully", response)
 }
@@ -180,14 +172,39 @@ func (cc *ComponentController) GetComponentsByCategory(c *gin.Context) {
 // @Produce json
 // @Param page query int false "Page number" default(1)
 // @Param page_size query int false "Records per page" default(10)
+// @Param after query string false "Continue after this cursor (next_cursor of the previous page)"
+// @Param before query string false "Continue before this cursor (prev_cursor of the next page)"
+// @Param total query bool false "Count the matching records; defaults to true with page, false with a cursor"
+// @Param room_id query int false "Filter by room"
+// @Param category_id query int false "Filter by category"
+// @Param format query string false "Export format (json, csv, xlsx, pdf)"
 // @Success 200 {object} utils.PaginatedResponse
 // @Failure 400 {object} map[string]interface{}
 // @Router /api/v1/components [get]
 func (cc *ComponentController) GetAllComponents(c *gin.Context) {
 	var pagination utils.PaginationQuery
+	var filter utils.ComponentFilterQuery
+	var export utils.ExportQuery
 
 	if err := c.ShouldBindQuery(&pagination); err != nil {
-		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid query parameters", err.Error())
+		utils.BindingError(c, "Invalid query parameters", err)
+		return
+	}
+	if err := c.ShouldBindQuery(&filter); err != nil {
+		utils.BindingError(c, "Invalid query parameters", err)
+		return
+	}
+	if err := c.ShouldBindQuery(&export); err != nil {
+		utils.BindingError(c, "Invalid query parameters", err)
+		return
+	}
+
+	// File exports ignore pagination and stream every matching component
+	if format := utils.ResolveExportFormat(c, export.Format); format != utils.ExportFormatJSON {
+		utils.SetExportHeaders(c, format, "components")
+		if err := cc.exportService.ExportComponents(c.Request.Context(), c.Writer, format, &filter); err != nil {
+			writeExportError(c, err)
+		}
 		return
 	}
 
@@ -198,21 +215,13 @@ func (cc *ComponentController) GetAllComponents(c *gin.Context) {
 		pagination.PageSize = 10
 	}
 
-	components, total, err := cc.service.GetAllComponents(pagination.Page, pagination.PageSize)
+	components, info, err := cc.service.GetAllComponents(c.Request.Context(), &pagination, &filter)
 	if err != nil {
-		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to fetch components", err.Error())
+		utils.HandleError(c, "Failed to fetch components", err)
 		return
 	}
 
-	totalPage := (int(total) + pagination.PageSize - 1) / pagination.PageSize
-
-	response := utils.PaginatedResponse{
-		Data:      components,
-		Page:      pagination.Page,
-		PageSize:  pagination.PageSize,
-		Total:     total,
-		TotalPage: totalPage,
-	}
+	response := utils.NewPaginatedResponse(components, &pagination, info)
 
 	utils.SuccessResponse(c, http.StatusOK, "Components retrieved successfully", response)
 }
@@ -223,10 +232,13 @@ func (cc *ComponentController) GetAllComponents(c *gin.Context) {
 // @Accept json
 // @Produce json
 // @Param id path int true "Component ID"
+// @Param If-Match header string false "ETag of the version being changed"
 // @Param request body utils.AssignRoomRequest true "Room assignment details"
 // @Success 200 {object} utils.ComponentResponse
+// @Header 200 {string} ETag "Version of the record, for If-Match"
 // @Failure 400 {object} map[string]interface{}
 // @Failure 404 {object} map[string]interface{}
+// @Failure 412 {object} map[string]interface{}
 // @Router /api/v1/components/{id}/assign-room [put]
 func (cc *ComponentController) AssignRoomToComponent(c *gin.Context) {
 	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
@@ -237,16 +249,17 @@ func (cc *ComponentController) AssignRoomToComponent(c *gin.Context) {
 
 	var req utils.AssignRoomRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid request format", err.Error())
+		utils.BindingError(c, "Invalid request format", err)
 		return
 	}
 
-	component, err := cc.service.AssignRoomToComponent(uint(id), &req)
+	component, err := cc.service.AssignRoomToComponent(c.Request.Context(), uint(id), &req)
 	if err != nil {
-		utils.ErrorResponse(c, http.StatusBadRequest, "Failed to assign room to component", err.Error())
+		utils.HandleError(c, "Failed to assign room to component", err)
 		return
 	}
 
+	utils.SetETag(c, component.Version)
 	utils.SuccessResponse(c, http.StatusOK, "Room assigned to component successfully", component)
 }
 
@@ -256,10 +269,13 @@ func (cc *ComponentController) AssignRoomToComponent(c *gin.Context) {
 // @Accept json
 // @Produce json
 // @Param id path int true "Component ID"
+// @Param If-Match header string false "ETag of the version being changed"
 // @Param request body utils.UpdateComponentRequest true "Component details to update"
 // @Success 200 {object} utils.ComponentResponse
+// @Header 200 {string} ETag "Version of the record, for If-Match"
 // @Failure 400 {object} map[string]interface{}
 // @Failure 404 {object} map[string]interface{}
+// @Failure 412 {object} map[string]interface{}
 // @Router /api/v1/components/{id} [put]
 func (cc *ComponentController) UpdateComponent(c *gin.Context) {
 	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
@@ -270,16 +286,59 @@ func (cc *ComponentController) UpdateComponent(c *gin.Context) {
 
 	var req utils.UpdateComponentRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BindingError(c, "Invalid request format", err)
+		return
+	}
+
+	component, err := cc.service.UpdateComponent(c.Request.Context(), uint(id), &req)
+	if err != nil {
+		utils.HandleError(c, "Failed to update component", err)
+		return
+	}
+
+	utils.SetETag(c, component.Version)
+	utils.SuccessResponse(c, http.StatusOK, "Component updated successfully", component)
+}
+
+// PatchComponent handles PATCH /api/v1/components/:id
+// @Summary Patch a component
+// @Description Applies a JSON Merge Patch to an existing component; a null member clears the field
+// @Accept application/merge-patch+json,json
+// @Produce json
+// @Param id path int true "Component ID"
+// @Param If-Match header string false "ETag of the version being changed"
+// @Param request body utils.PatchComponentRequest true "Members to change, null to clear"
+// @Success 200 {object} utils.ComponentResponse
+// @Header 200 {string} ETag "Version of the record, for If-Match"
+// @Failure 400 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 412 {object} map[string]interface{}
+// @Failure 415 {object} map[string]interface{}
+// @Router /api/v1/components/{id} [patch]
+func (cc *ComponentController) PatchComponent(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid component ID", err.Error())
+		return
+	}
+
+	if !utils.IsMergePatch(c.ContentType()) {
+		utils.ErrorResponse(c, http.StatusUnsupportedMediaType, "Unsupported patch format", "send a JSON Merge Patch as "+utils.MergePatchContentType)
+		return
+	}
+	patch, err := c.GetRawData()
+	if err != nil {
 		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid request format", err.Error())
 		return
 	}
 
-	component, err := cc.service.UpdateComponent(uint(id), &req)
+	component, err := cc.service.PatchComponent(c.Request.Context(), uint(id), patch)
 	if err != nil {
-		utils.ErrorResponse(c, http.StatusBadRequest, "Failed to update component", err.Error())
+		utils.HandleError(c, "Failed to update component", err)
 		return
 	}
 
+	utils.SetETag(c, component.Version)
 	utils.SuccessResponse(c, http.StatusOK, "Component updated successfully", component)
 }
 
@@ -288,8 +347,10 @@ func (cc *ComponentController) UpdateComponent(c *gin.Context) {
 // @Description Deletes (soft delete) an existing component
 // @Produce json
 // @Param id path int true "Component ID"
+// @Param If-Match header string false "ETag of the version being changed"
 // @Success 204 {object} map[string]interface{}
 // @Failure 404 {object} map[string]interface{}
+// @Failure 412 {object} map[string]interface{}
 // @Router /api/v1/components/{id} [delete]
 func (cc *ComponentController) DeleteComponent(c *gin.Context) {
 	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
@@ -298,11 +359,89 @@ func (cc *ComponentController) DeleteComponent(c *gin.Context) {
 		return
 	}
 
-	err = cc.service.DeleteComponent(uint(id))
+	err = cc.service.DeleteComponent(c.Request.Context(), uint(id))
 	if err != nil {
-		utils.ErrorResponse(c, http.StatusNotFound, "Component not found", err.Error())
+		utils.HandleError(c, "Failed to delete component", err)
 		return
 	}
 
 	c.JSON(http.StatusNoContent, gin.H{})
 }
+
+// GetComponentReliability handles GET /api/v1/components/:id/reliability
+// @Summary Get component reliability
+// @Description Retrieves failure count, MTBF, age and repair cost of a component
+// @Produce json
+// @Param id path int true "Component ID"
+// @Success 200 {object} utils.ComponentReliability
+// @Failure 404 {object} map[string]interface{}
+// @Router /api/v1/components/{id}/reliability [get]
+func (cc *ComponentController) GetComponentReliability(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid component ID", err.Error())
+		return
+	}
+
+	reliability, err := cc.reliabilityService.GetComponentReliability(c.Request.Context(), uint(id))
+	if err != nil {
+		utils.HandleError(c, "Failed to retrieve component reliability", err)
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Component reliability retrieved successfully", reliability)
+}
+
+// GetReplacementCandidates handles GET /api/v1/components/replacement-candidates
+// @Summary Rank components for replacement
+// @Description Ranks components by a weighted score of age, failure frequency and repair cost
+// @Produce json
+// @Param category_id query int false "Filter by category"
+// @Param limit query int false "Number of entries" default(10)
+// @Param age_weight query number false "Weight of the component age"
+// @Param failure_weight query number false "Weight of the failures per year"
+// @Param cost_weight query number false "Weight of the total repair cost"
+// @Success 200 {array} utils.ReplacementCandidate
+// @Failure 400 {object} map[string]interface{}
+// @Router /api/v1/components/replacement-candidates [get]
+func (cc *ComponentController) GetReplacementCandidates(c *gin.Context) {
+	var query utils.ReplacementCandidatesQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		utils.BindingError(c, "Invalid query parameters", err)
+		return
+	}
+
+	candidates, err := cc.reliabilityService.GetReplacementCandidates(c.Request.Context(), &query)
+	if err != nil {
+		utils.HandleError(c, "Failed to rank replacement candidates", err)
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Replacement candidates retrieved successfully", candidates)
+}
+
+// BulkMoveComponents handles POST /api/v1/components/bulk-move
+// @Summary Move many components to a room
+// @Description Moves every listed component to one room, all or nothing (atomic) or best effort
+// @Accept json
+// @Produce json
+// @Param request body utils.BulkMoveComponentsRequest true "Components and the room to move them to"
+// @Success 200 {object} utils.BulkResponse
+// @Failure 400 {object} map[string]interface{}
+// @Failure 409 {object} utils.BulkResponse
+// @Router /api/v1/components/bulk-move [post]
+func (cc *ComponentController) BulkMoveComponents(c *gin.Context) {
+	var req utils.BulkMoveComponentsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BindingError(c, "Invalid request format", err)
+		return
+	}
+
+	result, err := cc.service.BulkMoveComponents(c.Request.Context(), &req)
+	if err != nil {
+		utils.HandleError(c, "Failed to move components", err)
+		return
+	}
+
+	utils.BulkResultResponse(c, "Components moved", result)
+}
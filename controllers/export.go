@@ -0,0 +1,21 @@
+package controllers
+
+import (
+	"incident-report/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// writeExportError reports a failed export
+// Once the file has started streaming the status line is gone, so the connection is simply aborted
+func writeExportError(c *gin.Context, err error) {
+	if c.Writer.Written() {
+		_ = c.Error(err)
+		c.Abort()
+		return
+	}
+
+	c.Writer.Header().Del("Content-Type")
+	c.Writer.Header().Del("Content-Disposition")
+	utils.HandleError(c, "Failed to export data", err)
+}
@@ -16,9 +16,9 @@ type FloorController struct {
 }
 
 // NewFloorController creates a new instance of FloorController
-func NewFloorController() *FloorController {
+func NewFloorController(service *services.FloorService) *FloorController {
 	return &FloorController{
-		service: services.NewFloorService(),
+		service: service,
 	}
 }
 
@@ -35,13 +35,13 @@ func (fc *FloorController) CreateFloor(c *gin.Context) {
 	var req utils.CreateFloorRequest
 
 	if err := c.ShouldBindJSON(&req); err != nil {
-		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid request format", err.Error())
+		utils.BindingError(c, "Invalid request format", err)
 		return
 	}
 
-	floor, err := fc.service.CreateFloor(&req)
+	floor, err := fc.service.CreateFloor(c.Request.Context(), &req)
 	if err != nil {
-		utils.ErrorResponse(c, http.StatusBadRequest, "Failed to create floor", err.Error())
+		utils.HandleError(c, "Failed to create floor", err)
 		return
 	}
 
@@ -54,6 +54,9 @@ func (fc *FloorController) CreateFloor(c *gin.Context) {
 // @Produce json
 // @Param page query int false "Page number" default(1)
 // @Param page_size query int false "Records per page" default(10)
+// @Param after query string false "Continue after this cursor (next_cursor of the previous page)"
+// @Param before query string false "Continue before this cursor (prev_cursor of the next page)"
+// @Param total query bool false "Count the matching records; defaults to true with page, false with a cursor"
 // @Success 200 {object} utils.PaginatedResponse
 // @Failure 400 {object} map[string]interface{}
 // @Router /api/v1/floors [get]
@@ -61,7 +64,7 @@ func (fc *FloorController) GetAllFloors(c *gin.Context) {
 	var pagination utils.PaginationQuery
 
 	if err := c.ShouldBindQuery(&pagination); err != nil {
-		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid query parameters", err.Error())
+		utils.BindingError(c, "Invalid query parameters", err)
 		return
 	}
 
@@ -72,21 +75,13 @@ func (fc *FloorController) GetAllFloors(c *gin.Context) {
 		pagination.PageSize = 10
 	}
 
-	floors, total, err := fc.service.GetAllFloors(pagination.Page, pagination.PageSize)
+	floors, info, err := fc.service.GetAllFloors(c.Request.Context(), &pagination)
 	if err != nil {
-		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to fetch floors", err.Error())
+		utils.HandleError(c, "Failed to fetch floors", err)
 		return
 	}
 
-	totalPage := (int(total) + pagination.PageSize - 1) / pagination.PageSize
-
-	response := utils.PaginatedResponse{
-		Data:      floors,
-		Page:      pagination.Page,
-		PageSize:  pagination.PageSize,
-		Total:     total,
-		TotalPage: totalPage,
-	}
+	response := utils.NewPaginatedResponse(floors, &pagination, info)
 
 	utils.SuccessResponse(c, http.StatusOK, "Floors retrieved successfully", response)
 }
@@ -97,6 +92,7 @@ func (fc *FloorController) GetAllFloors(c *gin.Context) {
 // @Produce json
 // @Param id path int true "Floor ID"
 // @Success 200 {object} utils.FloorResponse
+// @Header 200 {string} ETag "Version of the record, for If-Match"
 // @Failure 404 {object} map[string]interface{}
 // @Router /api/v1/floors/{id} [get]
 func (fc *FloorController) GetFloor(c *gin.Context) {
@@ -106,12 +102,13 @@ func (fc *FloorController) GetFloor(c *gin.Context) {
 		return
 	}
 
-	floor, err := fc.service.GetFloorByID(uint(id))
+	floor, err := fc.service.GetFloorByID(c.Request.Context(), uint(id))
 	if err != nil {
-		utils.ErrorResponse(c, http.StatusNotFound, "Floor not found", err.Error())
+		utils.HandleError(c, "Failed to retrieve floor", err)
 		return
 	}
 
+	utils.SetETag(c, floor.Version)
 	utils.SuccessResponse(c, http.StatusOK, "Floor retrieved successfully", floor)
 }
 
@@ -122,11 +119,14 @@ func (fc *FloorController) GetFloor(c *gin.Context) {
 // @Param buildingId path int true "Building ID"
 // @Param page query int false "Page number" default(1)
 // @Param pageSize query int false "Page size" default(10)
+// @Param after query string false "Continue after this cursor (next_cursor of the previous page)"
+// @Param before query string false "Continue before this cursor (prev_cursor of the next page)"
+// @Param total query bool false "Count the matching records; defaults to true with page, false with a cursor"
 // @Success 200 {object} utils.PaginatedResponse
 // @Failure 400 {object} map[string]interface{}
 // @Router /api/v1/buildings/{buildingId}/floors [get]
 func (fc *FloorController) GetFloorsByBuilding(c *gin.Context) {
-	buildingID, err := strconv.ParseUint(c.Param("buildingId"), 10, 32)
+	buildingID, err := strconv.ParseUint(c.Param("id"), 10, 32)
 	if err != nil {
 		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid building ID", err.Error())
 		return
@@ -134,32 +134,24 @@ func (fc *FloorController) GetFloorsByBuilding(c *gin.Context) {
 
 	var query utils.PaginationQuery
 	if err := c.ShouldBindQuery(&query); err != nil {
-		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid query parameters", err.Error())
+		utils.BindingError(c, "Invalid query parameters", err)
 		return
 	}
 
-	page := query.Page
-	pageSize := query.PageSize
-	if page <= 0 {
-		page = 1
+	if query.Page == 0 {
+		query.Page = 1
 	}
-	if pageSize <= 0 || pageSize > 100 {
-		pageSize = 10
+	if query.PageSize == 0 {
+		query.PageSize = 10
 	}
 
-	floors, total, err := fc.service.GetFloorsByBuildingID(uint(buildingID), page, pageSize)
+	floors, info, err := fc.service.GetFloorsByBuildingID(c.Request.Context(), uint(buildingID), &query)
 	if err != nil {
-		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to retrieve floors", err.Error())
+		utils.HandleError(c, "Failed to retrieve floors", err)
 		return
 	}
 
-	response := utils.PaginatedResponse{
-		Data:      floors,
-		Total:     total,
-		Page:      page,
-		PageSize:  pageSize,
-		TotalPage: int((total + int64(pageSize) - 1) / int64(pageSize)),
-	}
+	response := utils.NewPaginatedResponse(floors, &query, info)
 
 	utils.SuccessResponse(c, http.StatusOK, "Floors retrieved successfully", response)
 }
@@ -170,10 +162,13 @@ func (fc *FloorController) GetFloorsByBuilding(c *gin.Context) {
 // @Accept json
 // @Produce json
 // @Param id path int true "Floor ID"
+// @Param If-Match header string false "ETag of the version being changed"
 // @Param request body utils.UpdateFloorRequest true "Floor details to update"
 // @Success 200 {object} utils.FloorResponse
+// @Header 200 {string} ETag "Version of the record, for If-Match"
 // @Failure 400 {object} map[string]interface{}
 // @Failure 404 {object} map[string]interface{}
+// @Failure 412 {object} map[string]interface{}
 // @Router /api/v1/floors/{id} [put]
 func (fc *FloorController) UpdateFloor(c *gin.Context) {
 	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
@@ -184,26 +179,97 @@ func (fc *FloorController) UpdateFloor(c *gin.Context) {
 
 	var req utils.UpdateFloorRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BindingError(c, "Invalid request format", err)
+		return
+	}
+
+	floor, err := fc.service.UpdateFloor(c.Request.Context(), uint(id), &req)
+	if err != nil {
+		utils.HandleError(c, "Failed to update floor", err)
+		return
+	}
+
+	utils.SetETag(c, floor.Version)
+	utils.SuccessResponse(c, http.StatusOK, "Floor updated successfully", floor)
+}
+
+// PatchFloor handles PATCH /api/v1/floors/:id
+// @Summary Patch a floor
+// @Description Applies a JSON Merge Patch to an existing floor; a null member clears the field
+// @Accept application/merge-patch+json,json
+// @Produce json
+// @Param id path int true "Floor ID"
+// @Param If-Match header string false "ETag of the version being changed"
+// @Param request body utils.PatchFloorRequest true "Members to change, null to clear"
+// @Success 200 {object} utils.FloorResponse
+// @Header 200 {string} ETag "Version of the record, for If-Match"
+// @Failure 400 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 412 {object} map[string]interface{}
+// @Failure 415 {object} map[string]interface{}
+// @Router /api/v1/floors/{id} [patch]
+func (fc *FloorController) PatchFloor(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid floor ID", err.Error())
+		return
+	}
+
+	if !utils.IsMergePatch(c.ContentType()) {
+		utils.ErrorResponse(c, http.StatusUnsupportedMediaType, "Unsupported patch format", "send a JSON Merge Patch as "+utils.MergePatchContentType)
+		return
+	}
+	patch, err := c.GetRawData()
+	if err != nil {
 		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid request format", err.Error())
 		return
 	}
 
-	floor, err := fc.service.UpdateFloor(uint(id), &req)
+	floor, err := fc.service.PatchFloor(c.Request.Context(), uint(id), patch)
 	if err != nil {
-		utils.ErrorResponse(c, http.StatusBadRequest, "Failed to update floor", err.Error())
+		utils.HandleError(c, "Failed to update floor", err)
 		return
 	}
 
+	utils.SetETag(c, floor.Version)
 	utils.SuccessResponse(c, http.StatusOK, "Floor updated successfully", floor)
 }
 
+// GetFloorDeleteImpact handles GET /api/v1/floors/:id/delete-impact
+// @Summary Preview the impact of deleting a floor
+// @Description Counts the floors, rooms, components and reports a cascading delete would remove
+// @Produce json
+// @Param id path int true "Floor ID"
+// @Success 200 {object} utils.DeleteImpactResponse
+// @Failure 404 {object} map[string]interface{}
+// @Router /api/v1/floors/{id}/delete-impact [get]
+func (fc *FloorController) GetFloorDeleteImpact(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid floor ID", err.Error())
+		return
+	}
+
+	impact, err := fc.service.GetFloorDeleteImpact(c.Request.Context(), uint(id))
+	if err != nil {
+		utils.HandleError(c, "Failed to compute delete impact", err)
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Delete impact retrieved successfully", impact)
+}
+
 // DeleteFloor handles DELETE /api/v1/floors/:id
 // @Summary Delete a floor
 // @Description Deletes (soft delete) an existing floor
 // @Produce json
 // @Param id path int true "Floor ID"
+// @Param If-Match header string false "ETag of the version being changed"
+// @Param cascade query bool false "Delete the dependents too"
 // @Success 204 {object} map[string]interface{}
 // @Failure 404 {object} map[string]interface{}
+// @Failure 409 {object} map[string]interface{}
+// @Failure 412 {object} map[string]interface{}
 // @Router /api/v1/floors/{id} [delete]
 func (fc *FloorController) DeleteFloor(c *gin.Context) {
 	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
@@ -212,9 +278,15 @@ func (fc *FloorController) DeleteFloor(c *gin.Context) {
 		return
 	}
 
-	err = fc.service.DeleteFloor(uint(id))
+	var query utils.DeleteQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		utils.BindingError(c, "Invalid query parameters", err)
+		return
+	}
+
+	err = fc.service.DeleteFloor(c.Request.Context(), uint(id), query.Cascade)
 	if err != nil {
-		utils.ErrorResponse(c, http.StatusNotFound, "Floor not found", err.Error())
+		utils.HandleError(c, "Failed to delete floor", err)
 		return
 	}
 
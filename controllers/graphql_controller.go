@@ -0,0 +1,50 @@
+package controllers
+
+import (
+	"net/http"
+
+	"incident-report/graph"
+	"incident-report/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GraphQLController handles HTTP requests for the GraphQL API
+type GraphQLController struct {
+	schema *graph.Schema
+}
+
+// NewGraphQLController creates a new instance of GraphQLController
+func NewGraphQLController(schema *graph.Schema) *GraphQLController {
+	return &GraphQLController{
+		schema: schema,
+	}
+}
+
+// graphQLRequest is the body of a GraphQL request
+type graphQLRequest struct {
+	Query         string                 `json:"query" binding:"required"`
+	OperationName string                 `json:"operationName"`
+	Variables     map[string]interface{} `json:"variables"`
+}
+
+// Execute handles POST /graphql
+// @Summary Run a GraphQL query or mutation
+// @Description Runs a GraphQL document against the schema in graph/schema.graphql. Errors are reported in the errors member of a 200 response, each with its error code in extensions.code.
+// @Accept json
+// @Produce json
+// @Param request body graphQLRequest true "GraphQL request"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]interface{}
+// @Router /graphql [post]
+func (gc *GraphQLController) Execute(c *gin.Context) {
+	var req graphQLRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BindingError(c, "Invalid GraphQL request", err)
+		return
+	}
+
+	response := gc.schema.Exec(c.Request.Context(), req.Query, req.OperationName, req.Variables)
+	c.JSON(http.StatusOK, response)
+}
@@ -1,6 +1,7 @@
 package controllers
 
 import (
+	"bytes"
 	"incident-report/services"
 	"incident-report/utils"
 	"net/http"
@@ -12,12 +13,14 @@ import (
 // ReportController handles HTTP requests for report operations
 type ReportController struct {
 	reportService *services.ReportService
+	exportService *services.ExportService
 }
 
 // NewReportController creates a new instance of ReportController with dependency injection
-func NewReportController(reportService *services.ReportService) *ReportController {
+func NewReportController(reportService *services.ReportService, exportService *services.ExportService) *ReportController {
 	return &ReportController{
 		reportService: reportService,
+		exportService: exportService,
 	}
 }
 
@@ -30,14 +33,14 @@ func (rc *ReportController) CreateReport(c *gin.Context) {
 
 	// Bind and validate request JSON
 	if err := c.ShouldBindJSON(&req); err != nil {
-		utils.ErrorResponse(c, http.StatusBadRequest, "Validation failed", err.Error())
+		utils.BindingError(c, "Validation failed", err)
 		return
 	}
 
 	// Call service to create report
-	report, err := rc.reportService.CreateReport(&req)
+	report, err := rc.reportService.CreateReport(c.Request.Context(), &req)
 	if err != nil {
-		utils.ErrorResponse(c, http.StatusBadRequest, "Failed to create report", err.Error())
+		utils.HandleError(c, "Failed to create report", err)
 		return
 	}
 
@@ -45,8 +48,8 @@ func (rc *ReportController) CreateReport(c *gin.Context) {
 }
 
 // GetReport handles GET /api/v1/reports/:id request to retrieve a specific report
-// @param c *gin.Context with :id parameter
-// Response: ReportResponse with HTTP 200 OK
+// @param c *gin.Context with :id parameter and optional query parameter: format (json, pdf)
+// Response: ReportResponse with HTTP 200 OK, or a printable PDF summary
 func (rc *ReportController) GetReport(c *gin.Context) {
 	// Extract report ID from URL parameter
 	idStr := c.Param("id")
@@ -56,25 +59,61 @@ func (rc *ReportController) GetReport(c *gin.Context) {
 		return
 	}
 
+	var export utils.ExportQuery
+	if err := c.ShouldBindQuery(&export); err != nil {
+		utils.BindingError(c, "Invalid query parameters", err)
+		return
+	}
+
+	switch utils.ResolveExportFormat(c, export.Format) {
+	case utils.ExportFormatJSON:
+	case utils.ExportFormatPDF:
+		rc.exportReportPDF(c, uint(id))
+		return
+	default:
+		utils.HandleError(c, "Unsupported export format", utils.InvalidField("format", "a single report can only be exported as pdf"))
+		return
+	}
+
 	// Call service to fetch report
-	report, err := rc.reportService.GetReportByID(uint(id))
+	report, err := rc.reportService.GetReportByID(c.Request.Context(), uint(id))
 	if err != nil {
-		utils.ErrorResponse(c, http.StatusNotFound, "Report not found", err.Error())
+		utils.HandleError(c, "Failed to retrieve report", err)
 		return
 	}
 
+	utils.SetETag(c, report.Version)
 	utils.SuccessResponse(c, http.StatusOK, "Report retrieved successfully", report)
 }
 
 // GetAllReports handles GET /api/v1/reports request to retrieve all reports with pagination
-// @param c *gin.Context with optional query parameters: page, page_size
-// Response: PaginatedResponse with array of reports and HTTP 200 OK
+// @param c *gin.Context with optional query parameters: page, page_size, after, before, total, status, room_id, user_id, component_id, format
+// Response: PaginatedResponse with array of reports and HTTP 200 OK, or a CSV/XLSX/PDF export of every matching report
 func (rc *ReportController) GetAllReports(c *gin.Context) {
 	var pagination utils.PaginationQuery
+	var filter utils.ReportFilterQuery
+	var export utils.ExportQuery
 
 	// Bind query parameters with default values
 	if err := c.ShouldBindQuery(&pagination); err != nil {
-		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid query parameters", err.Error())
+		utils.BindingError(c, "Invalid query parameters", err)
+		return
+	}
+	if err := c.ShouldBindQuery(&filter); err != nil {
+		utils.BindingError(c, "Invalid query parameters", err)
+		return
+	}
+	if err := c.ShouldBindQuery(&export); err != nil {
+		utils.BindingError(c, "Invalid query parameters", err)
+		return
+	}
+
+	// File exports ignore pagination and stream every matching report
+	if format := utils.ResolveExportFormat(c, export.Format); format != utils.ExportFormatJSON {
+		utils.SetExportHeaders(c, format, "reports")
+		if err := rc.exportService.ExportReports(c.Request.Context(), c.Writer, format, &filter); err != nil {
+			writeExportError(c, err)
+		}
 		return
 	}
 
@@ -87,23 +126,14 @@ func (rc *ReportController) GetAllReports(c *gin.Context) {
 	}
 
 	// Call service to fetch paginated reports
-	reports, total, err := rc.reportService.GetAllReports(pagination.Page, pagination.PageSize)
+	reports, info, err := rc.reportService.GetAllReports(c.Request.Context(), &pagination, &filter)
 	if err != nil {
-		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to fetch reports", err.Error())
+		utils.HandleError(c, "Failed to fetch reports", err)
 		return
 	}
 
-	// Calculate total pages
-	totalPage := (int(total) + pagination.PageSize - 1) / pagination.PageSize
-
 	// Create paginated response
-	response := utils.PaginatedResponse{
-		Data:      reports,
-		Page:      pagination.Page,
-		PageSize:  pagination.PageSize,
-		Total:     total,
-		TotalPage: totalPage,
-	}
+	response := utils.NewPaginatedResponse(reports, &pagination, info)
 
 	utils.SuccessResponse(c, http.StatusOK, "Reports retrieved successfully", response)
 }
@@ -125,17 +155,53 @@ func (rc *ReportController) UpdateReport(c *gin.Context) {
 
 	// Bind and validate request JSON
 	if err := c.ShouldBindJSON(&req); err != nil {
-		utils.ErrorResponse(c, http.StatusBadRequest, "Validation failed", err.Error())
+		utils.BindingError(c, "Validation failed", err)
 		return
 	}
 
 	// Call service to update report
-	report, err := rc.reportService.UpdateReport(uint(id), &req)
+	report, err := rc.reportService.UpdateReport(c.Request.Context(), uint(id), &req)
 	if err != nil {
-		utils.ErrorResponse(c, http.StatusBadRequest, "Failed to update report", err.Error())
+		utils.HandleError(c, "Failed to update report", err)
 		return
 	}
 
+	utils.SetETag(c, report.Version)
+	utils.SuccessResponse(c, http.StatusOK, "Report updated successfully", report)
+}
+
+// PatchReport handles PATCH /api/v1/reports/:id request to patch a report
+// @param c *gin.Context with :id parameter
+// Request body: JSON Merge Patch of PatchReportRequest
+// Response: ReportResponse with HTTP 200 OK
+func (rc *ReportController) PatchReport(c *gin.Context) {
+	// Extract report ID from URL parameter
+	idStr := c.Param("id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid report ID", "ID must be a valid number")
+		return
+	}
+
+	// Read the merge patch; it is applied to the stored record by the service
+	if !utils.IsMergePatch(c.ContentType()) {
+		utils.ErrorResponse(c, http.StatusUnsupportedMediaType, "Unsupported patch format", "send a JSON Merge Patch as "+utils.MergePatchContentType)
+		return
+	}
+	patch, err := c.GetRawData()
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Validation failed", err.Error())
+		return
+	}
+
+	// Call service to patch report
+	report, err := rc.reportService.PatchReport(c.Request.Context(), uint(id), patch)
+	if err != nil {
+		utils.HandleError(c, "Failed to update report", err)
+		return
+	}
+
+	utils.SetETag(c, report.Version)
 	utils.SuccessResponse(c, http.StatusOK, "Report updated successfully", report)
 }
 
@@ -152,9 +218,9 @@ func (rc *ReportController) DeleteReport(c *gin.Context) {
 	}
 
 	// Call service to delete report
-	err = rc.reportService.DeleteReport(uint(id))
+	err = rc.reportService.DeleteReport(c.Request.Context(), uint(id))
 	if err != nil {
-		utils.ErrorResponse(c, http.StatusBadRequest, "Failed to delete report", err.Error())
+		utils.HandleError(c, "Failed to delete report", err)
 		return
 	}
 
@@ -178,16 +244,112 @@ func (rc *ReportController) AssignUserToReport(c *gin.Context) {
 
 	// Bind and validate request JSON
 	if err := c.ShouldBindJSON(&req); err != nil {
-		utils.ErrorResponse(c, http.StatusBadRequest, "Validation failed", err.Error())
+		utils.BindingError(c, "Validation failed", err)
 		return
 	}
 
 	// Call service to assign user to report
-	report, err := rc.reportService.AssignUserToReport(uint(id), req.UserID)
+	report, err := rc.reportService.AssignUserToReport(c.Request.Context(), uint(id), req.UserID)
 	if err != nil {
-		utils.ErrorResponse(c, http.StatusBadRequest, "Failed to assign user to report", err.Error())
+		utils.HandleError(c, "Failed to assign user to report", err)
 		return
 	}
 
+	utils.SetETag(c, report.Version)
 	utils.SuccessResponse(c, http.StatusOK, "User assigned to report successfully", report)
 }
+
+// exportReportPDF streams the printable summary of a single report
+func (rc *ReportController) exportReportPDF(c *gin.Context, id uint) {
+	// Render into a buffer first so a missing report can still be answered with JSON
+	var buf bytes.Buffer
+	if err := rc.exportService.ExportReportPDF(c.Request.Context(), &buf, id); err != nil {
+		utils.HandleError(c, "Failed to export report", err)
+		return
+	}
+
+	utils.SetExportHeaders(c, utils.ExportFormatPDF, "report-"+strconv.FormatUint(uint64(id), 10))
+	c.Data(http.StatusOK, utils.ExportContentType(utils.ExportFormatPDF), buf.Bytes())
+}
+
+// AddTagsToReport handles POST /api/v1/reports/:id/tags request to attach tags to a report
+// @param c *gin.Context with :id parameter
+// Request body: ReportTagsRequest (tag_ids)
+// Response: ReportResponse with its tags and HTTP 200 OK
+func (rc *ReportController) AddTagsToReport(c *gin.Context) {
+	// Extract report ID from URL parameter
+	idStr := c.Param("id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid report ID", "ID must be a valid number")
+		return
+	}
+
+	var req utils.ReportTagsRequest
+
+	// Bind and validate request JSON
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BindingError(c, "Validation failed", err)
+		return
+	}
+
+	// Call service to attach the tags
+	report, err := rc.reportService.AddTagsToReport(c.Request.Context(), uint(id), req.TagIDs)
+	if err != nil {
+		utils.HandleError(c, "Failed to add tags to report", err)
+		return
+	}
+
+	utils.SetETag(c, report.Version)
+	utils.SuccessResponse(c, http.StatusOK, "Tags added to report successfully", report)
+}
+
+// RemoveTagFromReport handles DELETE /api/v1/reports/:id/tags/:tagId request to detach a tag from a report
+// @param c *gin.Context with :id and :tagId parameters
+// Response: ReportResponse with its remaining tags and HTTP 200 OK
+func (rc *ReportController) RemoveTagFromReport(c *gin.Context) {
+	// Extract report and tag IDs from URL parameters
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid report ID", "ID must be a valid number")
+		return
+	}
+	tagID, err := strconv.ParseUint(c.Param("tagId"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid tag ID", "ID must be a valid number")
+		return
+	}
+
+	// Call service to detach the tag
+	report, err := rc.reportService.RemoveTagFromReport(c.Request.Context(), uint(id), uint(tagID))
+	if err != nil {
+		utils.HandleError(c, "Failed to remove tag from report", err)
+		return
+	}
+
+	utils.SetETag(c, report.Version)
+	utils.SuccessResponse(c, http.StatusOK, "Tag removed from report successfully", report)
+}
+
+// BulkReports handles POST /api/v1/reports/bulk request to act on many reports at once
+// @param c *gin.Context
+// Request body: BulkReportRequest (action, ids or filter, mode)
+// Response: BulkResponse with the outcome of every report; HTTP 409 when an atomic request was rolled back
+func (rc *ReportController) BulkReports(c *gin.Context) {
+	var req utils.BulkReportRequest
+
+	// Bind and validate request JSON
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BindingError(c, "Validation failed", err)
+		return
+	}
+
+	// Call service to apply the action to every selected report
+	result, err := rc.reportService.BulkReports(c.Request.Context(), &req)
+	if err != nil {
+		utils.HandleError(c, "Failed to apply bulk action", err)
+		return
+	}
+
+	utils.BulkResultResponse(c, "Bulk action applied", result)
+}
@@ -16,9 +16,9 @@ type RoomController struct {
 }
 
 // NewRoomController creates a new instance of RoomController
-func NewRoomController() *RoomController {
+func NewRoomController(service *services.RoomService) *RoomController {
 	return &RoomController{
-		service: services.NewRoomService(),
+		service: service,
 	}
 }
 
@@ -35,13 +35,13 @@ func (rc *RoomController) CreateRoom(c *gin.Context) {
 	var req utils.CreateRoomRequest
 
 	if err := c.ShouldBindJSON(&req); err != nil {
-		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid request format", err.Error())
+		utils.BindingError(c, "Invalid request format", err)
 		return
 	}
 
-	room, err := rc.service.CreateRoom(&req)
+	room, err := rc.service.CreateRoom(c.Request.Context(), &req)
 	if err != nil {
-		utils.ErrorResponse(c, http.StatusBadRequest, "Failed to create room", err.Error())
+		utils.HandleError(c, "Failed to create room", err)
 		return
 	}
 
@@ -54,6 +54,9 @@ func (rc *RoomController) CreateRoom(c *gin.Context) {
 // @Produce json
 // @Param page query int false "Page number" default(1)
 // @Param page_size query int false "Records per page" default(10)
+// @Param after query string false "Continue after this cursor (next_cursor of the previous page)"
+// @Param before query string false "Continue before this cursor (prev_cursor of the next page)"
+// @Param total query bool false "Count the matching records; defaults to true with page, false with a cursor"
 // @Success 200 {object} utils.PaginatedResponse
 // @Failure 400 {object} map[string]interface{}
 // @Router /api/v1/rooms [get]
@@ -61,7 +64,7 @@ func (rc *RoomController) GetAllRooms(c *gin.Context) {
 	var pagination utils.PaginationQuery
 
 	if err := c.ShouldBindQuery(&pagination); err != nil {
-		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid query parameters", err.Error())
+		utils.BindingError(c, "Invalid query parameters", err)
 		return
 	}
 
@@ -72,21 +75,13 @@ func (rc *RoomController) GetAllRooms(c *gin.Context) {
 		pagination.PageSize = 10
 	}
 
-	rooms, total, err := rc.service.GetAllRooms(pagination.Page, pagination.PageSize)
+	rooms, info, err := rc.service.GetAllRooms(c.Request.Context(), &pagination)
 	if err != nil {
-		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to fetch rooms", err.Error())
+		utils.HandleError(c, "Failed to fetch rooms", err)
 		return
 	}
 
-	totalPage := (int(total) + pagination.PageSize - 1) / pagination.PageSize
-
-	response := utils.PaginatedResponse{
-		Data:      rooms,
-		Page:      pagination.Page,
-		PageSize:  pagination.PageSize,
-		Total:     total,
-		TotalPage: totalPage,
-	}
+	response := utils.NewPaginatedResponse(rooms, &pagination, info)
 
 	utils.SuccessResponse(c, http.StatusOK, "Rooms retrieved successfully", response)
 }
@@ -97,6 +92,7 @@ func (rc *RoomController) GetAllRooms(c *gin.Context) {
 // @Produce json
 // @Param id path int true "Room ID"
 // @Success 200 {object} utils.RoomResponse
+// @Header 200 {string} ETag "Version of the record, for If-Match"
 // @Failure 404 {object} map[string]interface{}
 // @Router /api/v1/rooms/{id} [get]
 func (rc *RoomController) GetRoom(c *gin.Context) {
@@ -106,12 +102,13 @@ func (rc *RoomController) GetRoom(c *gin.Context) {
 		return
 	}
 
-	room, err := rc.service.GetRoomByID(uint(id))
+	room, err := rc.service.GetRoomByID(c.Request.Context(), uint(id))
 	if err != nil {
-		utils.ErrorResponse(c, http.StatusNotFound, "Room not found", err.Error())
+		utils.HandleError(c, "Failed to retrieve room", err)
 		return
 	}
 
+	utils.SetETag(c, room.Version)
 	utils.SuccessResponse(c, http.StatusOK, "Room retrieved successfully", room)
 }
 
@@ -122,11 +119,14 @@ func (rc *RoomController) GetRoom(c *gin.Context) {
 // @Param floorId path int true "Floor ID"
 // @Param page query int false "Page number" default(1)
 // @Param pageSize query int false "Page size" default(10)
+// @Param after query string false "Continue after this cursor (next_cursor of the previous page)"
+// @Param before query string false "Continue before this cursor (prev_cursor of the next page)"
+// @Param total query bool false "Count the matching records; defaults to true with page, false with a cursor"
 // @Success 200 {object} utils.PaginatedResponse
 // @Failure 400 {object} map[string]interface{}
 // @Router /api/v1/floors/{floorId}/rooms [get]
 func (rc *RoomController) GetRoomsByFloor(c *gin.Context) {
-	floorID, err := strconv.ParseUint(c.Param("floorId"), 10, 32)
+	floorID, err := strconv.ParseUint(c.Param("id"), 10, 32)
 	if err != nil {
 		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid floor ID", err.Error())
 		return
@@ -134,32 +134,24 @@ func (rc *RoomController) GetRoomsByFloor(c *gin.Context) {
 
 	var query utils.PaginationQuery
 	if err := c.ShouldBindQuery(&query); err != nil {
-		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid query parameters", err.Error())
+		utils.BindingError(c, "Invalid query parameters", err)
 		return
 	}
 
-	page := query.Page
-	pageSize := query.PageSize
-	if page <= 0 {
-		page = 1
+	if query.Page == 0 {
+		query.Page = 1
 	}
-	if pageSize <= 0 || pageSize > 100 {
-		pageSize = 10
+	if query.PageSize == 0 {
+		query.PageSize = 10
 	}
 
-	rooms, total, err := rc.service.GetRoomsByFloorID(uint(floorID), page, pageSize)
+	rooms, info, err := rc.service.GetRoomsByFloorID(c.Request.Context(), uint(floorID), &query)
 	if err != nil {
-		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to retrieve rooms", err.Error())
+		utils.HandleError(c, "Failed to retrieve rooms", err)
 		return
 	}
 
-	response := utils.PaginatedResponse{
-		Data:      rooms,
-		Total:     total,
-		Page:      page,
-		PageSize:  pageSize,
-		TotalPage: int((total + int64(pageSize) - 1) / int64(pageSize)),
-	}
+	response := utils.NewPaginatedResponse(rooms, &query, info)
 
 	utils.SuccessResponse(c, http.StatusOK, "Rooms retrieved successfully", response)
 }
@@ -170,10 +162,13 @@ func (rc *RoomController) GetRoomsByFloor(c *gin.Context) {
 // @Accept json
 // @Produce json
 // @Param id path int true "Room ID"
+// @Param If-Match header string false "ETag of the version being changed"
 // @Param request body utils.UpdateRoomRequest true "Room details to update"
 // @Success 200 {object} utils.RoomResponse
+// @Header 200 {string} ETag "Version of the record, for If-Match"
 // @Failure 400 {object} map[string]interface{}
 // @Failure 404 {object} map[string]interface{}
+// @Failure 412 {object} map[string]interface{}
 // @Router /api/v1/rooms/{id} [put]
 func (rc *RoomController) UpdateRoom(c *gin.Context) {
 	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
@@ -184,26 +179,97 @@ func (rc *RoomController) UpdateRoom(c *gin.Context) {
 
 	var req utils.UpdateRoomRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BindingError(c, "Invalid request format", err)
+		return
+	}
+
+	room, err := rc.service.UpdateRoom(c.Request.Context(), uint(id), &req)
+	if err != nil {
+		utils.HandleError(c, "Failed to update room", err)
+		return
+	}
+
+	utils.SetETag(c, room.Version)
+	utils.SuccessResponse(c, http.StatusOK, "Room updated successfully", room)
+}
+
+// PatchRoom handles PATCH /api/v1/rooms/:id
+// @Summary Patch a room
+// @Description Applies a JSON Merge Patch to an existing room; a null member clears the field
+// @Accept application/merge-patch+json,json
+// @Produce json
+// @Param id path int true "Room ID"
+// @Param If-Match header string false "ETag of the version being changed"
+// @Param request body utils.PatchRoomRequest true "Members to change, null to clear"
+// @Success 200 {object} utils.RoomResponse
+// @Header 200 {string} ETag "Version of the record, for If-Match"
+// @Failure 400 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 412 {object} map[string]interface{}
+// @Failure 415 {object} map[string]interface{}
+// @Router /api/v1/rooms/{id} [patch]
+func (rc *RoomController) PatchRoom(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid room ID", err.Error())
+		return
+	}
+
+	if !utils.IsMergePatch(c.ContentType()) {
+		utils.ErrorResponse(c, http.StatusUnsupportedMediaType, "Unsupported patch format", "send a JSON Merge Patch as "+utils.MergePatchContentType)
+		return
+	}
+	patch, err := c.GetRawData()
+	if err != nil {
 		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid request format", err.Error())
 		return
 	}
 
-	room, err := rc.service.UpdateRoom(uint(id), &req)
+	room, err := rc.service.PatchRoom(c.Request.Context(), uint(id), patch)
 	if err != nil {
-		utils.ErrorResponse(c, http.StatusBadRequest, "Failed to update room", err.Error())
+		utils.HandleError(c, "Failed to update room", err)
 		return
 	}
 
+	utils.SetETag(c, room.Version)
 	utils.SuccessResponse(c, http.StatusOK, "Room updated successfully", room)
 }
 
+// GetRoomDeleteImpact handles GET /api/v1/rooms/:id/delete-impact
+// @Summary Preview the impact of deleting a room
+// @Description Counts the floors, rooms, components and reports a cascading delete would remove
+// @Produce json
+// @Param id path int true "Room ID"
+// @Success 200 {object} utils.DeleteImpactResponse
+// @Failure 404 {object} map[string]interface{}
+// @Router /api/v1/rooms/{id}/delete-impact [get]
+func (rc *RoomController) GetRoomDeleteImpact(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid room ID", err.Error())
+		return
+	}
+
+	impact, err := rc.service.GetRoomDeleteImpact(c.Request.Context(), uint(id))
+	if err != nil {
+		utils.HandleError(c, "Failed to compute delete impact", err)
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Delete impact retrieved successfully", impact)
+}
+
 // DeleteRoom handles DELETE /api/v1/rooms/:id
 // @Summary Delete a room
 // @Description Deletes (soft delete) an existing room
 // @Produce json
 // @Param id path int true "Room ID"
+// @Param If-Match header string false "ETag of the version being changed"
+// @Param cascade query bool false "Delete the dependents too"
 // @Success 204 {object} map[string]interface{}
 // @Failure 404 {object} map[string]interface{}
+// @Failure 409 {object} map[string]interface{}
+// @Failure 412 {object} map[string]interface{}
 // @Router /api/v1/rooms/{id} [delete]
 func (rc *RoomController) DeleteRoom(c *gin.Context) {
 	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
@@ -212,9 +278,15 @@ func (rc *RoomController) DeleteRoom(c *gin.Context) {
 		return
 	}
 
-	err = rc.service.DeleteRoom(uint(id))
+	var query utils.DeleteQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		utils.BindingError(c, "Invalid query parameters", err)
+		return
+	}
+
+	err = rc.service.DeleteRoom(c.Request.Context(), uint(id), query.Cascade)
 	if err != nil {
-		utils.ErrorResponse(c, http.StatusNotFound, "Room not found", err.Error())
+		utils.HandleError(c, "Failed to delete room", err)
 		return
 	}
 
@@ -0,0 +1,211 @@
+package controllers
+
+import (
+	"net/http"
+	"strconv"
+
+	"incident-report/services"
+	"incident-report/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// TagController handles HTTP requests for tag operations
+type TagController struct {
+	service *services.TagService
+}
+
+// NewTagController creates a new instance of TagController
+func NewTagController(service *services.TagService) *TagController {
+	return &TagController{
+		service: service,
+	}
+}
+
+// CreateTag handles POST /api/v1/tags
+// @Summary Create a new tag
+// @Accept json
+// @Produce json
+// @Param request body utils.CreateTagRequest true "Tag details"
+// @Success 201 {object} utils.TagResponse
+// @Failure 400 {object} map[string]interface{}
+// @Router /api/v1/tags [post]
+func (tc *TagController) CreateTag(c *gin.Context) {
+	var req utils.CreateTagRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BindingError(c, "Invalid request format", err)
+		return
+	}
+
+	tag, err := tc.service.CreateTag(c.Request.Context(), &req)
+	if err != nil {
+		utils.HandleError(c, "Failed to create tag", err)
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusCreated, "Tag created successfully", tag)
+}
+
+// GetTag handles GET /api/v1/tags/:id
+// @Summary Get tag by ID
+// @Produce json
+// @Param id path int true "Tag ID"
+// @Success 200 {object} utils.TagResponse
+// @Header 200 {string} ETag "Version of the record, for If-Match"
+// @Failure 404 {object} map[string]interface{}
+// @Router /api/v1/tags/{id} [get]
+func (tc *TagController) GetTag(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid tag ID", err.Error())
+		return
+	}
+
+	tag, err := tc.service.GetTagByID(c.Request.Context(), uint(id))
+	if err != nil {
+		utils.HandleError(c, "Failed to retrieve tag", err)
+		return
+	}
+
+	utils.SetETag(c, tag.Version)
+	utils.SuccessResponse(c, http.StatusOK, "Tag retrieved successfully", tag)
+}
+
+// GetAllTags handles GET /api/v1/tags
+// @Summary Get all tags
+// @Produce json
+// @Param page query int false "Page number" default(1)
+// @Param page_size query int false "Records per page" default(10)
+// @Param after query string false "Continue after this cursor (next_cursor of the previous page)"
+// @Param before query string false "Continue before this cursor (prev_cursor of the next page)"
+// @Param total query bool false "Count the matching records; defaults to true with page, false with a cursor"
+// @Success 200 {object} utils.PaginatedResponse
+// @Failure 400 {object} map[string]interface{}
+// @Router /api/v1/tags [get]
+func (tc *TagController) GetAllTags(c *gin.Context) {
+	var pagination utils.PaginationQuery
+
+	if err := c.ShouldBindQuery(&pagination); err != nil {
+		utils.BindingError(c, "Invalid query parameters", err)
+		return
+	}
+
+	if pagination.Page == 0 {
+		pagination.Page = 1
+	}
+	if pagination.PageSize == 0 {
+		pagination.PageSize = 10
+	}
+
+	tags, info, err := tc.service.GetAllTags(c.Request.Context(), &pagination)
+	if err != nil {
+		utils.HandleError(c, "Failed to fetch tags", err)
+		return
+	}
+
+	response := utils.NewPaginatedResponse(tags, &pagination, info)
+
+	utils.SuccessResponse(c, http.StatusOK, "Tags retrieved successfully", response)
+}
+
+// UpdateTag handles PUT /api/v1/tags/:id
+// @Summary Update a tag
+// @Accept json
+// @Produce json
+// @Param id path int true "Tag ID"
+// @Param If-Match header string false "ETag of the version being changed"
+// @Param request body utils.UpdateTagRequest true "Tag details to update"
+// @Success 200 {object} utils.TagResponse
+// @Header 200 {string} ETag "Version of the record, for If-Match"
+// @Failure 400 {object} map[string]interface{}
+// @Failure 412 {object} map[string]interface{}
+// @Router /api/v1/tags/{id} [put]
+func (tc *TagController) UpdateTag(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid tag ID", err.Error())
+		return
+	}
+
+	var req utils.UpdateTagRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BindingError(c, "Invalid request format", err)
+		return
+	}
+
+	tag, err := tc.service.UpdateTag(c.Request.Context(), uint(id), &req)
+	if err != nil {
+		utils.HandleError(c, "Failed to update tag", err)
+		return
+	}
+
+	utils.SetETag(c, tag.Version)
+	utils.SuccessResponse(c, http.StatusOK, "Tag updated successfully", tag)
+}
+
+// PatchTag handles PATCH /api/v1/tags/:id
+// @Summary Patch a tag
+// @Accept application/merge-patch+json,json
+// @Produce json
+// @Param id path int true "Tag ID"
+// @Param If-Match header string false "ETag of the version being changed"
+// @Param request body utils.PatchTagRequest true "Members to change, null to clear"
+// @Success 200 {object} utils.TagResponse
+// @Header 200 {string} ETag "Version of the record, for If-Match"
+// @Failure 400 {object} map[string]interface{}
+// @Failure 412 {object} map[string]interface{}
+// @Failure 415 {object} map[string]interface{}
+// @Router /api/v1/tags/{id} [patch]
+func (tc *TagController) PatchTag(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid tag ID", err.Error())
+		return
+	}
+
+	if !utils.IsMergePatch(c.ContentType()) {
+		utils.ErrorResponse(c, http.StatusUnsupportedMediaType, "Unsupported patch format", "send a JSON Merge Patch as "+utils.MergePatchContentType)
+		return
+	}
+	patch, err := c.GetRawData()
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid request format", err.Error())
+		return
+	}
+
+	tag, err := tc.service.PatchTag(c.Request.Context(), uint(id), patch)
+	if err != nil {
+		utils.HandleError(c, "Failed to update tag", err)
+		return
+	}
+
+	utils.SetETag(c, tag.Version)
+	utils.SuccessResponse(c, http.StatusOK, "Tag updated successfully", tag)
+}
+
+// DeleteTag handles DELETE /api/v1/tags/:id
+// @Summary Delete a tag
+// @Description Deletes a tag and detaches it from every report
+// @Produce json
+// @Param id path int true "Tag ID"
+// @Param If-Match header string false "ETag of the version being changed"
+// @Success 204 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 412 {object} map[string]interface{}
+// @Router /api/v1/tags/{id} [delete]
+func (tc *TagController) DeleteTag(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid tag ID", err.Error())
+		return
+	}
+
+	err = tc.service.DeleteTag(c.Request.Context(), uint(id))
+	if err != nil {
+		utils.HandleError(c, "Failed to delete tag", err)
+		return
+	}
+
+	c.JSON(http.StatusNoContent, gin.H{})
+}
@@ -0,0 +1,114 @@
+package controllers
+
+import (
+	"net/http"
+	"strconv"
+
+	"incident-report/services"
+	"incident-report/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// TrashController handles HTTP requests for soft-deleted records
+type TrashController struct {
+	service *services.TrashService
+}
+
+// NewTrashController creates a new instance of TrashController
+func NewTrashController(service *services.TrashService) *TrashController {
+	return &TrashController{
+		service: service,
+	}
+}
+
+// ListTrash handles GET /api/v1/trash
+// @Summary List deleted records
+// @Description Lists soft-deleted records, most recently deleted first
+// @Produce json
+// @Param type query string false "Record type" Enums(buildings, floors, rooms, component-categories, components, reports, users)
+// @Param page query int false "Page number" default(1)
+// @Param page_size query int false "Records per page" default(10)
+// @Param after query string false "Continue after this cursor (next_cursor of the previous page)"
+// @Param before query string false "Continue before this cursor (prev_cursor of the next page)"
+// @Param total query bool false "Count the matching records; defaults to true with page, false with a cursor"
+// @Success 200 {object} utils.PaginatedResponse
+// @Failure 400 {object} map[string]interface{}
+// @Router /api/v1/trash [get]
+func (tc *TrashController) ListTrash(c *gin.Context) {
+	var query utils.TrashQuery
+
+	if err := c.ShouldBindQuery(&query); err != nil {
+		utils.BindingError(c, "Invalid query parameters", err)
+		return
+	}
+
+	if query.Page == 0 {
+		query.Page = 1
+	}
+	if query.PageSize == 0 {
+		query.PageSize = 10
+	}
+
+	items, info, err := tc.service.ListTrash(c.Request.Context(), query.Type, &query.PaginationQuery)
+	if err != nil {
+		utils.HandleError(c, "Failed to fetch trash", err)
+		return
+	}
+
+	response := utils.NewPaginatedResponse(items, &query.PaginationQuery, info)
+
+	utils.SuccessResponse(c, http.StatusOK, "Trash retrieved successfully", response)
+}
+
+// Restore returns the handler of POST /api/v1/{entityType}/:id/restore
+// @Summary Restore a deleted record
+// @Description Restores the record and the children that were deleted with it
+// @Produce json
+// @Param id path int true "Record ID"
+// @Success 200 {object} utils.TrashItemResponse
+// @Failure 404 {object} map[string]interface{}
+// @Failure 409 {object} map[string]interface{}
+func (tc *TrashController) Restore(entityType string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+		if err != nil {
+			utils.ErrorResponse(c, http.StatusBadRequest, "Invalid ID", err.Error())
+			return
+		}
+
+		item, err := tc.service.Restore(c.Request.Context(), entityType, uint(id))
+		if err != nil {
+			utils.HandleError(c, "Failed to restore record", err)
+			return
+		}
+
+		utils.SuccessResponse(c, http.StatusOK, "Record restored successfully", item)
+	}
+}
+
+// Purge returns the handler of DELETE /api/v1/{entityType}/:id/purge
+// @Summary Permanently delete a record from the trash
+// @Description Admin only. Refused while other records still reference it.
+// @Produce json
+// @Param id path int true "Record ID"
+// @Success 204 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 409 {object} map[string]interface{}
+func (tc *TrashController) Purge(entityType string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+		if err != nil {
+			utils.ErrorResponse(c, http.StatusBadRequest, "Invalid ID", err.Error())
+			return
+		}
+
+		if err := tc.service.Purge(c.Request.Context(), entityType, uint(id)); err != nil {
+			utils.HandleError(c, "Failed to purge record", err)
+			return
+		}
+
+		c.JSON(http.StatusNoContent, gin.H{})
+	}
+}
@@ -0,0 +1,96 @@
+package controllers
+
+import (
+	"net/http"
+	"strconv"
+
+	"incident-report/services"
+	"incident-report/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// TreeController handles HTTP requests for the location hierarchy tree
+type TreeController struct {
+	service *services.TreeService
+}
+
+// NewTreeController creates a new instance of TreeController
+func NewTreeController(service *services.TreeService) *TreeController {
+	return &TreeController{
+		service: service,
+	}
+}
+
+// GetTree handles GET /api/v1/tree
+// @Summary Get the full location hierarchy
+// @Description Retrieves every building with its floors (sorted by number) and rooms
+// @Produce json
+// @Param depth query int false "1 = buildings, 2 = floors, 3 = rooms" default(3)
+// @Param include query string false "Comma separated: component_count, open_report_count"
+// @Success 200 {array} utils.BuildingTreeNode
+// @Failure 400 {object} map[string]interface{}
+// @Router /api/v1/tree [get]
+func (tc *TreeController) GetTree(c *gin.Context) {
+	options, ok := bindTreeOptions(c)
+	if !ok {
+		return
+	}
+
+	tree, err := tc.service.GetTree(c.Request.Context(), options)
+	if err != nil {
+		utils.HandleError(c, "Failed to retrieve hierarchy tree", err)
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Hierarchy tree retrieved successfully", tree)
+}
+
+// GetBuildingTree handles GET /api/v1/buildings/:id/tree
+// @Summary Get the location hierarchy of a building
+// @Description Retrieves a building with its floors (sorted by number) and rooms
+// @Produce json
+// @Param id path int true "Building ID"
+// @Param depth query int false "1 = building, 2 = floors, 3 = rooms" default(3)
+// @Param include query string false "Comma separated: component_count, open_report_count"
+// @Success 200 {object} utils.BuildingTreeNode
+// @Failure 400 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Router /api/v1/buildings/{id}/tree [get]
+func (tc *TreeController) GetBuildingTree(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid building ID", err.Error())
+		return
+	}
+
+	options, ok := bindTreeOptions(c)
+	if !ok {
+		return
+	}
+
+	tree, err := tc.service.GetBuildingTree(c.Request.Context(), uint(id), options)
+	if err != nil {
+		utils.HandleError(c, "Failed to retrieve building tree", err)
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Building tree retrieved successfully", tree)
+}
+
+// bindTreeOptions binds and validates the depth and include query parameters
+func bindTreeOptions(c *gin.Context) (services.TreeOptions, bool) {
+	var query utils.TreeQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		utils.BindingError(c, "Invalid query parameters", err)
+		return services.TreeOptions{}, false
+	}
+
+	options, err := services.ParseTreeOptions(&query)
+	if err != nil {
+		utils.HandleError(c, "Invalid query parameters", err)
+		return services.TreeOptions{}, false
+	}
+
+	return options, true
+}
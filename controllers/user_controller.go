@@ -30,14 +30,14 @@ func (uc *UserController) CreateUser(c *gin.Context) {
 
 	// Bind and validate request JSON
 	if err := c.ShouldBindJSON(&req); err != nil {
-		utils.ErrorResponse(c, http.StatusBadRequest, "Validation failed", err.Error())
+		utils.BindingError(c, "Validation failed", err)
 		return
 	}
 
 	// Call service to create user
-	user, err := uc.userService.CreateUser(&req)
+	user, err := uc.userService.CreateUser(c.Request.Context(), &req)
 	if err != nil {
-		utils.ErrorResponse(c, http.StatusBadRequest, "Failed to create user", err.Error())
+		utils.HandleError(c, "Failed to create user", err)
 		return
 	}
 
@@ -57,24 +57,25 @@ func (uc *UserController) GetUser(c *gin.Context) {
 	}
 
 	// Call service to fetch user
-	user, err := uc.userService.GetUserByID(uint(id))
+	user, err := uc.userService.GetUserByID(c.Request.Context(), uint(id))
 	if err != nil {
-		utils.ErrorResponse(c, http.StatusNotFound, "User not found", err.Error())
+		utils.HandleError(c, "Failed to retrieve user", err)
 		return
 	}
 
+	utils.SetETag(c, user.Version)
 	utils.SuccessResponse(c, http.StatusOK, "User retrieved successfully", user)
 }
 
 // GetAllUsers handles GET /api/v1/users request to retrieve all users with pagination
-// @param c *gin.Context with optional query parameters: page, page_size
+// @param c *gin.Context with optional query parameters: page, page_size, after, before, total
 // Response: PaginatedResponse with array of users and HTTP 200 OK
 func (uc *UserController) GetAllUsers(c *gin.Context) {
 	var pagination utils.PaginationQuery
 
 	// Bind query parameters with default values
 	if err := c.ShouldBindQuery(&pagination); err != nil {
-		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid query parameters", err.Error())
+		utils.BindingError(c, "Invalid query parameters", err)
 		return
 	}
 
@@ -87,23 +88,14 @@ func (uc *UserController) GetAllUsers(c *gin.Context) {
 	}
 
 	// Call service to fetch paginated users
-	users, total, err := uc.userService.GetAllUsers(pagination.Page, pagination.PageSize)
+	users, info, err := uc.userService.GetAllUsers(c.Request.Context(), &pagination)
 	if err != nil {
-		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to fetch users", err.Error())
+		utils.HandleError(c, "Failed to fetch users", err)
 		return
 	}
 
-	// Calculate total pages
-	totalPage := (int(total) + pagination.PageSize - 1) / pagination.PageSize
-
 	// Create paginated response
-	response := utils.PaginatedResponse{
-		Data:      users,
-		Page:      pagination.Page,
-		PageSize:  pagination.PageSize,
-		Total:     total,
-		TotalPage: totalPage,
-	}
+	response := utils.NewPaginatedResponse(users, &pagination, info)
 
 	utils.SuccessResponse(c, http.StatusOK, "Users retrieved successfully", response)
 }
@@ -125,17 +117,53 @@ func (uc *UserController) UpdateUser(c *gin.Context) {
 
 	// Bind and validate request JSON
 	if err := c.ShouldBindJSON(&req); err != nil {
-		utils.ErrorResponse(c, http.StatusBadRequest, "Validation failed", err.Error())
+		utils.BindingError(c, "Validation failed", err)
 		return
 	}
 
 	// Call service to update user
-	user, err := uc.userService.UpdateUser(uint(id), &req)
+	user, err := uc.userService.UpdateUser(c.Request.Context(), uint(id), &req)
+	if err != nil {
+		utils.HandleError(c, "Failed to update user", err)
+		return
+	}
+
+	utils.SetETag(c, user.Version)
+	utils.SuccessResponse(c, http.StatusOK, "User updated successfully", user)
+}
+
+// PatchUser handles PATCH /api/v1/users/:id request to patch a user
+// @param c *gin.Context with :id parameter
+// Request body: JSON Merge Patch of PatchUserRequest
+// Response: UserResponse with HTTP 200 OK
+func (uc *UserController) PatchUser(c *gin.Context) {
+	// Extract user ID from URL parameter
+	idStr := c.Param("id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid user ID", "ID must be a valid number")
+		return
+	}
+
+	// Read the merge patch; it is applied to the stored record by the service
+	if !utils.IsMergePatch(c.ContentType()) {
+		utils.ErrorResponse(c, http.StatusUnsupportedMediaType, "Unsupported patch format", "send a JSON Merge Patch as "+utils.MergePatchContentType)
+		return
+	}
+	patch, err := c.GetRawData()
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Validation failed", err.Error())
+		return
+	}
+
+	// Call service to patch user
+	user, err := uc.userService.PatchUser(c.Request.Context(), uint(id), patch)
 	if err != nil {
-		utils.ErrorResponse(c, http.StatusBadRequest, "Failed to update user", err.Error())
+		utils.HandleError(c, "Failed to update user", err)
 		return
 	}
 
+	utils.SetETag(c, user.Version)
 	utils.SuccessResponse(c, http.StatusOK, "User updated successfully", user)
 }
 
@@ -152,9 +180,9 @@ func (uc *UserController) DeleteUser(c *gin.Context) {
 	}
 
 	// Call service to delete user
-	err = uc.userService.DeleteUser(uint(id))
+	err = uc.userService.DeleteUser(c.Request.Context(), uint(id))
 	if err != nil {
-		utils.ErrorResponse(c, http.StatusBadRequest, "Failed to delete user", err.Error())
+		utils.HandleError(c, "Failed to delete user", err)
 		return
 	}
 
@@ -0,0 +1,279 @@
+package graph
+
+import (
+	"context"
+	"errors"
+	"incident-report/models"
+	"incident-report/utils"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	graphql "github.com/graph-gophers/graphql-go"
+)
+
+// toID formats a record ID as a GraphQL ID
+func toID(id uint) graphql.ID {
+	return graphql.ID(strconv.FormatUint(uint64(id), 10))
+}
+
+// parseID reads a record ID from an argument named field
+func parseID(field string, id graphql.ID) (uint, error) {
+	parsed, err := strconv.ParseUint(string(id), 10, 32)
+	if err != nil || parsed == 0 {
+		return 0, utils.InvalidField(field, field+" must be the ID of a record")
+	}
+	return uint(parsed), nil
+}
+
+// parseOptionalID reads a record ID from an optional argument; a missing ID is 0
+func parseOptionalID(field string, id *graphql.ID) (uint, error) {
+	if id == nil {
+		return 0, nil
+	}
+	return parseID(field, *id)
+}
+
+// unixTime converts the Unix timestamps of the location records
+func unixTime(seconds int64) graphql.Time {
+	return graphql.Time{Time: time.Unix(seconds, 0)}
+}
+
+// parseTime converts the formatted timestamps of report responses back into times
+func parseTime(formatted string) graphql.Time {
+	parsed, _ := time.Parse(time.RFC3339, formatted)
+	return graphql.Time{Time: parsed}
+}
+
+// withVersion requires the record a mutation writes to be at version, as If-Match does in REST
+func withVersion(ctx context.Context, version *int32) context.Context {
+	if version == nil {
+		return ctx
+	}
+	return utils.WithExpectedVersion(ctx, uint(*version))
+}
+
+// queryError reports a service error with its error code, and its rejected fields if any, in
+// the extensions of the GraphQL error
+type queryError struct {
+	err error
+}
+
+// Error returns the message of the service error
+func (e queryError) Error() string {
+	return e.err.Error()
+}
+
+// Unwrap returns the service error
+func (e queryError) Unwrap() error {
+	return e.err
+}
+
+// Extensions returns the error code and details, as the REST error responses have them
+func (e queryError) Extensions() map[string]interface{} {
+	extensions := map[string]interface{}{"code": utils.ErrorCode(e.err)}
+	var appErr *utils.AppError
+	if errors.As(e.err, &appErr) && len(appErr.Fields) > 0 {
+		extensions["details"] = appErr.Fields
+	}
+	return extensions
+}
+
+// wrapError converts a service error into a GraphQL error
+func wrapError(err error) error {
+	if err == nil {
+		return nil
+	}
+	return queryError{err}
+}
+
+// pageInput selects a page of a list
+type pageInput struct {
+	Page     *int32
+	PageSize *int32
+	After    *string
+	Before   *string
+	Total    *bool
+}
+
+// paginationOf converts a page argument into the pagination query of the REST lists
+// It is checked by the same rules and gets the same defaults.
+func paginationOf(page *pageInput) (*utils.PaginationQuery, error) {
+	q := &utils.PaginationQuery{}
+	if page != nil {
+		if page.Page != nil {
+			q.Page = int(*page.Page)
+		}
+		if page.PageSize != nil {
+			q.PageSize = int(*page.PageSize)
+		}
+		if page.After != nil {
+			q.After = *page.After
+		}
+		if page.Before != nil {
+			q.Before = *page.Before
+		}
+		q.Total = page.Total
+	}
+	if err := utils.Validate(q); err != nil {
+		return nil, err
+	}
+
+	if q.Page == 0 {
+		q.Page = 1
+	}
+	if q.PageSize == 0 {
+		q.PageSize = 10
+	}
+	return q, nil
+}
+
+// pageInfoResolver resolves the PageInfo of a connection
+type pageInfoResolver struct {
+	page utils.PaginatedResponse
+}
+
+// newPageInfo describes a page of a list loaded for the query
+func newPageInfo(q *utils.PaginationQuery, info utils.PageInfo) *pageInfoResolver {
+	return &pageInfoResolver{page: utils.NewPaginatedResponse(nil, q, info)}
+}
+
+func (r *pageInfoResolver) Page() *int32 {
+	return optionalInt(r.page.Page)
+}
+
+func (r *pageInfoResolver) PageSize() int32 {
+	return int32(r.page.PageSize)
+}
+
+func (r *pageInfoResolver) Total() *int32 {
+	if r.page.Total == nil {
+		return nil
+	}
+	total := int32(*r.page.Total)
+	return &total
+}
+
+func (r *pageInfoResolver) TotalPages() *int32 {
+	return optionalInt(r.page.TotalPage)
+}
+
+func (r *pageInfoResolver) NextCursor() *string {
+	return optionalString(r.page.NextCursor)
+}
+
+func (r *pageInfoResolver) PrevCursor() *string {
+	return optionalString(r.page.PrevCursor)
+}
+
+// connection resolves a page of a list
+type connection[T any] struct {
+	nodes []T
+	info  *pageInfoResolver
+}
+
+func (c *connection[T]) Nodes() []T {
+	return c.nodes
+}
+
+func (c *connection[T]) PageInfo() *pageInfoResolver {
+	return c.info
+}
+
+// optionalInt returns nil for an unset number
+func optionalInt(n int) *int32 {
+	if n == 0 {
+		return nil
+	}
+	value := int32(n)
+	return &value
+}
+
+// optionalString returns nil for an empty string
+func optionalString(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
+
+// reportFilter filters a report list
+type reportFilter struct {
+	Status      *string
+	RoomID      *graphql.ID
+	UserID      *graphql.ID
+	ComponentID *graphql.ID
+	Tags        *[]string
+	TagMatch    *string
+}
+
+// reportFilterOf converts a report filter argument into the filter of the REST list
+func reportFilterOf(filter *reportFilter) (*utils.ReportFilterQuery, error) {
+	query := &utils.ReportFilterQuery{}
+	if filter == nil {
+		return query, nil
+	}
+
+	var err error
+	if filter.Status != nil {
+		query.Status = *filter.Status
+	}
+	if query.RoomID, err = parseOptionalID("roomId", filter.RoomID); err != nil {
+		return nil, err
+	}
+	if query.UserID, err = parseOptionalID("userId", filter.UserID); err != nil {
+		return nil, err
+	}
+	if query.ComponentID, err = parseOptionalID("componentId", filter.ComponentID); err != nil {
+		return nil, err
+	}
+	if filter.Tags != nil {
+		query.Tags = strings.Join(*filter.Tags, ",")
+	}
+	if filter.TagMatch != nil {
+		query.TagMatch = strings.ToLower(*filter.TagMatch)
+	}
+	return query, nil
+}
+
+// componentFilter filters a component list
+type componentFilter struct {
+	RoomID     *graphql.ID
+	CategoryID *graphql.ID
+}
+
+// auditFilter filters the audit log
+type auditFilter struct {
+	Entity   *string
+	EntityID *graphql.ID
+	ActorID  *graphql.ID
+	From     *string
+	To       *string
+}
+
+// reportsArgs narrows the reports of a record by status
+type reportsArgs struct {
+	Status *string
+	Open   *bool
+}
+
+// filterReports keeps the reports that match the arguments
+func filterReports(reports []utils.ReportResponse, args reportsArgs) []utils.ReportResponse {
+	var kept []utils.ReportResponse
+	for _, report := range reports {
+		if args.Status != nil && report.Status != *args.Status {
+			continue
+		}
+		if args.Open != nil && isOpen(report.Status) != *args.Open {
+			continue
+		}
+		kept = append(kept, report)
+	}
+	return kept
+}
+
+// isOpen reports whether a report status still needs work
+func isOpen(status string) bool {
+	return slices.Contains(models.OpenReportStatuses, models.ReportStatus(status))
+}
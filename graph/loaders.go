@@ -0,0 +1,277 @@
+package graph
+
+import (
+	"context"
+	"incident-report/services"
+	"incident-report/utils"
+	"slices"
+	"sync"
+)
+
+// loader batches the lookups of one relation within a request
+// IDs are queued as soon as the records they belong to are known (see dataloaders), and the first
+// lookup of any of them loads every queued ID with one call of fetch. Later lookups are answered
+// from the loaded results.
+type loader[V any] struct {
+	fetch func(ctx context.Context, ids []uint) (map[uint]V, error)
+	// seen is called with every batch of loaded values, to queue the relations of their records
+	seen func(values map[uint]V)
+
+	// fetching serialises the loads. mu guards the queue and the results and is never held
+	// during a fetch, so that queueing IDs never waits for a load of another relation.
+	fetching sync.Mutex
+	mu       sync.Mutex
+	queued   map[uint]bool
+	results  map[uint]V
+}
+
+// newLoader creates a loader that loads values with fetch and hands them to seen
+func newLoader[V any](fetch func(ctx context.Context, ids []uint) (map[uint]V, error), seen func(values map[uint]V)) *loader[V] {
+	return &loader[V]{fetch: fetch, seen: seen, queued: make(map[uint]bool), results: make(map[uint]V)}
+}
+
+// prime queues IDs to be loaded by the next lookup
+func (l *loader[V]) prime(ids ...uint) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	for _, id := range ids {
+		if _, loaded := l.results[id]; !loaded {
+			l.queued[id] = true
+		}
+	}
+}
+
+// store records a value resolved elsewhere, so that looking its ID up costs no query
+func (l *loader[V]) store(id uint, value V) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.results[id] = value
+	delete(l.queued, id)
+}
+
+// cached returns the loaded value of an ID
+func (l *loader[V]) cached(id uint) (V, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	value, ok := l.results[id]
+	return value, ok
+}
+
+// load returns the value of an ID, loading it together with every queued ID
+// IDs without a record get the zero value.
+func (l *loader[V]) load(ctx context.Context, id uint) (V, error) {
+	if value, ok := l.cached(id); ok {
+		return value, nil
+	}
+
+	l.fetching.Lock()
+	defer l.fetching.Unlock()
+
+	// Another lookup may have loaded the ID while this one waited
+	if value, ok := l.cached(id); ok {
+		return value, nil
+	}
+
+	l.mu.Lock()
+	l.queued[id] = true
+	ids := make([]uint, 0, len(l.queued))
+	for queued := range l.queued {
+		ids = append(ids, queued)
+	}
+	l.queued = make(map[uint]bool)
+	l.mu.Unlock()
+	slices.Sort(ids)
+
+	// On failure the IDs are dropped from the queue; their own lookups queue them again
+	values, err := l.fetch(ctx, ids)
+	if err != nil {
+		var zero V
+		return zero, err
+	}
+
+	l.mu.Lock()
+	for _, loaded := range ids {
+		l.results[loaded] = values[loaded]
+	}
+	l.mu.Unlock()
+
+	if l.seen != nil {
+		l.seen(values)
+	}
+	return values[id], nil
+}
+
+// dataloaders holds the loaders of a single request
+// Whenever records are resolved, they are cached by ID and the IDs of their relations are queued
+// on the loaders of those relations, so that resolving a relation for one record loads it for
+// all of them. A query
+// therefore costs one database query per relation and level, whatever the number of records.
+type dataloaders struct {
+	batch *services.BatchService
+
+	buildings  *loader[*utils.BuildingResponse]
+	floors     *loader[*utils.FloorResponse]
+	rooms      *loader[*utils.RoomResponse]
+	categories *loader[*utils.ComponentCategoryResponse]
+	components *loader[*utils.ComponentResponse]
+	users      *loader[*utils.UserResponse]
+
+	floorsByBuilding     *loader[[]utils.FloorResponse]
+	roomsByFloor         *loader[[]utils.RoomResponse]
+	componentsByRoom     *loader[[]utils.ComponentResponse]
+	componentsByCategory *loader[[]utils.ComponentResponse]
+	reportsByRoom        *loader[[]utils.ReportResponse]
+	reportsByComponent   *loader[[]utils.ReportResponse]
+	reportsByUser        *loader[[]utils.ReportResponse]
+	reportsByTag         *loader[[]utils.ReportResponse]
+	tagsByReport         *loader[[]utils.TagResponse]
+}
+
+// newLoaders creates the dataloaders of a request
+func newLoaders(batch *services.BatchService) *dataloaders {
+	l := &dataloaders{batch: batch}
+	l.reset()
+	return l
+}
+
+// reset drops everything the loaders have loaded or queued
+// Mutations reset them, since their writes make what was loaded before stale. Mutations run
+// one after the other, so no other lookup runs meanwhile.
+func (l *dataloaders) reset() {
+	batch := l.batch
+	l.buildings = newLoader(batch.Buildings, func(values map[uint]*utils.BuildingResponse) { l.seeBuildings(deref(values)...) })
+	l.floors = newLoader(batch.Floors, func(values map[uint]*utils.FloorResponse) { l.seeFloors(deref(values)...) })
+	l.rooms = newLoader(batch.Rooms, func(values map[uint]*utils.RoomResponse) { l.seeRooms(deref(values)...) })
+	l.categories = newLoader(batch.ComponentCategories, func(values map[uint]*utils.ComponentCategoryResponse) { l.seeCategories(deref(values)...) })
+	l.components = newLoader(batch.Components, func(values map[uint]*utils.ComponentResponse) { l.seeComponents(deref(values)...) })
+	l.users = newLoader(batch.Users, func(values map[uint]*utils.UserResponse) { l.seeUsers(deref(values)...) })
+
+	l.floorsByBuilding = newLoader(batch.FloorsByBuilding, func(values map[uint][]utils.FloorResponse) { l.seeFloors(flatten(values)...) })
+	l.roomsByFloor = newLoader(batch.RoomsByFloor, func(values map[uint][]utils.RoomResponse) { l.seeRooms(flatten(values)...) })
+	l.componentsByRoom = newLoader(batch.ComponentsByRoom, func(values map[uint][]utils.ComponentResponse) { l.seeComponents(flatten(values)...) })
+	l.componentsByCategory = newLoader(batch.ComponentsByCategory, func(values map[uint][]utils.ComponentResponse) { l.seeComponents(flatten(values)...) })
+	l.reportsByRoom = newLoader(batch.ReportsByRoom, func(values map[uint][]utils.ReportResponse) { l.seeReports(flatten(values)...) })
+	l.reportsByComponent = newLoader(batch.ReportsByComponent, func(values map[uint][]utils.ReportResponse) { l.seeReports(flatten(values)...) })
+	l.reportsByUser = newLoader(batch.ReportsByUser, func(values map[uint][]utils.ReportResponse) { l.seeReports(flatten(values)...) })
+	l.reportsByTag = newLoader(batch.ReportsByTag, func(values map[uint][]utils.ReportResponse) { l.seeReports(flatten(values)...) })
+	l.tagsByReport = newLoader(batch.TagsByReport, func(values map[uint][]utils.TagResponse) { l.seeTags(flatten(values)...) })
+}
+
+// loadersKey is the context key of the request's dataloaders
+type loadersKey struct{}
+
+// withLoaders returns a copy of ctx that carries the dataloaders of a request
+func withLoaders(ctx context.Context, loaders *dataloaders) context.Context {
+	return context.WithValue(ctx, loadersKey{}, loaders)
+}
+
+// loadersFrom returns the dataloaders of the request
+func loadersFrom(ctx context.Context) *dataloaders {
+	return ctx.Value(loadersKey{}).(*dataloaders)
+}
+
+// seeBuildings queues the relations of resolved buildings
+func (l *dataloaders) seeBuildings(buildings ...utils.BuildingResponse) {
+	for _, building := range buildings {
+		l.buildings.store(building.ID, &building)
+		l.floorsByBuilding.prime(building.ID)
+	}
+}
+
+// seeFloors queues the relations of resolved floors
+func (l *dataloaders) seeFloors(floors ...utils.FloorResponse) {
+	for _, floor := range floors {
+		l.floors.store(floor.ID, &floor)
+		l.buildings.prime(floor.BuildingID)
+		l.roomsByFloor.prime(floor.ID)
+	}
+}
+
+// seeRooms queues the relations of resolved rooms
+func (l *dataloaders) seeRooms(rooms ...utils.RoomResponse) {
+	for _, room := range rooms {
+		l.rooms.store(room.ID, &room)
+		l.floors.prime(room.FloorID)
+		l.componentsByRoom.prime(room.ID)
+		l.reportsByRoom.prime(room.ID)
+	}
+}
+
+// seeCategories queues the relations of resolved component categories
+func (l *dataloaders) seeCategories(categories ...utils.ComponentCategoryResponse) {
+	for _, category := range categories {
+		l.categories.store(category.ID, &category)
+		l.componentsByCategory.prime(category.ID)
+	}
+}
+
+// seeComponents queues the relations of resolved components
+func (l *dataloaders) seeComponents(components ...utils.ComponentResponse) {
+	for _, component := range components {
+		l.components.store(component.ID, &component)
+		if component.RoomID != nil {
+			l.rooms.prime(*component.RoomID)
+		}
+		l.categories.prime(component.CategoryID)
+		l.reportsByComponent.prime(component.ID)
+	}
+}
+
+// seeReports queues the relations of resolved reports
+func (l *dataloaders) seeReports(reports ...utils.ReportResponse) {
+	for _, report := range reports {
+		l.rooms.prime(report.RoomID)
+		l.components.prime(report.ComponentID)
+		if report.UserID != nil {
+			l.users.prime(*report.UserID)
+		}
+		l.tagsByReport.prime(report.ID)
+	}
+}
+
+// seeUsers queues the relations of resolved users
+func (l *dataloaders) seeUsers(users ...utils.UserResponse) {
+	for _, user := range users {
+		l.users.store(user.ID, &user)
+		l.reportsByUser.prime(user.ID)
+	}
+}
+
+// seeTags queues the relations of resolved tags
+func (l *dataloaders) seeTags(tags ...utils.TagResponse) {
+	for _, tag := range tags {
+		l.reportsByTag.prime(tag.ID)
+	}
+}
+
+// seeAuditLogs queues the relations of resolved audit log entries
+func (l *dataloaders) seeAuditLogs(entries ...utils.AuditLogResponse) {
+	for _, entry := range entries {
+		if entry.ActorID != nil {
+			l.users.prime(*entry.ActorID)
+		}
+	}
+}
+
+// deref lists the records loaded by ID
+func deref[V any](values map[uint]*V) []V {
+	records := make([]V, 0, len(values))
+	for _, value := range values {
+		if value != nil {
+			records = append(records, *value)
+		}
+	}
+	return records
+}
+
+// flatten lists the records loaded by parent
+func flatten[V any](values map[uint][]V) []V {
+	var records []V
+	for _, group := range values {
+		records = append(records, group...)
+	}
+	return records
+}
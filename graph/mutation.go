@@ -0,0 +1,207 @@
+package graph
+
+import (
+	"context"
+	"incident-report/utils"
+
+	graphql "github.com/graph-gophers/graphql-go"
+)
+
+// createReportInput is the input of createReport
+type createReportInput struct {
+	Name        string
+	RoomID      graphql.ID
+	ComponentID graphql.ID
+	UserID      *graphql.ID
+	Status      string
+	RepairCost  *float64
+}
+
+// updateReportInput is the input of updateReport; missing fields are left unchanged
+type updateReportInput struct {
+	Name        *string
+	RoomID      *graphql.ID
+	ComponentID *graphql.ID
+	Status      *string
+	RepairCost  *float64
+}
+
+// versionArgs selects a record by ID, optionally at a version
+type versionArgs struct {
+	ID      graphql.ID
+	Version *int32
+}
+
+// CreateReport files a report, checked by the same rules as POST /reports
+func (r *Resolver) CreateReport(ctx context.Context, args struct{ Input createReportInput }) (*reportResolver, error) {
+	loadersFrom(ctx).reset()
+
+	req := &utils.CreateReportRequest{Name: args.Input.Name, Status: args.Input.Status}
+	var err error
+	if req.RoomID, err = parseID("roomId", args.Input.RoomID); err != nil {
+		return nil, wrapError(err)
+	}
+	if req.ComponentID, err = parseID("componentId", args.Input.ComponentID); err != nil {
+		return nil, wrapError(err)
+	}
+	if args.Input.UserID != nil {
+		userID, err := parseID("userId", *args.Input.UserID)
+		if err != nil {
+			return nil, wrapError(err)
+		}
+		req.UserID = &userID
+	}
+	if args.Input.RepairCost != nil {
+		req.RepairCost = *args.Input.RepairCost
+	}
+	if err := utils.Validate(req); err != nil {
+		return nil, wrapError(err)
+	}
+
+	report, err := r.services.Reports.CreateReport(ctx, req)
+	if err != nil {
+		return nil, wrapError(err)
+	}
+	return newReports(ctx, *report)[0], nil
+}
+
+// UpdateReport changes the given fields of a report, like PUT /reports/:id
+func (r *Resolver) UpdateReport(ctx context.Context, args struct {
+	ID      graphql.ID
+	Input   updateReportInput
+	Version *int32
+}) (*reportResolver, error) {
+	loadersFrom(ctx).reset()
+
+	id, err := parseID("id", args.ID)
+	if err != nil {
+		return nil, wrapError(err)
+	}
+	req := &utils.UpdateReportRequest{RepairCost: args.Input.RepairCost}
+	if args.Input.Name != nil {
+		req.Name = *args.Input.Name
+	}
+	if args.Input.Status != nil {
+		req.Status = *args.Input.Status
+	}
+	if req.RoomID, err = parseOptionalID("roomId", args.Input.RoomID); err != nil {
+		return nil, wrapError(err)
+	}
+	if req.ComponentID, err = parseOptionalID("componentId", args.Input.ComponentID); err != nil {
+		return nil, wrapError(err)
+	}
+	if err := utils.Validate(req); err != nil {
+		return nil, wrapError(err)
+	}
+
+	report, err := r.services.Reports.UpdateReport(withVersion(ctx, args.Version), id, req)
+	if err != nil {
+		return nil, wrapError(err)
+	}
+	return newReports(ctx, *report)[0], nil
+}
+
+// DeleteReport moves a report to the trash
+func (r *Resolver) DeleteReport(ctx context.Context, args versionArgs) (bool, error) {
+	loadersFrom(ctx).reset()
+
+	id, err := parseID("id", args.ID)
+	if err != nil {
+		return false, wrapError(err)
+	}
+	if err := r.services.Reports.DeleteReport(withVersion(ctx, args.Version), id); err != nil {
+		return false, wrapError(err)
+	}
+	return true, nil
+}
+
+// AssignReport assigns a report to a user
+func (r *Resolver) AssignReport(ctx context.Context, args struct {
+	ID      graphql.ID
+	UserID  graphql.ID
+	Version *int32
+}) (*reportResolver, error) {
+	loadersFrom(ctx).reset()
+
+	id, err := parseID("id", args.ID)
+	if err != nil {
+		return nil, wrapError(err)
+	}
+	userID, err := parseID("userId", args.UserID)
+	if err != nil {
+		return nil, wrapError(err)
+	}
+
+	report, err := r.services.Reports.AssignUserToReport(withVersion(ctx, args.Version), id, userID)
+	if err != nil {
+		return nil, wrapError(err)
+	}
+	return newReports(ctx, *report)[0], nil
+}
+
+// UnassignReport takes a report back from its user, like a merge patch setting user_id to null
+func (r *Resolver) UnassignReport(ctx context.Context, args versionArgs) (*reportResolver, error) {
+	loadersFrom(ctx).reset()
+
+	id, err := parseID("id", args.ID)
+	if err != nil {
+		return nil, wrapError(err)
+	}
+
+	report, err := r.services.Reports.PatchReport(withVersion(ctx, args.Version), id, []byte(`{"user_id":null}`))
+	if err != nil {
+		return nil, wrapError(err)
+	}
+	return newReports(ctx, *report)[0], nil
+}
+
+// TagReport attaches tags to a report
+func (r *Resolver) TagReport(ctx context.Context, args struct {
+	ID     graphql.ID
+	TagIDs []graphql.ID
+}) (*reportResolver, error) {
+	loadersFrom(ctx).reset()
+
+	id, err := parseID("id", args.ID)
+	if err != nil {
+		return nil, wrapError(err)
+	}
+	if len(args.TagIDs) == 0 {
+		return nil, wrapError(utils.InvalidField("tagIds", "at least one tag is required"))
+	}
+	tagIDs := make([]uint, len(args.TagIDs))
+	for i, tagID := range args.TagIDs {
+		if tagIDs[i], err = parseID("tagIds", tagID); err != nil {
+			return nil, wrapError(err)
+		}
+	}
+
+	report, err := r.services.Reports.AddTagsToReport(ctx, id, tagIDs)
+	if err != nil {
+		return nil, wrapError(err)
+	}
+	return newReports(ctx, *report)[0], nil
+}
+
+// UntagReport detaches a tag from a report
+func (r *Resolver) UntagReport(ctx context.Context, args struct {
+	ID    graphql.ID
+	TagID graphql.ID
+}) (*reportResolver, error) {
+	loadersFrom(ctx).reset()
+
+	id, err := parseID("id", args.ID)
+	if err != nil {
+		return nil, wrapError(err)
+	}
+	tagID, err := parseID("tagId", args.TagID)
+	if err != nil {
+		return nil, wrapError(err)
+	}
+
+	report, err := r.services.Reports.RemoveTagFromReport(ctx, id, tagID)
+	if err != nil {
+		return nil, wrapError(err)
+	}
+	return newReports(ctx, *report)[0], nil
+}
@@ -0,0 +1,282 @@
+package graph
+
+import (
+	"context"
+	"incident-report/utils"
+
+	graphql "github.com/graph-gophers/graphql-go"
+)
+
+// idArgs selects a record by ID
+type idArgs struct {
+	ID graphql.ID
+}
+
+// pageArgs selects a page of a list
+type pageArgs struct {
+	Page *pageInput
+}
+
+// Viewer resolves the user the request runs as, null for anonymous requests
+func (r *Resolver) Viewer(ctx context.Context) (*userResolver, error) {
+	actor, ok := utils.ActorFromContext(ctx)
+	if !ok {
+		return nil, nil
+	}
+	user, err := r.services.Users.GetUserByID(ctx, actor.UserID)
+	if err != nil {
+		return nil, wrapError(err)
+	}
+	return newUsers(ctx, *user)[0], nil
+}
+
+func (r *Resolver) Building(ctx context.Context, args idArgs) (*buildingResolver, error) {
+	id, err := parseID("id", args.ID)
+	if err != nil {
+		return nil, wrapError(err)
+	}
+	building, err := r.services.Buildings.GetBuildingByID(ctx, id)
+	if err != nil {
+		return nil, wrapError(err)
+	}
+	return newBuildings(ctx, *building)[0], nil
+}
+
+func (r *Resolver) Buildings(ctx context.Context, args pageArgs) (*connection[*buildingResolver], error) {
+	q, err := paginationOf(args.Page)
+	if err != nil {
+		return nil, wrapError(err)
+	}
+	buildings, info, err := r.services.Buildings.GetAllBuildings(ctx, q)
+	if err != nil {
+		return nil, wrapError(err)
+	}
+	return &connection[*buildingResolver]{newBuildings(ctx, buildings...), newPageInfo(q, info)}, nil
+}
+
+func (r *Resolver) Floor(ctx context.Context, args idArgs) (*floorResolver, error) {
+	id, err := parseID("id", args.ID)
+	if err != nil {
+		return nil, wrapError(err)
+	}
+	floor, err := r.services.Floors.GetFloorByID(ctx, id)
+	if err != nil {
+		return nil, wrapError(err)
+	}
+	return newFloors(ctx, *floor)[0], nil
+}
+
+func (r *Resolver) Floors(ctx context.Context, args pageArgs) (*connection[*floorResolver], error) {
+	q, err := paginationOf(args.Page)
+	if err != nil {
+		return nil, wrapError(err)
+	}
+	floors, info, err := r.services.Floors.GetAllFloors(ctx, q)
+	if err != nil {
+		return nil, wrapError(err)
+	}
+	return &connection[*floorResolver]{newFloors(ctx, floors...), newPageInfo(q, info)}, nil
+}
+
+func (r *Resolver) Room(ctx context.Context, args idArgs) (*roomResolver, error) {
+	id, err := parseID("id", args.ID)
+	if err != nil {
+		return nil, wrapError(err)
+	}
+	room, err := r.services.Rooms.GetRoomByID(ctx, id)
+	if err != nil {
+		return nil, wrapError(err)
+	}
+	return newRooms(ctx, *room)[0], nil
+}
+
+func (r *Resolver) Rooms(ctx context.Context, args pageArgs) (*connection[*roomResolver], error) {
+	q, err := paginationOf(args.Page)
+	if err != nil {
+		return nil, wrapError(err)
+	}
+	rooms, info, err := r.services.Rooms.GetAllRooms(ctx, q)
+	if err != nil {
+		return nil, wrapError(err)
+	}
+	return &connection[*roomResolver]{newRooms(ctx, rooms...), newPageInfo(q, info)}, nil
+}
+
+func (r *Resolver) ComponentCategory(ctx context.Context, args idArgs) (*componentCategoryResolver, error) {
+	id, err := parseID("id", args.ID)
+	if err != nil {
+		return nil, wrapError(err)
+	}
+	category, err := r.services.ComponentCategories.GetComponentCategoryByID(ctx, id)
+	if err != nil {
+		return nil, wrapError(err)
+	}
+	return newComponentCategories(ctx, *category)[0], nil
+}
+
+func (r *Resolver) ComponentCategories(ctx context.Context, args pageArgs) (*connection[*componentCategoryResolver], error) {
+	q, err := paginationOf(args.Page)
+	if err != nil {
+		return nil, wrapError(err)
+	}
+	categories, info, err := r.services.ComponentCategories.GetAllComponentCategories(ctx, q)
+	if err != nil {
+		return nil, wrapError(err)
+	}
+	return &connection[*componentCategoryResolver]{newComponentCategories(ctx, categories...), newPageInfo(q, info)}, nil
+}
+
+func (r *Resolver) Component(ctx context.Context, args idArgs) (*componentResolver, error) {
+	id, err := parseID("id", args.ID)
+	if err != nil {
+		return nil, wrapError(err)
+	}
+	component, err := r.services.Components.GetComponentByID(ctx, id)
+	if err != nil {
+		return nil, wrapError(err)
+	}
+	return newComponents(ctx, *component)[0], nil
+}
+
+func (r *Resolver) Components(ctx context.Context, args struct {
+	Filter *componentFilter
+	Page   *pageInput
+}) (*connection[*componentResolver], error) {
+	q, err := paginationOf(args.Page)
+	if err != nil {
+		return nil, wrapError(err)
+	}
+	filter := &utils.ComponentFilterQuery{}
+	if args.Filter != nil {
+		if filter.RoomID, err = parseOptionalID("roomId", args.Filter.RoomID); err != nil {
+			return nil, wrapError(err)
+		}
+		if filter.CategoryID, err = parseOptionalID("categoryId", args.Filter.CategoryID); err != nil {
+			return nil, wrapError(err)
+		}
+	}
+
+	components, info, err := r.services.Components.GetAllComponents(ctx, q, filter)
+	if err != nil {
+		return nil, wrapError(err)
+	}
+	return &connection[*componentResolver]{newComponents(ctx, components...), newPageInfo(q, info)}, nil
+}
+
+func (r *Resolver) Report(ctx context.Context, args idArgs) (*reportResolver, error) {
+	id, err := parseID("id", args.ID)
+	if err != nil {
+		return nil, wrapError(err)
+	}
+	report, err := r.services.Reports.GetReportByID(ctx, id)
+	if err != nil {
+		return nil, wrapError(err)
+	}
+	return newReports(ctx, *report)[0], nil
+}
+
+func (r *Resolver) Reports(ctx context.Context, args struct {
+	Filter *reportFilter
+	Page   *pageInput
+}) (*connection[*reportResolver], error) {
+	q, err := paginationOf(args.Page)
+	if err != nil {
+		return nil, wrapError(err)
+	}
+	filter, err := reportFilterOf(args.Filter)
+	if err != nil {
+		return nil, wrapError(err)
+	}
+
+	reports, info, err := r.services.Reports.GetAllReports(ctx, q, filter)
+	if err != nil {
+		return nil, wrapError(err)
+	}
+	return &connection[*reportResolver]{newReports(ctx, reports...), newPageInfo(q, info)}, nil
+}
+
+func (r *Resolver) User(ctx context.Context, args idArgs) (*userResolver, error) {
+	id, err := parseID("id", args.ID)
+	if err != nil {
+		return nil, wrapError(err)
+	}
+	user, err := r.services.Users.GetUserByID(ctx, id)
+	if err != nil {
+		return nil, wrapError(err)
+	}
+	return newUsers(ctx, *user)[0], nil
+}
+
+func (r *Resolver) Users(ctx context.Context, args pageArgs) (*connection[*userResolver], error) {
+	q, err := paginationOf(args.Page)
+	if err != nil {
+		return nil, wrapError(err)
+	}
+	users, info, err := r.services.Users.GetAllUsers(ctx, q)
+	if err != nil {
+		return nil, wrapError(err)
+	}
+	return &connection[*userResolver]{newUsers(ctx, users...), newPageInfo(q, info)}, nil
+}
+
+func (r *Resolver) Tag(ctx context.Context, args idArgs) (*tagResolver, error) {
+	id, err := parseID("id", args.ID)
+	if err != nil {
+		return nil, wrapError(err)
+	}
+	tag, err := r.services.Tags.GetTagByID(ctx, id)
+	if err != nil {
+		return nil, wrapError(err)
+	}
+	return newTags(ctx, *tag)[0], nil
+}
+
+func (r *Resolver) Tags(ctx context.Context, args pageArgs) (*connection[*tagResolver], error) {
+	q, err := paginationOf(args.Page)
+	if err != nil {
+		return nil, wrapError(err)
+	}
+	tags, info, err := r.services.Tags.GetAllTags(ctx, q)
+	if err != nil {
+		return nil, wrapError(err)
+	}
+	return &connection[*tagResolver]{newTags(ctx, tags...), newPageInfo(q, info)}, nil
+}
+
+func (r *Resolver) AuditLogs(ctx context.Context, args struct {
+	Filter *auditFilter
+	Page   *pageInput
+}) (*connection[*auditLogResolver], error) {
+	q, err := paginationOf(args.Page)
+	if err != nil {
+		return nil, wrapError(err)
+	}
+	query := &utils.AuditQuery{}
+	if f := args.Filter; f != nil {
+		if f.Entity != nil {
+			query.Entity = *f.Entity
+		}
+		if f.From != nil {
+			query.From = *f.From
+		}
+		if f.To != nil {
+			query.To = *f.To
+		}
+		if query.EntityID, err = parseOptionalID("entityId", f.EntityID); err != nil {
+			return nil, wrapError(err)
+		}
+		if query.Actor, err = parseOptionalID("actorId", f.ActorID); err != nil {
+			return nil, wrapError(err)
+		}
+	}
+	if err := utils.Validate(query); err != nil {
+		return nil, wrapError(err)
+	}
+	query.PaginationQuery = *q
+
+	entries, info, err := r.services.Audit.ListAuditLogs(ctx, query)
+	if err != nil {
+		return nil, wrapError(err)
+	}
+	return &connection[*auditLogResolver]{newAuditLogs(ctx, entries...), newPageInfo(q, info)}, nil
+}
@@ -0,0 +1,352 @@
+package graph
+
+import (
+	"context"
+	"incident-report/utils"
+
+	graphql "github.com/graph-gophers/graphql-go"
+)
+
+// The object resolvers wrap the response DTOs of the services. Relations are looked up on the
+// request's dataloaders, which also learn about every record a resolver is created for.
+
+// buildingResolver resolves a Building
+type buildingResolver struct {
+	building *utils.BuildingResponse
+}
+
+// newBuildings creates the resolvers of resolved buildings
+func newBuildings(ctx context.Context, buildings ...utils.BuildingResponse) []*buildingResolver {
+	loadersFrom(ctx).seeBuildings(buildings...)
+	resolvers := make([]*buildingResolver, len(buildings))
+	for i := range buildings {
+		resolvers[i] = &buildingResolver{&buildings[i]}
+	}
+	return resolvers
+}
+
+func (r *buildingResolver) ID() graphql.ID          { return toID(r.building.ID) }
+func (r *buildingResolver) Code() string            { return r.building.Code }
+func (r *buildingResolver) Name() string            { return r.building.Name }
+func (r *buildingResolver) Location() string        { return r.building.Location }
+func (r *buildingResolver) CreatedAt() graphql.Time { return unixTime(r.building.CreatedAt) }
+func (r *buildingResolver) UpdatedAt() graphql.Time { return unixTime(r.building.UpdatedAt) }
+func (r *buildingResolver) Version() int32          { return int32(r.building.Version) }
+
+func (r *buildingResolver) Floors(ctx context.Context) ([]*floorResolver, error) {
+	floors, err := loadersFrom(ctx).floorsByBuilding.load(ctx, r.building.ID)
+	return newFloors(ctx, floors...), wrapError(err)
+}
+
+// floorResolver resolves a Floor
+type floorResolver struct {
+	floor *utils.FloorResponse
+}
+
+// newFloors creates the resolvers of resolved floors
+func newFloors(ctx context.Context, floors ...utils.FloorResponse) []*floorResolver {
+	loadersFrom(ctx).seeFloors(floors...)
+	resolvers := make([]*floorResolver, len(floors))
+	for i := range floors {
+		resolvers[i] = &floorResolver{&floors[i]}
+	}
+	return resolvers
+}
+
+func (r *floorResolver) ID() graphql.ID          { return toID(r.floor.ID) }
+func (r *floorResolver) Number() int32           { return int32(r.floor.FloorNumber) }
+func (r *floorResolver) Name() string            { return r.floor.Name }
+func (r *floorResolver) CreatedAt() graphql.Time { return unixTime(r.floor.CreatedAt) }
+func (r *floorResolver) UpdatedAt() graphql.Time { return unixTime(r.floor.UpdatedAt) }
+func (r *floorResolver) Version() int32          { return int32(r.floor.Version) }
+
+func (r *floorResolver) Building(ctx context.Context) (*buildingResolver, error) {
+	building := r.floor.Building
+	if building == nil {
+		var err error
+		if building, err = loadersFrom(ctx).buildings.load(ctx, r.floor.BuildingID); err != nil || building == nil {
+			return nil, wrapError(err)
+		}
+	}
+	return newBuildings(ctx, *building)[0], nil
+}
+
+func (r *floorResolver) Rooms(ctx context.Context) ([]*roomResolver, error) {
+	rooms, err := loadersFrom(ctx).roomsByFloor.load(ctx, r.floor.ID)
+	return newRooms(ctx, rooms...), wrapError(err)
+}
+
+// roomResolver resolves a Room
+type roomResolver struct {
+	room *utils.RoomResponse
+}
+
+// newRooms creates the resolvers of resolved rooms
+func newRooms(ctx context.Context, rooms ...utils.RoomResponse) []*roomResolver {
+	loadersFrom(ctx).seeRooms(rooms...)
+	resolvers := make([]*roomResolver, len(rooms))
+	for i := range rooms {
+		resolvers[i] = &roomResolver{&rooms[i]}
+	}
+	return resolvers
+}
+
+func (r *roomResolver) ID() graphql.ID          { return toID(r.room.ID) }
+func (r *roomResolver) Code() string            { return r.room.Code }
+func (r *roomResolver) Name() string            { return r.room.Name }
+func (r *roomResolver) CreatedAt() graphql.Time { return unixTime(r.room.CreatedAt) }
+func (r *roomResolver) UpdatedAt() graphql.Time { return unixTime(r.room.UpdatedAt) }
+func (r *roomResolver) Version() int32          { return int32(r.room.Version) }
+
+func (r *roomResolver) Floor(ctx context.Context) (*floorResolver, error) {
+	floor := r.room.Floor
+	if floor == nil {
+		var err error
+		if floor, err = loadersFrom(ctx).floors.load(ctx, r.room.FloorID); err != nil || floor == nil {
+			return nil, wrapError(err)
+		}
+	}
+	return newFloors(ctx, *floor)[0], nil
+}
+
+func (r *roomResolver) Components(ctx context.Context) ([]*componentResolver, error) {
+	components, err := loadersFrom(ctx).componentsByRoom.load(ctx, r.room.ID)
+	return newComponents(ctx, components...), wrapError(err)
+}
+
+func (r *roomResolver) Reports(ctx context.Context, args reportsArgs) ([]*reportResolver, error) {
+	reports, err := loadersFrom(ctx).reportsByRoom.load(ctx, r.room.ID)
+	return newReports(ctx, filterReports(reports, args)...), wrapError(err)
+}
+
+// componentCategoryResolver resolves a ComponentCategory
+type componentCategoryResolver struct {
+	category *utils.ComponentCategoryResponse
+}
+
+// newComponentCategories creates the resolvers of resolved component categories
+func newComponentCategories(ctx context.Context, categories ...utils.ComponentCategoryResponse) []*componentCategoryResolver {
+	loadersFrom(ctx).seeCategories(categories...)
+	resolvers := make([]*componentCategoryResolver, len(categories))
+	for i := range categories {
+		resolvers[i] = &componentCategoryResolver{&categories[i]}
+	}
+	return resolvers
+}
+
+func (r *componentCategoryResolver) ID() graphql.ID          { return toID(r.category.ID) }
+func (r *componentCategoryResolver) Code() string            { return r.category.Code }
+func (r *componentCategoryResolver) Name() string            { return r.category.Name }
+func (r *componentCategoryResolver) Description() string     { return r.category.Description }
+func (r *componentCategoryResolver) CreatedAt() graphql.Time { return unixTime(r.category.CreatedAt) }
+func (r *componentCategoryResolver) UpdatedAt() graphql.Time { return unixTime(r.category.UpdatedAt) }
+func (r *componentCategoryResolver) Version() int32          { return int32(r.category.Version) }
+
+func (r *componentCategoryResolver) Components(ctx context.Context) ([]*componentResolver, error) {
+	components, err := loadersFrom(ctx).componentsByCategory.load(ctx, r.category.ID)
+	return newComponents(ctx, components...), wrapError(err)
+}
+
+// componentResolver resolves a Component
+type componentResolver struct {
+	component *utils.ComponentResponse
+}
+
+// newComponents creates the resolvers of resolved components
+func newComponents(ctx context.Context, components ...utils.ComponentResponse) []*componentResolver {
+	loadersFrom(ctx).seeComponents(components...)
+	resolvers := make([]*componentResolver, len(components))
+	for i := range components {
+		resolvers[i] = &componentResolver{&components[i]}
+	}
+	return resolvers
+}
+
+func (r *componentResolver) ID() graphql.ID          { return toID(r.component.ID) }
+func (r *componentResolver) Code() string            { return r.component.Code }
+func (r *componentResolver) Name() string            { return r.component.Name }
+func (r *componentResolver) Brand() string           { return r.component.Brand }
+func (r *componentResolver) Specification() string   { return r.component.Specification }
+func (r *componentResolver) ProcurementYear() int32  { return int32(r.component.ProcurementYear) }
+func (r *componentResolver) CreatedAt() graphql.Time { return unixTime(r.component.CreatedAt) }
+func (r *componentResolver) UpdatedAt() graphql.Time { return unixTime(r.component.UpdatedAt) }
+func (r *componentResolver) Version() int32          { return int32(r.component.Version) }
+
+// Room is null for components that are not installed in a room
+func (r *componentResolver) Room(ctx context.Context) (*roomResolver, error) {
+	room := r.component.Room
+	if room == nil && r.component.RoomID != nil {
+		var err error
+		if room, err = loadersFrom(ctx).rooms.load(ctx, *r.component.RoomID); err != nil {
+			return nil, wrapError(err)
+		}
+	}
+	if room == nil {
+		return nil, nil
+	}
+	return newRooms(ctx, *room)[0], nil
+}
+
+func (r *componentResolver) Category(ctx context.Context) (*componentCategoryResolver, error) {
+	category, err := loadersFrom(ctx).categories.load(ctx, r.component.CategoryID)
+	if err != nil || category == nil {
+		return nil, wrapError(err)
+	}
+	return newComponentCategories(ctx, *category)[0], nil
+}
+
+func (r *componentResolver) Reports(ctx context.Context, args reportsArgs) ([]*reportResolver, error) {
+	reports, err := loadersFrom(ctx).reportsByComponent.load(ctx, r.component.ID)
+	return newReports(ctx, filterReports(reports, args)...), wrapError(err)
+}
+
+// reportResolver resolves a Report
+type reportResolver struct {
+	report *utils.ReportResponse
+}
+
+// newReports creates the resolvers of resolved reports
+func newReports(ctx context.Context, reports ...utils.ReportResponse) []*reportResolver {
+	loadersFrom(ctx).seeReports(reports...)
+	resolvers := make([]*reportResolver, len(reports))
+	for i := range reports {
+		resolvers[i] = &reportResolver{&reports[i]}
+	}
+	return resolvers
+}
+
+func (r *reportResolver) ID() graphql.ID          { return toID(r.report.ID) }
+func (r *reportResolver) Name() string            { return r.report.Name }
+func (r *reportResolver) Status() string          { return r.report.Status }
+func (r *reportResolver) RepairCost() float64     { return r.report.RepairCost }
+func (r *reportResolver) CreatedAt() graphql.Time { return parseTime(r.report.CreatedAt) }
+func (r *reportResolver) UpdatedAt() graphql.Time { return parseTime(r.report.UpdatedAt) }
+func (r *reportResolver) Version() int32          { return int32(r.report.Version) }
+
+func (r *reportResolver) CompletedAt() *graphql.Time {
+	if r.report.CompletedAt == nil {
+		return nil
+	}
+	completed := parseTime(*r.report.CompletedAt)
+	return &completed
+}
+
+func (r *reportResolver) Room(ctx context.Context) (*roomResolver, error) {
+	room, err := loadersFrom(ctx).rooms.load(ctx, r.report.RoomID)
+	if err != nil || room == nil {
+		return nil, wrapError(err)
+	}
+	return newRooms(ctx, *room)[0], nil
+}
+
+func (r *reportResolver) Component(ctx context.Context) (*componentResolver, error) {
+	component, err := loadersFrom(ctx).components.load(ctx, r.report.ComponentID)
+	if err != nil || component == nil {
+		return nil, wrapError(err)
+	}
+	return newComponents(ctx, *component)[0], nil
+}
+
+// Assignee is the user the report is assigned to, null while it is unassigned
+func (r *reportResolver) Assignee(ctx context.Context) (*userResolver, error) {
+	if r.report.UserID == nil {
+		return nil, nil
+	}
+	user, err := loadersFrom(ctx).users.load(ctx, *r.report.UserID)
+	if err != nil || user == nil {
+		return nil, wrapError(err)
+	}
+	return newUsers(ctx, *user)[0], nil
+}
+
+func (r *reportResolver) Tags(ctx context.Context) ([]*tagResolver, error) {
+	tags, err := loadersFrom(ctx).tagsByReport.load(ctx, r.report.ID)
+	return newTags(ctx, tags...), wrapError(err)
+}
+
+// userResolver resolves a User
+type userResolver struct {
+	user *utils.UserResponse
+}
+
+// newUsers creates the resolvers of resolved users
+func newUsers(ctx context.Context, users ...utils.UserResponse) []*userResolver {
+	loadersFrom(ctx).seeUsers(users...)
+	resolvers := make([]*userResolver, len(users))
+	for i := range users {
+		resolvers[i] = &userResolver{&users[i]}
+	}
+	return resolvers
+}
+
+func (r *userResolver) ID() graphql.ID { return toID(r.user.ID) }
+func (r *userResolver) Name() string   { return r.user.Name }
+func (r *userResolver) Email() string  { return r.user.Email }
+func (r *userResolver) Role() string   { return r.user.Role }
+func (r *userResolver) Version() int32 { return int32(r.user.Version) }
+
+func (r *userResolver) Reports(ctx context.Context, args reportsArgs) ([]*reportResolver, error) {
+	reports, err := loadersFrom(ctx).reportsByUser.load(ctx, r.user.ID)
+	return newReports(ctx, filterReports(reports, args)...), wrapError(err)
+}
+
+// tagResolver resolves a Tag
+type tagResolver struct {
+	tag *utils.TagResponse
+}
+
+// newTags creates the resolvers of resolved tags
+func newTags(ctx context.Context, tags ...utils.TagResponse) []*tagResolver {
+	loadersFrom(ctx).seeTags(tags...)
+	resolvers := make([]*tagResolver, len(tags))
+	for i := range tags {
+		resolvers[i] = &tagResolver{&tags[i]}
+	}
+	return resolvers
+}
+
+func (r *tagResolver) ID() graphql.ID { return toID(r.tag.ID) }
+func (r *tagResolver) Name() string   { return r.tag.Name }
+func (r *tagResolver) Color() string  { return r.tag.Color }
+func (r *tagResolver) Version() int32 { return int32(r.tag.Version) }
+
+func (r *tagResolver) Reports(ctx context.Context, args reportsArgs) ([]*reportResolver, error) {
+	reports, err := loadersFrom(ctx).reportsByTag.load(ctx, r.tag.ID)
+	return newReports(ctx, filterReports(reports, args)...), wrapError(err)
+}
+
+// auditLogResolver resolves an AuditLog
+type auditLogResolver struct {
+	entry *utils.AuditLogResponse
+}
+
+// newAuditLogs creates the resolvers of resolved audit log entries
+func newAuditLogs(ctx context.Context, entries ...utils.AuditLogResponse) []*auditLogResolver {
+	loadersFrom(ctx).seeAuditLogs(entries...)
+	resolvers := make([]*auditLogResolver, len(entries))
+	for i := range entries {
+		resolvers[i] = &auditLogResolver{&entries[i]}
+	}
+	return resolvers
+}
+
+func (r *auditLogResolver) ID() graphql.ID          { return toID(r.entry.ID) }
+func (r *auditLogResolver) EntityType() string      { return r.entry.EntityType }
+func (r *auditLogResolver) EntityID() graphql.ID    { return toID(r.entry.EntityID) }
+func (r *auditLogResolver) Action() string          { return r.entry.Action }
+func (r *auditLogResolver) Changes() string         { return string(r.entry.Changes) }
+func (r *auditLogResolver) IP() string              { return r.entry.IP }
+func (r *auditLogResolver) RequestID() string       { return r.entry.RequestID }
+func (r *auditLogResolver) CreatedAt() graphql.Time { return graphql.Time{Time: r.entry.CreatedAt} }
+
+// Actor is the user who made the change, null for anonymous changes and deleted users
+func (r *auditLogResolver) Actor(ctx context.Context) (*userResolver, error) {
+	if r.entry.ActorID == nil {
+		return nil, nil
+	}
+	user, err := loadersFrom(ctx).users.load(ctx, *r.entry.ActorID)
+	if err != nil || user == nil {
+		return nil, wrapError(err)
+	}
+	return newUsers(ctx, *user)[0], nil
+}
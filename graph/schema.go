@@ -0,0 +1,62 @@
+// Package graph serves the GraphQL API
+//
+// The schema (schema.graphql) covers the same records as the REST API and is resolved by the
+// same services, so reads, writes, validation and authorization behave the same way. Relations
+// are resolved through per-request dataloaders backed by services.BatchService.
+package graph
+
+import (
+	"context"
+	_ "embed"
+	"incident-report/services"
+
+	graphql "github.com/graph-gophers/graphql-go"
+)
+
+//go:embed schema.graphql
+var schemaSDL string
+
+// maxDepth bounds the nesting of a query, which is deep enough for
+// building → floors → rooms → components → reports → tags and their connections
+const maxDepth = 10
+
+// Services are the services the resolvers run on; they are shared with the REST controllers
+type Services struct {
+	Users               *services.UserService
+	Buildings           *services.BuildingService
+	Floors              *services.FloorService
+	Rooms               *services.RoomService
+	ComponentCategories *services.ComponentCategoryService
+	Components          *services.ComponentService
+	Reports             *services.ReportService
+	Tags                *services.TagService
+	Audit               *services.AuditService
+	Batch               *services.BatchService
+}
+
+// Resolver is the root resolver of queries and mutations
+type Resolver struct {
+	services Services
+}
+
+// Schema is the executable GraphQL schema
+type Schema struct {
+	schema *graphql.Schema
+	batch  *services.BatchService
+}
+
+// NewSchema parses the schema and binds it to resolvers running on the services
+// It panics if the resolvers do not match the schema, which is a programming error.
+func NewSchema(svc Services) *Schema {
+	return &Schema{
+		schema: graphql.MustParseSchema(schemaSDL, &Resolver{services: svc}, graphql.MaxDepth(maxDepth)),
+		batch:  svc.Batch,
+	}
+}
+
+// Exec runs a query or mutation with fresh dataloaders
+// The context carries the caller, see utils.ActorFromContext; errors are reported in the
+// response, as GraphQL requires.
+func (s *Schema) Exec(ctx context.Context, query, operationName string, variables map[string]interface{}) *graphql.Response {
+	return s.schema.Exec(withLoaders(ctx, newLoaders(s.batch)), query, operationName, variables)
+}
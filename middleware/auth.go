@@ -103,22 +103,6 @@ func CORSMiddleware() gin.HandlerFunc {
 	}
 }
 
-// RequestLoggingMiddleware logs incoming requests and outgoing responses
-// Useful for debugging and monitoring API usage
-// Future enhancement: integrate with logging library (e.g., zap, logrus)
-func RequestLoggingMiddleware() gin.HandlerFunc {
-	return func(c *gin.Context) {
-		// Log request details
-		// log.Printf("METHOD: %s, PATH: %s, IP: %s", c.Request.Method, c.Request.URL.Path, c.ClientIP())
-
-		// Continue processing
-		c.Next()
-
-		// Log response details
-		// log.Printf("STATUS: %d, METHOD: %s, PATH: %s", c.Writer.Status(), c.Request.Method, c.Request.URL.Path)
-	}
-}
-
 // RateLimitMiddleware is a placeholder for implementing rate limiting
 // Future implementation should:
 // - Track requests per IP/user
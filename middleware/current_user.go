@@ -0,0 +1,72 @@
+package middleware
+
+import (
+	"errors"
+	"incident-report/repositories"
+	"incident-report/utils"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// UserIDHeader identifies the calling user
+// It stands in for real authentication (see AuthMiddleware) and must only be trusted behind
+// a gateway that sets it.
+const UserIDHeader = "X-User-ID"
+
+// CurrentUserMiddleware loads the user named by the X-User-ID header into the request context
+// Requests without the header run anonymously; an unknown user is rejected with 401.
+func CurrentUserMiddleware(users repositories.UserRepository) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		header := c.GetHeader(UserIDHeader)
+		if header == "" {
+			c.Next()
+			return
+		}
+
+		id, err := strconv.ParseUint(header, 10, 32)
+		if err != nil {
+			utils.ErrorResponse(c, http.StatusUnauthorized, "Invalid "+UserIDHeader+" header", err.Error())
+			c.Abort()
+			return
+		}
+
+		user, err := users.FindByID(c.Request.Context(), uint(id))
+		if err != nil {
+			if errors.Is(err, repositories.ErrNotFound) {
+				utils.ErrorResponse(c, http.StatusUnauthorized, "Unknown user", "user not found")
+			} else {
+				utils.HandleError(c, "Failed to identify user", err)
+			}
+			c.Abort()
+			return
+		}
+
+		ctx := utils.WithActor(c.Request.Context(), utils.Actor{UserID: user.ID, Role: user.Role})
+		ctx = utils.WithLogger(ctx, utils.Logger(ctx).With(slog.Uint64("user_id", uint64(user.ID))))
+		c.Request = c.Request.WithContext(ctx)
+		c.Next()
+	}
+}
+
+// RequireRole rejects requests whose user does not have the role
+// It must run after CurrentUserMiddleware.
+func RequireRole(role string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		actor, ok := utils.ActorFromContext(c.Request.Context())
+		if !ok {
+			utils.ErrorResponse(c, http.StatusUnauthorized, "Authentication required", "send the "+UserIDHeader+" header")
+			c.Abort()
+			return
+		}
+		if actor.Role != role {
+			utils.ErrorResponse(c, http.StatusForbidden, "Permission denied", "requires the "+role+" role")
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
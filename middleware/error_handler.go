@@ -1,17 +1,23 @@
 package middleware
 
 import (
+	"fmt"
 	"incident-report/utils"
 	"net/http"
+	"runtime/debug"
 
 	"github.com/gin-gonic/gin"
 )
 
 // ErrorHandlerMiddleware recovers from panics and returns error response
+// The panic is logged with its stack under the ID of the request.
 func ErrorHandlerMiddleware() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		defer func() {
 			if err := recover(); err != nil {
+				utils.Logger(c.Request.Context()).ErrorContext(c.Request.Context(), "panic",
+					"panic", fmt.Sprint(err), "stack", string(debug.Stack()))
+				_ = c.Error(fmt.Errorf("panic: %v", err))
 				utils.ErrorResponse(
 					c,
 					http.StatusInternalServerError,
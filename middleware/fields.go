@@ -0,0 +1,31 @@
+package middleware
+
+import (
+	"incident-report/utils"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// FieldsMiddleware puts the sparse fieldset of a read, its fields= query parameter, into the request context
+// The repositories then only select the columns behind those fields, and utils.SuccessResponse
+// leaves the other fields out. Writes ignore the parameter, so a narrowed record is never saved.
+func FieldsMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		raw, ok := c.GetQuery("fields")
+		if !ok || (c.Request.Method != http.MethodGet && c.Request.Method != http.MethodHead) {
+			c.Next()
+			return
+		}
+
+		fields, err := utils.ParseFields(raw)
+		if err != nil {
+			utils.HandleError(c, "Invalid fields", err)
+			c.Abort()
+			return
+		}
+
+		c.Request = c.Request.WithContext(utils.WithFields(c.Request.Context(), fields))
+		c.Next()
+	}
+}
@@ -0,0 +1,172 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"incident-report/models"
+	"incident-report/repositories"
+	"incident-report/utils"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// IdempotencyKeyHeader names a POST request, so that a retry of it is not run twice
+const IdempotencyKeyHeader = "Idempotency-Key"
+
+// IdempotentReplayedHeader marks a response that was stored for an earlier request with the same key
+const IdempotentReplayedHeader = "Idempotent-Replayed"
+
+// maxIdempotencyKeyLength is the size of the key column
+const maxIdempotencyKeyLength = 255
+
+// IdempotencyMiddleware runs a POST request with an Idempotency-Key header at most once per key
+// The response is stored for ttl and replayed to retries. A key reused for a different request
+// is rejected with 422, and a retry that arrives while the first request still runs with 409.
+// Server errors are not stored, so the request can be retried. Keys are scoped to the caller,
+// which makes this run after CurrentUserMiddleware. Anonymous callers share one scope, so their
+// keys are also scoped to the request: a retry is replayed, but a reused key runs as a new request.
+func IdempotencyMiddleware(keys repositories.IdempotencyRepository, ttl time.Duration) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		key := c.GetHeader(IdempotencyKeyHeader)
+		if c.Request.Method != http.MethodPost || key == "" {
+			c.Next()
+			return
+		}
+		if len(key) > maxIdempotencyKeyLength {
+			utils.ErrorResponse(c, http.StatusBadRequest, "Invalid "+IdempotencyKeyHeader+" header", "the key is longer than 255 characters")
+			c.Abort()
+			return
+		}
+
+		body, err := io.ReadAll(c.Request.Body)
+		if err != nil {
+			utils.ErrorResponse(c, http.StatusBadRequest, "Invalid request format", err.Error())
+			c.Abort()
+			return
+		}
+		c.Request.Body = io.NopCloser(bytes.NewReader(body))
+
+		ctx := c.Request.Context()
+		hash := requestHash(c.Request.Method, c.Request.URL.RequestURI(), body)
+		var userID uint
+		scoped := key
+		if actor, ok := utils.ActorFromContext(ctx); ok && actor.UserID != 0 {
+			userID = actor.UserID
+		} else {
+			scoped = anonymousKey(key, hash)
+		}
+
+		stored, err := keys.Find(ctx, userID, scoped)
+		if err != nil && !errors.Is(err, repositories.ErrNotFound) {
+			utils.HandleError(c, "Failed to check "+IdempotencyKeyHeader, err)
+			c.Abort()
+			return
+		}
+		if stored != nil {
+			replayStored(c, stored, hash)
+			c.Abort()
+			return
+		}
+
+		record := &models.IdempotencyKey{
+			UserID:      userID,
+			Key:         scoped,
+			RequestHash: hash,
+			ExpiresAt:   time.Now().Add(ttl),
+		}
+		if err := keys.Reserve(ctx, record); err != nil {
+			if errors.Is(err, utils.ErrConflict) {
+				err = utils.ConflictState("a request with this " + IdempotencyKeyHeader + " is still being processed")
+			}
+			utils.HandleError(c, "Failed to reserve "+IdempotencyKeyHeader, err)
+			c.Abort()
+			return
+		}
+
+		// The key is released or completed even when the client has gone away and cancelled the
+		// request's context; otherwise it would stay in progress until it expires
+		storeCtx := context.WithoutCancel(ctx)
+
+		// A panicking or failing request leaves the key free for the retry
+		completed := false
+		defer func() {
+			if !completed {
+				if err := keys.Release(storeCtx, record); err != nil {
+					utils.Logger(ctx).ErrorContext(ctx, "failed to release the "+IdempotencyKeyHeader, "key", key, "error", err.Error())
+				}
+			}
+		}()
+
+		recorder := &responseRecorder{ResponseWriter: c.Writer}
+		c.Writer = recorder
+		c.Next()
+
+		if recorder.Status() >= http.StatusInternalServerError {
+			return
+		}
+		record.StatusCode = recorder.Status()
+		record.ContentType = recorder.Header().Get("Content-Type")
+		record.Body = recorder.body.String()
+		if err := keys.Complete(storeCtx, record); err != nil {
+			utils.Logger(ctx).ErrorContext(ctx, "failed to store the response for the "+IdempotencyKeyHeader, "key", key, "error", err.Error())
+			return
+		}
+		completed = true
+	}
+}
+
+// replayStored answers a retry with the stored response, if it is a retry of the same request
+func replayStored(c *gin.Context, stored *models.IdempotencyKey, hash string) {
+	if stored.StatusCode == 0 {
+		utils.HandleError(c, "Request in progress",
+			utils.ConflictState("a request with this "+IdempotencyKeyHeader+" is still being processed"))
+		return
+	}
+	if stored.RequestHash != hash {
+		utils.ErrorResponse(c, http.StatusUnprocessableEntity, IdempotencyKeyHeader+" reused",
+			"the key was already used for a different request")
+		return
+	}
+
+	c.Header(IdempotentReplayedHeader, "true")
+	c.Data(stored.StatusCode, stored.ContentType, []byte(stored.Body))
+}
+
+// requestHash fingerprints a request, so that a reused key can be told from a retry
+func requestHash(method, uri string, body []byte) string {
+	h := sha256.New()
+	h.Write([]byte(method + " " + uri + "\n"))
+	h.Write(body)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// anonymousKey scopes the key of an anonymous caller to its request, so that callers who happen
+// to send the same key do not get each other's responses
+func anonymousKey(key, hash string) string {
+	sum := sha256.Sum256([]byte(key + "\n" + hash))
+	return hex.EncodeToString(sum[:])
+}
+
+// responseRecorder keeps a copy of the response body while writing it to the client
+type responseRecorder struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+// Write records and writes a chunk of the body
+func (w *responseRecorder) Write(data []byte) (int, error) {
+	w.body.Write(data)
+	return w.ResponseWriter.Write(data)
+}
+
+// WriteString records and writes a chunk of the body
+func (w *responseRecorder) WriteString(s string) (int, error) {
+	w.body.WriteString(s)
+	return w.ResponseWriter.WriteString(s)
+}
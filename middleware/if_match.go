@@ -0,0 +1,42 @@
+package middleware
+
+import (
+	"incident-report/utils"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// IfMatchMiddleware puts the version named by the If-Match header of a write into the request context
+// The services compare it with the stored version and answer 412 on a mismatch. "*" and a
+// missing header do not constrain the write. A tag that is not a version cannot match anything.
+// A POST is conditional only when it acts on one record, such as attaching tags to a report;
+// creates and bulk requests have no single version to compare.
+func IfMatchMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		header := c.GetHeader("If-Match")
+		switch c.Request.Method {
+		case http.MethodPut, http.MethodPatch, http.MethodDelete:
+		case http.MethodPost:
+			if c.Param("id") == "" {
+				header = ""
+			}
+		default:
+			header = ""
+		}
+		if header == "" || header == "*" {
+			c.Next()
+			return
+		}
+
+		version, err := utils.ParseETag(header)
+		if err != nil {
+			utils.HandleError(c, "Precondition failed", utils.PreconditionFailed("If-Match: "+err.Error()))
+			c.Abort()
+			return
+		}
+
+		c.Request = c.Request.WithContext(utils.WithExpectedVersion(c.Request.Context(), version))
+		c.Next()
+	}
+}
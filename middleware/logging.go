@@ -0,0 +1,56 @@
+package middleware
+
+import (
+	"incident-report/utils"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RequestLoggingMiddleware logs every request as one structured line once it has been answered
+// The line carries the method, route, status, latency, user and request ID. A logger tagged with
+// the request ID is put in the request context, see utils.Logger, so the services and the database
+// log under the same ID. It must run after RequestIDMiddleware.
+func RequestLoggingMiddleware(logger *slog.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+		info, _ := utils.RequestInfoFromContext(c.Request.Context())
+		requestLogger := logger.With(slog.String("request_id", info.ID))
+		c.Request = c.Request.WithContext(utils.WithLogger(c.Request.Context(), requestLogger))
+
+		c.Next()
+
+		// Requests that matched no route have no route pattern
+		route := c.FullPath()
+		if route == "" {
+			route = "unmatched"
+		}
+		status := c.Writer.Status()
+		attrs := []slog.Attr{
+			slog.String("method", c.Request.Method),
+			slog.String("route", route),
+			slog.String("path", c.Request.URL.Path),
+			slog.Int("status", status),
+			slog.Float64("latency_ms", float64(time.Since(start).Microseconds())/1000),
+			slog.String("ip", info.IP),
+			slog.Int("bytes", c.Writer.Size()),
+		}
+		if actor, ok := utils.ActorFromContext(c.Request.Context()); ok {
+			attrs = append(attrs, slog.Uint64("user_id", uint64(actor.UserID)))
+		}
+		if len(c.Errors) > 0 {
+			attrs = append(attrs, slog.String("error", c.Errors.String()))
+		}
+
+		level := slog.LevelInfo
+		switch {
+		case status >= http.StatusInternalServerError:
+			level = slog.LevelError
+		case status >= http.StatusBadRequest:
+			level = slog.LevelWarn
+		}
+		requestLogger.LogAttrs(c.Request.Context(), level, "request", attrs...)
+	}
+}
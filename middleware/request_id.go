@@ -0,0 +1,47 @@
+package middleware
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"incident-report/utils"
+	"regexp"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RequestIDHeader carries the ID of a request, in both directions
+const RequestIDHeader = "X-Request-ID"
+
+// validRequestID accepts the IDs a client or gateway may pass in; anything else is replaced
+var validRequestID = regexp.MustCompile(`^[A-Za-z0-9._:-]{1,64}$`)
+
+// RequestIDMiddleware gives every request an ID and puts it, with the client IP, in the request context
+// An ID sent by the client is kept, so a request can be followed across services.
+func RequestIDMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id := RequestID(c.GetHeader(RequestIDHeader))
+		c.Header(RequestIDHeader, id)
+
+		ctx := utils.WithRequestInfo(c.Request.Context(), utils.RequestInfo{ID: id, IP: c.ClientIP()})
+		c.Request = c.Request.WithContext(ctx)
+		c.Next()
+	}
+}
+
+// RequestID returns the ID a client sent, or a new one if it sent none or an invalid one
+// The gRPC server uses it to give its calls IDs the same way.
+func RequestID(sent string) string {
+	if validRequestID.MatchString(sent) {
+		return sent
+	}
+	return newRequestID()
+}
+
+// newRequestID returns a random 128-bit ID
+func newRequestID() string {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "unknown"
+	}
+	return hex.EncodeToString(b)
+}
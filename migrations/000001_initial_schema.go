@@ -0,0 +1,128 @@
+package migrations
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Migration 1: initial schema
+// The structs below are a frozen snapshot of the models at the time this migration was written.
+// The tables are auto-migrated rather than created, so databases that were previously managed
+// by AutoMigrate are adopted without losing data.
+func init() {
+	type user struct {
+		ID        uint   `gorm:"primaryKey;autoIncrement"`
+		Name      string `gorm:"type:varchar(255);not null"`
+		Email     string `gorm:"type:varchar(255);uniqueIndex;not null"`
+		CreatedAt time.Time
+		UpdatedAt time.Time
+		DeletedAt gorm.DeletedAt `gorm:"index"`
+	}
+
+	type componentCategory struct {
+		ID          uint   `gorm:"primaryKey;autoIncrement"`
+		Code        string `gorm:"type:varchar(100);uniqueIndex;not null"`
+		Name        string `gorm:"type:varchar(255);not null"`
+		Description string `gorm:"type:text"`
+		CreatedAt   int64
+		UpdatedAt   int64
+		DeletedAt   gorm.DeletedAt `gorm:"index"`
+	}
+
+	type building struct {
+		ID        uint   `gorm:"primaryKey;autoIncrement"`
+		Code      string `gorm:"type:varchar(100);uniqueIndex;not null"`
+		Name      string `gorm:"type:varchar(255);not null"`
+		Location  string `gorm:"type:varchar(500)"`
+		CreatedAt int64
+		UpdatedAt int64
+		DeletedAt gorm.DeletedAt `gorm:"index"`
+	}
+
+	type floor struct {
+		ID         uint     `gorm:"primaryKey;autoIncrement"`
+		BuildingID uint     `gorm:"not null;index"`
+		Number     int      `gorm:"not null"`
+		Name       string   `gorm:"type:varchar(255);not null"`
+		Building   building `gorm:"foreignKey:BuildingID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+		CreatedAt  int64
+		UpdatedAt  int64
+		DeletedAt  gorm.DeletedAt `gorm:"index"`
+	}
+
+	type room struct {
+		ID        uint   `gorm:"primaryKey;autoIncrement"`
+		FloorID   uint   `gorm:"not null;index"`
+		Code      string `gorm:"type:varchar(100);uniqueIndex;not null"`
+		Name      string `gorm:"type:varchar(255);not null"`
+		Floor     floor  `gorm:"foreignKey:FloorID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+		CreatedAt int64
+		UpdatedAt int64
+		DeletedAt gorm.DeletedAt `gorm:"index"`
+	}
+
+	type component struct {
+		ID              uint   `gorm:"primaryKey;autoIncrement"`
+		RoomID          *uint  `gorm:"index"`
+		CategoryID      uint   `gorm:"not null;index"`
+		Code            string `gorm:"type:varchar(100);uniqueIndex;not null"`
+		Name            string `gorm:"type:varchar(255);not null"`
+		Brand           string `gorm:"type:varchar(255)"`
+		Specification   string `gorm:"type:text"`
+		ProcurementYear int
+		Room            *room             `gorm:"foreignKey:RoomID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+		Category        componentCategory `gorm:"foreignKey:CategoryID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+		CreatedAt       int64
+		UpdatedAt       int64
+		DeletedAt       gorm.DeletedAt `gorm:"index"`
+	}
+
+	type tag struct {
+		ID        uint   `gorm:"primaryKey;autoIncrement"`
+		Name      string `gorm:"type:varchar(100);uniqueIndex;not null"`
+		Color     string `gorm:"type:varchar(7);not null;default:'#808080'"`
+		CreatedAt time.Time
+		UpdatedAt time.Time
+	}
+
+	type report struct {
+		ID          uint       `gorm:"primaryKey;autoIncrement"`
+		Name        string     `gorm:"type:text;not null"`
+		RoomID      uint       `gorm:"not null;index"`
+		UserID      *uint      `gorm:"index"`
+		ComponentID uint       `gorm:"not null;index"`
+		Status      string     `gorm:"type:varchar(20);not null;default:'PENDING'"`
+		RepairCost  float64    `gorm:"type:decimal(12,2);not null;default:0"`
+		CompletedAt *time.Time `gorm:"index"`
+		Room        room       `gorm:"foreignKey:RoomID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+		User        *user      `gorm:"foreignKey:UserID"`
+		Component   component  `gorm:"foreignKey:ComponentID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+		Tags        []tag      `gorm:"many2many:report_tags;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+		CreatedAt   time.Time
+		UpdatedAt   time.Time
+	}
+
+	Register(Migration{
+		Version: 1,
+		Name:    "initial_schema",
+		Up: func(tx *gorm.DB) error {
+			err := tx.AutoMigrate(&user{}, &building{}, &floor{}, &room{},
+				&componentCategory{}, &component{}, &tag{}, &report{})
+			if err != nil {
+				return err
+			}
+
+			// Backfill completed_at for reports completed before the column existed
+			// updated_at is the closest record of when they were resolved
+			return tx.Table("reports").
+				Where("status = ? AND completed_at IS NULL", "COMPLETED").
+				UpdateColumn("completed_at", gorm.Expr("updated_at")).Error
+		},
+		Down: func(tx *gorm.DB) error {
+			// DropTable works through its arguments last to first
+			return tx.Migrator().DropTable("users", "buildings", "floors", "rooms",
+				"component_categories", "components", "tags", "reports", "report_tags")
+		},
+	})
+}
@@ -0,0 +1,61 @@
+package migrations
+
+import (
+	"fmt"
+
+	"gorm.io/gorm"
+)
+
+// Migration 2: live_unique_indexes
+// The unique indexes of migration 1 also counted soft-deleted rows, so the code of a deleted
+// building could never be used again. They are replaced by indexes over live rows only:
+// a partial index on PostgreSQL and SQLite, and a functional index on MySQL (8.0.13+),
+// which has no partial indexes but skips NULL keys.
+func init() {
+	// liveUniqueColumns lists the unique column of every soft-deletable table
+	liveUniqueColumns := []struct{ table, column string }{
+		{"users", "email"},
+		{"buildings", "code"},
+		{"rooms", "code"},
+		{"component_categories", "code"},
+		{"components", "code"},
+	}
+
+	Register(Migration{
+		Version: 2,
+		Name:    "live_unique_indexes",
+		Up: func(tx *gorm.DB) error {
+			for _, u := range liveUniqueColumns {
+				if err := tx.Migrator().DropIndex(u.table, fmt.Sprintf("idx_%s_%s", u.table, u.column)); err != nil {
+					return err
+				}
+
+				index := fmt.Sprintf("idx_%s_%s_live", u.table, u.column)
+				var sql string
+				if tx.Dialector.Name() == "mysql" {
+					sql = fmt.Sprintf("CREATE UNIQUE INDEX %s ON %s ((IF(deleted_at IS NULL, %s, NULL)))", index, u.table, u.column)
+				} else {
+					sql = fmt.Sprintf("CREATE UNIQUE INDEX %s ON %s (%s) WHERE deleted_at IS NULL", index, u.table, u.column)
+				}
+				if err := tx.Exec(sql).Error; err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+		Down: func(tx *gorm.DB) error {
+			// Fails when a soft-deleted row shares its value with a live one
+			for _, u := range liveUniqueColumns {
+				if err := tx.Migrator().DropIndex(u.table, fmt.Sprintf("idx_%s_%s_live", u.table, u.column)); err != nil {
+					return err
+				}
+
+				index := fmt.Sprintf("idx_%s_%s", u.table, u.column)
+				if err := tx.Exec(fmt.Sprintf("CREATE UNIQUE INDEX %s ON %s (%s)", index, u.table, u.column)).Error; err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	})
+}
@@ -0,0 +1,43 @@
+package migrations
+
+import (
+	"gorm.io/gorm"
+)
+
+// Migration 3: user_roles_and_report_trash
+// Users get a role so that purging the trash can be limited to admins, and reports become
+// soft-deletable like the rest of the hierarchy.
+func init() {
+	type user struct {
+		Role string `gorm:"type:varchar(20);not null;default:'technician'"`
+	}
+
+	type report struct {
+		DeletedAt gorm.DeletedAt `gorm:"index"`
+	}
+
+	Register(Migration{
+		Version: 3,
+		Name:    "user_roles_and_report_trash",
+		Up: func(tx *gorm.DB) error {
+			if err := tx.Migrator().AddColumn(&user{}, "Role"); err != nil {
+				return err
+			}
+			if err := tx.Migrator().AddColumn(&report{}, "DeletedAt"); err != nil {
+				return err
+			}
+			return tx.Migrator().CreateIndex(&report{}, "DeletedAt")
+		},
+		Down: func(tx *gorm.DB) error {
+			if err := tx.Migrator().DropIndex(&report{}, "DeletedAt"); err != nil {
+				return err
+			}
+			// Plain ALTER TABLE, because the SQLite migrator rebuilds the table to drop a column
+			// and would lose the partial indexes of migration 2
+			if err := tx.Exec("ALTER TABLE reports DROP COLUMN deleted_at").Error; err != nil {
+				return err
+			}
+			return tx.Exec("ALTER TABLE users DROP COLUMN role").Error
+		},
+	})
+}
@@ -0,0 +1,34 @@
+package migrations
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Migration 4: audit_logs
+// Every create, update and delete of an audited record is logged with who made it and from where.
+func init() {
+	type auditLog struct {
+		ID         uint      `gorm:"primaryKey;autoIncrement"`
+		ActorID    *uint     `gorm:"index"`
+		EntityType string    `gorm:"type:varchar(50);not null;index:idx_audit_logs_entity"`
+		EntityID   uint      `gorm:"not null;index:idx_audit_logs_entity"`
+		Action     string    `gorm:"type:varchar(20);not null"`
+		Changes    string    `gorm:"type:text"`
+		IP         string    `gorm:"type:varchar(45)"`
+		RequestID  string    `gorm:"type:varchar(64)"`
+		CreatedAt  time.Time `gorm:"index"`
+	}
+
+	Register(Migration{
+		Version: 4,
+		Name:    "audit_logs",
+		Up: func(tx *gorm.DB) error {
+			return tx.Migrator().CreateTable(&auditLog{})
+		},
+		Down: func(tx *gorm.DB) error {
+			return tx.Migrator().DropTable(&auditLog{})
+		},
+	})
+}
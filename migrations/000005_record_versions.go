@@ -0,0 +1,37 @@
+package migrations
+
+import (
+	"gorm.io/gorm"
+)
+
+// Migration 5: record_versions
+// Every mutable table gets a version column for optimistic locking. Existing rows start at 1.
+func init() {
+	type versioned struct {
+		Version uint `gorm:"not null;default:1"`
+	}
+
+	tables := []string{"users", "buildings", "floors", "rooms", "component_categories", "components", "reports", "tags"}
+
+	Register(Migration{
+		Version: 5,
+		Name:    "record_versions",
+		Up: func(tx *gorm.DB) error {
+			for _, table := range tables {
+				if err := tx.Table(table).Migrator().AddColumn(&versioned{}, "Version"); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+		Down: func(tx *gorm.DB) error {
+			// Plain ALTER TABLE, see migration 3
+			for _, table := range tables {
+				if err := tx.Exec("ALTER TABLE " + table + " DROP COLUMN version").Error; err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	})
+}
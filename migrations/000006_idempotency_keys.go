@@ -0,0 +1,34 @@
+package migrations
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Migration 6: idempotency_keys
+// Responses to POST requests with an Idempotency-Key header are kept for replay to retries.
+func init() {
+	type idempotencyKey struct {
+		ID          uint   `gorm:"primaryKey;autoIncrement"`
+		UserID      uint   `gorm:"not null;default:0;uniqueIndex:idx_idempotency_keys_user_key"`
+		Key         string `gorm:"type:varchar(255);not null;uniqueIndex:idx_idempotency_keys_user_key"`
+		RequestHash string `gorm:"type:varchar(64);not null"`
+		StatusCode  int    `gorm:"not null;default:0"`
+		ContentType string `gorm:"type:varchar(100)"`
+		Body        string `gorm:"type:text"`
+		CreatedAt   time.Time
+		ExpiresAt   time.Time `gorm:"not null;index"`
+	}
+
+	Register(Migration{
+		Version: 6,
+		Name:    "idempotency_keys",
+		Up: func(tx *gorm.DB) error {
+			return tx.Migrator().CreateTable(&idempotencyKey{})
+		},
+		Down: func(tx *gorm.DB) error {
+			return tx.Migrator().DropTable(&idempotencyKey{})
+		},
+	})
+}
@@ -0,0 +1,284 @@
+// Package migrations holds the versioned database schema changes
+//
+// Every migration lives in its own file named <version>_<name>.go and registers
+// itself from init(). Applied versions are recorded in the schema_migrations table.
+// Migrations are written against the GORM migrator (or plain SQL) and must not
+// reference the models package, since models keep changing after a migration ships.
+package migrations
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Migration is a single versioned schema change
+type Migration struct {
+	Version int64
+	Name    string
+	Up      func(tx *gorm.DB) error
+	Down    func(tx *gorm.DB) error
+}
+
+// SchemaMigration records an applied migration in the schema_migrations table
+type SchemaMigration struct {
+	Version   int64     `gorm:"primaryKey;autoIncrement:false"`
+	Name      string    `gorm:"type:varchar(255);not null"`
+	AppliedAt time.Time `gorm:"not null"`
+}
+
+// TableName specifies the table name for SchemaMigration
+func (SchemaMigration) TableName() string {
+	return "schema_migrations"
+}
+
+// Status describes whether a migration has been applied
+type Status struct {
+	Version   int64
+	Name      string
+	AppliedAt *time.Time
+	// Missing is set for versions recorded in the database that this build does not know
+	Missing bool
+}
+
+// registry holds every registered migration keyed by version
+var registry = map[int64]Migration{}
+
+// Register adds a migration to the registry
+// It panics on duplicate versions so the mistake surfaces at startup
+func Register(migration Migration) {
+	if _, exists := registry[migration.Version]; exists {
+		panic(fmt.Sprintf("migrations: duplicate version %d", migration.Version))
+	}
+	registry[migration.Version] = migration
+}
+
+// All returns the registered migrations ordered by version
+func All() []Migration {
+	all := make([]Migration, 0, len(registry))
+	for _, migration := range registry {
+		all = append(all, migration)
+	}
+	sort.Slice(all, func(i, j int) bool {
+		return all[i].Version < all[j].Version
+	})
+	return all
+}
+
+// ensureTable creates the schema_migrations table when it does not exist yet
+func ensureTable(db *gorm.DB) error {
+	return db.AutoMigrate(&SchemaMigration{})
+}
+
+// applied returns the applied migrations keyed by version
+func applied(db *gorm.DB) (map[int64]SchemaMigration, error) {
+	if err := ensureTable(db); err != nil {
+		return nil, err
+	}
+
+	var rows []SchemaMigration
+	if err := db.Order("version").Find(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	versions := make(map[int64]SchemaMigration, len(rows))
+	for _, row := range rows {
+		versions[row.Version] = row
+	}
+	return versions, nil
+}
+
+// Up applies pending migrations in version order
+// A steps value of 0 applies all of them. It returns the migrations that were applied.
+func Up(db *gorm.DB, steps int) ([]Migration, error) {
+	done, err := applied(db)
+	if err != nil {
+		return nil, err
+	}
+
+	var ran []Migration
+	for _, migration := range All() {
+		if _, ok := done[migration.Version]; ok {
+			continue
+		}
+		if steps > 0 && len(ran) == steps {
+			break
+		}
+
+		err := db.Transaction(func(tx *gorm.DB) error {
+			if err := migration.Up(tx); err != nil {
+				return err
+			}
+			return tx.Create(&SchemaMigration{
+				Version:   migration.Version,
+				Name:      migration.Name,
+				AppliedAt: time.Now(),
+			}).Error
+		})
+		if err != nil {
+			return ran, fmt.Errorf("migration %d_%s failed: %w", migration.Version, migration.Name, err)
+		}
+		ran = append(ran, migration)
+	}
+
+	return ran, nil
+}
+
+// Down rolls back the most recently applied migrations
+// A steps value of 0 rolls back one migration. It returns the migrations that were rolled back.
+func Down(db *gorm.DB, steps int) ([]Migration, error) {
+	if steps <= 0 {
+		steps = 1
+	}
+
+	done, err := applied(db)
+	if err != nil {
+		return nil, err
+	}
+
+	versions := make([]int64, 0, len(done))
+	for version := range done {
+		versions = append(versions, version)
+	}
+	sort.Slice(versions, func(i, j int) bool {
+		return versions[i] > versions[j]
+	})
+
+	var rolledBack []Migration
+	for _, version := range versions {
+		if len(rolledBack) == steps {
+			break
+		}
+
+		migration, ok := registry[version]
+		if !ok {
+			return rolledBack, fmt.Errorf("migration %d is applied but unknown to this build", version)
+		}
+
+		err := db.Transaction(func(tx *gorm.DB) error {
+			if err := migration.Down(tx); err != nil {
+				return err
+			}
+			return tx.Delete(&SchemaMigration{}, version).Error
+		})
+		if err != nil {
+			return rolledBack, fmt.Errorf("rollback of %d_%s failed: %w", migration.Version, migration.Name, err)
+		}
+		rolledBack = append(rolledBack, migration)
+	}
+
+	return rolledBack, nil
+}
+
+// Statuses lists every known or applied migration ordered by version
+func Statuses(db *gorm.DB) ([]Status, error) {
+	done, err := applied(db)
+	if err != nil {
+		return nil, err
+	}
+
+	var statuses []Status
+	for _, migration := range All() {
+		status := Status{Version: migration.Version, Name: migration.Name}
+		if row, ok := done[migration.Version]; ok {
+			appliedAt := row.AppliedAt
+			status.AppliedAt = &appliedAt
+		}
+		statuses = append(statuses, status)
+	}
+	for version, row := range done {
+		if _, ok := registry[version]; !ok {
+			appliedAt := row.AppliedAt
+			statuses = append(statuses, Status{Version: version, Name: row.Name, AppliedAt: &appliedAt, Missing: true})
+		}
+	}
+
+	sort.Slice(statuses, func(i, j int) bool {
+		return statuses[i].Version < statuses[j].Version
+	})
+	return statuses, nil
+}
+
+// Pending counts the registered migrations that have not been applied
+func Pending(db *gorm.DB) (int, error) {
+	statuses, err := Statuses(db)
+	if err != nil {
+		return 0, err
+	}
+
+	pending := 0
+	for _, status := range statuses {
+		if status.AppliedAt == nil {
+			pending++
+		}
+	}
+	return pending, nil
+}
+
+// migrationFilePattern matches migration source files such as 000002_add_tags.go
+var migrationFilePattern = regexp.MustCompile(`^(\d+)_[a-z0-9_]+\.go$`)
+
+// migrationNamePattern restricts migration names to snake case
+var migrationNamePattern = regexp.MustCompile(`^[a-z0-9_]+$`)
+
+// Create writes a new empty migration file into dir and returns its path
+// The version is one higher than the highest version found in dir
+func Create(dir string, name string) (string, error) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	name = strings.NewReplacer(" ", "_", "-", "_").Replace(name)
+	if !migrationNamePattern.MatchString(name) {
+		return "", fmt.Errorf("invalid migration name %q: use letters, digits and underscores", name)
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return "", err
+	}
+
+	var version int64
+	for _, entry := range entries {
+		match := migrationFilePattern.FindStringSubmatch(entry.Name())
+		if match == nil {
+			continue
+		}
+		existing, err := strconv.ParseInt(match[1], 10, 64)
+		if err == nil && existing > version {
+			version = existing
+		}
+	}
+	version++
+
+	path := filepath.Join(dir, fmt.Sprintf("%06d_%s.go", version, name))
+	content := fmt.Sprintf(migrationTemplate, name, version)
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		return "", err
+	}
+	return path, nil
+}
+
+// migrationTemplate is the skeleton written by Create
+const migrationTemplate = `package migrations
+
+import "gorm.io/gorm"
+
+// Migration %[2]d: %[1]s
+func init() {
+	Register(Migration{
+		Version: %[2]d,
+		Name:    %[1]q,
+		Up: func(tx *gorm.DB) error {
+			return nil
+		},
+		Down: func(tx *gorm.DB) error {
+			return nil
+		},
+	})
+}
+`
@@ -0,0 +1,45 @@
+package models
+
+import "time"
+
+// Audit log actions
+const (
+	AuditActionCreate  = "create"
+	AuditActionUpdate  = "update"
+	AuditActionDelete  = "delete"
+	AuditActionRestore = "restore"
+	AuditActionPurge   = "purge"
+)
+
+// AuditLog records a single change to an audited record
+// Entries are written by the repositories and are never updated or deleted.
+type AuditLog struct {
+	// Primary key with auto increment
+	ID uint `gorm:"primaryKey;autoIncrement" json:"id"`
+
+	// User who made the change - nil for anonymous requests and the command line
+	ActorID *uint `gorm:"index" json:"actor_id"`
+
+	// Type of the changed record, as used in the API (buildings, component-categories, ...)
+	EntityType string `gorm:"type:varchar(50);not null;index:idx_audit_logs_entity" json:"entity_type"`
+
+	// ID of the changed record
+	EntityID uint `gorm:"not null;index:idx_audit_logs_entity" json:"entity_id"`
+
+	// One of the AuditAction constants
+	Action string `gorm:"type:varchar(20);not null" json:"action"`
+
+	// JSON object mapping every changed column to its before and after value
+	Changes string `gorm:"type:text" json:"changes"`
+
+	// Client address and request ID of the HTTP request that made the change
+	IP        string `gorm:"type:varchar(45)" json:"ip"`
+	RequestID string `gorm:"type:varchar(64)" json:"request_id"`
+
+	CreatedAt time.Time `gorm:"index" json:"created_at"`
+}
+
+// TableName specifies the table name for the AuditLog model
+func (AuditLog) TableName() string {
+	return "audit_logs"
+}
@@ -7,8 +7,11 @@ type Building struct {
 	// Primary key with auto increment
 	ID uint `gorm:"primaryKey;autoIncrement" json:"id"`
 
-	// Building code - unique identifier
-	Code string `gorm:"type:varchar(100);uniqueIndex;not null" json:"code" binding:"required"`
+	// Optimistic locking version, incremented by every update
+	Versioned
+
+	// Building code - unique among buildings that are not deleted
+	Code string `gorm:"type:varchar(100);uniqueIndex:idx_buildings_code_live,where:deleted_at IS NULL;not null" json:"code" binding:"required"`
 
 	// Building name
 	Name string `gorm:"type:varchar(255);not null" json:"name" binding:"required"`
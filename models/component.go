@@ -7,14 +7,17 @@ type Component struct {
 	// Primary key with auto increment
 	ID uint `gorm:"primaryKey;autoIncrement" json:"id"`
 
+	// Optimistic locking version, incremented by every update
+	Versioned
+
 	// Foreign key to Room
 	RoomID *uint `gorm:"nullable;index" json:"room_id" binding:"omitempty"`
 
 	// Foreign key to ComponentCategory
 	CategoryID uint `gorm:"not null;index" json:"category_id" binding:"required"`
 
-	// Component code - unique identifier
-	Code string `gorm:"type:varchar(100);uniqueIndex;not null" json:"code" binding:"required"`
+	// Component code - unique among components that are not deleted
+	Code string `gorm:"type:varchar(100);uniqueIndex:idx_components_code_live,where:deleted_at IS NULL;not null" json:"code" binding:"required"`
 
 	// Component name
 	Name string `gorm:"type:varchar(255);not null" json:"name" binding:"required"`
@@ -7,8 +7,11 @@ type ComponentCategory struct {
 	// Primary key with auto increment
 	ID uint `gorm:"primaryKey;autoIncrement" json:"id"`
 
-	// Category code - unique identifier
-	Code string `gorm:"type:varchar(100);uniqueIndex;not null" json:"code" binding:"required"`
+	// Optimistic locking version, incremented by every update
+	Versioned
+
+	// Category code - unique among categories that are not deleted
+	Code string `gorm:"type:varchar(100);uniqueIndex:idx_component_categories_code_live,where:deleted_at IS NULL;not null" json:"code" binding:"required"`
 
 	// Category name
 	Name string `gorm:"type:varchar(255);not null" json:"name" binding:"required"`
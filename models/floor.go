@@ -7,6 +7,9 @@ type Floor struct {
 	// Primary key with auto increment
 	ID uint `gorm:"primaryKey;autoIncrement" json:"id"`
 
+	// Optimistic locking version, incremented by every update
+	Versioned
+
 	// Foreign key to Building
 	BuildingID uint `gorm:"not null;index" json:"building_id" binding:"required"`
 
@@ -0,0 +1,34 @@
+package models
+
+import "time"
+
+// IdempotencyKey stores the response to a POST request sent with an Idempotency-Key header
+// A retry with the same key gets the stored response instead of running the request again.
+type IdempotencyKey struct {
+	// Primary key with auto increment
+	ID uint `gorm:"primaryKey;autoIncrement" json:"id"`
+
+	// Caller that sent the key - 0 for anonymous requests, whose keys are hashed with the request
+	UserID uint `gorm:"not null;default:0;uniqueIndex:idx_idempotency_keys_user_key" json:"user_id"`
+
+	// Key sent by the client, unique per caller
+	Key string `gorm:"type:varchar(255);not null;uniqueIndex:idx_idempotency_keys_user_key" json:"key"`
+
+	// SHA-256 of the method, path and body, to recognise a key reused for another request
+	RequestHash string `gorm:"type:varchar(64);not null" json:"request_hash"`
+
+	// Stored response - StatusCode is 0 while the first request is still running
+	StatusCode  int    `gorm:"not null;default:0" json:"status_code"`
+	ContentType string `gorm:"type:varchar(100)" json:"content_type"`
+	Body        string `gorm:"type:text" json:"body"`
+
+	CreatedAt time.Time `json:"created_at"`
+
+	// The key may be reused for a new request after this time
+	ExpiresAt time.Time `gorm:"not null;index" json:"expires_at"`
+}
+
+// TableName specifies the table name for the IdempotencyKey model
+func (IdempotencyKey) TableName() string {
+	return "idempotency_keys"
+}
@@ -2,6 +2,8 @@ package models
 
 import (
 	"time"
+
+	"gorm.io/gorm"
 )
 
 // ReportStatus represents the status of a report
@@ -13,11 +15,17 @@ const (
 	ReportStatusCompleted  ReportStatus = "COMPLETED"
 )
 
+// OpenReportStatuses lists the statuses of reports that still need work
+var OpenReportStatuses = []ReportStatus{ReportStatusPending, ReportStatusInProgress}
+
 // Report represents the Report entity in the database
 type Report struct {
 	// Primary key with auto increment
 	ID uint `gorm:"primaryKey;autoIncrement" json:"id"`
 
+	// Optimistic locking version, incremented by every update
+	Versioned
+
 	// Report name
 	Name string `gorm:"type:text;not null" json:"name" binding:"required"`
 
@@ -33,14 +41,24 @@ type Report struct {
 	// Report status
 	Status ReportStatus `gorm:"type:varchar(20);not null;default:'PENDING'" json:"status" binding:"required,oneof=PENDING IN_PROGRESS COMPLETED"`
 
+	// Cost of repairing the component for this report (optional)
+	RepairCost float64 `gorm:"type:decimal(12,2);not null;default:0" json:"repair_cost"`
+
 	// Timestamps for tracking report creation and updates
 	CreatedAt time.Time `json:"created_at"`
 	UpdatedAt time.Time `json:"updated_at"`
 
+	// Set when the report transitions to COMPLETED, cleared when it is reopened
+	CompletedAt *time.Time `gorm:"index" json:"completed_at,omitempty"`
+
+	// Soft delete; deleted reports wait in the trash until they are restored or purged
+	DeletedAt gorm.DeletedAt `gorm:"index" json:"deleted_at,omitempty"`
+
 	// Relationships
 	Room      Room      `gorm:"foreignKey:RoomID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE" json:"room,omitempty"`
 	User      *User     `gorm:"foreignKey:UserID" json:"user,omitempty"`
 	Component Component `gorm:"foreignKey:ComponentID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE" json:"component,omitempty"`
+	Tags      []Tag     `gorm:"many2many:report_tags;constraint:OnUpdate:CASCADE,OnDelete:CASCADE" json:"tags,omitempty"`
 }
 
 // TableName specifies the table name for the Report model
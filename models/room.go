@@ -7,11 +7,14 @@ type Room struct {
 	// Primary key with auto increment
 	ID uint `gorm:"primaryKey;autoIncrement" json:"id"`
 
+	// Optimistic locking version, incremented by every update
+	Versioned
+
 	// Foreign key to Floor
 	FloorID uint `gorm:"not null;index" json:"floor_id" binding:"required"`
 
-	// Room code - unique identifier
-	Code string `gorm:"type:varchar(100);uniqueIndex;not null" json:"code" binding:"required"`
+	// Room code - unique among rooms that are not deleted
+	Code string `gorm:"type:varchar(100);uniqueIndex:idx_rooms_code_live,where:deleted_at IS NULL;not null" json:"code" binding:"required"`
 
 	// Room name/description
 	Name string `gorm:"type:varchar(255);not null" json:"name" binding:"required"`
@@ -0,0 +1,30 @@
+package models
+
+import "time"
+
+// Tag represents a free-form label used to group reports (e.g. "exam-week", "electrical")
+type Tag struct {
+	// Primary key with auto increment
+	ID uint `gorm:"primaryKey;autoIncrement" json:"id"`
+
+	// Optimistic locking version, incremented by every update
+	Versioned
+
+	// Tag name - unique label
+	Name string `gorm:"type:varchar(100);uniqueIndex;not null" json:"name" binding:"required"`
+
+	// Display color as a hex code (e.g. #FF8800)
+	Color string `gorm:"type:varchar(7);not null;default:'#808080'" json:"color"`
+
+	// Relationship: Tag is attached to many Reports
+	Reports []Report `gorm:"many2many:report_tags;constraint:OnUpdate:CASCADE,OnDelete:CASCADE" json:"reports,omitempty"`
+
+	// Timestamps
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// TableName specifies the table name for Tag model
+func (Tag) TableName() string {
+	return "tags"
+}
@@ -6,16 +6,30 @@ import (
 	"gorm.io/gorm"
 )
 
+// User roles
+const (
+	// RoleTechnician is the default role; technicians work on reports
+	RoleTechnician = "technician"
+	// RoleAdmin may also purge deleted records and change roles
+	RoleAdmin = "admin"
+)
+
 // User represents the User entity in the database
 type User struct {
 	// Primary key with auto increment
 	ID uint `gorm:"primaryKey;autoIncrement" json:"id"`
 
+	// Optimistic locking version, incremented by every update
+	Versioned
+
 	// User's full name
 	Name string `gorm:"type:varchar(255);not null" json:"name" binding:"required"`
 
-	// User's email - unique constraint
-	Email string `gorm:"type:varchar(255);uniqueIndex;not null" json:"email" binding:"required,email"`
+	// User's email - unique among users that are not deleted
+	Email string `gorm:"type:varchar(255);uniqueIndex:idx_users_email_live,where:deleted_at IS NULL;not null" json:"email" binding:"required,email"`
+
+	// User's role - RoleTechnician or RoleAdmin
+	Role string `gorm:"type:varchar(20);not null;default:'technician'" json:"role"`
 
 	// Timestamps for tracking user creation and updates
 	CreatedAt time.Time      `json:"created_at"`
@@ -0,0 +1,18 @@
+package models
+
+// Versioned gives a model an optimistic locking version
+// The version starts at 1 and every update increments it; an update based on an older read
+// is rejected instead of silently overwriting the newer data. The API exposes it as the ETag.
+type Versioned struct {
+	Version uint `gorm:"not null;default:1" json:"version"`
+}
+
+// CurrentVersion returns the version the record was read at
+func (v *Versioned) CurrentVersion() uint {
+	return v.Version
+}
+
+// SetVersion changes the version of the record in memory
+func (v *Versioned) SetVersion(version uint) {
+	v.Version = version
+}
@@ -0,0 +1,235 @@
+package openapi
+
+import (
+	"encoding/json"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// hexColorPattern is the hexcolor rule of the validator
+const hexColorPattern = `^#(?:[0-9a-fA-F]{3}|[0-9a-fA-F]{4}|[0-9a-fA-F]{6}|[0-9a-fA-F]{8})$`
+
+var (
+	timeType       = reflect.TypeOf(time.Time{})
+	rawMessageType = reflect.TypeOf(json.RawMessage{})
+)
+
+// schemas turns DTO structs into schemas, registering every named struct once under components
+type schemas struct {
+	components openapi3.Schemas
+}
+
+// ref returns the schema of a Go value's type
+// A patch type describes the state a JSON Merge Patch is applied to: nothing is required and
+// every member may be null to clear it.
+func (s *schemas) ref(t reflect.Type, patch bool) *openapi3.SchemaRef {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	switch {
+	case t == timeType:
+		return openapi3.NewSchemaRef("", openapi3.NewDateTimeSchema())
+	case t == rawMessageType:
+		return openapi3.NewSchemaRef("", &openapi3.Schema{})
+	}
+
+	switch t.Kind() {
+	case reflect.String:
+		return openapi3.NewSchemaRef("", openapi3.NewStringSchema())
+	case reflect.Bool:
+		return openapi3.NewSchemaRef("", openapi3.NewBoolSchema())
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32:
+		return openapi3.NewSchemaRef("", openapi3.NewIntegerSchema())
+	case reflect.Int64:
+		return openapi3.NewSchemaRef("", openapi3.NewInt64Schema())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return openapi3.NewSchemaRef("", openapi3.NewIntegerSchema().WithMin(0))
+	case reflect.Float32, reflect.Float64:
+		return openapi3.NewSchemaRef("", openapi3.NewFloat64Schema())
+	case reflect.Slice, reflect.Array:
+		array := openapi3.NewArraySchema()
+		array.Items = s.ref(t.Elem(), false)
+		return openapi3.NewSchemaRef("", array)
+	case reflect.Map:
+		object := openapi3.NewObjectSchema()
+		object.AdditionalProperties = openapi3.AdditionalProperties{Schema: s.ref(t.Elem(), false)}
+		return openapi3.NewSchemaRef("", object)
+	case reflect.Struct:
+		if t.Name() == "" {
+			return openapi3.NewSchemaRef("", s.object(t, patch))
+		}
+		if _, ok := s.components[t.Name()]; !ok {
+			// Register before descending, so a type nesting itself refers to its own component
+			s.components[t.Name()] = openapi3.NewSchemaRef("", &openapi3.Schema{})
+			*s.components[t.Name()].Value = *s.object(t, patch)
+		}
+		return openapi3.NewSchemaRef("#/components/schemas/"+t.Name(), s.components[t.Name()].Value)
+	default:
+		return openapi3.NewSchemaRef("", &openapi3.Schema{})
+	}
+}
+
+// object returns the schema of a struct from the json and binding tags of its fields
+// Embedded structs are flattened, as encoding/json does.
+func (s *schemas) object(t reflect.Type, patch bool) *openapi3.Schema {
+	object := openapi3.NewObjectSchema()
+	eachField(t, "json", func(field reflect.StructField, name string) {
+		property := s.property(field, patch)
+		object.WithPropertyRef(name, property)
+		if !patch && hasRule(field, "required") {
+			object.Required = append(object.Required, name)
+		}
+	})
+	return object
+}
+
+// property returns the schema of a struct field with the constraints of its binding rules
+func (s *schemas) property(field reflect.StructField, patch bool) *openapi3.SchemaRef {
+	property := s.ref(field.Type, false)
+	nullable := patch || field.Type.Kind() == reflect.Pointer
+
+	if property.Ref != "" {
+		if !nullable {
+			return property
+		}
+		// Siblings of a $ref are ignored, so a nullable reference wraps it
+		wrapped := &openapi3.Schema{AllOf: openapi3.SchemaRefs{property}, Nullable: true}
+		return openapi3.NewSchemaRef("", wrapped)
+	}
+
+	schema := *property.Value
+	schema.Nullable = nullable
+	constrain(&schema, field)
+	return openapi3.NewSchemaRef("", &schema)
+}
+
+// parameters returns the query parameters of a query struct from its form and binding tags
+func (s *schemas) parameters(t reflect.Type) openapi3.Parameters {
+	var parameters openapi3.Parameters
+	eachField(t, "form", func(field reflect.StructField, name string) {
+		schema := *s.ref(field.Type, false).Value
+		constrain(&schema, field)
+
+		parameter := openapi3.NewQueryParameter(name).WithSchema(&schema)
+		parameter.Required = hasRule(field, "required")
+		parameters = append(parameters, &openapi3.ParameterRef{Value: parameter})
+	})
+	return parameters
+}
+
+// constrain adds the binding rules of a field that a schema can express
+// Rules between fields, such as excluded_with, are left to the handlers.
+func constrain(schema *openapi3.Schema, field reflect.StructField) {
+	kind := field.Type.Kind()
+	if kind == reflect.Pointer {
+		kind = field.Type.Elem().Kind()
+	}
+
+	omitempty := hasRule(field, "omitempty")
+	for _, rule := range rules(field) {
+		name, param, _ := strings.Cut(rule, "=")
+		switch name {
+		case "min", "max":
+			bound, err := strconv.ParseFloat(param, 64)
+			if err != nil {
+				continue
+			}
+			if name == "min" && bound == 1 && omitempty && isInteger(kind) {
+				// Zero leaves an omitempty field unset, e.g. page_size=0 takes the default
+				bound = 0
+			}
+			setBound(schema, kind, name == "min", bound)
+		case "oneof":
+			for _, value := range strings.Fields(param) {
+				if kind == reflect.String {
+					schema.Enum = append(schema.Enum, value)
+				} else if number, err := strconv.ParseFloat(value, 64); err == nil {
+					schema.Enum = append(schema.Enum, number)
+				}
+			}
+		case "email":
+			schema.Format = "email"
+		case "hexcolor":
+			schema.Pattern = hexColorPattern
+		case "datetime":
+			if param == time.DateOnly {
+				schema.Format = "date"
+			} else {
+				schema.Description = "Formatted as " + param
+			}
+		}
+	}
+}
+
+// setBound sets a min or max rule, which bounds the length of strings and slices and the value of numbers
+func setBound(schema *openapi3.Schema, kind reflect.Kind, min bool, bound float64) {
+	switch kind {
+	case reflect.String:
+		if min {
+			schema.MinLength = uint64(bound)
+		} else {
+			schema.MaxLength = openapi3.Ptr(uint64(bound))
+		}
+	case reflect.Slice, reflect.Array:
+		if min {
+			schema.MinItems = uint64(bound)
+		} else {
+			schema.MaxItems = openapi3.Ptr(uint64(bound))
+		}
+	default:
+		if min {
+			schema.Min = openapi3.Ptr(bound)
+		} else {
+			schema.Max = openapi3.Ptr(bound)
+		}
+	}
+}
+
+// isInteger reports whether a kind is an integer
+func isInteger(kind reflect.Kind) bool {
+	return reflect.Int <= kind && kind <= reflect.Uint64
+}
+
+// eachField calls fn for every exported field of a struct named by tag, flattening embedded structs
+// Fields without the tag keep their Go name; a "-" tag skips the field.
+func eachField(t reflect.Type, tag string, fn func(field reflect.StructField, name string)) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name := strings.Split(field.Tag.Get(tag), ",")[0]
+		if field.Anonymous && name == "" && field.Type.Kind() == reflect.Struct {
+			eachField(field.Type, tag, fn)
+			continue
+		}
+		if !field.IsExported() || name == "-" {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		fn(field, name)
+	}
+}
+
+// rules returns the binding rules of a field
+func rules(field reflect.StructField) []string {
+	binding := field.Tag.Get("binding")
+	if binding == "" {
+		return nil
+	}
+	return strings.Split(binding, ",")
+}
+
+// hasRule reports whether a field has a binding rule
+func hasRule(field reflect.StructField, rule string) bool {
+	for _, r := range rules(field) {
+		if r == rule {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,323 @@
+// Package openapi generates the OpenAPI 3 specification of the REST API from the route table and
+// the DTO structs, and validates requests against it.
+package openapi
+
+import (
+	"incident-report/utils"
+	"net/http"
+	"reflect"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/routers"
+	"github.com/gin-gonic/gin"
+)
+
+// Prefix is the path prefix of the documented routes; other routes, such as /graphql, are left out
+const Prefix = "/api/"
+
+// Operation describes what a route takes and returns
+// The values of Query, Body, Patch and Response only give their type: the schemas are derived
+// from the json, form and binding tags of the DTO structs.
+type Operation struct {
+	Summary string
+	// Query lists the query structs the handler binds
+	Query []any
+	// Body is the JSON request body
+	Body any
+	// Patch is the state a JSON Merge Patch request body is applied to
+	Patch any
+	// Response is the data of a success response; nil when there is none
+	Response any
+	// List pages Response, one item of a paginated list
+	List bool
+	// Exports lists the content types the response may be exported as besides JSON
+	Exports []string
+	// Status is the status of a success response, 200 by default
+	Status int
+	// Bare responses are not wrapped in the success envelope
+	Bare bool
+}
+
+// Operations maps a route of the route table, "METHOD /path" with gin path parameters, to its operation
+type Operations map[string]Operation
+
+// Spec is the specification of the routes of a router
+// It is built once every route has been registered, see Build.
+type Spec struct {
+	operations Operations
+	document   *openapi3.T
+	routes     map[string]*routers.Route
+}
+
+// NewSpec returns an empty specification of the given operations
+func NewSpec(operations Operations) *Spec {
+	return &Spec{operations: operations}
+}
+
+// Build generates the specification of the routes that have an operation
+func (s *Spec) Build(routes gin.RoutesInfo) {
+	schemas := &schemas{components: openapi3.Schemas{}}
+	document := &openapi3.T{
+		OpenAPI: "3.0.3",
+		Info: &openapi3.Info{
+			Title:       "Incident Report API",
+			Description: "Generated from the route table and the request and response DTOs",
+			Version:     "1.0.0",
+		},
+		Paths: openapi3.NewPaths(),
+		Components: &openapi3.Components{
+			Schemas:    schemas.components,
+			Parameters: headerParameters(),
+		},
+	}
+	errorSchema := schemas.ref(reflect.TypeOf(utils.ResponseData{}), false)
+	s.routes = map[string]*routers.Route{}
+
+	tags := map[string]bool{}
+	for _, route := range routes {
+		op, ok := s.operations[route.Method+" "+route.Path]
+		if !ok || !strings.HasPrefix(route.Path, Prefix) {
+			continue
+		}
+
+		path, params := openAPIPath(route.Path)
+		item := document.Paths.Value(path)
+		if item == nil {
+			item = &openapi3.PathItem{}
+			document.Paths.Set(path, item)
+		}
+
+		operation := s.operation(document, schemas, route, op, params, errorSchema)
+		item.SetOperation(route.Method, operation)
+		tags[operation.Tags[0]] = true
+
+		s.routes[route.Method+" "+route.Path] = &routers.Route{
+			Spec:      document,
+			Path:      path,
+			PathItem:  item,
+			Method:    route.Method,
+			Operation: operation,
+		}
+	}
+
+	for _, tag := range sortedKeys(tags) {
+		document.Tags = append(document.Tags, &openapi3.Tag{Name: tag})
+	}
+	s.document = document
+}
+
+// Document returns the specification
+func (s *Spec) Document() *openapi3.T {
+	return s.document
+}
+
+// Route returns the specified route of a method and gin path
+func (s *Spec) Route(method string, path string) (*routers.Route, bool) {
+	route, ok := s.routes[method+" "+path]
+	return route, ok
+}
+
+// operation returns the operation of a route
+func (s *Spec) operation(document *openapi3.T, schemas *schemas, route gin.RouteInfo, op Operation, params []string, errorSchema *openapi3.SchemaRef) *openapi3.Operation {
+	operation := openapi3.NewOperation()
+	operation.Summary = op.Summary
+	operation.OperationID = operationID(op.Summary)
+	operation.Tags = []string{tag(route.Path)}
+
+	for _, name := range params {
+		parameter := openapi3.NewPathParameter(name).WithSchema(openapi3.NewIntegerSchema().WithMin(1))
+		operation.AddParameter(parameter)
+	}
+	for _, query := range op.Query {
+		operation.Parameters = append(operation.Parameters, schemas.parameters(reflect.TypeOf(query))...)
+	}
+	operation.Parameters = append(operation.Parameters, headerRefs(route.Method, params, document.Components.Parameters)...)
+
+	switch {
+	case op.Body != nil:
+		body := openapi3.NewRequestBody().WithRequired(true).
+			WithJSONSchemaRef(schemas.ref(reflect.TypeOf(op.Body), false))
+		operation.RequestBody = &openapi3.RequestBodyRef{Value: body}
+	case op.Patch != nil:
+		schema := schemas.ref(reflect.TypeOf(op.Patch), true)
+		body := openapi3.NewRequestBody().WithRequired(true).WithContent(openapi3.Content{
+			utils.MergePatchContentType: openapi3.NewMediaType().WithSchemaRef(schema),
+			"application/json":          openapi3.NewMediaType().WithSchemaRef(schema),
+		})
+		operation.RequestBody = &openapi3.RequestBodyRef{Value: body}
+	}
+
+	status := op.Status
+	if status == 0 {
+		status = http.StatusOK
+	}
+	response := openapi3.NewResponse().WithDescription(http.StatusText(status))
+	if status != http.StatusNoContent {
+		content := openapi3.NewContentWithJSONSchemaRef(responseSchema(schemas, op))
+		for _, contentType := range op.Exports {
+			content[contentType] = openapi3.NewMediaType().WithSchema(openapi3.NewStringSchema().WithFormat("binary"))
+		}
+		response.WithContent(content)
+	}
+	operation.Responses = openapi3.NewResponses(
+		openapi3.WithStatus(status, &openapi3.ResponseRef{Value: response}),
+		openapi3.WithName("default", openapi3.NewResponse().WithDescription("Error").WithJSONSchemaRef(errorSchema)),
+	)
+	return operation
+}
+
+// responseSchema returns the schema of a success response of an operation
+func responseSchema(schemas *schemas, op Operation) *openapi3.SchemaRef {
+	var data *openapi3.SchemaRef
+	if op.Response != nil {
+		data = schemas.ref(reflect.TypeOf(op.Response), false)
+	}
+	if op.Bare {
+		return data
+	}
+
+	if op.List {
+		page := openapi3.NewObjectSchema().
+			WithPropertyRef("data", openapi3.NewSchemaRef("", &openapi3.Schema{Type: &openapi3.Types{openapi3.TypeArray}, Items: data})).
+			WithProperty("page", openapi3.NewIntegerSchema()).
+			WithProperty("page_size", openapi3.NewIntegerSchema()).
+			WithProperty("total", openapi3.NewInt64Schema()).
+			WithProperty("total_page", openapi3.NewIntegerSchema()).
+			WithProperty("next_cursor", openapi3.NewStringSchema()).
+			WithProperty("prev_cursor", openapi3.NewStringSchema())
+		data = openapi3.NewSchemaRef("", page)
+	}
+
+	envelope := openapi3.NewObjectSchema().
+		WithProperty("success", openapi3.NewBoolSchema()).
+		WithProperty("message", openapi3.NewStringSchema())
+	if data != nil {
+		envelope.WithPropertyRef("data", data)
+	}
+	return openapi3.NewSchemaRef("", envelope)
+}
+
+// Drift lists the differences between the routes under Prefix and the operations
+// A route without an operation is missing from the specification, and an operation without a
+// route documents an endpoint that does not exist.
+func Drift(routes gin.RoutesInfo, operations Operations) []string {
+	var drift []string
+	routed := map[string]bool{}
+	for _, route := range routes {
+		if !strings.HasPrefix(route.Path, Prefix) {
+			continue
+		}
+		key := route.Method + " " + route.Path
+		routed[key] = true
+		if _, ok := operations[key]; !ok {
+			drift = append(drift, key+" is routed but has no operation")
+		}
+	}
+	for key := range operations {
+		if !routed[key] {
+			drift = append(drift, key+" has an operation but no route")
+		}
+	}
+	sort.Strings(drift)
+	return drift
+}
+
+// headerParameters returns the request headers shared by the operations
+// They are strings here: the middleware reading them reports their own errors.
+func headerParameters() openapi3.ParametersMap {
+	header := func(name string, description string) *openapi3.ParameterRef {
+		parameter := openapi3.NewHeaderParameter(name).WithSchema(openapi3.NewStringSchema())
+		parameter.Description = description
+		return &openapi3.ParameterRef{Value: parameter}
+	}
+	fields := openapi3.NewQueryParameter("fields").WithSchema(openapi3.NewStringSchema())
+	fields.Description = "Comma separated sparse fieldset of the response data"
+
+	return openapi3.ParametersMap{
+		"UserID":         header("X-User-ID", "ID of the calling user"),
+		"IfMatch":        header("If-Match", "ETag of the version being changed"),
+		"IdempotencyKey": header("Idempotency-Key", "Key under which the response is replayed to a retried request"),
+		"Fields":         {Value: fields},
+	}
+}
+
+// headerRefs returns references to the shared parameters that apply to a method
+// A POST to one record, such as attaching tags to a report, is also conditional on If-Match.
+func headerRefs(method string, params []string, shared openapi3.ParametersMap) openapi3.Parameters {
+	names := []string{"UserID"}
+	switch method {
+	case http.MethodGet:
+		names = append(names, "Fields")
+	case http.MethodPost:
+		names = append(names, "IdempotencyKey")
+		if slices.Contains(params, "id") {
+			names = append(names, "IfMatch")
+		}
+	case http.MethodPut, http.MethodPatch, http.MethodDelete:
+		names = append(names, "IfMatch")
+	}
+
+	refs := make(openapi3.Parameters, len(names))
+	for i, name := range names {
+		refs[i] = &openapi3.ParameterRef{Ref: "#/components/parameters/" + name, Value: shared[name].Value}
+	}
+	return refs
+}
+
+// openAPIPath converts a gin path to an OpenAPI path and returns the names of its parameters
+func openAPIPath(path string) (string, []string) {
+	segments := strings.Split(path, "/")
+	var params []string
+	for i, segment := range segments {
+		if strings.HasPrefix(segment, ":") {
+			params = append(params, segment[1:])
+			segments[i] = "{" + segment[1:] + "}"
+		}
+	}
+	return strings.Join(segments, "/"), params
+}
+
+// tag groups an operation by the first segment after the version, e.g. "Component Categories"
+func tag(path string) string {
+	segments := strings.Split(strings.TrimPrefix(path, Prefix), "/")
+	resource := "/"
+	if len(segments) > 1 {
+		resource = segments[1]
+	}
+	words := strings.Split(resource, "-")
+	for i, word := range words {
+		words[i] = strings.ToUpper(word[:1]) + word[1:]
+	}
+	return strings.Join(words, " ")
+}
+
+// operationID derives a camel case operation ID from a summary, e.g. "Get a building" → getBuilding
+func operationID(summary string) string {
+	var id strings.Builder
+	for _, word := range strings.FieldsFunc(summary, func(r rune) bool {
+		return !('a' <= r && r <= 'z' || 'A' <= r && r <= 'Z' || '0' <= r && r <= '9')
+	}) {
+		switch strings.ToLower(word) {
+		case "a", "an", "the":
+			continue
+		}
+		if id.Len() == 0 {
+			id.WriteString(strings.ToLower(word[:1]) + word[1:])
+		} else {
+			id.WriteString(strings.ToUpper(word[:1]) + word[1:])
+		}
+	}
+	return id.String()
+}
+
+func sortedKeys(set map[string]bool) []string {
+	keys := make([]string, 0, len(set))
+	for key := range set {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,147 @@
+package openapi
+
+import (
+	"errors"
+	"incident-report/utils"
+	"mime"
+	"net/http"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/openapi3filter"
+	"github.com/gin-gonic/gin"
+)
+
+func init() {
+	// Merge patches are JSON documents
+	openapi3filter.RegisterBodyDecoder(utils.MergePatchContentType, openapi3filter.JSONBodyDecoder)
+}
+
+// ValidateRequest checks a request routed to a gin path against its operation
+// It returns a validation error listing every rejected parameter and body member, and nil for
+// routes the specification leaves out. A body of a content type the operation does not take is
+// left for the handler to refuse. The body can still be read afterwards.
+func (s *Spec) ValidateRequest(r *http.Request, path string, params gin.Params) error {
+	route, ok := s.Route(r.Method, path)
+	if !ok {
+		return nil
+	}
+
+	pathParams := make(map[string]string, len(params))
+	for _, param := range params {
+		pathParams[param.Key] = param.Value
+	}
+
+	options := &openapi3filter.Options{
+		MultiError:         true,
+		AuthenticationFunc: openapi3filter.NoopAuthenticationFunc,
+	}
+	if body := route.Operation.RequestBody; body != nil && r.ContentLength != 0 {
+		contentType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+		options.ExcludeRequestBody = body.Value.Content.Get(contentType) == nil
+	}
+
+	err := openapi3filter.ValidateRequest(r.Context(), &openapi3filter.RequestValidationInput{
+		Request:    r,
+		PathParams: pathParams,
+		Route:      route,
+		Options:    options,
+	})
+	if err == nil {
+		return nil
+	}
+
+	fields := fieldErrors(err)
+	if len(fields) == 0 {
+		return nil
+	}
+	messages := make([]string, len(fields))
+	for i, field := range fields {
+		messages[i] = field.Field + " " + field.Message
+	}
+	return utils.Validation(strings.Join(messages, "; "), fields...)
+}
+
+// fieldErrors converts the errors of a request validation into per-field details
+// A request error wraps the schema errors of its parameter or body, so only the outer list of
+// errors is taken apart before matching it.
+func fieldErrors(err error) []utils.FieldError {
+	if multi, ok := err.(openapi3.MultiError); ok {
+		var fields []utils.FieldError
+		for _, err := range multi {
+			fields = append(fields, fieldErrors(err)...)
+		}
+		return fields
+	}
+
+	var requestErr *openapi3filter.RequestError
+	if !errors.As(err, &requestErr) {
+		return []utils.FieldError{{Field: "request", Message: err.Error()}}
+	}
+
+	field := "body"
+	if requestErr.Parameter != nil {
+		field = requestErr.Parameter.Name
+	}
+
+	// A body that is not JSON at all is left to the handler, which answers 400 as without validation
+	var parseErr *openapi3filter.ParseError
+	if requestErr.RequestBody != nil && errors.As(requestErr.Err, &parseErr) {
+		return nil
+	}
+
+	var multiSchema openapi3.MultiError
+	if errors.As(requestErr.Err, &multiSchema) {
+		var fields []utils.FieldError
+		for _, err := range multiSchema {
+			fields = append(fields, schemaFieldError(field, err))
+		}
+		return fields
+	}
+	if requestErr.Err != nil {
+		return []utils.FieldError{schemaFieldError(field, requestErr.Err)}
+	}
+	return []utils.FieldError{{Field: field, Message: requestErr.Reason}}
+}
+
+// schemaFieldError names the member of a parameter or body that failed a schema
+func schemaFieldError(field string, err error) utils.FieldError {
+	var schemaErr *openapi3.SchemaError
+	if !errors.As(err, &schemaErr) {
+		var parseErr *openapi3filter.ParseError
+		if errors.As(err, &parseErr) {
+			return utils.FieldError{Field: field, Rule: "type", Message: err.Error()}
+		}
+		return utils.FieldError{Field: field, Message: err.Error()}
+	}
+
+	if pointer := schemaErr.JSONPointer(); len(pointer) > 0 && field == "body" {
+		field = strings.Join(pointer, ".")
+	}
+	return utils.FieldError{Field: field, Rule: bindingRule(schemaErr), Message: schemaErr.Reason}
+}
+
+// bindingRule names the binding rule a schema keyword was derived from, so that the details
+// match those of the handler's own validation
+func bindingRule(err *openapi3.SchemaError) string {
+	switch err.SchemaField {
+	case "enum":
+		return "oneof"
+	case "minLength", "minimum", "minItems":
+		return "min"
+	case "maxLength", "maximum", "maxItems":
+		return "max"
+	case "pattern":
+		if err.Schema != nil && err.Schema.Pattern == hexColorPattern {
+			return "hexcolor"
+		}
+	case "format":
+		if err.Schema != nil && err.Schema.Format == "date" {
+			return "datetime"
+		}
+		if err.Schema != nil {
+			return err.Schema.Format
+		}
+	}
+	return err.SchemaField
+}
@@ -0,0 +1,492 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        (unknown)
+// source: incident/v1/common.proto
+
+package incidentv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// BulkMode selects whether a bulk request changes every record or none, or keeps what succeeded
+type BulkMode int32
+
+const (
+	// Unspecified is atomic
+	BulkMode_BULK_MODE_UNSPECIFIED BulkMode = 0
+	BulkMode_BULK_MODE_ATOMIC      BulkMode = 1
+	BulkMode_BULK_MODE_BEST_EFFORT BulkMode = 2
+)
+
+// Enum value maps for BulkMode.
+var (
+	BulkMode_name = map[int32]string{
+		0: "BULK_MODE_UNSPECIFIED",
+		1: "BULK_MODE_ATOMIC",
+		2: "BULK_MODE_BEST_EFFORT",
+	}
+	BulkMode_value = map[string]int32{
+		"BULK_MODE_UNSPECIFIED": 0,
+		"BULK_MODE_ATOMIC":      1,
+		"BULK_MODE_BEST_EFFORT": 2,
+	}
+)
+
+func (x BulkMode) Enum() *BulkMode {
+	p := new(BulkMode)
+	*p = x
+	return p
+}
+
+func (x BulkMode) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (BulkMode) Descriptor() protoreflect.EnumDescriptor {
+	return file_incident_v1_common_proto_enumTypes[0].Descriptor()
+}
+
+func (BulkMode) Type() protoreflect.EnumType {
+	return &file_incident_v1_common_proto_enumTypes[0]
+}
+
+func (x BulkMode) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use BulkMode.Descriptor instead.
+func (BulkMode) EnumDescriptor() ([]byte, []int) {
+	return file_incident_v1_common_proto_rawDescGZIP(), []int{0}
+}
+
+// PageRequest selects a page of a list, either by page number or by the cursor of an earlier page.
+// It takes the same values as the page, page_size, after, before and total query parameters of
+// the REST lists.
+type PageRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Page number, from 1; not allowed with a cursor
+	Page int32 `protobuf:"varint,1,opt,name=page,proto3" json:"page,omitempty"`
+	// Records per page, 10 by default and at most 100
+	PageSize int32 `protobuf:"varint,2,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	// Cursor of the page to continue after
+	After string `protobuf:"bytes,3,opt,name=after,proto3" json:"after,omitempty"`
+	// Cursor of the page to continue before
+	Before string `protobuf:"bytes,4,opt,name=before,proto3" json:"before,omitempty"`
+	// Whether to count the matching records; by default page numbers are counted and cursors are not
+	Total         *bool `protobuf:"varint,5,opt,name=total,proto3,oneof" json:"total,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PageRequest) Reset() {
+	*x = PageRequest{}
+	mi := &file_incident_v1_common_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PageRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PageRequest) ProtoMessage() {}
+
+func (x *PageRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_incident_v1_common_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PageRequest.ProtoReflect.Descriptor instead.
+func (*PageRequest) Descriptor() ([]byte, []int) {
+	return file_incident_v1_common_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *PageRequest) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *PageRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *PageRequest) GetAfter() string {
+	if x != nil {
+		return x.After
+	}
+	return ""
+}
+
+func (x *PageRequest) GetBefore() string {
+	if x != nil {
+		return x.Before
+	}
+	return ""
+}
+
+func (x *PageRequest) GetTotal() bool {
+	if x != nil && x.Total != nil {
+		return *x.Total
+	}
+	return false
+}
+
+// PageInfo describes the page a list returned
+type PageInfo struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Page number, 0 for a page selected by cursor
+	Page     int32 `protobuf:"varint,1,opt,name=page,proto3" json:"page,omitempty"`
+	PageSize int32 `protobuf:"varint,2,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	// Number of matching records, unset when they were not counted
+	Total      *int64 `protobuf:"varint,3,opt,name=total,proto3,oneof" json:"total,omitempty"`
+	TotalPages int32  `protobuf:"varint,4,opt,name=total_pages,json=totalPages,proto3" json:"total_pages,omitempty"`
+	// Cursor of the next page, empty on the last page
+	NextCursor string `protobuf:"bytes,5,opt,name=next_cursor,json=nextCursor,proto3" json:"next_cursor,omitempty"`
+	// Cursor of the previous page, empty on the first page
+	PrevCursor    string `protobuf:"bytes,6,opt,name=prev_cursor,json=prevCursor,proto3" json:"prev_cursor,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PageInfo) Reset() {
+	*x = PageInfo{}
+	mi := &file_incident_v1_common_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PageInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PageInfo) ProtoMessage() {}
+
+func (x *PageInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_incident_v1_common_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PageInfo.ProtoReflect.Descriptor instead.
+func (*PageInfo) Descriptor() ([]byte, []int) {
+	return file_incident_v1_common_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *PageInfo) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *PageInfo) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *PageInfo) GetTotal() int64 {
+	if x != nil && x.Total != nil {
+		return *x.Total
+	}
+	return 0
+}
+
+func (x *PageInfo) GetTotalPages() int32 {
+	if x != nil {
+		return x.TotalPages
+	}
+	return 0
+}
+
+func (x *PageInfo) GetNextCursor() string {
+	if x != nil {
+		return x.NextCursor
+	}
+	return ""
+}
+
+func (x *PageInfo) GetPrevCursor() string {
+	if x != nil {
+		return x.PrevCursor
+	}
+	return ""
+}
+
+// BulkItemResult reports the outcome of a bulk request for a single record
+type BulkItemResult struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    uint32                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	// ok, failed or rolled_back
+	Status string `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	// Error code of a failed record, as in the REST error responses
+	Code          string `protobuf:"bytes,3,opt,name=code,proto3" json:"code,omitempty"`
+	Error         string `protobuf:"bytes,4,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BulkItemResult) Reset() {
+	*x = BulkItemResult{}
+	mi := &file_incident_v1_common_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BulkItemResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BulkItemResult) ProtoMessage() {}
+
+func (x *BulkItemResult) ProtoReflect() protoreflect.Message {
+	mi := &file_incident_v1_common_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BulkItemResult.ProtoReflect.Descriptor instead.
+func (*BulkItemResult) Descriptor() ([]byte, []int) {
+	return file_incident_v1_common_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *BulkItemResult) GetId() uint32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *BulkItemResult) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *BulkItemResult) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *BulkItemResult) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+// BulkResponse reports the outcome of a bulk request
+type BulkResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Mode  BulkMode               `protobuf:"varint,1,opt,name=mode,proto3,enum=incident.v1.BulkMode" json:"mode,omitempty"`
+	// False when an atomic request was rolled back
+	Committed     bool              `protobuf:"varint,2,opt,name=committed,proto3" json:"committed,omitempty"`
+	Succeeded     int32             `protobuf:"varint,3,opt,name=succeeded,proto3" json:"succeeded,omitempty"`
+	Failed        int32             `protobuf:"varint,4,opt,name=failed,proto3" json:"failed,omitempty"`
+	Results       []*BulkItemResult `protobuf:"bytes,5,rep,name=results,proto3" json:"results,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BulkResponse) Reset() {
+	*x = BulkResponse{}
+	mi := &file_incident_v1_common_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BulkResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BulkResponse) ProtoMessage() {}
+
+func (x *BulkResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_incident_v1_common_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BulkResponse.ProtoReflect.Descriptor instead.
+func (*BulkResponse) Descriptor() ([]byte, []int) {
+	return file_incident_v1_common_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *BulkResponse) GetMode() BulkMode {
+	if x != nil {
+		return x.Mode
+	}
+	return BulkMode_BULK_MODE_UNSPECIFIED
+}
+
+func (x *BulkResponse) GetCommitted() bool {
+	if x != nil {
+		return x.Committed
+	}
+	return false
+}
+
+func (x *BulkResponse) GetSucceeded() int32 {
+	if x != nil {
+		return x.Succeeded
+	}
+	return 0
+}
+
+func (x *BulkResponse) GetFailed() int32 {
+	if x != nil {
+		return x.Failed
+	}
+	return 0
+}
+
+func (x *BulkResponse) GetResults() []*BulkItemResult {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+var File_incident_v1_common_proto protoreflect.FileDescriptor
+
+const file_incident_v1_common_proto_rawDesc = "" +
+	"\n" +
+	"\x18incident/v1/common.proto\x12\vincident.v1\"\x91\x01\n" +
+	"\vPageRequest\x12\x12\n" +
+	"\x04page\x18\x01 \x01(\x05R\x04page\x12\x1b\n" +
+	"\tpage_size\x18\x02 \x01(\x05R\bpageSize\x12\x14\n" +
+	"\x05after\x18\x03 \x01(\tR\x05after\x12\x16\n" +
+	"\x06before\x18\x04 \x01(\tR\x06before\x12\x19\n" +
+	"\x05total\x18\x05 \x01(\bH\x00R\x05total\x88\x01\x01B\b\n" +
+	"\x06_total\"\xc3\x01\n" +
+	"\bPageInfo\x12\x12\n" +
+	"\x04page\x18\x01 \x01(\x05R\x04page\x12\x1b\n" +
+	"\tpage_size\x18\x02 \x01(\x05R\bpageSize\x12\x19\n" +
+	"\x05total\x18\x03 \x01(\x03H\x00R\x05total\x88\x01\x01\x12\x1f\n" +
+	"\vtotal_pages\x18\x04 \x01(\x05R\n" +
+	"totalPages\x12\x1f\n" +
+	"\vnext_cursor\x18\x05 \x01(\tR\n" +
+	"nextCursor\x12\x1f\n" +
+	"\vprev_cursor\x18\x06 \x01(\tR\n" +
+	"prevCursorB\b\n" +
+	"\x06_total\"b\n" +
+	"\x0eBulkItemResult\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\rR\x02id\x12\x16\n" +
+	"\x06status\x18\x02 \x01(\tR\x06status\x12\x12\n" +
+	"\x04code\x18\x03 \x01(\tR\x04code\x12\x14\n" +
+	"\x05error\x18\x04 \x01(\tR\x05error\"\xc4\x01\n" +
+	"\fBulkResponse\x12)\n" +
+	"\x04mode\x18\x01 \x01(\x0e2\x15.incident.v1.BulkModeR\x04mode\x12\x1c\n" +
+	"\tcommitted\x18\x02 \x01(\bR\tcommitted\x12\x1c\n" +
+	"\tsucceeded\x18\x03 \x01(\x05R\tsucceeded\x12\x16\n" +
+	"\x06failed\x18\x04 \x01(\x05R\x06failed\x125\n" +
+	"\aresults\x18\x05 \x03(\v2\x1b.incident.v1.BulkItemResultR\aresults*V\n" +
+	"\bBulkMode\x12\x19\n" +
+	"\x15BULK_MODE_UNSPECIFIED\x10\x00\x12\x14\n" +
+	"\x10BULK_MODE_ATOMIC\x10\x01\x12\x19\n" +
+	"\x15BULK_MODE_BEST_EFFORT\x10\x02B.Z,incident-report/proto/incident/v1;incidentv1b\x06proto3"
+
+var (
+	file_incident_v1_common_proto_rawDescOnce sync.Once
+	file_incident_v1_common_proto_rawDescData []byte
+)
+
+func file_incident_v1_common_proto_rawDescGZIP() []byte {
+	file_incident_v1_common_proto_rawDescOnce.Do(func() {
+		file_incident_v1_common_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_incident_v1_common_proto_rawDesc), len(file_incident_v1_common_proto_rawDesc)))
+	})
+	return file_incident_v1_common_proto_rawDescData
+}
+
+var file_incident_v1_common_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_incident_v1_common_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
+var file_incident_v1_common_proto_goTypes = []any{
+	(BulkMode)(0),          // 0: incident.v1.BulkMode
+	(*PageRequest)(nil),    // 1: incident.v1.PageRequest
+	(*PageInfo)(nil),       // 2: incident.v1.PageInfo
+	(*BulkItemResult)(nil), // 3: incident.v1.BulkItemResult
+	(*BulkResponse)(nil),   // 4: incident.v1.BulkResponse
+}
+var file_incident_v1_common_proto_depIdxs = []int32{
+	0, // 0: incident.v1.BulkResponse.mode:type_name -> incident.v1.BulkMode
+	3, // 1: incident.v1.BulkResponse.results:type_name -> incident.v1.BulkItemResult
+	2, // [2:2] is the sub-list for method output_type
+	2, // [2:2] is the sub-list for method input_type
+	2, // [2:2] is the sub-list for extension type_name
+	2, // [2:2] is the sub-list for extension extendee
+	0, // [0:2] is the sub-list for field type_name
+}
+
+func init() { file_incident_v1_common_proto_init() }
+func file_incident_v1_common_proto_init() {
+	if File_incident_v1_common_proto != nil {
+		return
+	}
+	file_incident_v1_common_proto_msgTypes[0].OneofWrappers = []any{}
+	file_incident_v1_common_proto_msgTypes[1].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_incident_v1_common_proto_rawDesc), len(file_incident_v1_common_proto_rawDesc)),
+			NumEnums:      1,
+			NumMessages:   4,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_incident_v1_common_proto_goTypes,
+		DependencyIndexes: file_incident_v1_common_proto_depIdxs,
+		EnumInfos:         file_incident_v1_common_proto_enumTypes,
+		MessageInfos:      file_incident_v1_common_proto_msgTypes,
+	}.Build()
+	File_incident_v1_common_proto = out.File
+	file_incident_v1_common_proto_goTypes = nil
+	file_incident_v1_common_proto_depIdxs = nil
+}
@@ -0,0 +1,865 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        (unknown)
+// source: incident/v1/components.proto
+
+package incidentv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type Component struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    uint32                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	// Unset for a component that is not installed in a room
+	RoomId          *uint32                `protobuf:"varint,2,opt,name=room_id,json=roomId,proto3,oneof" json:"room_id,omitempty"`
+	CategoryId      uint32                 `protobuf:"varint,3,opt,name=category_id,json=categoryId,proto3" json:"category_id,omitempty"`
+	Code            string                 `protobuf:"bytes,4,opt,name=code,proto3" json:"code,omitempty"`
+	Name            string                 `protobuf:"bytes,5,opt,name=name,proto3" json:"name,omitempty"`
+	Brand           string                 `protobuf:"bytes,6,opt,name=brand,proto3" json:"brand,omitempty"`
+	Specification   string                 `protobuf:"bytes,7,opt,name=specification,proto3" json:"specification,omitempty"`
+	ProcurementYear int32                  `protobuf:"varint,8,opt,name=procurement_year,json=procurementYear,proto3" json:"procurement_year,omitempty"`
+	CreatedAt       *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt       *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	Version         uint32                 `protobuf:"varint,11,opt,name=version,proto3" json:"version,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *Component) Reset() {
+	*x = Component{}
+	mi := &file_incident_v1_components_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Component) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Component) ProtoMessage() {}
+
+func (x *Component) ProtoReflect() protoreflect.Message {
+	mi := &file_incident_v1_components_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Component.ProtoReflect.Descriptor instead.
+func (*Component) Descriptor() ([]byte, []int) {
+	return file_incident_v1_components_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Component) GetId() uint32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Component) GetRoomId() uint32 {
+	if x != nil && x.RoomId != nil {
+		return *x.RoomId
+	}
+	return 0
+}
+
+func (x *Component) GetCategoryId() uint32 {
+	if x != nil {
+		return x.CategoryId
+	}
+	return 0
+}
+
+func (x *Component) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *Component) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Component) GetBrand() string {
+	if x != nil {
+		return x.Brand
+	}
+	return ""
+}
+
+func (x *Component) GetSpecification() string {
+	if x != nil {
+		return x.Specification
+	}
+	return ""
+}
+
+func (x *Component) GetProcurementYear() int32 {
+	if x != nil {
+		return x.ProcurementYear
+	}
+	return 0
+}
+
+func (x *Component) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Component) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+func (x *Component) GetVersion() uint32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+type CreateComponentRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	RoomId          *uint32                `protobuf:"varint,1,opt,name=room_id,json=roomId,proto3,oneof" json:"room_id,omitempty"`
+	CategoryId      uint32                 `protobuf:"varint,2,opt,name=category_id,json=categoryId,proto3" json:"category_id,omitempty"`
+	Code            string                 `protobuf:"bytes,3,opt,name=code,proto3" json:"code,omitempty"`
+	Name            string                 `protobuf:"bytes,4,opt,name=name,proto3" json:"name,omitempty"`
+	Brand           string                 `protobuf:"bytes,5,opt,name=brand,proto3" json:"brand,omitempty"`
+	Specification   string                 `protobuf:"bytes,6,opt,name=specification,proto3" json:"specification,omitempty"`
+	ProcurementYear int32                  `protobuf:"varint,7,opt,name=procurement_year,json=procurementYear,proto3" json:"procurement_year,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *CreateComponentRequest) Reset() {
+	*x = CreateComponentRequest{}
+	mi := &file_incident_v1_components_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateComponentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateComponentRequest) ProtoMessage() {}
+
+func (x *CreateComponentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_incident_v1_components_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateComponentRequest.ProtoReflect.Descriptor instead.
+func (*CreateComponentRequest) Descriptor() ([]byte, []int) {
+	return file_incident_v1_components_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *CreateComponentRequest) GetRoomId() uint32 {
+	if x != nil && x.RoomId != nil {
+		return *x.RoomId
+	}
+	return 0
+}
+
+func (x *CreateComponentRequest) GetCategoryId() uint32 {
+	if x != nil {
+		return x.CategoryId
+	}
+	return 0
+}
+
+func (x *CreateComponentRequest) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *CreateComponentRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CreateComponentRequest) GetBrand() string {
+	if x != nil {
+		return x.Brand
+	}
+	return ""
+}
+
+func (x *CreateComponentRequest) GetSpecification() string {
+	if x != nil {
+		return x.Specification
+	}
+	return ""
+}
+
+func (x *CreateComponentRequest) GetProcurementYear() int32 {
+	if x != nil {
+		return x.ProcurementYear
+	}
+	return 0
+}
+
+type GetComponentRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            uint32                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetComponentRequest) Reset() {
+	*x = GetComponentRequest{}
+	mi := &file_incident_v1_components_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetComponentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetComponentRequest) ProtoMessage() {}
+
+func (x *GetComponentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_incident_v1_components_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetComponentRequest.ProtoReflect.Descriptor instead.
+func (*GetComponentRequest) Descriptor() ([]byte, []int) {
+	return file_incident_v1_components_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *GetComponentRequest) GetId() uint32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type ListComponentsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RoomId        uint32                 `protobuf:"varint,1,opt,name=room_id,json=roomId,proto3" json:"room_id,omitempty"`
+	CategoryId    uint32                 `protobuf:"varint,2,opt,name=category_id,json=categoryId,proto3" json:"category_id,omitempty"`
+	Page          *PageRequest           `protobuf:"bytes,3,opt,name=page,proto3" json:"page,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListComponentsRequest) Reset() {
+	*x = ListComponentsRequest{}
+	mi := &file_incident_v1_components_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListComponentsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListComponentsRequest) ProtoMessage() {}
+
+func (x *ListComponentsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_incident_v1_components_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListComponentsRequest.ProtoReflect.Descriptor instead.
+func (*ListComponentsRequest) Descriptor() ([]byte, []int) {
+	return file_incident_v1_components_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *ListComponentsRequest) GetRoomId() uint32 {
+	if x != nil {
+		return x.RoomId
+	}
+	return 0
+}
+
+func (x *ListComponentsRequest) GetCategoryId() uint32 {
+	if x != nil {
+		return x.CategoryId
+	}
+	return 0
+}
+
+func (x *ListComponentsRequest) GetPage() *PageRequest {
+	if x != nil {
+		return x.Page
+	}
+	return nil
+}
+
+type ListComponentsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Components    []*Component           `protobuf:"bytes,1,rep,name=components,proto3" json:"components,omitempty"`
+	PageInfo      *PageInfo              `protobuf:"bytes,2,opt,name=page_info,json=pageInfo,proto3" json:"page_info,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListComponentsResponse) Reset() {
+	*x = ListComponentsResponse{}
+	mi := &file_incident_v1_components_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListComponentsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListComponentsResponse) ProtoMessage() {}
+
+func (x *ListComponentsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_incident_v1_components_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListComponentsResponse.ProtoReflect.Descriptor instead.
+func (*ListComponentsResponse) Descriptor() ([]byte, []int) {
+	return file_incident_v1_components_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ListComponentsResponse) GetComponents() []*Component {
+	if x != nil {
+		return x.Components
+	}
+	return nil
+}
+
+func (x *ListComponentsResponse) GetPageInfo() *PageInfo {
+	if x != nil {
+		return x.PageInfo
+	}
+	return nil
+}
+
+// UpdateComponentRequest changes the fields that are set; empty values are left unchanged, like PUT
+type UpdateComponentRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Id              uint32                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Code            string                 `protobuf:"bytes,2,opt,name=code,proto3" json:"code,omitempty"`
+	Name            string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	Brand           string                 `protobuf:"bytes,4,opt,name=brand,proto3" json:"brand,omitempty"`
+	Specification   string                 `protobuf:"bytes,5,opt,name=specification,proto3" json:"specification,omitempty"`
+	ProcurementYear int32                  `protobuf:"varint,6,opt,name=procurement_year,json=procurementYear,proto3" json:"procurement_year,omitempty"`
+	// Only update the component if it is at this version, like If-Match
+	ExpectedVersion *uint32 `protobuf:"varint,7,opt,name=expected_version,json=expectedVersion,proto3,oneof" json:"expected_version,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *UpdateComponentRequest) Reset() {
+	*x = UpdateComponentRequest{}
+	mi := &file_incident_v1_components_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateComponentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateComponentRequest) ProtoMessage() {}
+
+func (x *UpdateComponentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_incident_v1_components_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateComponentRequest.ProtoReflect.Descriptor instead.
+func (*UpdateComponentRequest) Descriptor() ([]byte, []int) {
+	return file_incident_v1_components_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *UpdateComponentRequest) GetId() uint32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *UpdateComponentRequest) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *UpdateComponentRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *UpdateComponentRequest) GetBrand() string {
+	if x != nil {
+		return x.Brand
+	}
+	return ""
+}
+
+func (x *UpdateComponentRequest) GetSpecification() string {
+	if x != nil {
+		return x.Specification
+	}
+	return ""
+}
+
+func (x *UpdateComponentRequest) GetProcurementYear() int32 {
+	if x != nil {
+		return x.ProcurementYear
+	}
+	return 0
+}
+
+func (x *UpdateComponentRequest) GetExpectedVersion() uint32 {
+	if x != nil && x.ExpectedVersion != nil {
+		return *x.ExpectedVersion
+	}
+	return 0
+}
+
+type DeleteComponentRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Id              uint32                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	ExpectedVersion *uint32                `protobuf:"varint,2,opt,name=expected_version,json=expectedVersion,proto3,oneof" json:"expected_version,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *DeleteComponentRequest) Reset() {
+	*x = DeleteComponentRequest{}
+	mi := &file_incident_v1_components_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteComponentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteComponentRequest) ProtoMessage() {}
+
+func (x *DeleteComponentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_incident_v1_components_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteComponentRequest.ProtoReflect.Descriptor instead.
+func (*DeleteComponentRequest) Descriptor() ([]byte, []int) {
+	return file_incident_v1_components_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *DeleteComponentRequest) GetId() uint32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *DeleteComponentRequest) GetExpectedVersion() uint32 {
+	if x != nil && x.ExpectedVersion != nil {
+		return *x.ExpectedVersion
+	}
+	return 0
+}
+
+type DeleteComponentResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteComponentResponse) Reset() {
+	*x = DeleteComponentResponse{}
+	mi := &file_incident_v1_components_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteComponentResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteComponentResponse) ProtoMessage() {}
+
+func (x *DeleteComponentResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_incident_v1_components_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteComponentResponse.ProtoReflect.Descriptor instead.
+func (*DeleteComponentResponse) Descriptor() ([]byte, []int) {
+	return file_incident_v1_components_proto_rawDescGZIP(), []int{7}
+}
+
+type AssignRoomRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Id              uint32                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	RoomId          uint32                 `protobuf:"varint,2,opt,name=room_id,json=roomId,proto3" json:"room_id,omitempty"`
+	ExpectedVersion *uint32                `protobuf:"varint,3,opt,name=expected_version,json=expectedVersion,proto3,oneof" json:"expected_version,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *AssignRoomRequest) Reset() {
+	*x = AssignRoomRequest{}
+	mi := &file_incident_v1_components_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AssignRoomRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AssignRoomRequest) ProtoMessage() {}
+
+func (x *AssignRoomRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_incident_v1_components_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AssignRoomRequest.ProtoReflect.Descriptor instead.
+func (*AssignRoomRequest) Descriptor() ([]byte, []int) {
+	return file_incident_v1_components_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *AssignRoomRequest) GetId() uint32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *AssignRoomRequest) GetRoomId() uint32 {
+	if x != nil {
+		return x.RoomId
+	}
+	return 0
+}
+
+func (x *AssignRoomRequest) GetExpectedVersion() uint32 {
+	if x != nil && x.ExpectedVersion != nil {
+		return *x.ExpectedVersion
+	}
+	return 0
+}
+
+type BulkMoveComponentsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Ids           []uint32               `protobuf:"varint,1,rep,packed,name=ids,proto3" json:"ids,omitempty"`
+	RoomId        uint32                 `protobuf:"varint,2,opt,name=room_id,json=roomId,proto3" json:"room_id,omitempty"`
+	Mode          BulkMode               `protobuf:"varint,3,opt,name=mode,proto3,enum=incident.v1.BulkMode" json:"mode,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BulkMoveComponentsRequest) Reset() {
+	*x = BulkMoveComponentsRequest{}
+	mi := &file_incident_v1_components_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BulkMoveComponentsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BulkMoveComponentsRequest) ProtoMessage() {}
+
+func (x *BulkMoveComponentsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_incident_v1_components_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BulkMoveComponentsRequest.ProtoReflect.Descriptor instead.
+func (*BulkMoveComponentsRequest) Descriptor() ([]byte, []int) {
+	return file_incident_v1_components_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *BulkMoveComponentsRequest) GetIds() []uint32 {
+	if x != nil {
+		return x.Ids
+	}
+	return nil
+}
+
+func (x *BulkMoveComponentsRequest) GetRoomId() uint32 {
+	if x != nil {
+		return x.RoomId
+	}
+	return 0
+}
+
+func (x *BulkMoveComponentsRequest) GetMode() BulkMode {
+	if x != nil {
+		return x.Mode
+	}
+	return BulkMode_BULK_MODE_UNSPECIFIED
+}
+
+var File_incident_v1_components_proto protoreflect.FileDescriptor
+
+const file_incident_v1_components_proto_rawDesc = "" +
+	"\n" +
+	"\x1cincident/v1/components.proto\x12\vincident.v1\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x18incident/v1/common.proto\"\x85\x03\n" +
+	"\tComponent\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\rR\x02id\x12\x1c\n" +
+	"\aroom_id\x18\x02 \x01(\rH\x00R\x06roomId\x88\x01\x01\x12\x1f\n" +
+	"\vcategory_id\x18\x03 \x01(\rR\n" +
+	"categoryId\x12\x12\n" +
+	"\x04code\x18\x04 \x01(\tR\x04code\x12\x12\n" +
+	"\x04name\x18\x05 \x01(\tR\x04name\x12\x14\n" +
+	"\x05brand\x18\x06 \x01(\tR\x05brand\x12$\n" +
+	"\rspecification\x18\a \x01(\tR\rspecification\x12)\n" +
+	"\x10procurement_year\x18\b \x01(\x05R\x0fprocurementYear\x129\n" +
+	"\n" +
+	"created_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\n" +
+	" \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x12\x18\n" +
+	"\aversion\x18\v \x01(\rR\aversionB\n" +
+	"\n" +
+	"\b_room_id\"\xf2\x01\n" +
+	"\x16CreateComponentRequest\x12\x1c\n" +
+	"\aroom_id\x18\x01 \x01(\rH\x00R\x06roomId\x88\x01\x01\x12\x1f\n" +
+	"\vcategory_id\x18\x02 \x01(\rR\n" +
+	"categoryId\x12\x12\n" +
+	"\x04code\x18\x03 \x01(\tR\x04code\x12\x12\n" +
+	"\x04name\x18\x04 \x01(\tR\x04name\x12\x14\n" +
+	"\x05brand\x18\x05 \x01(\tR\x05brand\x12$\n" +
+	"\rspecification\x18\x06 \x01(\tR\rspecification\x12)\n" +
+	"\x10procurement_year\x18\a \x01(\x05R\x0fprocurementYearB\n" +
+	"\n" +
+	"\b_room_id\"%\n" +
+	"\x13GetComponentRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\rR\x02id\"\x7f\n" +
+	"\x15ListComponentsRequest\x12\x17\n" +
+	"\aroom_id\x18\x01 \x01(\rR\x06roomId\x12\x1f\n" +
+	"\vcategory_id\x18\x02 \x01(\rR\n" +
+	"categoryId\x12,\n" +
+	"\x04page\x18\x03 \x01(\v2\x18.incident.v1.PageRequestR\x04page\"\x84\x01\n" +
+	"\x16ListComponentsResponse\x126\n" +
+	"\n" +
+	"components\x18\x01 \x03(\v2\x16.incident.v1.ComponentR\n" +
+	"components\x122\n" +
+	"\tpage_info\x18\x02 \x01(\v2\x15.incident.v1.PageInfoR\bpageInfo\"\xfc\x01\n" +
+	"\x16UpdateComponentRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\rR\x02id\x12\x12\n" +
+	"\x04code\x18\x02 \x01(\tR\x04code\x12\x12\n" +
+	"\x04name\x18\x03 \x01(\tR\x04name\x12\x14\n" +
+	"\x05brand\x18\x04 \x01(\tR\x05brand\x12$\n" +
+	"\rspecification\x18\x05 \x01(\tR\rspecification\x12)\n" +
+	"\x10procurement_year\x18\x06 \x01(\x05R\x0fprocurementYear\x12.\n" +
+	"\x10expected_version\x18\a \x01(\rH\x00R\x0fexpectedVersion\x88\x01\x01B\x13\n" +
+	"\x11_expected_version\"m\n" +
+	"\x16DeleteComponentRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\rR\x02id\x12.\n" +
+	"\x10expected_version\x18\x02 \x01(\rH\x00R\x0fexpectedVersion\x88\x01\x01B\x13\n" +
+	"\x11_expected_version\"\x19\n" +
+	"\x17DeleteComponentResponse\"\x81\x01\n" +
+	"\x11AssignRoomRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\rR\x02id\x12\x17\n" +
+	"\aroom_id\x18\x02 \x01(\rR\x06roomId\x12.\n" +
+	"\x10expected_version\x18\x03 \x01(\rH\x00R\x0fexpectedVersion\x88\x01\x01B\x13\n" +
+	"\x11_expected_version\"q\n" +
+	"\x19BulkMoveComponentsRequest\x12\x10\n" +
+	"\x03ids\x18\x01 \x03(\rR\x03ids\x12\x17\n" +
+	"\aroom_id\x18\x02 \x01(\rR\x06roomId\x12)\n" +
+	"\x04mode\x18\x03 \x01(\x0e2\x15.incident.v1.BulkModeR\x04mode2\xd4\x04\n" +
+	"\x10ComponentService\x12N\n" +
+	"\x0fCreateComponent\x12#.incident.v1.CreateComponentRequest\x1a\x16.incident.v1.Component\x12H\n" +
+	"\fGetComponent\x12 .incident.v1.GetComponentRequest\x1a\x16.incident.v1.Component\x12Y\n" +
+	"\x0eListComponents\x12\".incident.v1.ListComponentsRequest\x1a#.incident.v1.ListComponentsResponse\x12N\n" +
+	"\x0fUpdateComponent\x12#.incident.v1.UpdateComponentRequest\x1a\x16.incident.v1.Component\x12\\\n" +
+	"\x0fDeleteComponent\x12#.incident.v1.DeleteComponentRequest\x1a$.incident.v1.DeleteComponentResponse\x12D\n" +
+	"\n" +
+	"AssignRoom\x12\x1e.incident.v1.AssignRoomRequest\x1a\x16.incident.v1.Component\x12W\n" +
+	"\x12BulkMoveComponents\x12&.incident.v1.BulkMoveComponentsRequest\x1a\x19.incident.v1.BulkResponseB.Z,incident-report/proto/incident/v1;incidentv1b\x06proto3"
+
+var (
+	file_incident_v1_components_proto_rawDescOnce sync.Once
+	file_incident_v1_components_proto_rawDescData []byte
+)
+
+func file_incident_v1_components_proto_rawDescGZIP() []byte {
+	file_incident_v1_components_proto_rawDescOnce.Do(func() {
+		file_incident_v1_components_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_incident_v1_components_proto_rawDesc), len(file_incident_v1_components_proto_rawDesc)))
+	})
+	return file_incident_v1_components_proto_rawDescData
+}
+
+var file_incident_v1_components_proto_msgTypes = make([]protoimpl.MessageInfo, 10)
+var file_incident_v1_components_proto_goTypes = []any{
+	(*Component)(nil),                 // 0: incident.v1.Component
+	(*CreateComponentRequest)(nil),    // 1: incident.v1.CreateComponentRequest
+	(*GetComponentRequest)(nil),       // 2: incident.v1.GetComponentRequest
+	(*ListComponentsRequest)(nil),     // 3: incident.v1.ListComponentsRequest
+	(*ListComponentsResponse)(nil),    // 4: incident.v1.ListComponentsResponse
+	(*UpdateComponentRequest)(nil),    // 5: incident.v1.UpdateComponentRequest
+	(*DeleteComponentRequest)(nil),    // 6: incident.v1.DeleteComponentRequest
+	(*DeleteComponentResponse)(nil),   // 7: incident.v1.DeleteComponentResponse
+	(*AssignRoomRequest)(nil),         // 8: incident.v1.AssignRoomRequest
+	(*BulkMoveComponentsRequest)(nil), // 9: incident.v1.BulkMoveComponentsRequest
+	(*timestamppb.Timestamp)(nil),     // 10: google.protobuf.Timestamp
+	(*PageRequest)(nil),               // 11: incident.v1.PageRequest
+	(*PageInfo)(nil),                  // 12: incident.v1.PageInfo
+	(BulkMode)(0),                     // 13: incident.v1.BulkMode
+	(*BulkResponse)(nil),              // 14: incident.v1.BulkResponse
+}
+var file_incident_v1_components_proto_depIdxs = []int32{
+	10, // 0: incident.v1.Component.created_at:type_name -> google.protobuf.Timestamp
+	10, // 1: incident.v1.Component.updated_at:type_name -> google.protobuf.Timestamp
+	11, // 2: incident.v1.ListComponentsRequest.page:type_name -> incident.v1.PageRequest
+	0,  // 3: incident.v1.ListComponentsResponse.components:type_name -> incident.v1.Component
+	12, // 4: incident.v1.ListComponentsResponse.page_info:type_name -> incident.v1.PageInfo
+	13, // 5: incident.v1.BulkMoveComponentsRequest.mode:type_name -> incident.v1.BulkMode
+	1,  // 6: incident.v1.ComponentService.CreateComponent:input_type -> incident.v1.CreateComponentRequest
+	2,  // 7: incident.v1.ComponentService.GetComponent:input_type -> incident.v1.GetComponentRequest
+	3,  // 8: incident.v1.ComponentService.ListComponents:input_type -> incident.v1.ListComponentsRequest
+	5,  // 9: incident.v1.ComponentService.UpdateComponent:input_type -> incident.v1.UpdateComponentRequest
+	6,  // 10: incident.v1.ComponentService.DeleteComponent:input_type -> incident.v1.DeleteComponentRequest
+	8,  // 11: incident.v1.ComponentService.AssignRoom:input_type -> incident.v1.AssignRoomRequest
+	9,  // 12: incident.v1.ComponentService.BulkMoveComponents:input_type -> incident.v1.BulkMoveComponentsRequest
+	0,  // 13: incident.v1.ComponentService.CreateComponent:output_type -> incident.v1.Component
+	0,  // 14: incident.v1.ComponentService.GetComponent:output_type -> incident.v1.Component
+	4,  // 15: incident.v1.ComponentService.ListComponents:output_type -> incident.v1.ListComponentsResponse
+	0,  // 16: incident.v1.ComponentService.UpdateComponent:output_type -> incident.v1.Component
+	7,  // 17: incident.v1.ComponentService.DeleteComponent:output_type -> incident.v1.DeleteComponentResponse
+	0,  // 18: incident.v1.ComponentService.AssignRoom:output_type -> incident.v1.Component
+	14, // 19: incident.v1.ComponentService.BulkMoveComponents:output_type -> incident.v1.BulkResponse
+	13, // [13:20] is the sub-list for method output_type
+	6,  // [6:13] is the sub-list for method input_type
+	6,  // [6:6] is the sub-list for extension type_name
+	6,  // [6:6] is the sub-list for extension extendee
+	0,  // [0:6] is the sub-list for field type_name
+}
+
+func init() { file_incident_v1_components_proto_init() }
+func file_incident_v1_components_proto_init() {
+	if File_incident_v1_components_proto != nil {
+		return
+	}
+	file_incident_v1_common_proto_init()
+	file_incident_v1_components_proto_msgTypes[0].OneofWrappers = []any{}
+	file_incident_v1_components_proto_msgTypes[1].OneofWrappers = []any{}
+	file_incident_v1_components_proto_msgTypes[5].OneofWrappers = []any{}
+	file_incident_v1_components_proto_msgTypes[6].OneofWrappers = []any{}
+	file_incident_v1_components_proto_msgTypes[8].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_incident_v1_components_proto_rawDesc), len(file_incident_v1_components_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   10,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_incident_v1_components_proto_goTypes,
+		DependencyIndexes: file_incident_v1_components_proto_depIdxs,
+		MessageInfos:      file_incident_v1_components_proto_msgTypes,
+	}.Build()
+	File_incident_v1_components_proto = out.File
+	file_incident_v1_components_proto_goTypes = nil
+	file_incident_v1_components_proto_depIdxs = nil
+}
@@ -0,0 +1,359 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             (unknown)
+// source: incident/v1/components.proto
+
+package incidentv1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	ComponentService_CreateComponent_FullMethodName    = "/incident.v1.ComponentService/CreateComponent"
+	ComponentService_GetComponent_FullMethodName       = "/incident.v1.ComponentService/GetComponent"
+	ComponentService_ListComponents_FullMethodName     = "/incident.v1.ComponentService/ListComponents"
+	ComponentService_UpdateComponent_FullMethodName    = "/incident.v1.ComponentService/UpdateComponent"
+	ComponentService_DeleteComponent_FullMethodName    = "/incident.v1.ComponentService/DeleteComponent"
+	ComponentService_AssignRoom_FullMethodName         = "/incident.v1.ComponentService/AssignRoom"
+	ComponentService_BulkMoveComponents_FullMethodName = "/incident.v1.ComponentService/BulkMoveComponents"
+)
+
+// ComponentServiceClient is the client API for ComponentService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// ComponentService manages the components installed in rooms.
+// It mirrors the /components REST endpoints.
+type ComponentServiceClient interface {
+	CreateComponent(ctx context.Context, in *CreateComponentRequest, opts ...grpc.CallOption) (*Component, error)
+	GetComponent(ctx context.Context, in *GetComponentRequest, opts ...grpc.CallOption) (*Component, error)
+	ListComponents(ctx context.Context, in *ListComponentsRequest, opts ...grpc.CallOption) (*ListComponentsResponse, error)
+	UpdateComponent(ctx context.Context, in *UpdateComponentRequest, opts ...grpc.CallOption) (*Component, error)
+	DeleteComponent(ctx context.Context, in *DeleteComponentRequest, opts ...grpc.CallOption) (*DeleteComponentResponse, error)
+	// AssignRoom moves a component to a room
+	AssignRoom(ctx context.Context, in *AssignRoomRequest, opts ...grpc.CallOption) (*Component, error)
+	// BulkMoveComponents moves many components to one room
+	BulkMoveComponents(ctx context.Context, in *BulkMoveComponentsRequest, opts ...grpc.CallOption) (*BulkResponse, error)
+}
+
+type componentServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewComponentServiceClient(cc grpc.ClientConnInterface) ComponentServiceClient {
+	return &componentServiceClient{cc}
+}
+
+func (c *componentServiceClient) CreateComponent(ctx context.Context, in *CreateComponentRequest, opts ...grpc.CallOption) (*Component, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Component)
+	err := c.cc.Invoke(ctx, ComponentService_CreateComponent_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *componentServiceClient) GetComponent(ctx context.Context, in *GetComponentRequest, opts ...grpc.CallOption) (*Component, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Component)
+	err := c.cc.Invoke(ctx, ComponentService_GetComponent_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *componentServiceClient) ListComponents(ctx context.Context, in *ListComponentsRequest, opts ...grpc.CallOption) (*ListComponentsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListComponentsResponse)
+	err := c.cc.Invoke(ctx, ComponentService_ListComponents_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *componentServiceClient) UpdateComponent(ctx context.Context, in *UpdateComponentRequest, opts ...grpc.CallOption) (*Component, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Component)
+	err := c.cc.Invoke(ctx, ComponentService_UpdateComponent_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *componentServiceClient) DeleteComponent(ctx context.Context, in *DeleteComponentRequest, opts ...grpc.CallOption) (*DeleteComponentResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteComponentResponse)
+	err := c.cc.Invoke(ctx, ComponentService_DeleteComponent_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *componentServiceClient) AssignRoom(ctx context.Context, in *AssignRoomRequest, opts ...grpc.CallOption) (*Component, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Component)
+	err := c.cc.Invoke(ctx, ComponentService_AssignRoom_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *componentServiceClient) BulkMoveComponents(ctx context.Context, in *BulkMoveComponentsRequest, opts ...grpc.CallOption) (*BulkResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BulkResponse)
+	err := c.cc.Invoke(ctx, ComponentService_BulkMoveComponents_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ComponentServiceServer is the server API for ComponentService service.
+// All implementations must embed UnimplementedComponentServiceServer
+// for forward compatibility.
+//
+// ComponentService manages the components installed in rooms.
+// It mirrors the /components REST endpoints.
+type ComponentServiceServer interface {
+	CreateComponent(context.Context, *CreateComponentRequest) (*Component, error)
+	GetComponent(context.Context, *GetComponentRequest) (*Component, error)
+	ListComponents(context.Context, *ListComponentsRequest) (*ListComponentsResponse, error)
+	UpdateComponent(context.Context, *UpdateComponentRequest) (*Component, error)
+	DeleteComponent(context.Context, *DeleteComponentRequest) (*DeleteComponentResponse, error)
+	// AssignRoom moves a component to a room
+	AssignRoom(context.Context, *AssignRoomRequest) (*Component, error)
+	// BulkMoveComponents moves many components to one room
+	BulkMoveComponents(context.Context, *BulkMoveComponentsRequest) (*BulkResponse, error)
+	mustEmbedUnimplementedComponentServiceServer()
+}
+
+// UnimplementedComponentServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedComponentServiceServer struct{}
+
+func (UnimplementedComponentServiceServer) CreateComponent(context.Context, *CreateComponentRequest) (*Component, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateComponent not implemented")
+}
+func (UnimplementedComponentServiceServer) GetComponent(context.Context, *GetComponentRequest) (*Component, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetComponent not implemented")
+}
+func (UnimplementedComponentServiceServer) ListComponents(context.Context, *ListComponentsRequest) (*ListComponentsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListComponents not implemented")
+}
+func (UnimplementedComponentServiceServer) UpdateComponent(context.Context, *UpdateComponentRequest) (*Component, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateComponent not implemented")
+}
+func (UnimplementedComponentServiceServer) DeleteComponent(context.Context, *DeleteComponentRequest) (*DeleteComponentResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteComponent not implemented")
+}
+func (UnimplementedComponentServiceServer) AssignRoom(context.Context, *AssignRoomRequest) (*Component, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AssignRoom not implemented")
+}
+func (UnimplementedComponentServiceServer) BulkMoveComponents(context.Context, *BulkMoveComponentsRequest) (*BulkResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BulkMoveComponents not implemented")
+}
+func (UnimplementedComponentServiceServer) mustEmbedUnimplementedComponentServiceServer() {}
+func (UnimplementedComponentServiceServer) testEmbeddedByValue()                          {}
+
+// UnsafeComponentServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to ComponentServiceServer will
+// result in compilation errors.
+type UnsafeComponentServiceServer interface {
+	mustEmbedUnimplementedComponentServiceServer()
+}
+
+func RegisterComponentServiceServer(s grpc.ServiceRegistrar, srv ComponentServiceServer) {
+	// If the following call pancis, it indicates UnimplementedComponentServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&ComponentService_ServiceDesc, srv)
+}
+
+func _ComponentService_CreateComponent_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateComponentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ComponentServiceServer).CreateComponent(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ComponentService_CreateComponent_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ComponentServiceServer).CreateComponent(ctx, req.(*CreateComponentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ComponentService_GetComponent_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetComponentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ComponentServiceServer).GetComponent(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ComponentService_GetComponent_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ComponentServiceServer).GetComponent(ctx, req.(*GetComponentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ComponentService_ListComponents_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListComponentsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ComponentServiceServer).ListComponents(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ComponentService_ListComponents_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ComponentServiceServer).ListComponents(ctx, req.(*ListComponentsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ComponentService_UpdateComponent_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateComponentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ComponentServiceServer).UpdateComponent(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ComponentService_UpdateComponent_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ComponentServiceServer).UpdateComponent(ctx, req.(*UpdateComponentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ComponentService_DeleteComponent_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteComponentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ComponentServiceServer).DeleteComponent(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ComponentService_DeleteComponent_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ComponentServiceServer).DeleteComponent(ctx, req.(*DeleteComponentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ComponentService_AssignRoom_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AssignRoomRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ComponentServiceServer).AssignRoom(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ComponentService_AssignRoom_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ComponentServiceServer).AssignRoom(ctx, req.(*AssignRoomRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ComponentService_BulkMoveComponents_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BulkMoveComponentsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ComponentServiceServer).BulkMoveComponents(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ComponentService_BulkMoveComponents_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ComponentServiceServer).BulkMoveComponents(ctx, req.(*BulkMoveComponentsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// ComponentService_ServiceDesc is the grpc.ServiceDesc for ComponentService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var ComponentService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "incident.v1.ComponentService",
+	HandlerType: (*ComponentServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CreateComponent",
+			Handler:    _ComponentService_CreateComponent_Handler,
+		},
+		{
+			MethodName: "GetComponent",
+			Handler:    _ComponentService_GetComponent_Handler,
+		},
+		{
+			MethodName: "ListComponents",
+			Handler:    _ComponentService_ListComponents_Handler,
+		},
+		{
+			MethodName: "UpdateComponent",
+			Handler:    _ComponentService_UpdateComponent_Handler,
+		},
+		{
+			MethodName: "DeleteComponent",
+			Handler:    _ComponentService_DeleteComponent_Handler,
+		},
+		{
+			MethodName: "AssignRoom",
+			Handler:    _ComponentService_AssignRoom_Handler,
+		},
+		{
+			MethodName: "BulkMoveComponents",
+			Handler:    _ComponentService_BulkMoveComponents_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "incident/v1/components.proto",
+}
@@ -0,0 +1,2095 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        (unknown)
+// source: incident/v1/location.proto
+
+package incidentv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type Building struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            uint32                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Code          string                 `protobuf:"bytes,2,opt,name=code,proto3" json:"code,omitempty"`
+	Name          string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	Location      string                 `protobuf:"bytes,4,opt,name=location,proto3" json:"location,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	Version       uint32                 `protobuf:"varint,7,opt,name=version,proto3" json:"version,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Building) Reset() {
+	*x = Building{}
+	mi := &file_incident_v1_location_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Building) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Building) ProtoMessage() {}
+
+func (x *Building) ProtoReflect() protoreflect.Message {
+	mi := &file_incident_v1_location_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Building.ProtoReflect.Descriptor instead.
+func (*Building) Descriptor() ([]byte, []int) {
+	return file_incident_v1_location_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Building) GetId() uint32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Building) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *Building) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Building) GetLocation() string {
+	if x != nil {
+		return x.Location
+	}
+	return ""
+}
+
+func (x *Building) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Building) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+func (x *Building) GetVersion() uint32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+type Floor struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            uint32                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	BuildingId    uint32                 `protobuf:"varint,2,opt,name=building_id,json=buildingId,proto3" json:"building_id,omitempty"`
+	FloorNumber   int32                  `protobuf:"varint,3,opt,name=floor_number,json=floorNumber,proto3" json:"floor_number,omitempty"`
+	Name          string                 `protobuf:"bytes,4,opt,name=name,proto3" json:"name,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	Version       uint32                 `protobuf:"varint,7,opt,name=version,proto3" json:"version,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Floor) Reset() {
+	*x = Floor{}
+	mi := &file_incident_v1_location_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Floor) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Floor) ProtoMessage() {}
+
+func (x *Floor) ProtoReflect() protoreflect.Message {
+	mi := &file_incident_v1_location_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Floor.ProtoReflect.Descriptor instead.
+func (*Floor) Descriptor() ([]byte, []int) {
+	return file_incident_v1_location_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Floor) GetId() uint32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Floor) GetBuildingId() uint32 {
+	if x != nil {
+		return x.BuildingId
+	}
+	return 0
+}
+
+func (x *Floor) GetFloorNumber() int32 {
+	if x != nil {
+		return x.FloorNumber
+	}
+	return 0
+}
+
+func (x *Floor) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Floor) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Floor) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+func (x *Floor) GetVersion() uint32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+type Room struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            uint32                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	FloorId       uint32                 `protobuf:"varint,2,opt,name=floor_id,json=floorId,proto3" json:"floor_id,omitempty"`
+	Code          string                 `protobuf:"bytes,3,opt,name=code,proto3" json:"code,omitempty"`
+	Name          string                 `protobuf:"bytes,4,opt,name=name,proto3" json:"name,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	Version       uint32                 `protobuf:"varint,7,opt,name=version,proto3" json:"version,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Room) Reset() {
+	*x = Room{}
+	mi := &file_incident_v1_location_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Room) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Room) ProtoMessage() {}
+
+func (x *Room) ProtoReflect() protoreflect.Message {
+	mi := &file_incident_v1_location_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Room.ProtoReflect.Descriptor instead.
+func (*Room) Descriptor() ([]byte, []int) {
+	return file_incident_v1_location_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *Room) GetId() uint32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Room) GetFloorId() uint32 {
+	if x != nil {
+		return x.FloorId
+	}
+	return 0
+}
+
+func (x *Room) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *Room) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Room) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Room) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+func (x *Room) GetVersion() uint32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+type CreateBuildingRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Code          string                 `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Location      string                 `protobuf:"bytes,3,opt,name=location,proto3" json:"location,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateBuildingRequest) Reset() {
+	*x = CreateBuildingRequest{}
+	mi := &file_incident_v1_location_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateBuildingRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateBuildingRequest) ProtoMessage() {}
+
+func (x *CreateBuildingRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_incident_v1_location_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateBuildingRequest.ProtoReflect.Descriptor instead.
+func (*CreateBuildingRequest) Descriptor() ([]byte, []int) {
+	return file_incident_v1_location_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *CreateBuildingRequest) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *CreateBuildingRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CreateBuildingRequest) GetLocation() string {
+	if x != nil {
+		return x.Location
+	}
+	return ""
+}
+
+type GetBuildingRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            uint32                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetBuildingRequest) Reset() {
+	*x = GetBuildingRequest{}
+	mi := &file_incident_v1_location_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetBuildingRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetBuildingRequest) ProtoMessage() {}
+
+func (x *GetBuildingRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_incident_v1_location_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetBuildingRequest.ProtoReflect.Descriptor instead.
+func (*GetBuildingRequest) Descriptor() ([]byte, []int) {
+	return file_incident_v1_location_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *GetBuildingRequest) GetId() uint32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type ListBuildingsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Page          *PageRequest           `protobuf:"bytes,1,opt,name=page,proto3" json:"page,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListBuildingsRequest) Reset() {
+	*x = ListBuildingsRequest{}
+	mi := &file_incident_v1_location_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListBuildingsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListBuildingsRequest) ProtoMessage() {}
+
+func (x *ListBuildingsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_incident_v1_location_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListBuildingsRequest.ProtoReflect.Descriptor instead.
+func (*ListBuildingsRequest) Descriptor() ([]byte, []int) {
+	return file_incident_v1_location_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ListBuildingsRequest) GetPage() *PageRequest {
+	if x != nil {
+		return x.Page
+	}
+	return nil
+}
+
+type ListBuildingsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Buildings     []*Building            `protobuf:"bytes,1,rep,name=buildings,proto3" json:"buildings,omitempty"`
+	PageInfo      *PageInfo              `protobuf:"bytes,2,opt,name=page_info,json=pageInfo,proto3" json:"page_info,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListBuildingsResponse) Reset() {
+	*x = ListBuildingsResponse{}
+	mi := &file_incident_v1_location_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListBuildingsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListBuildingsResponse) ProtoMessage() {}
+
+func (x *ListBuildingsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_incident_v1_location_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListBuildingsResponse.ProtoReflect.Descriptor instead.
+func (*ListBuildingsResponse) Descriptor() ([]byte, []int) {
+	return file_incident_v1_location_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ListBuildingsResponse) GetBuildings() []*Building {
+	if x != nil {
+		return x.Buildings
+	}
+	return nil
+}
+
+func (x *ListBuildingsResponse) GetPageInfo() *PageInfo {
+	if x != nil {
+		return x.PageInfo
+	}
+	return nil
+}
+
+// UpdateBuildingRequest changes the fields that are set; empty strings are left unchanged, like PUT
+type UpdateBuildingRequest struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Id       uint32                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Code     string                 `protobuf:"bytes,2,opt,name=code,proto3" json:"code,omitempty"`
+	Name     string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	Location string                 `protobuf:"bytes,4,opt,name=location,proto3" json:"location,omitempty"`
+	// Only update the building if it is at this version, like If-Match
+	ExpectedVersion *uint32 `protobuf:"varint,5,opt,name=expected_version,json=expectedVersion,proto3,oneof" json:"expected_version,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *UpdateBuildingRequest) Reset() {
+	*x = UpdateBuildingRequest{}
+	mi := &file_incident_v1_location_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateBuildingRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateBuildingRequest) ProtoMessage() {}
+
+func (x *UpdateBuildingRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_incident_v1_location_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateBuildingRequest.ProtoReflect.Descriptor instead.
+func (*UpdateBuildingRequest) Descriptor() ([]byte, []int) {
+	return file_incident_v1_location_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *UpdateBuildingRequest) GetId() uint32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *UpdateBuildingRequest) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *UpdateBuildingRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *UpdateBuildingRequest) GetLocation() string {
+	if x != nil {
+		return x.Location
+	}
+	return ""
+}
+
+func (x *UpdateBuildingRequest) GetExpectedVersion() uint32 {
+	if x != nil && x.ExpectedVersion != nil {
+		return *x.ExpectedVersion
+	}
+	return 0
+}
+
+type DeleteBuildingRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    uint32                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	// Also delete the floors, rooms, components and reports of the building
+	Cascade         bool    `protobuf:"varint,2,opt,name=cascade,proto3" json:"cascade,omitempty"`
+	ExpectedVersion *uint32 `protobuf:"varint,3,opt,name=expected_version,json=expectedVersion,proto3,oneof" json:"expected_version,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *DeleteBuildingRequest) Reset() {
+	*x = DeleteBuildingRequest{}
+	mi := &file_incident_v1_location_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteBuildingRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteBuildingRequest) ProtoMessage() {}
+
+func (x *DeleteBuildingRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_incident_v1_location_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteBuildingRequest.ProtoReflect.Descriptor instead.
+func (*DeleteBuildingRequest) Descriptor() ([]byte, []int) {
+	return file_incident_v1_location_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *DeleteBuildingRequest) GetId() uint32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *DeleteBuildingRequest) GetCascade() bool {
+	if x != nil {
+		return x.Cascade
+	}
+	return false
+}
+
+func (x *DeleteBuildingRequest) GetExpectedVersion() uint32 {
+	if x != nil && x.ExpectedVersion != nil {
+		return *x.ExpectedVersion
+	}
+	return 0
+}
+
+type DeleteBuildingResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteBuildingResponse) Reset() {
+	*x = DeleteBuildingResponse{}
+	mi := &file_incident_v1_location_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteBuildingResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteBuildingResponse) ProtoMessage() {}
+
+func (x *DeleteBuildingResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_incident_v1_location_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteBuildingResponse.ProtoReflect.Descriptor instead.
+func (*DeleteBuildingResponse) Descriptor() ([]byte, []int) {
+	return file_incident_v1_location_proto_rawDescGZIP(), []int{9}
+}
+
+type CreateFloorRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	BuildingId    uint32                 `protobuf:"varint,1,opt,name=building_id,json=buildingId,proto3" json:"building_id,omitempty"`
+	FloorNumber   int32                  `protobuf:"varint,2,opt,name=floor_number,json=floorNumber,proto3" json:"floor_number,omitempty"`
+	Name          string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateFloorRequest) Reset() {
+	*x = CreateFloorRequest{}
+	mi := &file_incident_v1_location_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateFloorRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateFloorRequest) ProtoMessage() {}
+
+func (x *CreateFloorRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_incident_v1_location_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateFloorRequest.ProtoReflect.Descriptor instead.
+func (*CreateFloorRequest) Descriptor() ([]byte, []int) {
+	return file_incident_v1_location_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *CreateFloorRequest) GetBuildingId() uint32 {
+	if x != nil {
+		return x.BuildingId
+	}
+	return 0
+}
+
+func (x *CreateFloorRequest) GetFloorNumber() int32 {
+	if x != nil {
+		return x.FloorNumber
+	}
+	return 0
+}
+
+func (x *CreateFloorRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type GetFloorRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            uint32                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetFloorRequest) Reset() {
+	*x = GetFloorRequest{}
+	mi := &file_incident_v1_location_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetFloorRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetFloorRequest) ProtoMessage() {}
+
+func (x *GetFloorRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_incident_v1_location_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetFloorRequest.ProtoReflect.Descriptor instead.
+func (*GetFloorRequest) Descriptor() ([]byte, []int) {
+	return file_incident_v1_location_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *GetFloorRequest) GetId() uint32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type ListFloorsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Only list the floors of this building
+	BuildingId    uint32       `protobuf:"varint,1,opt,name=building_id,json=buildingId,proto3" json:"building_id,omitempty"`
+	Page          *PageRequest `protobuf:"bytes,2,opt,name=page,proto3" json:"page,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListFloorsRequest) Reset() {
+	*x = ListFloorsRequest{}
+	mi := &file_incident_v1_location_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListFloorsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListFloorsRequest) ProtoMessage() {}
+
+func (x *ListFloorsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_incident_v1_location_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListFloorsRequest.ProtoReflect.Descriptor instead.
+func (*ListFloorsRequest) Descriptor() ([]byte, []int) {
+	return file_incident_v1_location_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *ListFloorsRequest) GetBuildingId() uint32 {
+	if x != nil {
+		return x.BuildingId
+	}
+	return 0
+}
+
+func (x *ListFloorsRequest) GetPage() *PageRequest {
+	if x != nil {
+		return x.Page
+	}
+	return nil
+}
+
+type ListFloorsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Floors        []*Floor               `protobuf:"bytes,1,rep,name=floors,proto3" json:"floors,omitempty"`
+	PageInfo      *PageInfo              `protobuf:"bytes,2,opt,name=page_info,json=pageInfo,proto3" json:"page_info,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListFloorsResponse) Reset() {
+	*x = ListFloorsResponse{}
+	mi := &file_incident_v1_location_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListFloorsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListFloorsResponse) ProtoMessage() {}
+
+func (x *ListFloorsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_incident_v1_location_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListFloorsResponse.ProtoReflect.Descriptor instead.
+func (*ListFloorsResponse) Descriptor() ([]byte, []int) {
+	return file_incident_v1_location_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *ListFloorsResponse) GetFloors() []*Floor {
+	if x != nil {
+		return x.Floors
+	}
+	return nil
+}
+
+func (x *ListFloorsResponse) GetPageInfo() *PageInfo {
+	if x != nil {
+		return x.PageInfo
+	}
+	return nil
+}
+
+type UpdateFloorRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Id              uint32                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	FloorNumber     int32                  `protobuf:"varint,2,opt,name=floor_number,json=floorNumber,proto3" json:"floor_number,omitempty"`
+	Name            string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	ExpectedVersion *uint32                `protobuf:"varint,4,opt,name=expected_version,json=expectedVersion,proto3,oneof" json:"expected_version,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *UpdateFloorRequest) Reset() {
+	*x = UpdateFloorRequest{}
+	mi := &file_incident_v1_location_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateFloorRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateFloorRequest) ProtoMessage() {}
+
+func (x *UpdateFloorRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_incident_v1_location_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateFloorRequest.ProtoReflect.Descriptor instead.
+func (*UpdateFloorRequest) Descriptor() ([]byte, []int) {
+	return file_incident_v1_location_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *UpdateFloorRequest) GetId() uint32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *UpdateFloorRequest) GetFloorNumber() int32 {
+	if x != nil {
+		return x.FloorNumber
+	}
+	return 0
+}
+
+func (x *UpdateFloorRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *UpdateFloorRequest) GetExpectedVersion() uint32 {
+	if x != nil && x.ExpectedVersion != nil {
+		return *x.ExpectedVersion
+	}
+	return 0
+}
+
+type DeleteFloorRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Id              uint32                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Cascade         bool                   `protobuf:"varint,2,opt,name=cascade,proto3" json:"cascade,omitempty"`
+	ExpectedVersion *uint32                `protobuf:"varint,3,opt,name=expected_version,json=expectedVersion,proto3,oneof" json:"expected_version,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *DeleteFloorRequest) Reset() {
+	*x = DeleteFloorRequest{}
+	mi := &file_incident_v1_location_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteFloorRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteFloorRequest) ProtoMessage() {}
+
+func (x *DeleteFloorRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_incident_v1_location_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteFloorRequest.ProtoReflect.Descriptor instead.
+func (*DeleteFloorRequest) Descriptor() ([]byte, []int) {
+	return file_incident_v1_location_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *DeleteFloorRequest) GetId() uint32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *DeleteFloorRequest) GetCascade() bool {
+	if x != nil {
+		return x.Cascade
+	}
+	return false
+}
+
+func (x *DeleteFloorRequest) GetExpectedVersion() uint32 {
+	if x != nil && x.ExpectedVersion != nil {
+		return *x.ExpectedVersion
+	}
+	return 0
+}
+
+type DeleteFloorResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteFloorResponse) Reset() {
+	*x = DeleteFloorResponse{}
+	mi := &file_incident_v1_location_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteFloorResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteFloorResponse) ProtoMessage() {}
+
+func (x *DeleteFloorResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_incident_v1_location_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteFloorResponse.ProtoReflect.Descriptor instead.
+func (*DeleteFloorResponse) Descriptor() ([]byte, []int) {
+	return file_incident_v1_location_proto_rawDescGZIP(), []int{16}
+}
+
+type CreateRoomRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	FloorId       uint32                 `protobuf:"varint,1,opt,name=floor_id,json=floorId,proto3" json:"floor_id,omitempty"`
+	Code          string                 `protobuf:"bytes,2,opt,name=code,proto3" json:"code,omitempty"`
+	Name          string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateRoomRequest) Reset() {
+	*x = CreateRoomRequest{}
+	mi := &file_incident_v1_location_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateRoomRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateRoomRequest) ProtoMessage() {}
+
+func (x *CreateRoomRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_incident_v1_location_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateRoomRequest.ProtoReflect.Descriptor instead.
+func (*CreateRoomRequest) Descriptor() ([]byte, []int) {
+	return file_incident_v1_location_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *CreateRoomRequest) GetFloorId() uint32 {
+	if x != nil {
+		return x.FloorId
+	}
+	return 0
+}
+
+func (x *CreateRoomRequest) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *CreateRoomRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type GetRoomRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            uint32                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetRoomRequest) Reset() {
+	*x = GetRoomRequest{}
+	mi := &file_incident_v1_location_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetRoomRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetRoomRequest) ProtoMessage() {}
+
+func (x *GetRoomRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_incident_v1_location_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetRoomRequest.ProtoReflect.Descriptor instead.
+func (*GetRoomRequest) Descriptor() ([]byte, []int) {
+	return file_incident_v1_location_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *GetRoomRequest) GetId() uint32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type ListRoomsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Only list the rooms of this floor
+	FloorId       uint32       `protobuf:"varint,1,opt,name=floor_id,json=floorId,proto3" json:"floor_id,omitempty"`
+	Page          *PageRequest `protobuf:"bytes,2,opt,name=page,proto3" json:"page,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListRoomsRequest) Reset() {
+	*x = ListRoomsRequest{}
+	mi := &file_incident_v1_location_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListRoomsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListRoomsRequest) ProtoMessage() {}
+
+func (x *ListRoomsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_incident_v1_location_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListRoomsRequest.ProtoReflect.Descriptor instead.
+func (*ListRoomsRequest) Descriptor() ([]byte, []int) {
+	return file_incident_v1_location_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *ListRoomsRequest) GetFloorId() uint32 {
+	if x != nil {
+		return x.FloorId
+	}
+	return 0
+}
+
+func (x *ListRoomsRequest) GetPage() *PageRequest {
+	if x != nil {
+		return x.Page
+	}
+	return nil
+}
+
+type ListRoomsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Rooms         []*Room                `protobuf:"bytes,1,rep,name=rooms,proto3" json:"rooms,omitempty"`
+	PageInfo      *PageInfo              `protobuf:"bytes,2,opt,name=page_info,json=pageInfo,proto3" json:"page_info,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListRoomsResponse) Reset() {
+	*x = ListRoomsResponse{}
+	mi := &file_incident_v1_location_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListRoomsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListRoomsResponse) ProtoMessage() {}
+
+func (x *ListRoomsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_incident_v1_location_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListRoomsResponse.ProtoReflect.Descriptor instead.
+func (*ListRoomsResponse) Descriptor() ([]byte, []int) {
+	return file_incident_v1_location_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *ListRoomsResponse) GetRooms() []*Room {
+	if x != nil {
+		return x.Rooms
+	}
+	return nil
+}
+
+func (x *ListRoomsResponse) GetPageInfo() *PageInfo {
+	if x != nil {
+		return x.PageInfo
+	}
+	return nil
+}
+
+type UpdateRoomRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Id              uint32                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Code            string                 `protobuf:"bytes,2,opt,name=code,proto3" json:"code,omitempty"`
+	Name            string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	ExpectedVersion *uint32                `protobuf:"varint,4,opt,name=expected_version,json=expectedVersion,proto3,oneof" json:"expected_version,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *UpdateRoomRequest) Reset() {
+	*x = UpdateRoomRequest{}
+	mi := &file_incident_v1_location_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateRoomRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateRoomRequest) ProtoMessage() {}
+
+func (x *UpdateRoomRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_incident_v1_location_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateRoomRequest.ProtoReflect.Descriptor instead.
+func (*UpdateRoomRequest) Descriptor() ([]byte, []int) {
+	return file_incident_v1_location_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *UpdateRoomRequest) GetId() uint32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *UpdateRoomRequest) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *UpdateRoomRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *UpdateRoomRequest) GetExpectedVersion() uint32 {
+	if x != nil && x.ExpectedVersion != nil {
+		return *x.ExpectedVersion
+	}
+	return 0
+}
+
+type DeleteRoomRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Id              uint32                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Cascade         bool                   `protobuf:"varint,2,opt,name=cascade,proto3" json:"cascade,omitempty"`
+	ExpectedVersion *uint32                `protobuf:"varint,3,opt,name=expected_version,json=expectedVersion,proto3,oneof" json:"expected_version,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *DeleteRoomRequest) Reset() {
+	*x = DeleteRoomRequest{}
+	mi := &file_incident_v1_location_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteRoomRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteRoomRequest) ProtoMessage() {}
+
+func (x *DeleteRoomRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_incident_v1_location_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteRoomRequest.ProtoReflect.Descriptor instead.
+func (*DeleteRoomRequest) Descriptor() ([]byte, []int) {
+	return file_incident_v1_location_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *DeleteRoomRequest) GetId() uint32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *DeleteRoomRequest) GetCascade() bool {
+	if x != nil {
+		return x.Cascade
+	}
+	return false
+}
+
+func (x *DeleteRoomRequest) GetExpectedVersion() uint32 {
+	if x != nil && x.ExpectedVersion != nil {
+		return *x.ExpectedVersion
+	}
+	return 0
+}
+
+type DeleteRoomResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteRoomResponse) Reset() {
+	*x = DeleteRoomResponse{}
+	mi := &file_incident_v1_location_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteRoomResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteRoomResponse) ProtoMessage() {}
+
+func (x *DeleteRoomResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_incident_v1_location_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteRoomResponse.ProtoReflect.Descriptor instead.
+func (*DeleteRoomResponse) Descriptor() ([]byte, []int) {
+	return file_incident_v1_location_proto_rawDescGZIP(), []int{23}
+}
+
+type GetTreeRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Only return this building
+	BuildingId uint32 `protobuf:"varint,1,opt,name=building_id,json=buildingId,proto3" json:"building_id,omitempty"`
+	// 1 returns buildings only, 2 adds floors and 3 (default) adds rooms
+	Depth                  int32 `protobuf:"varint,2,opt,name=depth,proto3" json:"depth,omitempty"`
+	IncludeComponentCount  bool  `protobuf:"varint,3,opt,name=include_component_count,json=includeComponentCount,proto3" json:"include_component_count,omitempty"`
+	IncludeOpenReportCount bool  `protobuf:"varint,4,opt,name=include_open_report_count,json=includeOpenReportCount,proto3" json:"include_open_report_count,omitempty"`
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *GetTreeRequest) Reset() {
+	*x = GetTreeRequest{}
+	mi := &file_incident_v1_location_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTreeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTreeRequest) ProtoMessage() {}
+
+func (x *GetTreeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_incident_v1_location_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTreeRequest.ProtoReflect.Descriptor instead.
+func (*GetTreeRequest) Descriptor() ([]byte, []int) {
+	return file_incident_v1_location_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *GetTreeRequest) GetBuildingId() uint32 {
+	if x != nil {
+		return x.BuildingId
+	}
+	return 0
+}
+
+func (x *GetTreeRequest) GetDepth() int32 {
+	if x != nil {
+		return x.Depth
+	}
+	return 0
+}
+
+func (x *GetTreeRequest) GetIncludeComponentCount() bool {
+	if x != nil {
+		return x.IncludeComponentCount
+	}
+	return false
+}
+
+func (x *GetTreeRequest) GetIncludeOpenReportCount() bool {
+	if x != nil {
+		return x.IncludeOpenReportCount
+	}
+	return false
+}
+
+type GetTreeResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Buildings     []*BuildingNode        `protobuf:"bytes,1,rep,name=buildings,proto3" json:"buildings,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTreeResponse) Reset() {
+	*x = GetTreeResponse{}
+	mi := &file_incident_v1_location_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTreeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTreeResponse) ProtoMessage() {}
+
+func (x *GetTreeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_incident_v1_location_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTreeResponse.ProtoReflect.Descriptor instead.
+func (*GetTreeResponse) Descriptor() ([]byte, []int) {
+	return file_incident_v1_location_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *GetTreeResponse) GetBuildings() []*BuildingNode {
+	if x != nil {
+		return x.Buildings
+	}
+	return nil
+}
+
+// BuildingNode is a building with its floors in the hierarchy tree
+type BuildingNode struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Id              uint32                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Code            string                 `protobuf:"bytes,2,opt,name=code,proto3" json:"code,omitempty"`
+	Name            string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	Location        string                 `protobuf:"bytes,4,opt,name=location,proto3" json:"location,omitempty"`
+	ComponentCount  *int64                 `protobuf:"varint,5,opt,name=component_count,json=componentCount,proto3,oneof" json:"component_count,omitempty"`
+	OpenReportCount *int64                 `protobuf:"varint,6,opt,name=open_report_count,json=openReportCount,proto3,oneof" json:"open_report_count,omitempty"`
+	Floors          []*FloorNode           `protobuf:"bytes,7,rep,name=floors,proto3" json:"floors,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *BuildingNode) Reset() {
+	*x = BuildingNode{}
+	mi := &file_incident_v1_location_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BuildingNode) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BuildingNode) ProtoMessage() {}
+
+func (x *BuildingNode) ProtoReflect() protoreflect.Message {
+	mi := &file_incident_v1_location_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BuildingNode.ProtoReflect.Descriptor instead.
+func (*BuildingNode) Descriptor() ([]byte, []int) {
+	return file_incident_v1_location_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *BuildingNode) GetId() uint32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *BuildingNode) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *BuildingNode) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *BuildingNode) GetLocation() string {
+	if x != nil {
+		return x.Location
+	}
+	return ""
+}
+
+func (x *BuildingNode) GetComponentCount() int64 {
+	if x != nil && x.ComponentCount != nil {
+		return *x.ComponentCount
+	}
+	return 0
+}
+
+func (x *BuildingNode) GetOpenReportCount() int64 {
+	if x != nil && x.OpenReportCount != nil {
+		return *x.OpenReportCount
+	}
+	return 0
+}
+
+func (x *BuildingNode) GetFloors() []*FloorNode {
+	if x != nil {
+		return x.Floors
+	}
+	return nil
+}
+
+// FloorNode is a floor with its rooms in the hierarchy tree
+type FloorNode struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Id              uint32                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	FloorNumber     int32                  `protobuf:"varint,2,opt,name=floor_number,json=floorNumber,proto3" json:"floor_number,omitempty"`
+	Name            string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	ComponentCount  *int64                 `protobuf:"varint,4,opt,name=component_count,json=componentCount,proto3,oneof" json:"component_count,omitempty"`
+	OpenReportCount *int64                 `protobuf:"varint,5,opt,name=open_report_count,json=openReportCount,proto3,oneof" json:"open_report_count,omitempty"`
+	Rooms           []*RoomNode            `protobuf:"bytes,6,rep,name=rooms,proto3" json:"rooms,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *FloorNode) Reset() {
+	*x = FloorNode{}
+	mi := &file_incident_v1_location_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FloorNode) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FloorNode) ProtoMessage() {}
+
+func (x *FloorNode) ProtoReflect() protoreflect.Message {
+	mi := &file_incident_v1_location_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FloorNode.ProtoReflect.Descriptor instead.
+func (*FloorNode) Descriptor() ([]byte, []int) {
+	return file_incident_v1_location_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *FloorNode) GetId() uint32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *FloorNode) GetFloorNumber() int32 {
+	if x != nil {
+		return x.FloorNumber
+	}
+	return 0
+}
+
+func (x *FloorNode) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *FloorNode) GetComponentCount() int64 {
+	if x != nil && x.ComponentCount != nil {
+		return *x.ComponentCount
+	}
+	return 0
+}
+
+func (x *FloorNode) GetOpenReportCount() int64 {
+	if x != nil && x.OpenReportCount != nil {
+		return *x.OpenReportCount
+	}
+	return 0
+}
+
+func (x *FloorNode) GetRooms() []*RoomNode {
+	if x != nil {
+		return x.Rooms
+	}
+	return nil
+}
+
+// RoomNode is a room in the hierarchy tree
+type RoomNode struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Id              uint32                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Code            string                 `protobuf:"bytes,2,opt,name=code,proto3" json:"code,omitempty"`
+	Name            string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	ComponentCount  *int64                 `protobuf:"varint,4,opt,name=component_count,json=componentCount,proto3,oneof" json:"component_count,omitempty"`
+	OpenReportCount *int64                 `protobuf:"varint,5,opt,name=open_report_count,json=openReportCount,proto3,oneof" json:"open_report_count,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *RoomNode) Reset() {
+	*x = RoomNode{}
+	mi := &file_incident_v1_location_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RoomNode) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RoomNode) ProtoMessage() {}
+
+func (x *RoomNode) ProtoReflect() protoreflect.Message {
+	mi := &file_incident_v1_location_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RoomNode.ProtoReflect.Descriptor instead.
+func (*RoomNode) Descriptor() ([]byte, []int) {
+	return file_incident_v1_location_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *RoomNode) GetId() uint32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *RoomNode) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *RoomNode) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *RoomNode) GetComponentCount() int64 {
+	if x != nil && x.ComponentCount != nil {
+		return *x.ComponentCount
+	}
+	return 0
+}
+
+func (x *RoomNode) GetOpenReportCount() int64 {
+	if x != nil && x.OpenReportCount != nil {
+		return *x.OpenReportCount
+	}
+	return 0
+}
+
+var File_incident_v1_location_proto protoreflect.FileDescriptor
+
+const file_incident_v1_location_proto_rawDesc = "" +
+	"\n" +
+	"\x1aincident/v1/location.proto\x12\vincident.v1\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x18incident/v1/common.proto\"\xee\x01\n" +
+	"\bBuilding\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\rR\x02id\x12\x12\n" +
+	"\x04code\x18\x02 \x01(\tR\x04code\x12\x12\n" +
+	"\x04name\x18\x03 \x01(\tR\x04name\x12\x1a\n" +
+	"\blocation\x18\x04 \x01(\tR\blocation\x129\n" +
+	"\n" +
+	"created_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x12\x18\n" +
+	"\aversion\x18\a \x01(\rR\aversion\"\xff\x01\n" +
+	"\x05Floor\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\rR\x02id\x12\x1f\n" +
+	"\vbuilding_id\x18\x02 \x01(\rR\n" +
+	"buildingId\x12!\n" +
+	"\ffloor_number\x18\x03 \x01(\x05R\vfloorNumber\x12\x12\n" +
+	"\x04name\x18\x04 \x01(\tR\x04name\x129\n" +
+	"\n" +
+	"created_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x12\x18\n" +
+	"\aversion\x18\a \x01(\rR\aversion\"\xe9\x01\n" +
+	"\x04Room\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\rR\x02id\x12\x19\n" +
+	"\bfloor_id\x18\x02 \x01(\rR\afloorId\x12\x12\n" +
+	"\x04code\x18\x03 \x01(\tR\x04code\x12\x12\n" +
+	"\x04name\x18\x04 \x01(\tR\x04name\x129\n" +
+	"\n" +
+	"created_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x12\x18\n" +
+	"\aversion\x18\a \x01(\rR\aversion\"[\n" +
+	"\x15CreateBuildingRequest\x12\x12\n" +
+	"\x04code\x18\x01 \x01(\tR\x04code\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x1a\n" +
+	"\blocation\x18\x03 \x01(\tR\blocation\"$\n" +
+	"\x12GetBuildingRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\rR\x02id\"D\n" +
+	"\x14ListBuildingsRequest\x12,\n" +
+	"\x04page\x18\x01 \x01(\v2\x18.incident.v1.PageRequestR\x04page\"\x80\x01\n" +
+	"\x15ListBuildingsResponse\x123\n" +
+	"\tbuildings\x18\x01 \x03(\v2\x15.incident.v1.BuildingR\tbuildings\x122\n" +
+	"\tpage_info\x18\x02 \x01(\v2\x15.incident.v1.PageInfoR\bpageInfo\"\xb0\x01\n" +
+	"\x15UpdateBuildingRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\rR\x02id\x12\x12\n" +
+	"\x04code\x18\x02 \x01(\tR\x04code\x12\x12\n" +
+	"\x04name\x18\x03 \x01(\tR\x04name\x12\x1a\n" +
+	"\blocation\x18\x04 \x01(\tR\blocation\x12.\n" +
+	"\x10expected_version\x18\x05 \x01(\rH\x00R\x0fexpectedVersion\x88\x01\x01B\x13\n" +
+	"\x11_expected_version\"\x86\x01\n" +
+	"\x15DeleteBuildingRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\rR\x02id\x12\x18\n" +
+	"\acascade\x18\x02 \x01(\bR\acascade\x12.\n" +
+	"\x10expected_version\x18\x03 \x01(\rH\x00R\x0fexpectedVersion\x88\x01\x01B\x13\n" +
+	"\x11_expected_version\"\x18\n" +
+	"\x16DeleteBuildingResponse\"l\n" +
+	"\x12CreateFloorRequest\x12\x1f\n" +
+	"\vbuilding_id\x18\x01 \x01(\rR\n" +
+	"buildingId\x12!\n" +
+	"\ffloor_number\x18\x02 \x01(\x05R\vfloorNumber\x12\x12\n" +
+	"\x04name\x18\x03 \x01(\tR\x04name\"!\n" +
+	"\x0fGetFloorRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\rR\x02id\"b\n" +
+	"\x11ListFloorsRequest\x12\x1f\n" +
+	"\vbuilding_id\x18\x01 \x01(\rR\n" +
+	"buildingId\x12,\n" +
+	"\x04page\x18\x02 \x01(\v2\x18.incident.v1.PageRequestR\x04page\"t\n" +
+	"\x12ListFloorsResponse\x12*\n" +
+	"\x06floors\x18\x01 \x03(\v2\x12.incident.v1.FloorR\x06floors\x122\n" +
+	"\tpage_info\x18\x02 \x01(\v2\x15.incident.v1.PageInfoR\bpageInfo\"\xa0\x01\n" +
+	"\x12UpdateFloorRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\rR\x02id\x12!\n" +
+	"\ffloor_number\x18\x02 \x01(\x05R\vfloorNumber\x12\x12\n" +
+	"\x04name\x18\x03 \x01(\tR\x04name\x12.\n" +
+	"\x10expected_version\x18\x04 \x01(\rH\x00R\x0fexpectedVersion\x88\x01\x01B\x13\n" +
+	"\x11_expected_version\"\x83\x01\n" +
+	"\x12DeleteFloorRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\rR\x02id\x12\x18\n" +
+	"\acascade\x18\x02 \x01(\bR\acascade\x12.\n" +
+	"\x10expected_version\x18\x03 \x01(\rH\x00R\x0fexpectedVersion\x88\x01\x01B\x13\n" +
+	"\x11_expected_version\"\x15\n" +
+	"\x13DeleteFloorResponse\"V\n" +
+	"\x11CreateRoomRequest\x12\x19\n" +
+	"\bfloor_id\x18\x01 \x01(\rR\afloorId\x12\x12\n" +
+	"\x04code\x18\x02 \x01(\tR\x04code\x12\x12\n" +
+	"\x04name\x18\x03 \x01(\tR\x04name\" \n" +
+	"\x0eGetRoomRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\rR\x02id\"[\n" +
+	"\x10ListRoomsRequest\x12\x19\n" +
+	"\bfloor_id\x18\x01 \x01(\rR\afloorId\x12,\n" +
+	"\x04page\x18\x02 \x01(\v2\x18.incident.v1.PageRequestR\x04page\"p\n" +
+	"\x11ListRoomsResponse\x12'\n" +
+	"\x05rooms\x18\x01 \x03(\v2\x11.incident.v1.RoomR\x05rooms\x122\n" +
+	"\tpage_info\x18\x02 \x01(\v2\x15.incident.v1.PageInfoR\bpageInfo\"\x90\x01\n" +
+	"\x11UpdateRoomRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\rR\x02id\x12\x12\n" +
+	"\x04code\x18\x02 \x01(\tR\x04code\x12\x12\n" +
+	"\x04name\x18\x03 \x01(\tR\x04name\x12.\n" +
+	"\x10expected_version\x18\x04 \x01(\rH\x00R\x0fexpectedVersion\x88\x01\x01B\x13\n" +
+	"\x11_expected_version\"\x82\x01\n" +
+	"\x11DeleteRoomRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\rR\x02id\x12\x18\n" +
+	"\acascade\x18\x02 \x01(\bR\acascade\x12.\n" +
+	"\x10expected_version\x18\x03 \x01(\rH\x00R\x0fexpectedVersion\x88\x01\x01B\x13\n" +
+	"\x11_expected_version\"\x14\n" +
+	"\x12DeleteRoomResponse\"\xba\x01\n" +
+	"\x0eGetTreeRequest\x12\x1f\n" +
+	"\vbuilding_id\x18\x01 \x01(\rR\n" +
+	"buildingId\x12\x14\n" +
+	"\x05depth\x18\x02 \x01(\x05R\x05depth\x126\n" +
+	"\x17include_component_count\x18\x03 \x01(\bR\x15includeComponentCount\x129\n" +
+	"\x19include_open_report_count\x18\x04 \x01(\bR\x16includeOpenReportCount\"J\n" +
+	"\x0fGetTreeResponse\x127\n" +
+	"\tbuildings\x18\x01 \x03(\v2\x19.incident.v1.BuildingNodeR\tbuildings\"\x9b\x02\n" +
+	"\fBuildingNode\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\rR\x02id\x12\x12\n" +
+	"\x04code\x18\x02 \x01(\tR\x04code\x12\x12\n" +
+	"\x04name\x18\x03 \x01(\tR\x04name\x12\x1a\n" +
+	"\blocation\x18\x04 \x01(\tR\blocation\x12,\n" +
+	"\x0fcomponent_count\x18\x05 \x01(\x03H\x00R\x0ecomponentCount\x88\x01\x01\x12/\n" +
+	"\x11open_report_count\x18\x06 \x01(\x03H\x01R\x0fopenReportCount\x88\x01\x01\x12.\n" +
+	"\x06floors\x18\a \x03(\v2\x16.incident.v1.FloorNodeR\x06floorsB\x12\n" +
+	"\x10_component_countB\x14\n" +
+	"\x12_open_report_count\"\x88\x02\n" +
+	"\tFloorNode\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\rR\x02id\x12!\n" +
+	"\ffloor_number\x18\x02 \x01(\x05R\vfloorNumber\x12\x12\n" +
+	"\x04name\x18\x03 \x01(\tR\x04name\x12,\n" +
+	"\x0fcomponent_count\x18\x04 \x01(\x03H\x00R\x0ecomponentCount\x88\x01\x01\x12/\n" +
+	"\x11open_report_count\x18\x05 \x01(\x03H\x01R\x0fopenReportCount\x88\x01\x01\x12+\n" +
+	"\x05rooms\x18\x06 \x03(\v2\x15.incident.v1.RoomNodeR\x05roomsB\x12\n" +
+	"\x10_component_countB\x14\n" +
+	"\x12_open_report_count\"\xcb\x01\n" +
+	"\bRoomNode\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\rR\x02id\x12\x12\n" +
+	"\x04code\x18\x02 \x01(\tR\x04code\x12\x12\n" +
+	"\x04name\x18\x03 \x01(\tR\x04name\x12,\n" +
+	"\x0fcomponent_count\x18\x04 \x01(\x03H\x00R\x0ecomponentCount\x88\x01\x01\x12/\n" +
+	"\x11open_report_count\x18\x05 \x01(\x03H\x01R\x0fopenReportCount\x88\x01\x01B\x12\n" +
+	"\x10_component_countB\x14\n" +
+	"\x12_open_report_count2\xaa\t\n" +
+	"\x0fLocationService\x12K\n" +
+	"\x0eCreateBuilding\x12\".incident.v1.CreateBuildingRequest\x1a\x15.incident.v1.Building\x12E\n" +
+	"\vGetBuilding\x12\x1f.incident.v1.GetBuildingRequest\x1a\x15.incident.v1.Building\x12V\n" +
+	"\rListBuildings\x12!.incident.v1.ListBuildingsRequest\x1a\".incident.v1.ListBuildingsResponse\x12K\n" +
+	"\x0eUpdateBuilding\x12\".incident.v1.UpdateBuildingRequest\x1a\x15.incident.v1.Building\x12Y\n" +
+	"\x0eDeleteBuilding\x12\".incident.v1.DeleteBuildingRequest\x1a#.incident.v1.DeleteBuildingResponse\x12B\n" +
+	"\vCreateFloor\x12\x1f.incident.v1.CreateFloorRequest\x1a\x12.incident.v1.Floor\x12<\n" +
+	"\bGetFloor\x12\x1c.incident.v1.GetFloorRequest\x1a\x12.incident.v1.Floor\x12M\n" +
+	"\n" +
+	"ListFloors\x12\x1e.incident.v1.ListFloorsRequest\x1a\x1f.incident.v1.ListFloorsResponse\x12B\n" +
+	"\vUpdateFloor\x12\x1f.incident.v1.UpdateFloorRequest\x1a\x12.incident.v1.Floor\x12P\n" +
+	"\vDeleteFloor\x12\x1f.incident.v1.DeleteFloorRequest\x1a .incident.v1.DeleteFloorResponse\x12?\n" +
+	"\n" +
+	"CreateRoom\x12\x1e.incident.v1.CreateRoomRequest\x1a\x11.incident.v1.Room\x129\n" +
+	"\aGetRoom\x12\x1b.incident.v1.GetRoomRequest\x1a\x11.incident.v1.Room\x12J\n" +
+	"\tListRooms\x12\x1d.incident.v1.ListRoomsRequest\x1a\x1e.incident.v1.ListRoomsResponse\x12?\n" +
+	"\n" +
+	"UpdateRoom\x12\x1e.incident.v1.UpdateRoomRequest\x1a\x11.incident.v1.Room\x12M\n" +
+	"\n" +
+	"DeleteRoom\x12\x1e.incident.v1.DeleteRoomRequest\x1a\x1f.incident.v1.DeleteRoomResponse\x12D\n" +
+	"\aGetTree\x12\x1b.incident.v1.GetTreeRequest\x1a\x1c.incident.v1.GetTreeResponseB.Z,incident-report/proto/incident/v1;incidentv1b\x06proto3"
+
+var (
+	file_incident_v1_location_proto_rawDescOnce sync.Once
+	file_incident_v1_location_proto_rawDescData []byte
+)
+
+func file_incident_v1_location_proto_rawDescGZIP() []byte {
+	file_incident_v1_location_proto_rawDescOnce.Do(func() {
+		file_incident_v1_location_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_incident_v1_location_proto_rawDesc), len(file_incident_v1_location_proto_rawDesc)))
+	})
+	return file_incident_v1_location_proto_rawDescData
+}
+
+var file_incident_v1_location_proto_msgTypes = make([]protoimpl.MessageInfo, 29)
+var file_incident_v1_location_proto_goTypes = []any{
+	(*Building)(nil),               // 0: incident.v1.Building
+	(*Floor)(nil),                  // 1: incident.v1.Floor
+	(*Room)(nil),                   // 2: incident.v1.Room
+	(*CreateBuildingRequest)(nil),  // 3: incident.v1.CreateBuildingRequest
+	(*GetBuildingRequest)(nil),     // 4: incident.v1.GetBuildingRequest
+	(*ListBuildingsRequest)(nil),   // 5: incident.v1.ListBuildingsRequest
+	(*ListBuildingsResponse)(nil),  // 6: incident.v1.ListBuildingsResponse
+	(*UpdateBuildingRequest)(nil),  // 7: incident.v1.UpdateBuildingRequest
+	(*DeleteBuildingRequest)(nil),  // 8: incident.v1.DeleteBuildingRequest
+	(*DeleteBuildingResponse)(nil), // 9: incident.v1.DeleteBuildingResponse
+	(*CreateFloorRequest)(nil),     // 10: incident.v1.CreateFloorRequest
+	(*GetFloorRequest)(nil),        // 11: incident.v1.GetFloorRequest
+	(*ListFloorsRequest)(nil),      // 12: incident.v1.ListFloorsRequest
+	(*ListFloorsResponse)(nil),     // 13: incident.v1.ListFloorsResponse
+	(*UpdateFloorRequest)(nil),     // 14: incident.v1.UpdateFloorRequest
+	(*DeleteFloorRequest)(nil),     // 15: incident.v1.DeleteFloorRequest
+	(*DeleteFloorResponse)(nil),    // 16: incident.v1.DeleteFloorResponse
+	(*CreateRoomRequest)(nil),      // 17: incident.v1.CreateRoomRequest
+	(*GetRoomRequest)(nil),         // 18: incident.v1.GetRoomRequest
+	(*ListRoomsRequest)(nil),       // 19: incident.v1.ListRoomsRequest
+	(*ListRoomsResponse)(nil),      // 20: incident.v1.ListRoomsResponse
+	(*UpdateRoomRequest)(nil),      // 21: incident.v1.UpdateRoomRequest
+	(*DeleteRoomRequest)(nil),      // 22: incident.v1.DeleteRoomRequest
+	(*DeleteRoomResponse)(nil),     // 23: incident.v1.DeleteRoomResponse
+	(*GetTreeRequest)(nil),         // 24: incident.v1.GetTreeRequest
+	(*GetTreeResponse)(nil),        // 25: incident.v1.GetTreeResponse
+	(*BuildingNode)(nil),           // 26: incident.v1.BuildingNode
+	(*FloorNode)(nil),              // 27: incident.v1.FloorNode
+	(*RoomNode)(nil),               // 28: incident.v1.RoomNode
+	(*timestamppb.Timestamp)(nil),  // 29: google.protobuf.Timestamp
+	(*PageRequest)(nil),            // 30: incident.v1.PageRequest
+	(*PageInfo)(nil),               // 31: incident.v1.PageInfo
+}
+var file_incident_v1_location_proto_depIdxs = []int32{
+	29, // 0: incident.v1.Building.created_at:type_name -> google.protobuf.Timestamp
+	29, // 1: incident.v1.Building.updated_at:type_name -> google.protobuf.Timestamp
+	29, // 2: incident.v1.Floor.created_at:type_name -> google.protobuf.Timestamp
+	29, // 3: incident.v1.Floor.updated_at:type_name -> google.protobuf.Timestamp
+	29, // 4: incident.v1.Room.created_at:type_name -> google.protobuf.Timestamp
+	29, // 5: incident.v1.Room.updated_at:type_name -> google.protobuf.Timestamp
+	30, // 6: incident.v1.ListBuildingsRequest.page:type_name -> incident.v1.PageRequest
+	0,  // 7: incident.v1.ListBuildingsResponse.buildings:type_name -> incident.v1.Building
+	31, // 8: incident.v1.ListBuildingsResponse.page_info:type_name -> incident.v1.PageInfo
+	30, // 9: incident.v1.ListFloorsRequest.page:type_name -> incident.v1.PageRequest
+	1,  // 10: incident.v1.ListFloorsResponse.floors:type_name -> incident.v1.Floor
+	31, // 11: incident.v1.ListFloorsResponse.page_info:type_name -> incident.v1.PageInfo
+	30, // 12: incident.v1.ListRoomsRequest.page:type_name -> incident.v1.PageRequest
+	2,  // 13: incident.v1.ListRoomsResponse.rooms:type_name -> incident.v1.Room
+	31, // 14: incident.v1.ListRoomsResponse.page_info:type_name -> incident.v1.PageInfo
+	26, // 15: incident.v1.GetTreeResponse.buildings:type_name -> incident.v1.BuildingNode
+	27, // 16: incident.v1.BuildingNode.floors:type_name -> incident.v1.FloorNode
+	28, // 17: incident.v1.FloorNode.rooms:type_name -> incident.v1.RoomNode
+	3,  // 18: incident.v1.LocationService.CreateBuilding:input_type -> incident.v1.CreateBuildingRequest
+	4,  // 19: incident.v1.LocationService.GetBuilding:input_type -> incident.v1.GetBuildingRequest
+	5,  // 20: incident.v1.LocationService.ListBuildings:input_type -> incident.v1.ListBuildingsRequest
+	7,  // 21: incident.v1.LocationService.UpdateBuilding:input_type -> incident.v1.UpdateBuildingRequest
+	8,  // 22: incident.v1.LocationService.DeleteBuilding:input_type -> incident.v1.DeleteBuildingRequest
+	10, // 23: incident.v1.LocationService.CreateFloor:input_type -> incident.v1.CreateFloorRequest
+	11, // 24: incident.v1.LocationService.GetFloor:input_type -> incident.v1.GetFloorRequest
+	12, // 25: incident.v1.LocationService.ListFloors:input_type -> incident.v1.ListFloorsRequest
+	14, // 26: incident.v1.LocationService.UpdateFloor:input_type -> incident.v1.UpdateFloorRequest
+	15, // 27: incident.v1.LocationService.DeleteFloor:input_type -> incident.v1.DeleteFloorRequest
+	17, // 28: incident.v1.LocationService.CreateRoom:input_type -> incident.v1.CreateRoomRequest
+	18, // 29: incident.v1.LocationService.GetRoom:input_type -> incident.v1.GetRoomRequest
+	19, // 30: incident.v1.LocationService.ListRooms:input_type -> incident.v1.ListRoomsRequest
+	21, // 31: incident.v1.LocationService.UpdateRoom:input_type -> incident.v1.UpdateRoomRequest
+	22, // 32: incident.v1.LocationService.DeleteRoom:input_type -> incident.v1.DeleteRoomRequest
+	24, // 33: incident.v1.LocationService.GetTree:input_type -> incident.v1.GetTreeRequest
+	0,  // 34: incident.v1.LocationService.CreateBuilding:output_type -> incident.v1.Building
+	0,  // 35: incident.v1.LocationService.GetBuilding:output_type -> incident.v1.Building
+	6,  // 36: incident.v1.LocationService.ListBuildings:output_type -> incident.v1.ListBuildingsResponse
+	0,  // 37: incident.v1.LocationService.UpdateBuilding:output_type -> incident.v1.Building
+	9,  // 38: incident.v1.LocationService.DeleteBuilding:output_type -> incident.v1.DeleteBuildingResponse
+	1,  // 39: incident.v1.LocationService.CreateFloor:output_type -> incident.v1.Floor
+	1,  // 40: incident.v1.LocationService.GetFloor:output_type -> incident.v1.Floor
+	13, // 41: incident.v1.LocationService.ListFloors:output_type -> incident.v1.ListFloorsResponse
+	1,  // 42: incident.v1.LocationService.UpdateFloor:output_type -> incident.v1.Floor
+	16, // 43: incident.v1.LocationService.DeleteFloor:output_type -> incident.v1.DeleteFloorResponse
+	2,  // 44: incident.v1.LocationService.CreateRoom:output_type -> incident.v1.Room
+	2,  // 45: incident.v1.LocationService.GetRoom:output_type -> incident.v1.Room
+	20, // 46: incident.v1.LocationService.ListRooms:output_type -> incident.v1.ListRoomsResponse
+	2,  // 47: incident.v1.LocationService.UpdateRoom:output_type -> incident.v1.Room
+	23, // 48: incident.v1.LocationService.DeleteRoom:output_type -> incident.v1.DeleteRoomResponse
+	25, // 49: incident.v1.LocationService.GetTree:output_type -> incident.v1.GetTreeResponse
+	34, // [34:50] is the sub-list for method output_type
+	18, // [18:34] is the sub-list for method input_type
+	18, // [18:18] is the sub-list for extension type_name
+	18, // [18:18] is the sub-list for extension extendee
+	0,  // [0:18] is the sub-list for field type_name
+}
+
+func init() { file_incident_v1_location_proto_init() }
+func file_incident_v1_location_proto_init() {
+	if File_incident_v1_location_proto != nil {
+		return
+	}
+	file_incident_v1_common_proto_init()
+	file_incident_v1_location_proto_msgTypes[7].OneofWrappers = []any{}
+	file_incident_v1_location_proto_msgTypes[8].OneofWrappers = []any{}
+	file_incident_v1_location_proto_msgTypes[14].OneofWrappers = []any{}
+	file_incident_v1_location_proto_msgTypes[15].OneofWrappers = []any{}
+	file_incident_v1_location_proto_msgTypes[21].OneofWrappers = []any{}
+	file_incident_v1_location_proto_msgTypes[22].OneofWrappers = []any{}
+	file_incident_v1_location_proto_msgTypes[26].OneofWrappers = []any{}
+	file_incident_v1_location_proto_msgTypes[27].OneofWrappers = []any{}
+	file_incident_v1_location_proto_msgTypes[28].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_incident_v1_location_proto_rawDesc), len(file_incident_v1_location_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   29,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_incident_v1_location_proto_goTypes,
+		DependencyIndexes: file_incident_v1_location_proto_depIdxs,
+		MessageInfos:      file_incident_v1_location_proto_msgTypes,
+	}.Build()
+	File_incident_v1_location_proto = out.File
+	file_incident_v1_location_proto_goTypes = nil
+	file_incident_v1_location_proto_depIdxs = nil
+}
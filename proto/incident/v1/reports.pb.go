@@ -0,0 +1,1450 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        (unknown)
+// source: incident/v1/reports.proto
+
+package incidentv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type ReportStatus int32
+
+const (
+	ReportStatus_REPORT_STATUS_UNSPECIFIED ReportStatus = 0
+	ReportStatus_REPORT_STATUS_PENDING     ReportStatus = 1
+	ReportStatus_REPORT_STATUS_IN_PROGRESS ReportStatus = 2
+	ReportStatus_REPORT_STATUS_COMPLETED   ReportStatus = 3
+)
+
+// Enum value maps for ReportStatus.
+var (
+	ReportStatus_name = map[int32]string{
+		0: "REPORT_STATUS_UNSPECIFIED",
+		1: "REPORT_STATUS_PENDING",
+		2: "REPORT_STATUS_IN_PROGRESS",
+		3: "REPORT_STATUS_COMPLETED",
+	}
+	ReportStatus_value = map[string]int32{
+		"REPORT_STATUS_UNSPECIFIED": 0,
+		"REPORT_STATUS_PENDING":     1,
+		"REPORT_STATUS_IN_PROGRESS": 2,
+		"REPORT_STATUS_COMPLETED":   3,
+	}
+)
+
+func (x ReportStatus) Enum() *ReportStatus {
+	p := new(ReportStatus)
+	*p = x
+	return p
+}
+
+func (x ReportStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ReportStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_incident_v1_reports_proto_enumTypes[0].Descriptor()
+}
+
+func (ReportStatus) Type() protoreflect.EnumType {
+	return &file_incident_v1_reports_proto_enumTypes[0]
+}
+
+func (x ReportStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ReportStatus.Descriptor instead.
+func (ReportStatus) EnumDescriptor() ([]byte, []int) {
+	return file_incident_v1_reports_proto_rawDescGZIP(), []int{0}
+}
+
+type TagMatch int32
+
+const (
+	// Unspecified matches any
+	TagMatch_TAG_MATCH_UNSPECIFIED TagMatch = 0
+	TagMatch_TAG_MATCH_ANY         TagMatch = 1
+	TagMatch_TAG_MATCH_ALL         TagMatch = 2
+)
+
+// Enum value maps for TagMatch.
+var (
+	TagMatch_name = map[int32]string{
+		0: "TAG_MATCH_UNSPECIFIED",
+		1: "TAG_MATCH_ANY",
+		2: "TAG_MATCH_ALL",
+	}
+	TagMatch_value = map[string]int32{
+		"TAG_MATCH_UNSPECIFIED": 0,
+		"TAG_MATCH_ANY":         1,
+		"TAG_MATCH_ALL":         2,
+	}
+)
+
+func (x TagMatch) Enum() *TagMatch {
+	p := new(TagMatch)
+	*p = x
+	return p
+}
+
+func (x TagMatch) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (TagMatch) Descriptor() protoreflect.EnumDescriptor {
+	return file_incident_v1_reports_proto_enumTypes[1].Descriptor()
+}
+
+func (TagMatch) Type() protoreflect.EnumType {
+	return &file_incident_v1_reports_proto_enumTypes[1]
+}
+
+func (x TagMatch) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use TagMatch.Descriptor instead.
+func (TagMatch) EnumDescriptor() ([]byte, []int) {
+	return file_incident_v1_reports_proto_rawDescGZIP(), []int{1}
+}
+
+type BulkReportAction int32
+
+const (
+	BulkReportAction_BULK_REPORT_ACTION_UNSPECIFIED BulkReportAction = 0
+	BulkReportAction_BULK_REPORT_ACTION_ASSIGN      BulkReportAction = 1
+	BulkReportAction_BULK_REPORT_ACTION_TRANSITION  BulkReportAction = 2
+	BulkReportAction_BULK_REPORT_ACTION_TAG         BulkReportAction = 3
+	BulkReportAction_BULK_REPORT_ACTION_DELETE      BulkReportAction = 4
+	BulkReportAction_BULK_REPORT_ACTION_UNASSIGN    BulkReportAction = 5
+)
+
+// Enum value maps for BulkReportAction.
+var (
+	BulkReportAction_name = map[int32]string{
+		0: "BULK_REPORT_ACTION_UNSPECIFIED",
+		1: "BULK_REPORT_ACTION_ASSIGN",
+		2: "BULK_REPORT_ACTION_TRANSITION",
+		3: "BULK_REPORT_ACTION_TAG",
+		4: "BULK_REPORT_ACTION_DELETE",
+		5: "BULK_REPORT_ACTION_UNASSIGN",
+	}
+	BulkReportAction_value = map[string]int32{
+		"BULK_REPORT_ACTION_UNSPECIFIED": 0,
+		"BULK_REPORT_ACTION_ASSIGN":      1,
+		"BULK_REPORT_ACTION_TRANSITION":  2,
+		"BULK_REPORT_ACTION_TAG":         3,
+		"BULK_REPORT_ACTION_DELETE":      4,
+		"BULK_REPORT_ACTION_UNASSIGN":    5,
+	}
+)
+
+func (x BulkReportAction) Enum() *BulkReportAction {
+	p := new(BulkReportAction)
+	*p = x
+	return p
+}
+
+func (x BulkReportAction) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (BulkReportAction) Descriptor() protoreflect.EnumDescriptor {
+	return file_incident_v1_reports_proto_enumTypes[2].Descriptor()
+}
+
+func (BulkReportAction) Type() protoreflect.EnumType {
+	return &file_incident_v1_reports_proto_enumTypes[2]
+}
+
+func (x BulkReportAction) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use BulkReportAction.Descriptor instead.
+func (BulkReportAction) EnumDescriptor() ([]byte, []int) {
+	return file_incident_v1_reports_proto_rawDescGZIP(), []int{2}
+}
+
+type Tag struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            uint32                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Color         string                 `protobuf:"bytes,3,opt,name=color,proto3" json:"color,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Tag) Reset() {
+	*x = Tag{}
+	mi := &file_incident_v1_reports_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Tag) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Tag) ProtoMessage() {}
+
+func (x *Tag) ProtoReflect() protoreflect.Message {
+	mi := &file_incident_v1_reports_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Tag.ProtoReflect.Descriptor instead.
+func (*Tag) Descriptor() ([]byte, []int) {
+	return file_incident_v1_reports_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Tag) GetId() uint32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Tag) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Tag) GetColor() string {
+	if x != nil {
+		return x.Color
+	}
+	return ""
+}
+
+type Report struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Id     uint32                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name   string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	RoomId uint32                 `protobuf:"varint,3,opt,name=room_id,json=roomId,proto3" json:"room_id,omitempty"`
+	// Unset for an unassigned report
+	UserId        *uint32                `protobuf:"varint,4,opt,name=user_id,json=userId,proto3,oneof" json:"user_id,omitempty"`
+	ComponentId   uint32                 `protobuf:"varint,5,opt,name=component_id,json=componentId,proto3" json:"component_id,omitempty"`
+	Status        ReportStatus           `protobuf:"varint,6,opt,name=status,proto3,enum=incident.v1.ReportStatus" json:"status,omitempty"`
+	RepairCost    float64                `protobuf:"fixed64,7,opt,name=repair_cost,json=repairCost,proto3" json:"repair_cost,omitempty"`
+	Tags          []*Tag                 `protobuf:"bytes,8,rep,name=tags,proto3" json:"tags,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	CompletedAt   *timestamppb.Timestamp `protobuf:"bytes,11,opt,name=completed_at,json=completedAt,proto3" json:"completed_at,omitempty"`
+	Version       uint32                 `protobuf:"varint,12,opt,name=version,proto3" json:"version,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Report) Reset() {
+	*x = Report{}
+	mi := &file_incident_v1_reports_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Report) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Report) ProtoMessage() {}
+
+func (x *Report) ProtoReflect() protoreflect.Message {
+	mi := &file_incident_v1_reports_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Report.ProtoReflect.Descriptor instead.
+func (*Report) Descriptor() ([]byte, []int) {
+	return file_incident_v1_reports_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Report) GetId() uint32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Report) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Report) GetRoomId() uint32 {
+	if x != nil {
+		return x.RoomId
+	}
+	return 0
+}
+
+func (x *Report) GetUserId() uint32 {
+	if x != nil && x.UserId != nil {
+		return *x.UserId
+	}
+	return 0
+}
+
+func (x *Report) GetComponentId() uint32 {
+	if x != nil {
+		return x.ComponentId
+	}
+	return 0
+}
+
+func (x *Report) GetStatus() ReportStatus {
+	if x != nil {
+		return x.Status
+	}
+	return ReportStatus_REPORT_STATUS_UNSPECIFIED
+}
+
+func (x *Report) GetRepairCost() float64 {
+	if x != nil {
+		return x.RepairCost
+	}
+	return 0
+}
+
+func (x *Report) GetTags() []*Tag {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *Report) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Report) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+func (x *Report) GetCompletedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CompletedAt
+	}
+	return nil
+}
+
+func (x *Report) GetVersion() uint32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+type CreateReportRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	RoomId        uint32                 `protobuf:"varint,2,opt,name=room_id,json=roomId,proto3" json:"room_id,omitempty"`
+	UserId        *uint32                `protobuf:"varint,3,opt,name=user_id,json=userId,proto3,oneof" json:"user_id,omitempty"`
+	ComponentId   uint32                 `protobuf:"varint,4,opt,name=component_id,json=componentId,proto3" json:"component_id,omitempty"`
+	Status        ReportStatus           `protobuf:"varint,5,opt,name=status,proto3,enum=incident.v1.ReportStatus" json:"status,omitempty"`
+	RepairCost    float64                `protobuf:"fixed64,6,opt,name=repair_cost,json=repairCost,proto3" json:"repair_cost,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateReportRequest) Reset() {
+	*x = CreateReportRequest{}
+	mi := &file_incident_v1_reports_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateReportRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateReportRequest) ProtoMessage() {}
+
+func (x *CreateReportRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_incident_v1_reports_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateReportRequest.ProtoReflect.Descriptor instead.
+func (*CreateReportRequest) Descriptor() ([]byte, []int) {
+	return file_incident_v1_reports_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *CreateReportRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CreateReportRequest) GetRoomId() uint32 {
+	if x != nil {
+		return x.RoomId
+	}
+	return 0
+}
+
+func (x *CreateReportRequest) GetUserId() uint32 {
+	if x != nil && x.UserId != nil {
+		return *x.UserId
+	}
+	return 0
+}
+
+func (x *CreateReportRequest) GetComponentId() uint32 {
+	if x != nil {
+		return x.ComponentId
+	}
+	return 0
+}
+
+func (x *CreateReportRequest) GetStatus() ReportStatus {
+	if x != nil {
+		return x.Status
+	}
+	return ReportStatus_REPORT_STATUS_UNSPECIFIED
+}
+
+func (x *CreateReportRequest) GetRepairCost() float64 {
+	if x != nil {
+		return x.RepairCost
+	}
+	return 0
+}
+
+type GetReportRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            uint32                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetReportRequest) Reset() {
+	*x = GetReportRequest{}
+	mi := &file_incident_v1_reports_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetReportRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetReportRequest) ProtoMessage() {}
+
+func (x *GetReportRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_incident_v1_reports_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetReportRequest.ProtoReflect.Descriptor instead.
+func (*GetReportRequest) Descriptor() ([]byte, []int) {
+	return file_incident_v1_reports_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *GetReportRequest) GetId() uint32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+// ReportFilter selects reports; unset fields match every report
+type ReportFilter struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Status      ReportStatus           `protobuf:"varint,1,opt,name=status,proto3,enum=incident.v1.ReportStatus" json:"status,omitempty"`
+	RoomId      uint32                 `protobuf:"varint,2,opt,name=room_id,json=roomId,proto3" json:"room_id,omitempty"`
+	UserId      uint32                 `protobuf:"varint,3,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	ComponentId uint32                 `protobuf:"varint,4,opt,name=component_id,json=componentId,proto3" json:"component_id,omitempty"`
+	// Tag names; tag_match selects reports having any (default) or all of them
+	Tags          []string `protobuf:"bytes,5,rep,name=tags,proto3" json:"tags,omitempty"`
+	TagMatch      TagMatch `protobuf:"varint,6,opt,name=tag_match,json=tagMatch,proto3,enum=incident.v1.TagMatch" json:"tag_match,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReportFilter) Reset() {
+	*x = ReportFilter{}
+	mi := &file_incident_v1_reports_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReportFilter) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReportFilter) ProtoMessage() {}
+
+func (x *ReportFilter) ProtoReflect() protoreflect.Message {
+	mi := &file_incident_v1_reports_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReportFilter.ProtoReflect.Descriptor instead.
+func (*ReportFilter) Descriptor() ([]byte, []int) {
+	return file_incident_v1_reports_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ReportFilter) GetStatus() ReportStatus {
+	if x != nil {
+		return x.Status
+	}
+	return ReportStatus_REPORT_STATUS_UNSPECIFIED
+}
+
+func (x *ReportFilter) GetRoomId() uint32 {
+	if x != nil {
+		return x.RoomId
+	}
+	return 0
+}
+
+func (x *ReportFilter) GetUserId() uint32 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *ReportFilter) GetComponentId() uint32 {
+	if x != nil {
+		return x.ComponentId
+	}
+	return 0
+}
+
+func (x *ReportFilter) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *ReportFilter) GetTagMatch() TagMatch {
+	if x != nil {
+		return x.TagMatch
+	}
+	return TagMatch_TAG_MATCH_UNSPECIFIED
+}
+
+type ListReportsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Filter        *ReportFilter          `protobuf:"bytes,1,opt,name=filter,proto3" json:"filter,omitempty"`
+	Page          *PageRequest           `protobuf:"bytes,2,opt,name=page,proto3" json:"page,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListReportsRequest) Reset() {
+	*x = ListReportsRequest{}
+	mi := &file_incident_v1_reports_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListReportsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListReportsRequest) ProtoMessage() {}
+
+func (x *ListReportsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_incident_v1_reports_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListReportsRequest.ProtoReflect.Descriptor instead.
+func (*ListReportsRequest) Descriptor() ([]byte, []int) {
+	return file_incident_v1_reports_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ListReportsRequest) GetFilter() *ReportFilter {
+	if x != nil {
+		return x.Filter
+	}
+	return nil
+}
+
+func (x *ListReportsRequest) GetPage() *PageRequest {
+	if x != nil {
+		return x.Page
+	}
+	return nil
+}
+
+type ListReportsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Reports       []*Report              `protobuf:"bytes,1,rep,name=reports,proto3" json:"reports,omitempty"`
+	PageInfo      *PageInfo              `protobuf:"bytes,2,opt,name=page_info,json=pageInfo,proto3" json:"page_info,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListReportsResponse) Reset() {
+	*x = ListReportsResponse{}
+	mi := &file_incident_v1_reports_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListReportsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListReportsResponse) ProtoMessage() {}
+
+func (x *ListReportsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_incident_v1_reports_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListReportsResponse.ProtoReflect.Descriptor instead.
+func (*ListReportsResponse) Descriptor() ([]byte, []int) {
+	return file_incident_v1_reports_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ListReportsResponse) GetReports() []*Report {
+	if x != nil {
+		return x.Reports
+	}
+	return nil
+}
+
+func (x *ListReportsResponse) GetPageInfo() *PageInfo {
+	if x != nil {
+		return x.PageInfo
+	}
+	return nil
+}
+
+// UpdateReportRequest changes the fields that are set; empty values are left unchanged, like PUT
+type UpdateReportRequest struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Id          uint32                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name        string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	RoomId      uint32                 `protobuf:"varint,3,opt,name=room_id,json=roomId,proto3" json:"room_id,omitempty"`
+	ComponentId uint32                 `protobuf:"varint,4,opt,name=component_id,json=componentId,proto3" json:"component_id,omitempty"`
+	Status      ReportStatus           `protobuf:"varint,5,opt,name=status,proto3,enum=incident.v1.ReportStatus" json:"status,omitempty"`
+	RepairCost  *float64               `protobuf:"fixed64,6,opt,name=repair_cost,json=repairCost,proto3,oneof" json:"repair_cost,omitempty"`
+	// Only update the report if it is at this version, like If-Match
+	ExpectedVersion *uint32 `protobuf:"varint,7,opt,name=expected_version,json=expectedVersion,proto3,oneof" json:"expected_version,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *UpdateReportRequest) Reset() {
+	*x = UpdateReportRequest{}
+	mi := &file_incident_v1_reports_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateReportRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateReportRequest) ProtoMessage() {}
+
+func (x *UpdateReportRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_incident_v1_reports_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateReportRequest.ProtoReflect.Descriptor instead.
+func (*UpdateReportRequest) Descriptor() ([]byte, []int) {
+	return file_incident_v1_reports_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *UpdateReportRequest) GetId() uint32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *UpdateReportRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *UpdateReportRequest) GetRoomId() uint32 {
+	if x != nil {
+		return x.RoomId
+	}
+	return 0
+}
+
+func (x *UpdateReportRequest) GetComponentId() uint32 {
+	if x != nil {
+		return x.ComponentId
+	}
+	return 0
+}
+
+func (x *UpdateReportRequest) GetStatus() ReportStatus {
+	if x != nil {
+		return x.Status
+	}
+	return ReportStatus_REPORT_STATUS_UNSPECIFIED
+}
+
+func (x *UpdateReportRequest) GetRepairCost() float64 {
+	if x != nil && x.RepairCost != nil {
+		return *x.RepairCost
+	}
+	return 0
+}
+
+func (x *UpdateReportRequest) GetExpectedVersion() uint32 {
+	if x != nil && x.ExpectedVersion != nil {
+		return *x.ExpectedVersion
+	}
+	return 0
+}
+
+type DeleteReportRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Id              uint32                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	ExpectedVersion *uint32                `protobuf:"varint,2,opt,name=expected_version,json=expectedVersion,proto3,oneof" json:"expected_version,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *DeleteReportRequest) Reset() {
+	*x = DeleteReportRequest{}
+	mi := &file_incident_v1_reports_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteReportRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteReportRequest) ProtoMessage() {}
+
+func (x *DeleteReportRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_incident_v1_reports_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteReportRequest.ProtoReflect.Descriptor instead.
+func (*DeleteReportRequest) Descriptor() ([]byte, []int) {
+	return file_incident_v1_reports_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *DeleteReportRequest) GetId() uint32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *DeleteReportRequest) GetExpectedVersion() uint32 {
+	if x != nil && x.ExpectedVersion != nil {
+		return *x.ExpectedVersion
+	}
+	return 0
+}
+
+type DeleteReportResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteReportResponse) Reset() {
+	*x = DeleteReportResponse{}
+	mi := &file_incident_v1_reports_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteReportResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteReportResponse) ProtoMessage() {}
+
+func (x *DeleteReportResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_incident_v1_reports_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteReportResponse.ProtoReflect.Descriptor instead.
+func (*DeleteReportResponse) Descriptor() ([]byte, []int) {
+	return file_incident_v1_reports_proto_rawDescGZIP(), []int{9}
+}
+
+type AssignUserRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Id              uint32                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	UserId          uint32                 `protobuf:"varint,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	ExpectedVersion *uint32                `protobuf:"varint,3,opt,name=expected_version,json=expectedVersion,proto3,oneof" json:"expected_version,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *AssignUserRequest) Reset() {
+	*x = AssignUserRequest{}
+	mi := &file_incident_v1_reports_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AssignUserRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AssignUserRequest) ProtoMessage() {}
+
+func (x *AssignUserRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_incident_v1_reports_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AssignUserRequest.ProtoReflect.Descriptor instead.
+func (*AssignUserRequest) Descriptor() ([]byte, []int) {
+	return file_incident_v1_reports_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *AssignUserRequest) GetId() uint32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *AssignUserRequest) GetUserId() uint32 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *AssignUserRequest) GetExpectedVersion() uint32 {
+	if x != nil && x.ExpectedVersion != nil {
+		return *x.ExpectedVersion
+	}
+	return 0
+}
+
+type UnassignUserRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Id              uint32                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	ExpectedVersion *uint32                `protobuf:"varint,2,opt,name=expected_version,json=expectedVersion,proto3,oneof" json:"expected_version,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *UnassignUserRequest) Reset() {
+	*x = UnassignUserRequest{}
+	mi := &file_incident_v1_reports_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UnassignUserRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnassignUserRequest) ProtoMessage() {}
+
+func (x *UnassignUserRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_incident_v1_reports_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UnassignUserRequest.ProtoReflect.Descriptor instead.
+func (*UnassignUserRequest) Descriptor() ([]byte, []int) {
+	return file_incident_v1_reports_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *UnassignUserRequest) GetId() uint32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *UnassignUserRequest) GetExpectedVersion() uint32 {
+	if x != nil && x.ExpectedVersion != nil {
+		return *x.ExpectedVersion
+	}
+	return 0
+}
+
+type AddTagsRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Id              uint32                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	TagIds          []uint32               `protobuf:"varint,2,rep,packed,name=tag_ids,json=tagIds,proto3" json:"tag_ids,omitempty"`
+	ExpectedVersion *uint32                `protobuf:"varint,3,opt,name=expected_version,json=expectedVersion,proto3,oneof" json:"expected_version,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *AddTagsRequest) Reset() {
+	*x = AddTagsRequest{}
+	mi := &file_incident_v1_reports_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddTagsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddTagsRequest) ProtoMessage() {}
+
+func (x *AddTagsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_incident_v1_reports_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddTagsRequest.ProtoReflect.Descriptor instead.
+func (*AddTagsRequest) Descriptor() ([]byte, []int) {
+	return file_incident_v1_reports_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *AddTagsRequest) GetId() uint32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *AddTagsRequest) GetTagIds() []uint32 {
+	if x != nil {
+		return x.TagIds
+	}
+	return nil
+}
+
+func (x *AddTagsRequest) GetExpectedVersion() uint32 {
+	if x != nil && x.ExpectedVersion != nil {
+		return *x.ExpectedVersion
+	}
+	return 0
+}
+
+type RemoveTagRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Id              uint32                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	TagId           uint32                 `protobuf:"varint,2,opt,name=tag_id,json=tagId,proto3" json:"tag_id,omitempty"`
+	ExpectedVersion *uint32                `protobuf:"varint,3,opt,name=expected_version,json=expectedVersion,proto3,oneof" json:"expected_version,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *RemoveTagRequest) Reset() {
+	*x = RemoveTagRequest{}
+	mi := &file_incident_v1_reports_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RemoveTagRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoveTagRequest) ProtoMessage() {}
+
+func (x *RemoveTagRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_incident_v1_reports_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoveTagRequest.ProtoReflect.Descriptor instead.
+func (*RemoveTagRequest) Descriptor() ([]byte, []int) {
+	return file_incident_v1_reports_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *RemoveTagRequest) GetId() uint32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *RemoveTagRequest) GetTagId() uint32 {
+	if x != nil {
+		return x.TagId
+	}
+	return 0
+}
+
+func (x *RemoveTagRequest) GetExpectedVersion() uint32 {
+	if x != nil && x.ExpectedVersion != nil {
+		return *x.ExpectedVersion
+	}
+	return 0
+}
+
+// BulkReportsRequest applies one action to the reports selected by ID or by filter
+type BulkReportsRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Action BulkReportAction       `protobuf:"varint,1,opt,name=action,proto3,enum=incident.v1.BulkReportAction" json:"action,omitempty"`
+	Ids    []uint32               `protobuf:"varint,2,rep,packed,name=ids,proto3" json:"ids,omitempty"`
+	Filter *ReportFilter          `protobuf:"bytes,3,opt,name=filter,proto3" json:"filter,omitempty"`
+	Mode   BulkMode               `protobuf:"varint,4,opt,name=mode,proto3,enum=incident.v1.BulkMode" json:"mode,omitempty"`
+	// User to assign to, required by BULK_REPORT_ACTION_ASSIGN
+	UserId *uint32 `protobuf:"varint,5,opt,name=user_id,json=userId,proto3,oneof" json:"user_id,omitempty"`
+	// Status to transition to
+	Status ReportStatus `protobuf:"varint,6,opt,name=status,proto3,enum=incident.v1.ReportStatus" json:"status,omitempty"`
+	// Tags to attach
+	TagIds        []uint32 `protobuf:"varint,7,rep,packed,name=tag_ids,json=tagIds,proto3" json:"tag_ids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BulkReportsRequest) Reset() {
+	*x = BulkReportsRequest{}
+	mi := &file_incident_v1_reports_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BulkReportsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BulkReportsRequest) ProtoMessage() {}
+
+func (x *BulkReportsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_incident_v1_reports_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BulkReportsRequest.ProtoReflect.Descriptor instead.
+func (*BulkReportsRequest) Descriptor() ([]byte, []int) {
+	return file_incident_v1_reports_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *BulkReportsRequest) GetAction() BulkReportAction {
+	if x != nil {
+		return x.Action
+	}
+	return BulkReportAction_BULK_REPORT_ACTION_UNSPECIFIED
+}
+
+func (x *BulkReportsRequest) GetIds() []uint32 {
+	if x != nil {
+		return x.Ids
+	}
+	return nil
+}
+
+func (x *BulkReportsRequest) GetFilter() *ReportFilter {
+	if x != nil {
+		return x.Filter
+	}
+	return nil
+}
+
+func (x *BulkReportsRequest) GetMode() BulkMode {
+	if x != nil {
+		return x.Mode
+	}
+	return BulkMode_BULK_MODE_UNSPECIFIED
+}
+
+func (x *BulkReportsRequest) GetUserId() uint32 {
+	if x != nil && x.UserId != nil {
+		return *x.UserId
+	}
+	return 0
+}
+
+func (x *BulkReportsRequest) GetStatus() ReportStatus {
+	if x != nil {
+		return x.Status
+	}
+	return ReportStatus_REPORT_STATUS_UNSPECIFIED
+}
+
+func (x *BulkReportsRequest) GetTagIds() []uint32 {
+	if x != nil {
+		return x.TagIds
+	}
+	return nil
+}
+
+var File_incident_v1_reports_proto protoreflect.FileDescriptor
+
+const file_incident_v1_reports_proto_rawDesc = "" +
+	"\n" +
+	"\x19incident/v1/reports.proto\x12\vincident.v1\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x18incident/v1/common.proto\"?\n" +
+	"\x03Tag\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\rR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x14\n" +
+	"\x05color\x18\x03 \x01(\tR\x05color\"\xdb\x03\n" +
+	"\x06Report\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\rR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x17\n" +
+	"\aroom_id\x18\x03 \x01(\rR\x06roomId\x12\x1c\n" +
+	"\auser_id\x18\x04 \x01(\rH\x00R\x06userId\x88\x01\x01\x12!\n" +
+	"\fcomponent_id\x18\x05 \x01(\rR\vcomponentId\x121\n" +
+	"\x06status\x18\x06 \x01(\x0e2\x19.incident.v1.ReportStatusR\x06status\x12\x1f\n" +
+	"\vrepair_cost\x18\a \x01(\x01R\n" +
+	"repairCost\x12$\n" +
+	"\x04tags\x18\b \x03(\v2\x10.incident.v1.TagR\x04tags\x129\n" +
+	"\n" +
+	"created_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\n" +
+	" \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x12=\n" +
+	"\fcompleted_at\x18\v \x01(\v2\x1a.google.protobuf.TimestampR\vcompletedAt\x12\x18\n" +
+	"\aversion\x18\f \x01(\rR\aversionB\n" +
+	"\n" +
+	"\b_user_id\"\xe3\x01\n" +
+	"\x13CreateReportRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x17\n" +
+	"\aroom_id\x18\x02 \x01(\rR\x06roomId\x12\x1c\n" +
+	"\auser_id\x18\x03 \x01(\rH\x00R\x06userId\x88\x01\x01\x12!\n" +
+	"\fcomponent_id\x18\x04 \x01(\rR\vcomponentId\x121\n" +
+	"\x06status\x18\x05 \x01(\x0e2\x19.incident.v1.ReportStatusR\x06status\x12\x1f\n" +
+	"\vrepair_cost\x18\x06 \x01(\x01R\n" +
+	"repairCostB\n" +
+	"\n" +
+	"\b_user_id\"\"\n" +
+	"\x10GetReportRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\rR\x02id\"\xde\x01\n" +
+	"\fReportFilter\x121\n" +
+	"\x06status\x18\x01 \x01(\x0e2\x19.incident.v1.ReportStatusR\x06status\x12\x17\n" +
+	"\aroom_id\x18\x02 \x01(\rR\x06roomId\x12\x17\n" +
+	"\auser_id\x18\x03 \x01(\rR\x06userId\x12!\n" +
+	"\fcomponent_id\x18\x04 \x01(\rR\vcomponentId\x12\x12\n" +
+	"\x04tags\x18\x05 \x03(\tR\x04tags\x122\n" +
+	"\ttag_match\x18\x06 \x01(\x0e2\x15.incident.v1.TagMatchR\btagMatch\"u\n" +
+	"\x12ListReportsRequest\x121\n" +
+	"\x06filter\x18\x01 \x01(\v2\x19.incident.v1.ReportFilterR\x06filter\x12,\n" +
+	"\x04page\x18\x02 \x01(\v2\x18.incident.v1.PageRequestR\x04page\"x\n" +
+	"\x13ListReportsResponse\x12-\n" +
+	"\areports\x18\x01 \x03(\v2\x13.incident.v1.ReportR\areports\x122\n" +
+	"\tpage_info\x18\x02 \x01(\v2\x15.incident.v1.PageInfoR\bpageInfo\"\xa3\x02\n" +
+	"\x13UpdateReportRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\rR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x17\n" +
+	"\aroom_id\x18\x03 \x01(\rR\x06roomId\x12!\n" +
+	"\fcomponent_id\x18\x04 \x01(\rR\vcomponentId\x121\n" +
+	"\x06status\x18\x05 \x01(\x0e2\x19.incident.v1.ReportStatusR\x06status\x12$\n" +
+	"\vrepair_cost\x18\x06 \x01(\x01H\x00R\n" +
+	"repairCost\x88\x01\x01\x12.\n" +
+	"\x10expected_version\x18\a \x01(\rH\x01R\x0fexpectedVersion\x88\x01\x01B\x0e\n" +
+	"\f_repair_costB\x13\n" +
+	"\x11_expected_version\"j\n" +
+	"\x13DeleteReportRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\rR\x02id\x12.\n" +
+	"\x10expected_version\x18\x02 \x01(\rH\x00R\x0fexpectedVersion\x88\x01\x01B\x13\n" +
+	"\x11_expected_version\"\x16\n" +
+	"\x14DeleteReportResponse\"\x81\x01\n" +
+	"\x11AssignUserRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\rR\x02id\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\rR\x06userId\x12.\n" +
+	"\x10expected_version\x18\x03 \x01(\rH\x00R\x0fexpectedVersion\x88\x01\x01B\x13\n" +
+	"\x11_expected_version\"j\n" +
+	"\x13UnassignUserRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\rR\x02id\x12.\n" +
+	"\x10expected_version\x18\x02 \x01(\rH\x00R\x0fexpectedVersion\x88\x01\x01B\x13\n" +
+	"\x11_expected_version\"~\n" +
+	"\x0eAddTagsRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\rR\x02id\x12\x17\n" +
+	"\atag_ids\x18\x02 \x03(\rR\x06tagIds\x12.\n" +
+	"\x10expected_version\x18\x03 \x01(\rH\x00R\x0fexpectedVersion\x88\x01\x01B\x13\n" +
+	"\x11_expected_version\"~\n" +
+	"\x10RemoveTagRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\rR\x02id\x12\x15\n" +
+	"\x06tag_id\x18\x02 \x01(\rR\x05tagId\x12.\n" +
+	"\x10expected_version\x18\x03 \x01(\rH\x00R\x0fexpectedVersion\x88\x01\x01B\x13\n" +
+	"\x11_expected_version\"\xb1\x02\n" +
+	"\x12BulkReportsRequest\x125\n" +
+	"\x06action\x18\x01 \x01(\x0e2\x1d.incident.v1.BulkReportActionR\x06action\x12\x10\n" +
+	"\x03ids\x18\x02 \x03(\rR\x03ids\x121\n" +
+	"\x06filter\x18\x03 \x01(\v2\x19.incident.v1.ReportFilterR\x06filter\x12)\n" +
+	"\x04mode\x18\x04 \x01(\x0e2\x15.incident.v1.BulkModeR\x04mode\x12\x1c\n" +
+	"\auser_id\x18\x05 \x01(\rH\x00R\x06userId\x88\x01\x01\x121\n" +
+	"\x06status\x18\x06 \x01(\x0e2\x19.incident.v1.ReportStatusR\x06status\x12\x17\n" +
+	"\atag_ids\x18\a \x03(\rR\x06tagIdsB\n" +
+	"\n" +
+	"\b_user_id*\x84\x01\n" +
+	"\fReportStatus\x12\x1d\n" +
+	"\x19REPORT_STATUS_UNSPECIFIED\x10\x00\x12\x19\n" +
+	"\x15REPORT_STATUS_PENDING\x10\x01\x12\x1d\n" +
+	"\x19REPORT_STATUS_IN_PROGRESS\x10\x02\x12\x1b\n" +
+	"\x17REPORT_STATUS_COMPLETED\x10\x03*K\n" +
+	"\bTagMatch\x12\x19\n" +
+	"\x15TAG_MATCH_UNSPECIFIED\x10\x00\x12\x11\n" +
+	"\rTAG_MATCH_ANY\x10\x01\x12\x11\n" +
+	"\rTAG_MATCH_ALL\x10\x02*\xd4\x01\n" +
+	"\x10BulkReportAction\x12\"\n" +
+	"\x1eBULK_REPORT_ACTION_UNSPECIFIED\x10\x00\x12\x1d\n" +
+	"\x19BULK_REPORT_ACTION_ASSIGN\x10\x01\x12!\n" +
+	"\x1dBULK_REPORT_ACTION_TRANSITION\x10\x02\x12\x1a\n" +
+	"\x16BULK_REPORT_ACTION_TAG\x10\x03\x12\x1d\n" +
+	"\x19BULK_REPORT_ACTION_DELETE\x10\x04\x12\x1f\n" +
+	"\x1bBULK_REPORT_ACTION_UNASSIGN\x10\x052\xd8\x05\n" +
+	"\rReportService\x12E\n" +
+	"\fCreateReport\x12 .incident.v1.CreateReportRequest\x1a\x13.incident.v1.Report\x12?\n" +
+	"\tGetReport\x12\x1d.incident.v1.GetReportRequest\x1a\x13.incident.v1.Report\x12P\n" +
+	"\vListReports\x12\x1f.incident.v1.ListReportsRequest\x1a .incident.v1.ListReportsResponse\x12E\n" +
+	"\fUpdateReport\x12 .incident.v1.UpdateReportRequest\x1a\x13.incident.v1.Report\x12S\n" +
+	"\fDeleteReport\x12 .incident.v1.DeleteReportRequest\x1a!.incident.v1.DeleteReportResponse\x12A\n" +
+	"\n" +
+	"AssignUser\x12\x1e.incident.v1.AssignUserRequest\x1a\x13.incident.v1.Report\x12E\n" +
+	"\fUnassignUser\x12 .incident.v1.UnassignUserRequest\x1a\x13.incident.v1.Report\x12;\n" +
+	"\aAddTags\x12\x1b.incident.v1.AddTagsRequest\x1a\x13.incident.v1.Report\x12?\n" +
+	"\tRemoveTag\x12\x1d.incident.v1.RemoveTagRequest\x1a\x13.incident.v1.Report\x12I\n" +
+	"\vBulkReports\x12\x1f.incident.v1.BulkReportsRequest\x1a\x19.incident.v1.BulkResponseB.Z,incident-report/proto/incident/v1;incidentv1b\x06proto3"
+
+var (
+	file_incident_v1_reports_proto_rawDescOnce sync.Once
+	file_incident_v1_reports_proto_rawDescData []byte
+)
+
+func file_incident_v1_reports_proto_rawDescGZIP() []byte {
+	file_incident_v1_reports_proto_rawDescOnce.Do(func() {
+		file_incident_v1_reports_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_incident_v1_reports_proto_rawDesc), len(file_incident_v1_reports_proto_rawDesc)))
+	})
+	return file_incident_v1_reports_proto_rawDescData
+}
+
+var file_incident_v1_reports_proto_enumTypes = make([]protoimpl.EnumInfo, 3)
+var file_incident_v1_reports_proto_msgTypes = make([]protoimpl.MessageInfo, 15)
+var file_incident_v1_reports_proto_goTypes = []any{
+	(ReportStatus)(0),             // 0: incident.v1.ReportStatus
+	(TagMatch)(0),                 // 1: incident.v1.TagMatch
+	(BulkReportAction)(0),         // 2: incident.v1.BulkReportAction
+	(*Tag)(nil),                   // 3: incident.v1.Tag
+	(*Report)(nil),                // 4: incident.v1.Report
+	(*CreateReportRequest)(nil),   // 5: incident.v1.CreateReportRequest
+	(*GetReportRequest)(nil),      // 6: incident.v1.GetReportRequest
+	(*ReportFilter)(nil),          // 7: incident.v1.ReportFilter
+	(*ListReportsRequest)(nil),    // 8: incident.v1.ListReportsRequest
+	(*ListReportsResponse)(nil),   // 9: incident.v1.ListReportsResponse
+	(*UpdateReportRequest)(nil),   // 10: incident.v1.UpdateReportRequest
+	(*DeleteReportRequest)(nil),   // 11: incident.v1.DeleteReportRequest
+	(*DeleteReportResponse)(nil),  // 12: incident.v1.DeleteReportResponse
+	(*AssignUserRequest)(nil),     // 13: incident.v1.AssignUserRequest
+	(*UnassignUserRequest)(nil),   // 14: incident.v1.UnassignUserRequest
+	(*AddTagsRequest)(nil),        // 15: incident.v1.AddTagsRequest
+	(*RemoveTagRequest)(nil),      // 16: incident.v1.RemoveTagRequest
+	(*BulkReportsRequest)(nil),    // 17: incident.v1.BulkReportsRequest
+	(*timestamppb.Timestamp)(nil), // 18: google.protobuf.Timestamp
+	(*PageRequest)(nil),           // 19: incident.v1.PageRequest
+	(*PageInfo)(nil),              // 20: incident.v1.PageInfo
+	(BulkMode)(0),                 // 21: incident.v1.BulkMode
+	(*BulkResponse)(nil),          // 22: incident.v1.BulkResponse
+}
+var file_incident_v1_reports_proto_depIdxs = []int32{
+	0,  // 0: incident.v1.Report.status:type_name -> incident.v1.ReportStatus
+	3,  // 1: incident.v1.Report.tags:type_name -> incident.v1.Tag
+	18, // 2: incident.v1.Report.created_at:type_name -> google.protobuf.Timestamp
+	18, // 3: incident.v1.Report.updated_at:type_name -> google.protobuf.Timestamp
+	18, // 4: incident.v1.Report.completed_at:type_name -> google.protobuf.Timestamp
+	0,  // 5: incident.v1.CreateReportRequest.status:type_name -> incident.v1.ReportStatus
+	0,  // 6: incident.v1.ReportFilter.status:type_name -> incident.v1.ReportStatus
+	1,  // 7: incident.v1.ReportFilter.tag_match:type_name -> incident.v1.TagMatch
+	7,  // 8: incident.v1.ListReportsRequest.filter:type_name -> incident.v1.ReportFilter
+	19, // 9: incident.v1.ListReportsRequest.page:type_name -> incident.v1.PageRequest
+	4,  // 10: incident.v1.ListReportsResponse.reports:type_name -> incident.v1.Report
+	20, // 11: incident.v1.ListReportsResponse.page_info:type_name -> incident.v1.PageInfo
+	0,  // 12: incident.v1.UpdateReportRequest.status:type_name -> incident.v1.ReportStatus
+	2,  // 13: incident.v1.BulkReportsRequest.action:type_name -> incident.v1.BulkReportAction
+	7,  // 14: incident.v1.BulkReportsRequest.filter:type_name -> incident.v1.ReportFilter
+	21, // 15: incident.v1.BulkReportsRequest.mode:type_name -> incident.v1.BulkMode
+	0,  // 16: incident.v1.BulkReportsRequest.status:type_name -> incident.v1.ReportStatus
+	5,  // 17: incident.v1.ReportService.CreateReport:input_type -> incident.v1.CreateReportRequest
+	6,  // 18: incident.v1.ReportService.GetReport:input_type -> incident.v1.GetReportRequest
+	8,  // 19: incident.v1.ReportService.ListReports:input_type -> incident.v1.ListReportsRequest
+	10, // 20: incident.v1.ReportService.UpdateReport:input_type -> incident.v1.UpdateReportRequest
+	11, // 21: incident.v1.ReportService.DeleteReport:input_type -> incident.v1.DeleteReportRequest
+	13, // 22: incident.v1.ReportService.AssignUser:input_type -> incident.v1.AssignUserRequest
+	14, // 23: incident.v1.ReportService.UnassignUser:input_type -> incident.v1.UnassignUserRequest
+	15, // 24: incident.v1.ReportService.AddTags:input_type -> incident.v1.AddTagsRequest
+	16, // 25: incident.v1.ReportService.RemoveTag:input_type -> incident.v1.RemoveTagRequest
+	17, // 26: incident.v1.ReportService.BulkReports:input_type -> incident.v1.BulkReportsRequest
+	4,  // 27: incident.v1.ReportService.CreateReport:output_type -> incident.v1.Report
+	4,  // 28: incident.v1.ReportService.GetReport:output_type -> incident.v1.Report
+	9,  // 29: incident.v1.ReportService.ListReports:output_type -> incident.v1.ListReportsResponse
+	4,  // 30: incident.v1.ReportService.UpdateReport:output_type -> incident.v1.Report
+	12, // 31: incident.v1.ReportService.DeleteReport:output_type -> incident.v1.DeleteReportResponse
+	4,  // 32: incident.v1.ReportService.AssignUser:output_type -> incident.v1.Report
+	4,  // 33: incident.v1.ReportService.UnassignUser:output_type -> incident.v1.Report
+	4,  // 34: incident.v1.ReportService.AddTags:output_type -> incident.v1.Report
+	4,  // 35: incident.v1.ReportService.RemoveTag:output_type -> incident.v1.Report
+	22, // 36: incident.v1.ReportService.BulkReports:output_type -> incident.v1.BulkResponse
+	27, // [27:37] is the sub-list for method output_type
+	17, // [17:27] is the sub-list for method input_type
+	17, // [17:17] is the sub-list for extension type_name
+	17, // [17:17] is the sub-list for extension extendee
+	0,  // [0:17] is the sub-list for field type_name
+}
+
+func init() { file_incident_v1_reports_proto_init() }
+func file_incident_v1_reports_proto_init() {
+	if File_incident_v1_reports_proto != nil {
+		return
+	}
+	file_incident_v1_common_proto_init()
+	file_incident_v1_reports_proto_msgTypes[1].OneofWrappers = []any{}
+	file_incident_v1_reports_proto_msgTypes[2].OneofWrappers = []any{}
+	file_incident_v1_reports_proto_msgTypes[7].OneofWrappers = []any{}
+	file_incident_v1_reports_proto_msgTypes[8].OneofWrappers = []any{}
+	file_incident_v1_reports_proto_msgTypes[10].OneofWrappers = []any{}
+	file_incident_v1_reports_proto_msgTypes[11].OneofWrappers = []any{}
+	file_incident_v1_reports_proto_msgTypes[12].OneofWrappers = []any{}
+	file_incident_v1_reports_proto_msgTypes[13].OneofWrappers = []any{}
+	file_incident_v1_reports_proto_msgTypes[14].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_incident_v1_reports_proto_rawDesc), len(file_incident_v1_reports_proto_rawDesc)),
+			NumEnums:      3,
+			NumMessages:   15,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_incident_v1_reports_proto_goTypes,
+		DependencyIndexes: file_incident_v1_reports_proto_depIdxs,
+		EnumInfos:         file_incident_v1_reports_proto_enumTypes,
+		MessageInfos:      file_incident_v1_reports_proto_msgTypes,
+	}.Build()
+	File_incident_v1_reports_proto = out.File
+	file_incident_v1_reports_proto_goTypes = nil
+	file_incident_v1_reports_proto_depIdxs = nil
+}
@@ -0,0 +1,345 @@
+package repositories
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"incident-report/models"
+	"incident-report/utils"
+	"reflect"
+	"strconv"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// AuditFilter selects audit log entries; zero fields do not filter
+// From is inclusive and To is exclusive.
+type AuditFilter struct {
+	EntityType string
+	EntityID   uint
+	ActorID    uint
+	From       time.Time
+	To         time.Time
+}
+
+// AuditRepository reads the audit log
+// Entries are written by GORM callbacks, see registerAuditCallbacks.
+type AuditRepository interface {
+	// List loads a page of entries, most recent first
+	List(ctx context.Context, filter AuditFilter, page Page) ([]models.AuditLog, utils.PageInfo, error)
+}
+
+// auditRepository is the GORM implementation of AuditRepository
+type auditRepository struct {
+	crudRepository[models.AuditLog]
+}
+
+// List loads a page of audit log entries matching the filter, most recent first
+func (r *auditRepository) List(ctx context.Context, filter AuditFilter, page Page) ([]models.AuditLog, utils.PageInfo, error) {
+	scope := func(db *gorm.DB) *gorm.DB {
+		if filter.EntityType != "" {
+			db = db.Where("entity_type = ?", filter.EntityType)
+		}
+		if filter.EntityID != 0 {
+			db = db.Where("entity_id = ?", filter.EntityID)
+		}
+		if filter.ActorID != 0 {
+			db = db.Where("actor_id = ?", filter.ActorID)
+		}
+		if !filter.From.IsZero() {
+			db = db.Where("created_at >= ?", filter.From)
+		}
+		if !filter.To.IsZero() {
+			db = db.Where("created_at < ?", filter.To)
+		}
+		return db
+	}
+	return r.list(ctx, scope, nil, auditByTime, page)
+}
+
+// auditByTime sorts audit log entries most recent first
+var auditByTime = keyset{
+	columns: []sortColumn{{name: "audit_logs.created_at", desc: true}, {name: "audit_logs.id", desc: true}},
+	values:  func(cursor *utils.Cursor) []any { return []any{cursor.Time, cursor.ID} },
+	cursor: func(record any) utils.Cursor {
+		entry := record.(*models.AuditLog)
+		return utils.Cursor{ID: entry.ID, Time: &entry.CreatedAt}
+	},
+}
+
+// auditedTables maps the table of every audited model to its entity type
+var auditedTables = map[string]string{
+	"users":                "users",
+	"buildings":            "buildings",
+	"floors":               "floors",
+	"rooms":                "rooms",
+	"component_categories": "component-categories",
+	"components":           "components",
+	"reports":              "reports",
+	"tags":                 "tags",
+}
+
+// unauditedColumns change on every write, so they are left out of the change sets
+var unauditedColumns = map[string]bool{
+	"id": true, "version": true, "created_at": true, "updated_at": true, "deleted_at": true,
+}
+
+// auditBeforeKey holds the columns of a record before an update or delete
+const auditBeforeKey = "audit:before"
+
+// auditChange is the value of a column before and after a change
+type auditChange struct {
+	Before any `json:"before"`
+	After  any `json:"after"`
+}
+
+// registerAuditCallbacks logs every create, update and delete of an audited model
+// The entry is written in the same transaction as the change, so one never exists without the other.
+// Batch statements that do not name a record by primary key are not logged; the repositories
+// only write single records, apart from the trash and the tags of reports, which log their own changes.
+// Registering is idempotent, so repositories can be created many times over one connection.
+func registerAuditCallbacks(db *gorm.DB) {
+	if db == nil {
+		return
+	}
+	callbacks := db.Callback()
+	if callbacks.Create().Get("audit:after_create") != nil {
+		return
+	}
+
+	err := errors.Join(
+		callbacks.Create().After("gorm:after_create").Before("gorm:commit_or_rollback_transaction").
+			Register("audit:after_create", auditAfter(models.AuditActionCreate)),
+		callbacks.Update().Before("gorm:update").Register("audit:before_update", auditBefore),
+		callbacks.Update().After("gorm:after_update").Before("gorm:commit_or_rollback_transaction").
+			Register("audit:after_update", auditAfter(models.AuditActionUpdate)),
+		callbacks.Delete().Before("gorm:delete").Register("audit:before_delete", auditBefore),
+		callbacks.Delete().After("gorm:after_delete").Before("gorm:commit_or_rollback_transaction").
+			Register("audit:after_delete", auditAfter(models.AuditActionDelete)),
+	)
+	if err != nil {
+		panic(fmt.Sprintf("register audit callbacks: %v", err))
+	}
+}
+
+// auditedEntity returns the entity type of the statement's model, if it is audited
+// Upserts are skipped: GORM issues them to save associations, not to change the associated records.
+func auditedEntity(db *gorm.DB) (string, bool) {
+	if db.Statement.Schema == nil {
+		return "", false
+	}
+	if _, ok := db.Statement.Clauses["ON CONFLICT"]; ok {
+		return "", false
+	}
+	entityType, ok := auditedTables[db.Statement.Schema.Table]
+	return entityType, ok
+}
+
+// auditBefore remembers the stored columns of the record about to be updated or deleted
+func auditBefore(db *gorm.DB) {
+	if db.Error != nil {
+		return
+	}
+	if _, ok := auditedEntity(db); !ok {
+		return
+	}
+	id, ok := primaryKey(db, db.Statement.ReflectValue)
+	if !ok {
+		return
+	}
+
+	sch := db.Statement.Schema
+	stored := reflect.New(sch.ModelType)
+	err := db.Session(&gorm.Session{NewDB: true, SkipHooks: true}).Unscoped().
+		Where(sch.PrioritizedPrimaryField.DBName+" = ?", id).
+		Take(stored.Interface()).Error
+	if err != nil {
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			db.AddError(err)
+		}
+		return
+	}
+	db.InstanceSet(auditBeforeKey, modelColumns(db, stored.Elem()))
+}
+
+// auditAfter returns the callback that logs a completed change
+func auditAfter(action string) func(db *gorm.DB) {
+	return func(db *gorm.DB) {
+		if db.Error != nil || db.RowsAffected == 0 {
+			return
+		}
+		entityType, ok := auditedEntity(db)
+		if !ok {
+			return
+		}
+
+		rv := db.Statement.ReflectValue
+		records := []reflect.Value{rv}
+		if rv.Kind() == reflect.Slice || rv.Kind() == reflect.Array {
+			records = records[:0]
+			for i := 0; i < rv.Len(); i++ {
+				records = append(records, reflect.Indirect(rv.Index(i)))
+			}
+		}
+
+		for _, record := range records {
+			id, ok := primaryKey(db, record)
+			if !ok {
+				continue
+			}
+
+			var before, after map[string]any
+			if action != models.AuditActionCreate {
+				stored, ok := db.InstanceGet(auditBeforeKey)
+				if !ok {
+					continue
+				}
+				before = stored.(map[string]any)
+			}
+			if action != models.AuditActionDelete {
+				after = modelColumns(db, record)
+			}
+
+			changes := diffColumns(before, after)
+			if len(changes) == 0 {
+				continue
+			}
+			if err := writeAuditLog(db, entityType, id, action, changes); err != nil {
+				db.AddError(err)
+				return
+			}
+		}
+	}
+}
+
+// primaryKey returns the ID of a record, if it is set
+func primaryKey(db *gorm.DB, record reflect.Value) (uint, bool) {
+	field := db.Statement.Schema.PrioritizedPrimaryField
+	if field == nil || record.Kind() != reflect.Struct {
+		return 0, false
+	}
+	value, zero := field.ValueOf(db.Statement.Context, record)
+	id, ok := value.(uint)
+	return id, ok && !zero
+}
+
+// modelColumns reads the audited columns of a record
+func modelColumns(db *gorm.DB, record reflect.Value) map[string]any {
+	columns := map[string]any{}
+	for _, field := range db.Statement.Schema.Fields {
+		if field.DBName == "" || unauditedColumns[field.DBName] {
+			continue
+		}
+		value, _ := field.ValueOf(db.Statement.Context, record)
+		columns[field.DBName] = value
+	}
+	return columns
+}
+
+// rowColumns reads the audited columns of rows of a table, keyed by ID
+// It serves the trash, which works on tables rather than models.
+func rowColumns(tx *gorm.DB, table string, ids []uint) (map[uint]map[string]any, error) {
+	var rows []map[string]any
+	if err := tx.Table(table).Where("id IN ?", ids).Find(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	columns := make(map[uint]map[string]any, len(rows))
+	for _, row := range rows {
+		id, err := strconv.ParseUint(fmt.Sprint(row["id"]), 10, 64)
+		if err != nil {
+			return nil, err
+		}
+		for column := range row {
+			if unauditedColumns[column] {
+				delete(row, column)
+			}
+		}
+		columns[uint(id)] = row
+	}
+	return columns, nil
+}
+
+// auditRows logs a trash action on rows of several types
+// Deletes and purges record the columns before the change, so they must run first;
+// restores record the columns after it. A row listed twice is logged once.
+func auditRows(tx *gorm.DB, action string, ids map[string][]uint) error {
+	for _, entityType := range TrashTypes {
+		if len(ids[entityType]) == 0 {
+			continue
+		}
+		rows, err := rowColumns(tx, trashTables[entityType].table, ids[entityType])
+		if err != nil {
+			return err
+		}
+		for _, id := range ids[entityType] {
+			if _, ok := rows[id]; !ok {
+				continue
+			}
+			var before, after map[string]any
+			if action == models.AuditActionRestore {
+				after = rows[id]
+			} else {
+				before = rows[id]
+			}
+			if err := writeAuditLog(tx, entityType, id, action, diffColumns(before, after)); err != nil {
+				return err
+			}
+			delete(rows, id)
+		}
+	}
+	return nil
+}
+
+// diffColumns returns the columns whose value differs between before and after
+// A nil side stands for a record that does not exist, so every column counts as changed.
+func diffColumns(before, after map[string]any) map[string]auditChange {
+	changes := map[string]auditChange{}
+	for _, side := range []map[string]any{before, after} {
+		for column := range side {
+			if _, seen := changes[column]; seen {
+				continue
+			}
+			b, a := before[column], after[column]
+			if before != nil && after != nil && sameJSON(b, a) {
+				continue
+			}
+			changes[column] = auditChange{Before: b, After: a}
+		}
+	}
+	return changes
+}
+
+// sameJSON reports whether two values serialise the same way
+// Values read back from the database may differ in type from the ones written, but not in JSON.
+func sameJSON(a, b any) bool {
+	ja, errA := json.Marshal(a)
+	jb, errB := json.Marshal(b)
+	return errA == nil && errB == nil && string(ja) == string(jb)
+}
+
+// writeAuditLog stores an entry, attributed to the actor and request of the statement's context
+func writeAuditLog(db *gorm.DB, entityType string, id uint, action string, changes map[string]auditChange) error {
+	encoded, err := json.Marshal(changes)
+	if err != nil {
+		return err
+	}
+
+	ctx := db.Statement.Context
+	entry := models.AuditLog{
+		EntityType: entityType,
+		EntityID:   id,
+		Action:     action,
+		Changes:    string(encoded),
+	}
+	if actor, ok := utils.ActorFromContext(ctx); ok && actor.UserID != 0 {
+		entry.ActorID = &actor.UserID
+	}
+	if info, ok := utils.RequestInfoFromContext(ctx); ok {
+		entry.IP = info.IP
+		entry.RequestID = info.ID
+	}
+	return db.Session(&gorm.Session{NewDB: true}).Create(&entry).Error
+}
@@ -0,0 +1,37 @@
+package repositories
+
+import (
+	"context"
+	"incident-report/models"
+	"incident-report/utils"
+)
+
+// BuildingRepository provides access to buildings
+type BuildingRepository interface {
+	Create(ctx context.Context, building *models.Building) error
+	FindByID(ctx context.Context, id uint) (*models.Building, error)
+	FindWithFloors(ctx context.Context, id uint) (*models.Building, error)
+	List(ctx context.Context, page Page) ([]models.Building, utils.PageInfo, error)
+	Save(ctx context.Context, building *models.Building) error
+	CodeTaken(ctx context.Context, code string, exceptID uint) (bool, error)
+	Delete(ctx context.Context, building *models.Building) error
+}
+
+// buildingRepository is the GORM implementation of BuildingRepository
+type buildingRepository struct {
+	crudRepository[models.Building]
+}
+
+// FindWithFloors loads a building together with its floors
+func (r *buildingRepository) FindWithFloors(ctx context.Context, id uint) (*models.Building, error) {
+	var building models.Building
+	if err := r.db.WithContext(ctx).Preload("Floors").First(&building, id).Error; err != nil {
+		return nil, err
+	}
+	return &building, nil
+}
+
+// CodeTaken reports whether another live building already uses the code
+func (r *buildingRepository) CodeTaken(ctx context.Context, code string, exceptID uint) (bool, error) {
+	return r.taken(ctx, "code", code, exceptID)
+}
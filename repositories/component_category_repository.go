@@ -0,0 +1,37 @@
+package repositories
+
+import (
+	"context"
+	"incident-report/models"
+	"incident-report/utils"
+)
+
+// ComponentCategoryRepository provides access to component categories
+type ComponentCategoryRepository interface {
+	Create(ctx context.Context, category *models.ComponentCategory) error
+	FindByID(ctx context.Context, id uint) (*models.ComponentCategory, error)
+	FindWithComponents(ctx context.Context, id uint) (*models.ComponentCategory, error)
+	List(ctx context.Context, page Page) ([]models.ComponentCategory, utils.PageInfo, error)
+	Save(ctx context.Context, category *models.ComponentCategory) error
+	CodeTaken(ctx context.Context, code string, exceptID uint) (bool, error)
+	Delete(ctx context.Context, category *models.ComponentCategory) error
+}
+
+// componentCategoryRepository is the GORM implementation of ComponentCategoryRepository
+type componentCategoryRepository struct {
+	crudRepository[models.ComponentCategory]
+}
+
+// FindWithComponents loads a category together with its components
+func (r *componentCategoryRepository) FindWithComponents(ctx context.Context, id uint) (*models.ComponentCategory, error) {
+	var category models.ComponentCategory
+	if err := r.db.WithContext(ctx).Preload("Components").First(&category, id).Error; err != nil {
+		return nil, err
+	}
+	return &category, nil
+}
+
+// CodeTaken reports whether another live category already uses the code
+func (r *componentCategoryRepository) CodeTaken(ctx context.Context, code string, exceptID uint) (bool, error) {
+	return r.taken(ctx, "code", code, exceptID)
+}
@@ -0,0 +1,63 @@
+package repositories
+
+import (
+	"context"
+	"incident-report/models"
+	"incident-report/utils"
+
+	"gorm.io/gorm"
+)
+
+// ComponentRepository provides access to components
+type ComponentRepository interface {
+	Create(ctx context.Context, component *models.Component) error
+	FindByID(ctx context.Context, id uint) (*models.Component, error)
+	// List loads the components matching filter together with their room, floor and building
+	List(ctx context.Context, filter *utils.ComponentFilterQuery, page Page) ([]models.Component, utils.PageInfo, error)
+	ListByRoom(ctx context.Context, roomID uint, page Page) ([]models.Component, utils.PageInfo, error)
+	ListByCategory(ctx context.Context, categoryID uint, page Page) ([]models.Component, utils.PageInfo, error)
+	Save(ctx context.Context, component *models.Component) error
+	CodeTaken(ctx context.Context, code string, exceptID uint) (bool, error)
+	Delete(ctx context.Context, component *models.Component) error
+}
+
+// componentRepository is the GORM implementation of ComponentRepository
+type componentRepository struct {
+	crudRepository[models.Component]
+}
+
+// List loads a page of the components matching filter
+func (r *componentRepository) List(ctx context.Context, filter *utils.ComponentFilterQuery, page Page) ([]models.Component, utils.PageInfo, error) {
+	return r.list(ctx, func(db *gorm.DB) *gorm.DB { return ApplyComponentFilter(db, filter) },
+		func(db *gorm.DB) *gorm.DB { return db.Preload("Room.Floor.Building") }, r.byID(), page)
+}
+
+// ListByRoom loads a page of the components in a room
+func (r *componentRepository) ListByRoom(ctx context.Context, roomID uint, page Page) ([]models.Component, utils.PageInfo, error) {
+	return r.list(ctx, func(db *gorm.DB) *gorm.DB { return db.Where("room_id = ?", roomID) }, nil, r.byID(), page)
+}
+
+// ListByCategory loads a page of the components in a category
+func (r *componentRepository) ListByCategory(ctx context.Context, categoryID uint, page Page) ([]models.Component, utils.PageInfo, error) {
+	return r.list(ctx, func(db *gorm.DB) *gorm.DB { return db.Where("category_id = ?", categoryID) }, nil, r.byID(), page)
+}
+
+// ApplyComponentFilter narrows a component query to the given filter
+// Column names are qualified so the filter also works on joined export queries
+func ApplyComponentFilter(db *gorm.DB, filter *utils.ComponentFilterQuery) *gorm.DB {
+	if filter == nil {
+		return db
+	}
+	if filter.RoomID != 0 {
+		db = db.Where("components.room_id = ?", filter.RoomID)
+	}
+	if filter.CategoryID != 0 {
+		db = db.Where("components.category_id = ?", filter.CategoryID)
+	}
+	return db
+}
+
+// CodeTaken reports whether another live component already uses the code
+func (r *componentRepository) CodeTaken(ctx context.Context, code string, exceptID uint) (bool, error) {
+	return r.taken(ctx, "code", code, exceptID)
+}
@@ -0,0 +1,92 @@
+package repositories
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+
+	"incident-report/utils"
+
+	sqlite "github.com/glebarez/go-sqlite"
+	"github.com/go-sql-driver/mysql"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// Driver error codes of a unique constraint violation
+const (
+	sqliteConstraintUnique     = 2067 // SQLITE_CONSTRAINT_UNIQUE
+	sqliteConstraintPrimaryKey = 1555 // SQLITE_CONSTRAINT_PRIMARYKEY
+	mysqlDuplicateEntry        = 1062 // ER_DUP_ENTRY
+	postgresUniqueViolation    = "23505"
+)
+
+var (
+	// sqliteUniquePattern matches "UNIQUE constraint failed: buildings.code"
+	sqliteUniquePattern = regexp.MustCompile(`UNIQUE constraint failed: \w+\.(\w+)`)
+	// mysqlKeyPattern matches "Duplicate entry 'B1' for key 'buildings.idx_buildings_code_live'"
+	mysqlKeyPattern = regexp.MustCompile(`for key '(?:(\w+)\.)?(\w+)'`)
+	// postgresKeyPattern matches the detail "Key (code)=(B1) already exists."
+	postgresKeyPattern = regexp.MustCompile(`^Key \((\w+)\)=`)
+)
+
+// translateError turns a duplicate-key error of any supported driver into a conflict on the field
+// Other errors are returned unchanged.
+func translateError(err error) error {
+	field, ok := duplicateKeyField(err)
+	if !ok {
+		return err
+	}
+
+	conflict := utils.Conflict(field, field+" already exists")
+	conflict.Err = err
+	return conflict
+}
+
+// duplicateKeyField reports whether err is a unique constraint violation and names the column
+// The column is empty when the driver does not say which constraint failed.
+func duplicateKeyField(err error) (string, bool) {
+	var sqliteErr *sqlite.Error
+	if errors.As(err, &sqliteErr) {
+		if sqliteErr.Code() != sqliteConstraintUnique && sqliteErr.Code() != sqliteConstraintPrimaryKey {
+			return "", false
+		}
+		if match := sqliteUniquePattern.FindStringSubmatch(sqliteErr.Error()); match != nil {
+			return match[1], true
+		}
+		return "", true
+	}
+
+	var mysqlErr *mysql.MySQLError
+	if errors.As(err, &mysqlErr) {
+		if mysqlErr.Number != mysqlDuplicateEntry {
+			return "", false
+		}
+		if match := mysqlKeyPattern.FindStringSubmatch(mysqlErr.Message); match != nil {
+			return indexColumn(match[1], match[2]), true
+		}
+		return "", true
+	}
+
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		if pgErr.Code != postgresUniqueViolation {
+			return "", false
+		}
+		if match := postgresKeyPattern.FindStringSubmatch(pgErr.Detail); match != nil {
+			return match[1], true
+		}
+		return indexColumn(pgErr.TableName, pgErr.ConstraintName), true
+	}
+
+	return "", false
+}
+
+// indexColumn recovers the column from a GORM style index name such as idx_buildings_code_live
+// Without the table name the last word is taken, which holds for every unique column in the schema.
+func indexColumn(table, index string) string {
+	column := strings.TrimSuffix(index, "_live")
+	if table != "" {
+		return strings.TrimPrefix(column, "idx_"+table+"_")
+	}
+	return column[strings.LastIndex(column, "_")+1:]
+}
@@ -0,0 +1,83 @@
+package repositories
+
+import (
+	"context"
+	"incident-report/utils"
+	"strings"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/schema"
+)
+
+// fieldColumns maps the response fields that are not named after their column
+var fieldColumns = map[string]string{
+	"floor_number": "number",
+}
+
+// selectFields narrows a query to the columns behind the response fields the request asked for
+// A field is matched to a column by the model's JSON name or column name, and a relation to its
+// foreign key. The ID, the version and the columns in keep are always selected. A field that
+// matches nothing, such as one a service computes, leaves every column selected.
+func selectFields(ctx context.Context, db *gorm.DB, model any, keep ...string) *gorm.DB {
+	fields, ok := utils.FieldsFromContext(ctx)
+	if !ok {
+		return db
+	}
+
+	stmt := &gorm.Statement{DB: db}
+	if err := stmt.Parse(model); err != nil {
+		return db
+	}
+	s := stmt.Schema
+
+	var columns []string
+	seen := map[string]bool{}
+	for _, column := range append([]string{s.Table + ".id"}, keep...) {
+		if !seen[column] {
+			seen[column] = true
+			columns = append(columns, column)
+		}
+	}
+	add := func(name string) {
+		if column := s.Table + "." + name; s.LookUpField(name) != nil && !seen[column] {
+			seen[column] = true
+			columns = append(columns, column)
+		}
+	}
+	add("version")
+
+	for _, name := range fields {
+		if column, ok := fieldColumns[name]; ok {
+			name = column
+		}
+		field := lookUpJSONField(s, name)
+		if field == nil {
+			return db
+		}
+
+		if relation, ok := s.Relationships.Relations[field.Name]; ok {
+			for _, ref := range relation.References {
+				if !ref.OwnPrimaryKey && ref.ForeignKey.Schema == s {
+					add(ref.ForeignKey.DBName)
+				}
+			}
+			continue
+		}
+		if field.DBName == "" {
+			return db
+		}
+		add(field.DBName)
+	}
+	return db.Select(columns)
+}
+
+// lookUpJSONField finds the field of a model with a JSON or column name
+func lookUpJSONField(s *schema.Schema, name string) *schema.Field {
+	for _, field := range s.Fields {
+		jsonName, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if jsonName == name {
+			return field
+		}
+	}
+	return s.LookUpField(name)
+}
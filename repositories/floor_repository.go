@@ -0,0 +1,46 @@
+package repositories
+
+import (
+	"context"
+	"incident-report/models"
+	"incident-report/utils"
+
+	"gorm.io/gorm"
+)
+
+// FloorRepository provides access to floors
+type FloorRepository interface {
+	Create(ctx context.Context, floor *models.Floor) error
+	FindByID(ctx context.Context, id uint) (*models.Floor, error)
+	FindWithRooms(ctx context.Context, id uint) (*models.Floor, error)
+	// List loads floors together with their building
+	List(ctx context.Context, page Page) ([]models.Floor, utils.PageInfo, error)
+	ListByBuilding(ctx context.Context, buildingID uint, page Page) ([]models.Floor, utils.PageInfo, error)
+	Save(ctx context.Context, floor *models.Floor) error
+	Delete(ctx context.Context, floor *models.Floor) error
+}
+
+// floorRepository is the GORM implementation of FloorRepository
+type floorRepository struct {
+	crudRepository[models.Floor]
+}
+
+// FindWithRooms loads a floor together with its rooms
+func (r *floorRepository) FindWithRooms(ctx context.Context, id uint) (*models.Floor, error) {
+	var floor models.Floor
+	if err := r.db.WithContext(ctx).Preload("Rooms").First(&floor, id).Error; err != nil {
+		return nil, err
+	}
+	return &floor, nil
+}
+
+// List loads a page of floors with their building
+func (r *floorRepository) List(ctx context.Context, page Page) ([]models.Floor, utils.PageInfo, error) {
+	return r.list(ctx, func(db *gorm.DB) *gorm.DB { return db },
+		func(db *gorm.DB) *gorm.DB { return db.Preload("Building") }, r.byID(), page)
+}
+
+// ListByBuilding loads a page of the floors of a building
+func (r *floorRepository) ListByBuilding(ctx context.Context, buildingID uint, page Page) ([]models.Floor, utils.PageInfo, error) {
+	return r.list(ctx, func(db *gorm.DB) *gorm.DB { return db.Where("building_id = ?", buildingID) }, nil, r.byID(), page)
+}
@@ -0,0 +1,57 @@
+package repositories
+
+import (
+	"context"
+	"incident-report/models"
+	"time"
+)
+
+// IdempotencyRepository stores the responses replayed to retried requests
+type IdempotencyRepository interface {
+	// Find loads the unexpired record of a caller's key
+	Find(ctx context.Context, userID uint, key string) (*models.IdempotencyKey, error)
+	// Reserve inserts the record of a request that is about to run, after dropping expired ones
+	// It returns a conflict when the caller's key is already taken.
+	Reserve(ctx context.Context, record *models.IdempotencyKey) error
+	// Complete stores the response of a reserved request
+	Complete(ctx context.Context, record *models.IdempotencyKey) error
+	// Release drops a reserved request, so that a retry runs it again
+	Release(ctx context.Context, record *models.IdempotencyKey) error
+}
+
+// idempotencyRepository is the GORM implementation of IdempotencyRepository
+type idempotencyRepository struct {
+	crudRepository[models.IdempotencyKey]
+}
+
+// Find loads the unexpired record of a caller's key
+func (r *idempotencyRepository) Find(ctx context.Context, userID uint, key string) (*models.IdempotencyKey, error) {
+	var record models.IdempotencyKey
+	// A map condition has its columns quoted; key is a reserved word in MySQL
+	err := r.db.WithContext(ctx).
+		Where(map[string]any{"user_id": userID, "key": key}).
+		Where("expires_at > ?", time.Now()).
+		Take(&record).Error
+	if err != nil {
+		return nil, err
+	}
+	return &record, nil
+}
+
+// Reserve drops the expired records and inserts the record of a request that is about to run
+func (r *idempotencyRepository) Reserve(ctx context.Context, record *models.IdempotencyKey) error {
+	if err := r.db.WithContext(ctx).Where("expires_at <= ?", time.Now()).Delete(&models.IdempotencyKey{}).Error; err != nil {
+		return err
+	}
+	return r.Create(ctx, record)
+}
+
+// Complete stores the response of a reserved request
+func (r *idempotencyRepository) Complete(ctx context.Context, record *models.IdempotencyKey) error {
+	return r.db.WithContext(ctx).Model(record).Select("StatusCode", "ContentType", "Body").Updates(record).Error
+}
+
+// Release drops a reserved request
+func (r *idempotencyRepository) Release(ctx context.Context, record *models.IdempotencyKey) error {
+	return r.db.WithContext(ctx).Delete(record).Error
+}
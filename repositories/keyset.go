@@ -0,0 +1,130 @@
+package repositories
+
+import (
+	"fmt"
+	"incident-report/utils"
+	"reflect"
+	"slices"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+// sortColumn is a column of a list's sort order
+type sortColumn struct {
+	name string // qualified column name
+	desc bool
+}
+
+// keyset is the sort order a list is paged by
+// The columns end with a unique one, so every record has its own position, and a cursor
+// holds the values of the record it was taken from.
+type keyset struct {
+	columns []sortColumn
+	// values returns the sort key held by a cursor, in column order
+	values func(cursor *utils.Cursor) []any
+	// cursor returns the cursor of a loaded record
+	cursor func(record any) utils.Cursor
+}
+
+// idKeyset sorts the records of a table by ID; an empty table leaves the column unqualified
+func idKeyset(table string) keyset {
+	column := "id"
+	if table != "" {
+		column = table + ".id"
+	}
+	return keyset{
+		columns: []sortColumn{{name: column}},
+		values:  func(cursor *utils.Cursor) []any { return []any{cursor.ID} },
+		cursor: func(record any) utils.Cursor {
+			return utils.Cursor{ID: uint(reflect.ValueOf(record).Elem().FieldByName("ID").Uint())}
+		},
+	}
+}
+
+// names lists the sort columns
+func (k keyset) names() []string {
+	names := make([]string, len(k.columns))
+	for i, column := range k.columns {
+		names[i] = column.name
+	}
+	return names
+}
+
+// apply sorts a query and positions it at the page's cursor or offset
+// Before a cursor the query runs in reverse, so the closest records come first.
+func (k keyset) apply(db *gorm.DB, page Page) *gorm.DB {
+	switch {
+	case page.After != nil:
+		sql, args := k.condition(page.After, true)
+		db = db.Where(sql, args...)
+	case page.Before != nil:
+		sql, args := k.condition(page.Before, false)
+		db = db.Where(sql, args...)
+	default:
+		db = db.Offset(page.Offset)
+	}
+	return db.Order(k.orderBy(page.Before == nil))
+}
+
+// condition selects the records after (or before) a cursor
+// For columns a, b it reads a > ? OR (a = ? AND b > ?), with < for descending columns.
+func (k keyset) condition(cursor *utils.Cursor, forward bool) (string, []any) {
+	values := k.values(cursor)
+
+	var sql string
+	var args []any
+	for i := len(k.columns) - 1; i >= 0; i-- {
+		column := k.columns[i]
+		op := ">"
+		if column.desc == forward {
+			op = "<"
+		}
+
+		if sql == "" {
+			sql = fmt.Sprintf("%s %s ?", column.name, op)
+			args = []any{values[i]}
+		} else {
+			sql = fmt.Sprintf("%s %s ? OR (%s = ? AND (%s))", column.name, op, column.name, sql)
+			args = append([]any{values[i], values[i]}, args...)
+		}
+	}
+	return "(" + sql + ")", args
+}
+
+// orderBy is the ORDER BY clause of the keyset, reversed when not going forward
+func (k keyset) orderBy(forward bool) string {
+	terms := make([]string, len(k.columns))
+	for i, column := range k.columns {
+		terms[i] = column.name
+		if column.desc == forward {
+			terms[i] += " DESC"
+		}
+	}
+	return strings.Join(terms, ", ")
+}
+
+// trimPage cuts the extra record a list loaded past the page and sets the page's cursors
+// Records loaded before a cursor come in reverse order and are turned back.
+func trimPage[T any](records []T, keys keyset, page Page, info utils.PageInfo) ([]T, utils.PageInfo, error) {
+	more := len(records) > page.Limit
+	if more {
+		records = records[:page.Limit]
+	}
+
+	hasNext, hasPrev := more, page.After != nil || page.Offset > 0
+	if page.Before != nil {
+		slices.Reverse(records)
+		hasNext, hasPrev = true, more
+	}
+
+	if len(records) > 0 {
+		if hasNext {
+			info.NextCursor = keys.cursor(&records[len(records)-1]).Encode()
+		}
+		if hasPrev {
+			info.PrevCursor = keys.cursor(&records[0]).Encode()
+		}
+	}
+	return records, info, nil
+}
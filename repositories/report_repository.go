@@ -0,0 +1,182 @@
+package repositories
+
+import (
+	"context"
+	"incident-report/models"
+	"incident-report/utils"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// ReportRepository provides access to reports
+type ReportRepository interface {
+	Create(ctx context.Context, report *models.Report) error
+	FindByID(ctx context.Context, id uint) (*models.Report, error)
+	// FindWithTags loads a report together with its tags
+	FindWithTags(ctx context.Context, id uint) (*models.Report, error)
+	// List loads the reports matching filter together with their tags
+	List(ctx context.Context, filter *utils.ReportFilterQuery, page Page) ([]models.Report, utils.PageInfo, error)
+	Save(ctx context.Context, report *models.Report) error
+	Delete(ctx context.Context, report *models.Report) error
+	// IDs lists the IDs of the reports matching filter, at most limit of them
+	IDs(ctx context.Context, filter *utils.ReportFilterQuery, limit int) ([]uint, error)
+	// CountOpen counts the reports among ids that still need work
+	CountOpen(ctx context.Context, ids []uint) (int64, error)
+	// AddTags and RemoveTag change the tags of a report and increment its version, like Save
+	AddTags(ctx context.Context, report *models.Report, tags []models.Tag) error
+	RemoveTag(ctx context.Context, report *models.Report, tag *models.Tag) error
+}
+
+// reportRepository is the GORM implementation of ReportRepository
+type reportRepository struct {
+	crudRepository[models.Report]
+}
+
+// FindWithTags loads a report together with its tags
+func (r *reportRepository) FindWithTags(ctx context.Context, id uint) (*models.Report, error) {
+	var report models.Report
+	if err := selectFields(ctx, r.db.WithContext(ctx), &report).Preload("Tags").First(&report, id).Error; err != nil {
+		return nil, err
+	}
+	return &report, nil
+}
+
+// List loads a page of the reports matching filter
+func (r *reportRepository) List(ctx context.Context, filter *utils.ReportFilterQuery, page Page) ([]models.Report, utils.PageInfo, error) {
+	return r.list(ctx, func(db *gorm.DB) *gorm.DB { return ApplyReportFilter(db, filter) },
+		func(db *gorm.DB) *gorm.DB { return db.Preload("Tags") }, r.byID(), page)
+}
+
+// IDs lists the IDs of the reports matching filter in ID order, at most limit of them
+func (r *reportRepository) IDs(ctx context.Context, filter *utils.ReportFilterQuery, limit int) ([]uint, error) {
+	var ids []uint
+	err := ApplyReportFilter(r.db.WithContext(ctx).Model(&models.Report{}), filter).
+		Order("reports.id").Limit(limit).Pluck("reports.id", &ids).Error
+	return ids, err
+}
+
+// CountOpen counts the pending and in-progress reports among ids
+func (r *reportRepository) CountOpen(ctx context.Context, ids []uint) (int64, error) {
+	if len(ids) == 0 {
+		return 0, nil
+	}
+
+	var count int64
+	err := r.db.WithContext(ctx).Model(&models.Report{}).
+		Where("id IN ? AND status IN ?", ids, models.OpenReportStatuses).
+		Count(&count).Error
+	return count, err
+}
+
+// AddTags attaches tags to a report; tags that are already attached are left untouched
+func (r *reportRepository) AddTags(ctx context.Context, report *models.Report, tags []models.Tag) error {
+	return r.changeTags(ctx, report, func(association *gorm.Association) error { return association.Append(tags) })
+}
+
+// RemoveTag detaches a tag from a report
+func (r *reportRepository) RemoveTag(ctx context.Context, report *models.Report, tag *models.Tag) error {
+	return r.changeTags(ctx, report, func(association *gorm.Association) error { return association.Delete(tag) })
+}
+
+// changeTags applies a change to the tags of a report and increments its version in one transaction
+// The tags are part of the report's representation, so a change must invalidate its ETag. Like Save,
+// it returns ErrStaleVersion and changes nothing when the report is no longer at the version it was read at.
+// The audit callbacks do not see join table writes, so the change is logged here as an update of the
+// report's tags column.
+func (r *reportRepository) changeTags(ctx context.Context, report *models.Report, change func(association *gorm.Association) error) error {
+	read := report.Version
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&models.Report{}).Where("id = ? AND version = ?", report.ID, read).
+			UpdateColumns(map[string]any{"version": read + 1, "updated_at": time.Now()})
+		if result.Error == nil && result.RowsAffected == 0 {
+			return ErrStaleVersion
+		}
+		if result.Error != nil {
+			return result.Error
+		}
+
+		before, err := reportTagNames(tx, report.ID)
+		if err != nil {
+			return err
+		}
+		report.Version = read + 1
+		if err := change(tx.Model(report).Association("Tags")); err != nil {
+			return err
+		}
+		after, err := reportTagNames(tx, report.ID)
+		if err != nil {
+			return err
+		}
+
+		changes := diffColumns(map[string]any{"tags": before}, map[string]any{"tags": after})
+		if len(changes) == 0 {
+			return nil
+		}
+		return writeAuditLog(tx, "reports", report.ID, models.AuditActionUpdate, changes)
+	})
+	if err != nil {
+		report.Version = read
+		return translateError(err)
+	}
+	return nil
+}
+
+// reportTagNames lists the names of the tags attached to a report in name order
+func reportTagNames(tx *gorm.DB, reportID uint) ([]string, error) {
+	names := []string{}
+	err := tx.Table("tags").Joins("JOIN report_tags ON report_tags.tag_id = tags.id").
+		Where("report_tags.report_id = ?", reportID).Order("tags.name").Pluck("tags.name", &names).Error
+	return names, err
+}
+
+// ApplyReportFilter narrows a report query to the given filter
+// Column names are qualified so the filter also works on joined export queries
+func ApplyReportFilter(db *gorm.DB, filter *utils.ReportFilterQuery) *gorm.DB {
+	if filter == nil {
+		return db
+	}
+	if filter.Status != "" {
+		db = db.Where("reports.status = ?", filter.Status)
+	}
+	if filter.RoomID != 0 {
+		db = db.Where("reports.room_id = ?", filter.RoomID)
+	}
+	if filter.UserID != 0 {
+		db = db.Where("reports.user_id = ?", filter.UserID)
+	}
+	if filter.ComponentID != 0 {
+		db = db.Where("reports.component_id = ?", filter.ComponentID)
+	}
+	if names, distinct := splitTagNames(filter.Tags); len(names) > 0 {
+		tagged := db.Session(&gorm.Session{NewDB: true}).Table("report_tags").
+			Select("report_tags.report_id").
+			Joins("JOIN tags ON tags.id = report_tags.tag_id").
+			Where("tags.name IN ?", names)
+		if filter.TagMatch == "all" {
+			tagged = tagged.Group("report_tags.report_id").Having("COUNT(DISTINCT LOWER(tags.name)) = ?", distinct)
+		}
+		db = db.Where("reports.id IN (?)", tagged)
+	}
+	return db
+}
+
+// splitTagNames parses a comma separated list of tag names, dropping repeated names
+// It also counts the names that differ in more than case, which is how many tags tag_match=all
+// requires. Whether "Urgent" finds the tag "urgent" depends on the collation (MySQL's default
+// ignores case), so the filter counts the matched tags by lower-cased name and every spelling
+// of a name stands for one tag; otherwise a repeated name would keep any report from matching.
+func splitTagNames(tags string) ([]string, int) {
+	var names []string
+	seen := map[string]bool{}
+	folded := map[string]bool{}
+	for _, name := range strings.Split(tags, ",") {
+		if name = strings.TrimSpace(name); name != "" && !seen[name] {
+			seen[name] = true
+			folded[strings.ToLower(name)] = true
+			names = append(names, name)
+		}
+	}
+	return names, len(folded)
+}
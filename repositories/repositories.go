@@ -0,0 +1,197 @@
+// Package repositories contains the data access layer
+//
+// Each aggregate has a repository interface and a GORM implementation. Every method takes
+// a context.Context that is attached to its queries, so a cancelled request or an expired
+// deadline stops the query.
+package repositories
+
+import (
+	"context"
+	"errors"
+	"incident-report/models"
+	"incident-report/utils"
+
+	"gorm.io/gorm"
+)
+
+// ErrNotFound is returned when a record does not exist
+// It is the GORM error, so errors.Is works with either name
+var ErrNotFound = gorm.ErrRecordNotFound
+
+// ErrStaleVersion is returned when a versioned record changed after it was read
+var ErrStaleVersion = errors.New("record was changed after it was read")
+
+// versioned is implemented by models with an optimistic locking version (models.Versioned)
+type versioned interface {
+	CurrentVersion() uint
+	SetVersion(version uint)
+}
+
+// Page selects a slice of a list query
+// The slice starts at Offset, or right after or before a cursor when After or Before is set
+// (keyset pagination, which ignores Offset).
+type Page struct {
+	Offset int
+	Limit  int
+	After  *utils.Cursor
+	Before *utils.Cursor
+	// SkipTotal leaves the matching records uncounted
+	SkipTotal bool
+}
+
+// Repositories bundles the repositories of every aggregate
+type Repositories struct {
+	db *gorm.DB
+
+	Users               UserRepository
+	Buildings           BuildingRepository
+	Floors              FloorRepository
+	Rooms               RoomRepository
+	ComponentCategories ComponentCategoryRepository
+	Components          ComponentRepository
+	Reports             ReportRepository
+	Tags                TagRepository
+	Trash               TrashRepository
+	Audit               AuditRepository
+	IdempotencyKeys     IdempotencyRepository
+}
+
+// New creates the GORM repositories over a database connection
+// It also hooks the audit log into the connection, see registerAuditCallbacks.
+func New(db *gorm.DB) *Repositories {
+	registerAuditCallbacks(db)
+
+	return &Repositories{
+		db:                  db,
+		Users:               &userRepository{crudRepository[models.User]{db}},
+		Buildings:           &buildingRepository{crudRepository[models.Building]{db}},
+		Floors:              &floorRepository{crudRepository[models.Floor]{db}},
+		Rooms:               &roomRepository{crudRepository[models.Room]{db}},
+		ComponentCategories: &componentCategoryRepository{crudRepository[models.ComponentCategory]{db}},
+		Components:          &componentRepository{crudRepository[models.Component]{db}},
+		Reports:             &reportRepository{crudRepository[models.Report]{db}},
+		Tags:                &tagRepository{crudRepository[models.Tag]{db}},
+		Trash:               &trashRepository{db},
+		Audit:               &auditRepository{crudRepository[models.AuditLog]{db}},
+		IdempotencyKeys:     &idempotencyRepository{crudRepository[models.IdempotencyKey]{db}},
+	}
+}
+
+// Transaction runs fn with repositories bound to a single database transaction
+// The transaction is committed when fn returns nil and rolled back otherwise.
+// Repositories that were not created by New (such as test fakes) run fn directly.
+func (r *Repositories) Transaction(ctx context.Context, fn func(tx *Repositories) error) error {
+	if r.db == nil {
+		return fn(r)
+	}
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return fn(New(tx))
+	})
+}
+
+// crudRepository implements the operations shared by every aggregate
+type crudRepository[T any] struct {
+	db *gorm.DB
+}
+
+// Create inserts a new record
+func (r crudRepository[T]) Create(ctx context.Context, entity *T) error {
+	return translateError(r.db.WithContext(ctx).Create(entity).Error)
+}
+
+// FindByID loads a record by primary key
+// A read that asked for a sparse fieldset only loads the columns it needs, see selectFields.
+func (r crudRepository[T]) FindByID(ctx context.Context, id uint) (*T, error) {
+	var entity T
+	if err := selectFields(ctx, r.db.WithContext(ctx), new(T)).First(&entity, id).Error; err != nil {
+		return nil, err
+	}
+	return &entity, nil
+}
+
+// Save updates every column of a record
+// A versioned record is only updated if it still has the version it was read at, and its
+// version is incremented; otherwise ErrStaleVersion is returned and nothing is written.
+func (r crudRepository[T]) Save(ctx context.Context, entity *T) error {
+	v, ok := any(entity).(versioned)
+	if !ok {
+		return translateError(r.db.WithContext(ctx).Save(entity).Error)
+	}
+
+	read := v.CurrentVersion()
+	v.SetVersion(read + 1)
+	result := r.db.WithContext(ctx).Model(entity).Where("version = ?", read).Select("*").Updates(entity)
+	if result.Error == nil && result.RowsAffected == 0 {
+		result.Error = ErrStaleVersion
+	}
+	if result.Error != nil {
+		v.SetVersion(read)
+		return translateError(result.Error)
+	}
+	return nil
+}
+
+// Delete deletes a record, softly when the model has a DeletedAt field
+// Like Save, it returns ErrStaleVersion when a versioned record changed after it was read.
+func (r crudRepository[T]) Delete(ctx context.Context, entity *T) error {
+	db := r.db.WithContext(ctx)
+	if v, ok := any(entity).(versioned); ok {
+		db = db.Where("version = ?", v.CurrentVersion())
+	}
+
+	result := db.Delete(entity)
+	if result.Error == nil && result.RowsAffected == 0 {
+		return ErrStaleVersion
+	}
+	return result.Error
+}
+
+// taken reports whether a live record other than exceptID already holds value in column
+// Soft-deleted records are ignored, so their unique values can be reused.
+func (r crudRepository[T]) taken(ctx context.Context, column string, value any, exceptID uint) (bool, error) {
+	var count int64
+	err := r.db.WithContext(ctx).Model(new(T)).
+		Where(column+" = ? AND id <> ?", value, exceptID).
+		Count(&count).Error
+	return count > 0, err
+}
+
+// List loads a page of records in ID order
+func (r crudRepository[T]) List(ctx context.Context, page Page) ([]T, utils.PageInfo, error) {
+	return r.list(ctx, func(db *gorm.DB) *gorm.DB { return db }, nil, r.byID(), page)
+}
+
+// list counts the records matching scope and loads a page of them in the order of keys
+// preload is applied to the page query only
+func (r crudRepository[T]) list(ctx context.Context, scope func(*gorm.DB) *gorm.DB, preload func(*gorm.DB) *gorm.DB, keys keyset, page Page) ([]T, utils.PageInfo, error) {
+	var info utils.PageInfo
+	if !page.SkipTotal {
+		var total int64
+		if err := scope(r.db.WithContext(ctx).Model(new(T))).Count(&total).Error; err != nil {
+			return nil, info, err
+		}
+		info.Total = &total
+	}
+
+	query := scope(r.db.WithContext(ctx).Model(new(T)))
+	if preload != nil {
+		query = preload(query)
+	}
+	query = selectFields(ctx, query, new(T), keys.names()...)
+
+	// One record more than the page tells whether another page follows
+	var entities []T
+	if err := keys.apply(query, page).Limit(page.Limit + 1).Find(&entities).Error; err != nil {
+		return nil, info, err
+	}
+	return trimPage(entities, keys, page, info)
+}
+
+// byID is the keyset of the records sorted by ID
+func (r crudRepository[T]) byID() keyset {
+	stmt := &gorm.Statement{DB: r.db}
+	if err := stmt.Parse(new(T)); err != nil {
+		return idKeyset("")
+	}
+	return idKeyset(stmt.Schema.Table)
+}
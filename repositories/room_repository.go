@@ -0,0 +1,52 @@
+package repositories
+
+import (
+	"context"
+	"incident-report/models"
+	"incident-report/utils"
+
+	"gorm.io/gorm"
+)
+
+// RoomRepository provides access to rooms
+type RoomRepository interface {
+	Create(ctx context.Context, room *models.Room) error
+	FindByID(ctx context.Context, id uint) (*models.Room, error)
+	FindWithComponents(ctx context.Context, id uint) (*models.Room, error)
+	// List loads rooms together with their floor and building
+	List(ctx context.Context, page Page) ([]models.Room, utils.PageInfo, error)
+	ListByFloor(ctx context.Context, floorID uint, page Page) ([]models.Room, utils.PageInfo, error)
+	Save(ctx context.Context, room *models.Room) error
+	CodeTaken(ctx context.Context, code string, exceptID uint) (bool, error)
+	Delete(ctx context.Context, room *models.Room) error
+}
+
+// roomRepository is the GORM implementation of RoomRepository
+type roomRepository struct {
+	crudRepository[models.Room]
+}
+
+// FindWithComponents loads a room together with its components
+func (r *roomRepository) FindWithComponents(ctx context.Context, id uint) (*models.Room, error) {
+	var room models.Room
+	if err := r.db.WithContext(ctx).Preload("Components").First(&room, id).Error; err != nil {
+		return nil, err
+	}
+	return &room, nil
+}
+
+// List loads a page of rooms with their floor and building
+func (r *roomRepository) List(ctx context.Context, page Page) ([]models.Room, utils.PageInfo, error) {
+	return r.list(ctx, func(db *gorm.DB) *gorm.DB { return db },
+		func(db *gorm.DB) *gorm.DB { return db.Preload("Floor.Building") }, r.byID(), page)
+}
+
+// ListByFloor loads a page of the rooms of a floor
+func (r *roomRepository) ListByFloor(ctx context.Context, floorID uint, page Page) ([]models.Room, utils.PageInfo, error) {
+	return r.list(ctx, func(db *gorm.DB) *gorm.DB { return db.Where("floor_id = ?", floorID) }, nil, r.byID(), page)
+}
+
+// CodeTaken reports whether another live room already uses the code
+func (r *roomRepository) CodeTaken(ctx context.Context, code string, exceptID uint) (bool, error) {
+	return r.taken(ctx, "code", code, exceptID)
+}
@@ -0,0 +1,71 @@
+package repositories
+
+import (
+	"context"
+	"incident-report/models"
+	"incident-report/utils"
+
+	"gorm.io/gorm"
+)
+
+// TagRepository provides access to tags
+type TagRepository interface {
+	Create(ctx context.Context, tag *models.Tag) error
+	FindByID(ctx context.Context, id uint) (*models.Tag, error)
+	FindByIDs(ctx context.Context, ids []uint) ([]models.Tag, error)
+	// List loads tags ordered by name
+	List(ctx context.Context, page Page) ([]models.Tag, utils.PageInfo, error)
+	Save(ctx context.Context, tag *models.Tag) error
+	NameTaken(ctx context.Context, name string, exceptID uint) (bool, error)
+	// Delete detaches a tag from every report and deletes it
+	Delete(ctx context.Context, tag *models.Tag) error
+}
+
+// tagRepository is the GORM implementation of TagRepository
+type tagRepository struct {
+	crudRepository[models.Tag]
+}
+
+// FindByIDs loads the tags with the given IDs; missing IDs are skipped
+func (r *tagRepository) FindByIDs(ctx context.Context, ids []uint) ([]models.Tag, error) {
+	var tags []models.Tag
+	if err := r.db.WithContext(ctx).Where("id IN ?", ids).Find(&tags).Error; err != nil {
+		return nil, err
+	}
+	return tags, nil
+}
+
+// List loads a page of tags ordered by name
+func (r *tagRepository) List(ctx context.Context, page Page) ([]models.Tag, utils.PageInfo, error) {
+	return r.list(ctx, func(db *gorm.DB) *gorm.DB { return db }, nil, tagsByName, page)
+}
+
+// tagsByName sorts tags by name; names are unique, the ID only breaks ties
+var tagsByName = keyset{
+	columns: []sortColumn{{name: "tags.name"}, {name: "tags.id"}},
+	values:  func(cursor *utils.Cursor) []any { return []any{cursor.Key, cursor.ID} },
+	cursor: func(record any) utils.Cursor {
+		tag := record.(*models.Tag)
+		return utils.Cursor{ID: tag.ID, Key: tag.Name}
+	},
+}
+
+// Delete detaches a tag from every report and deletes it in one transaction
+// Like crudRepository.Delete, it returns ErrStaleVersion when the tag changed after it was read.
+func (r *tagRepository) Delete(ctx context.Context, tag *models.Tag) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(tag).Association("Reports").Clear(); err != nil {
+			return err
+		}
+		result := tx.Where("version = ?", tag.Version).Delete(tag)
+		if result.Error == nil && result.RowsAffected == 0 {
+			return ErrStaleVersion
+		}
+		return result.Error
+	})
+}
+
+// NameTaken reports whether another live tag already uses the name
+func (r *tagRepository) NameTaken(ctx context.Context, name string, exceptID uint) (bool, error) {
+	return r.taken(ctx, "name", name, exceptID)
+}
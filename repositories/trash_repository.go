@@ -0,0 +1,401 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"incident-report/models"
+	"incident-report/utils"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// TrashItem is a soft-deleted record of any type
+type TrashItem struct {
+	Type      string
+	ID        uint
+	Name      string
+	DeletedAt *time.Time
+}
+
+// TrashRepository lists, restores and purges soft-deleted records
+// Records are addressed by type, the plural name used in the API (buildings, floors, ...).
+type TrashRepository interface {
+	// List loads a page of deleted records of one type, or of every type when entityType is empty
+	List(ctx context.Context, entityType string, page Page) ([]TrashItem, utils.PageInfo, error)
+	// Find loads a record whether or not it is deleted; DeletedAt is nil for live records
+	Find(ctx context.Context, entityType string, id uint) (*TrashItem, error)
+	// DeletedParent returns the type of a deleted record the given record belongs to, if any
+	DeletedParent(ctx context.Context, entityType string, id uint) (string, error)
+	// Descendants collects the live records below a record, keyed by type
+	Descendants(ctx context.Context, entityType string, id uint) (map[string][]uint, error)
+	// LockDescendants collects like Descendants, and locks the record and its descendants until the
+	// transaction it runs in ends, so that nothing is added below them or reopened meanwhile
+	LockDescendants(ctx context.Context, entityType string, id uint) (map[string][]uint, error)
+	// DeleteWithDescendants soft-deletes a record and the given descendants with one shared timestamp
+	// It returns ErrStaleVersion, and deletes nothing, when the record is no longer at version.
+	DeleteWithDescendants(ctx context.Context, entityType string, id, version uint, descendants map[string][]uint) error
+	// Restore undeletes a record together with the descendants that were deleted with it
+	Restore(ctx context.Context, entityType string, id uint) error
+	// Dependents counts the records, deleted or not, that still reference a record
+	Dependents(ctx context.Context, entityType string, id uint) (int64, error)
+	// Purge permanently deletes a record
+	Purge(ctx context.Context, entityType string, id uint) error
+}
+
+// reference is a foreign key between two soft-deletable tables
+type reference struct {
+	entityType string // type of the table on the other side
+	column     string // foreign key column, on the child side
+}
+
+// trashTable describes a soft-deletable table and how it hangs in the hierarchy
+type trashTable struct {
+	table    string
+	parents  []reference // column holds the parent ID on this table
+	children []reference // column holds this table's ID on the child table
+}
+
+// TrashTypes lists the soft-deletable types in the order the trash shows them
+var TrashTypes = []string{"buildings", "floors", "rooms", "component-categories", "components", "reports", "users"}
+
+// trashTables maps every type in TrashTypes to its table
+var trashTables = map[string]trashTable{
+	"buildings": {
+		table:    "buildings",
+		children: []reference{{"floors", "building_id"}},
+	},
+	"floors": {
+		table:    "floors",
+		parents:  []reference{{"buildings", "building_id"}},
+		children: []reference{{"rooms", "floor_id"}},
+	},
+	"rooms": {
+		table:    "rooms",
+		parents:  []reference{{"floors", "floor_id"}},
+		children: []reference{{"components", "room_id"}, {"reports", "room_id"}},
+	},
+	"component-categories": {
+		table:    "component_categories",
+		children: []reference{{"components", "category_id"}},
+	},
+	"components": {
+		table:    "components",
+		parents:  []reference{{"rooms", "room_id"}, {"component-categories", "category_id"}},
+		children: []reference{{"reports", "component_id"}},
+	},
+	"reports": {
+		table:   "reports",
+		parents: []reference{{"rooms", "room_id"}, {"components", "component_id"}},
+	},
+	"users": {
+		table:    "users",
+		children: []reference{{"reports", "user_id"}},
+	},
+}
+
+// trashRepository is the GORM implementation of TrashRepository
+type trashRepository struct {
+	db *gorm.DB
+}
+
+// lookup returns the table of a type
+func lookup(entityType string) (trashTable, error) {
+	table, ok := trashTables[entityType]
+	if !ok {
+		return trashTable{}, fmt.Errorf("unknown trash type %q", entityType)
+	}
+	return table, nil
+}
+
+// List loads a page of deleted records, most recently deleted first
+func (r *trashRepository) List(ctx context.Context, entityType string, page Page) ([]TrashItem, utils.PageInfo, error) {
+	var info utils.PageInfo
+	types := TrashTypes
+	if entityType != "" {
+		if _, err := lookup(entityType); err != nil {
+			return nil, info, err
+		}
+		types = []string{entityType}
+	}
+
+	// The types are fixed identifiers, so they can be inlined as literals
+	selects := make([]string, len(types))
+	for i, t := range types {
+		selects[i] = fmt.Sprintf("SELECT '%s' AS type, id, name, deleted_at FROM %s WHERE deleted_at IS NOT NULL",
+			t, trashTables[t].table)
+	}
+	union := strings.Join(selects, " UNION ALL ")
+
+	db := r.db.WithContext(ctx)
+	if !page.SkipTotal {
+		var total int64
+		if err := db.Raw("SELECT COUNT(*) FROM (" + union + ") AS trash").Scan(&total).Error; err != nil {
+			return nil, info, err
+		}
+		info.Total = &total
+	}
+
+	var items []TrashItem
+	query := trashByTime.apply(db.Table("("+union+") AS trash"), page)
+	if err := query.Limit(page.Limit + 1).Scan(&items).Error; err != nil {
+		return nil, info, err
+	}
+	return trimPage(items, trashByTime, page, info)
+}
+
+// trashByTime sorts deleted records most recently deleted first
+var trashByTime = keyset{
+	columns: []sortColumn{{name: "deleted_at", desc: true}, {name: "type"}, {name: "id"}},
+	values:  func(cursor *utils.Cursor) []any { return []any{cursor.Time, cursor.Key, cursor.ID} },
+	cursor: func(record any) utils.Cursor {
+		item := record.(*TrashItem)
+		return utils.Cursor{ID: item.ID, Key: item.Type, Time: item.DeletedAt}
+	},
+}
+
+// Find loads a record whether or not it is deleted
+func (r *trashRepository) Find(ctx context.Context, entityType string, id uint) (*TrashItem, error) {
+	table, err := lookup(entityType)
+	if err != nil {
+		return nil, err
+	}
+
+	var items []TrashItem
+	err = r.db.WithContext(ctx).Table(table.table).
+		Select("? AS type, id, name, deleted_at", entityType).
+		Where("id = ?", id).
+		Limit(1).Scan(&items).Error
+	if err != nil {
+		return nil, err
+	}
+	if len(items) == 0 {
+		return nil, ErrNotFound
+	}
+	return &items[0], nil
+}
+
+// DeletedParent returns the type of the first deleted parent of a record
+func (r *trashRepository) DeletedParent(ctx context.Context, entityType string, id uint) (string, error) {
+	table, err := lookup(entityType)
+	if err != nil {
+		return "", err
+	}
+
+	for _, parent := range table.parents {
+		var count int64
+		err := r.db.WithContext(ctx).Table(trashTables[parent.entityType].table).
+			Where("deleted_at IS NOT NULL").
+			Where("id = (?)", r.db.Table(table.table).Select(parent.column).Where("id = ?", id)).
+			Count(&count).Error
+		if err != nil {
+			return "", err
+		}
+		if count > 0 {
+			return parent.entityType, nil
+		}
+	}
+	return "", nil
+}
+
+// Descendants collects the live records below a record, level by level
+// A record reachable on two paths, such as a report below both its room and its component, is listed once.
+func (r *trashRepository) Descendants(ctx context.Context, entityType string, id uint) (map[string][]uint, error) {
+	return r.descendants(ctx, entityType, id, false)
+}
+
+// LockDescendants collects the live records below a record and locks every collected row for update
+// Each level is locked before its children are read: a row that references a locked parent cannot be
+// inserted until the transaction ends. SQLite has no row locks, but it runs one write at a time.
+func (r *trashRepository) LockDescendants(ctx context.Context, entityType string, id uint) (map[string][]uint, error) {
+	return r.descendants(ctx, entityType, id, true)
+}
+
+// descendants implements Descendants and LockDescendants
+func (r *trashRepository) descendants(ctx context.Context, entityType string, id uint, lock bool) (map[string][]uint, error) {
+	if _, err := lookup(entityType); err != nil {
+		return nil, err
+	}
+
+	descendants := map[string][]uint{}
+	seen := map[string]map[uint]bool{}
+	level := map[string][]uint{entityType: {id}}
+	for len(level) > 0 {
+		next := map[string][]uint{}
+		for parentType, parentIDs := range level {
+			if lock {
+				var locked []uint
+				err := r.db.WithContext(ctx).Table(trashTables[parentType].table).
+					Clauses(clause.Locking{Strength: "UPDATE"}).
+					Where("id IN ?", parentIDs).
+					Pluck("id", &locked).Error
+				if err != nil {
+					return nil, err
+				}
+			}
+			for _, child := range trashTables[parentType].children {
+				var ids []uint
+				err := r.db.WithContext(ctx).Table(trashTables[child.entityType].table).
+					Where(child.column+" IN ?", parentIDs).
+					Where("deleted_at IS NULL").
+					Pluck("id", &ids).Error
+				if err != nil {
+					return nil, err
+				}
+				for _, childID := range ids {
+					if seen[child.entityType] == nil {
+						seen[child.entityType] = map[uint]bool{}
+					}
+					if seen[child.entityType][childID] {
+						continue
+					}
+					seen[child.entityType][childID] = true
+					next[child.entityType] = append(next[child.entityType], childID)
+					descendants[child.entityType] = append(descendants[child.entityType], childID)
+				}
+			}
+		}
+		level = next
+	}
+	return descendants, nil
+}
+
+// DeleteWithDescendants soft-deletes a record and its descendants in one transaction
+// They all share one deletion timestamp, which is how Restore recognises them as one deletion.
+func (r *trashRepository) DeleteWithDescendants(ctx context.Context, entityType string, id, version uint, descendants map[string][]uint) error {
+	table, err := lookup(entityType)
+	if err != nil {
+		return err
+	}
+
+	deleted := map[string][]uint{entityType: {id}}
+	for t, ids := range descendants {
+		deleted[t] = append(deleted[t], ids...)
+	}
+
+	stamp := time.Now()
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := auditRows(tx, models.AuditActionDelete, deleted); err != nil {
+			return err
+		}
+		for _, t := range TrashTypes {
+			ids := descendants[t]
+			if len(ids) == 0 {
+				continue
+			}
+			err := tx.Table(trashTables[t].table).Where("id IN ? AND deleted_at IS NULL", ids).
+				UpdateColumn("deleted_at", stamp).Error
+			if err != nil {
+				return err
+			}
+		}
+		result := tx.Table(table.table).Where("id = ? AND version = ? AND deleted_at IS NULL", id, version).
+			UpdateColumn("deleted_at", stamp)
+		if result.Error == nil && result.RowsAffected == 0 {
+			return ErrStaleVersion
+		}
+		return result.Error
+	})
+}
+
+// Restore undeletes a record and every descendant that carries the same deletion timestamp
+// A cascading delete stamps the whole subtree with one timestamp, so this brings back exactly
+// what was deleted together, and leaves alone children that had been deleted on their own before.
+func (r *trashRepository) Restore(ctx context.Context, entityType string, id uint) error {
+	table, err := lookup(entityType)
+	if err != nil {
+		return err
+	}
+
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		stamp := tx.Table(table.table).Select("deleted_at").Where("id = ?", id)
+
+		// Collect the subtree first, while the root still carries its timestamp
+		restore := map[string][]uint{entityType: {id}}
+		level := map[string][]uint{entityType: {id}}
+		for len(level) > 0 {
+			next := map[string][]uint{}
+			for parentType, parentIDs := range level {
+				for _, child := range trashTables[parentType].children {
+					var ids []uint
+					err := tx.Table(trashTables[child.entityType].table).
+						Where(child.column+" IN ?", parentIDs).
+						Where("deleted_at = (?)", stamp).
+						Pluck("id", &ids).Error
+					if err != nil {
+						return err
+					}
+					if len(ids) > 0 {
+						next[child.entityType] = append(next[child.entityType], ids...)
+						restore[child.entityType] = append(restore[child.entityType], ids...)
+					}
+				}
+			}
+			level = next
+		}
+
+		// The root goes last, because the descendants are matched against its timestamp
+		for _, t := range TrashTypes {
+			ids := restore[t]
+			if t == entityType {
+				ids = ids[1:]
+			}
+			if len(ids) == 0 {
+				continue
+			}
+			err := tx.Table(trashTables[t].table).Where("id IN ?", ids).
+				UpdateColumn("deleted_at", nil).Error
+			if err != nil {
+				return translateError(err)
+			}
+		}
+		err := tx.Table(table.table).Where("id = ?", id).UpdateColumn("deleted_at", nil).Error
+		if err != nil {
+			return translateError(err)
+		}
+		return auditRows(tx, models.AuditActionRestore, restore)
+	})
+}
+
+// Dependents counts the records that reference a record, soft-deleted ones included
+func (r *trashRepository) Dependents(ctx context.Context, entityType string, id uint) (int64, error) {
+	table, err := lookup(entityType)
+	if err != nil {
+		return 0, err
+	}
+
+	var total int64
+	for _, child := range table.children {
+		var count int64
+		err := r.db.WithContext(ctx).Table(trashTables[child.entityType].table).
+			Where(child.column+" = ?", id).
+			Count(&count).Error
+		if err != nil {
+			return 0, err
+		}
+		total += count
+	}
+	return total, nil
+}
+
+// Purge permanently deletes a record; a report's tag links go with it
+func (r *trashRepository) Purge(ctx context.Context, entityType string, id uint) error {
+	table, err := lookup(entityType)
+	if err != nil {
+		return err
+	}
+
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := auditRows(tx, models.AuditActionPurge, map[string][]uint{entityType: {id}}); err != nil {
+			return err
+		}
+		if entityType == "reports" {
+			if err := tx.Exec("DELETE FROM report_tags WHERE report_id = ?", id).Error; err != nil {
+				return err
+			}
+		}
+		return tx.Exec("DELETE FROM "+table.table+" WHERE id = ?", id).Error
+	})
+}
@@ -0,0 +1,27 @@
+package repositories
+
+import (
+	"context"
+	"incident-report/models"
+	"incident-report/utils"
+)
+
+// UserRepository provides access to users
+type UserRepository interface {
+	Create(ctx context.Context, user *models.User) error
+	FindByID(ctx context.Context, id uint) (*models.User, error)
+	List(ctx context.Context, page Page) ([]models.User, utils.PageInfo, error)
+	Save(ctx context.Context, user *models.User) error
+	EmailTaken(ctx context.Context, email string, exceptID uint) (bool, error)
+	Delete(ctx context.Context, user *models.User) error
+}
+
+// userRepository is the GORM implementation of UserRepository
+type userRepository struct {
+	crudRepository[models.User]
+}
+
+// EmailTaken reports whether another live user already uses the email
+func (r *userRepository) EmailTaken(ctx context.Context, email string, exceptID uint) (bool, error) {
+	return r.taken(ctx, "email", email, exceptID)
+}
@@ -0,0 +1,93 @@
+package routes_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestAnalyticsEndpoints(t *testing.T) {
+	router := newTestRouter(t)
+	f := seed(t, router)
+
+	today := time.Now().Format("2006-01-02")
+	scope := "?from=" + today + "&to=" + today
+
+	rows := func(want int) func(t *testing.T, rec *httptest.ResponseRecorder) {
+		return func(t *testing.T, rec *httptest.ResponseRecorder) {
+			t.Helper()
+
+			var data []map[string]any
+			decodeData(t, rec, &data)
+			if len(data) != want {
+				t.Errorf("got %d rows, want %d", len(data), want)
+			}
+		}
+	}
+
+	// The seeded report is assigned but untagged, so it is missing from the tag counts
+	endpoints := []struct {
+		name string
+		rows int
+	}{
+		{"volume", 1}, {"top-components", 1}, {"top-categories", 1}, {"hotspots/buildings", 1},
+		{"hotspots/floors", 1}, {"technicians", 1}, {"tags", 0},
+	}
+
+	var cases []apiCase
+	for _, e := range endpoints {
+		endpoint, want := e.name, e.rows
+		cases = append(cases,
+			apiCase{name: endpoint, method: http.MethodGet, path: path("/analytics/%s%s", endpoint, scope),
+				status: http.StatusOK, check: rows(want)},
+			apiCase{name: endpoint + " other building", method: http.MethodGet, path: path("/analytics/%s%s&building_id=999", endpoint, scope),
+				status: http.StatusOK, check: rows(0)},
+			apiCase{name: endpoint + " invalid date", method: http.MethodGet, path: path("/analytics/%s?from=yesterday", endpoint),
+				status: http.StatusBadRequest},
+		)
+	}
+
+	cases = append(cases,
+		apiCase{name: "volume by week", method: http.MethodGet, path: path("/analytics/volume%s&interval=week", scope),
+			status: http.StatusOK, check: rows(1)},
+		apiCase{name: "volume invalid interval", method: http.MethodGet, path: path("/analytics/volume?interval=month"),
+			status: http.StatusBadRequest},
+		apiCase{name: "top components limit", method: http.MethodGet, path: path("/analytics/top-components?limit=100"),
+			status: http.StatusOK, check: rows(1)},
+		apiCase{name: "top components limit too large", method: http.MethodGet, path: path("/analytics/top-components?limit=101"),
+			status: http.StatusBadRequest},
+		apiCase{name: "resolution time", method: http.MethodGet, path: path("/analytics/resolution-time%s&building_id=%d", scope, f.BuildingID),
+			status: http.StatusOK, check: expectField("resolved", 0)},
+		apiCase{name: "resolution time invalid date", method: http.MethodGet, path: path("/analytics/resolution-time?to=2024-13-01"),
+			status: http.StatusBadRequest},
+	)
+
+	runCases(t, router, cases)
+}
+
+func TestServiceEndpoints(t *testing.T) {
+	router := newTestRouter(t)
+
+	runCases(t, router, []apiCase{
+		{name: "health", method: http.MethodGet, path: path("/health"),
+			status: http.StatusOK, check: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				if resp := decode(t, rec); resp.Message != "Server is running" {
+					t.Errorf("message = %q", resp.Message)
+				}
+			}},
+		{name: "swagger ui", method: http.MethodGet, path: "/swagger",
+			status: http.StatusOK, check: expectContentType("text/html")},
+		{name: "swagger ui index", method: http.MethodGet, path: "/swagger/index.html",
+			status: http.StatusMovedPermanently, check: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				// http.ServeFile canonicalises index.html requests to their directory
+				if location := rec.Header().Get("Location"); location != "./" {
+					t.Errorf("Location = %q, want ./", location)
+				}
+			}},
+		{name: "openapi spec", method: http.MethodGet, path: "/openapi.json",
+			status: http.StatusOK, check: expectContentType("application/json")},
+		{name: "unknown route", method: http.MethodGet, path: path("/nothing"),
+			status: http.StatusNotFound},
+	})
+}
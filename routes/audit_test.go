@@ -0,0 +1,56 @@
+package routes_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// expectRequestID asserts that the first audit entry was made in the given request
+func expectRequestID(want string) func(t *testing.T, rec *httptest.ResponseRecorder) {
+	return func(t *testing.T, rec *httptest.ResponseRecorder) {
+		t.Helper()
+
+		var p struct {
+			Data []struct {
+				RequestID string `json:"request_id"`
+				IP        string `json:"ip"`
+			} `json:"data"`
+		}
+		decodeData(t, rec, &p)
+		if len(p.Data) == 0 || p.Data[0].RequestID != want || p.Data[0].IP == "" {
+			t.Errorf("entries = %+v, want the latest made in request %s", p.Data, want)
+		}
+	}
+}
+
+func TestAuditEndpoints(t *testing.T) {
+	router := newTestRouter(t)
+	f := seed(t, router)
+
+	runCases(t, router, []apiCase{
+		{name: "seeded records", method: http.MethodGet, path: path("/audit"),
+			status: http.StatusOK, check: expectPage(8, 1, 10, 8)},
+		{name: "update room", method: http.MethodPut, path: path("/rooms/%d", f.RoomID),
+			body:   map[string]any{"code": "R102"},
+			header: map[string]string{"X-User-ID": "1", "X-Request-ID": "change-room-code"},
+			status: http.StatusOK},
+		{name: "room history", method: http.MethodGet, path: path("/audit?entity=rooms&entity_id=%d", f.RoomID),
+			status: http.StatusOK, check: expectRequestID("change-room-code")},
+		{name: "by actor", method: http.MethodGet, path: path("/audit?actor=%d", f.UserID),
+			status: http.StatusOK, check: expectPage(1, 1, 10, 1)},
+		{name: "by day", method: http.MethodGet, path: path("/audit?from=2000-01-01&to=2000-01-31"),
+			status: http.StatusOK, check: expectPage(0, 1, 10, 0)},
+
+		{name: "request id is generated", method: http.MethodGet, path: path("/audit"),
+			status: http.StatusOK, check: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				if len(rec.Header().Get("X-Request-ID")) != 32 {
+					t.Errorf("X-Request-ID = %q, want a generated ID", rec.Header().Get("X-Request-ID"))
+				}
+			}},
+		{name: "invalid entity", method: http.MethodGet, path: path("/audit?entity=trash"),
+			status: http.StatusBadRequest, check: expectDetail("entity", "oneof")},
+		{name: "invalid date", method: http.MethodGet, path: path("/audit?from=yesterday"),
+			status: http.StatusBadRequest, check: expectDetail("from", "datetime")},
+	})
+}
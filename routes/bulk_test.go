@@ -0,0 +1,84 @@
+package routes_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"incident-report/utils"
+)
+
+// expectBulk asserts the outcome counts of a bulk response
+func expectBulk(committed bool, succeeded, failed int) func(t *testing.T, rec *httptest.ResponseRecorder) {
+	return func(t *testing.T, rec *httptest.ResponseRecorder) {
+		t.Helper()
+
+		var got utils.BulkResponse
+		decodeData(t, rec, &got)
+		if got.Committed != committed || got.Succeeded != succeeded || got.Failed != failed {
+			t.Errorf("bulk = committed %v, %d succeeded, %d failed; want %v, %d, %d",
+				got.Committed, got.Succeeded, got.Failed, committed, succeeded, failed)
+		}
+	}
+}
+
+func TestBulkEndpoints(t *testing.T) {
+	router := newTestRouter(t)
+	f := seed(t, router)
+
+	second := create(t, router, "/api/v1/reports", map[string]any{
+		"name": "Dead socket", "room_id": f.RoomID, "component_id": f.ComponentID, "status": "PENDING",
+	})
+	room := create(t, router, "/api/v1/rooms", map[string]any{"floor_id": f.FloorID, "code": "R102", "name": "Lab 102"})
+
+	runCases(t, router, []apiCase{
+		{name: "atomic failure rolls back", method: http.MethodPost, path: path("/reports/bulk"),
+			body:   map[string]any{"action": "assign", "ids": []uint{f.ReportID, 999, second}, "user_id": f.UserID},
+			status: http.StatusConflict, check: expectBulk(false, 0, 1)},
+		{name: "second report not assigned", method: http.MethodGet, path: path("/reports?user_id=%d", f.UserID),
+			status: http.StatusOK, check: expectPage(1, 1, 10, 1)},
+		{name: "best effort keeps successes", method: http.MethodPost, path: path("/reports/bulk"),
+			body:   map[string]any{"action": "assign", "ids": []uint{f.ReportID, 999, second}, "user_id": f.UserID, "mode": "best_effort"},
+			status: http.StatusOK, check: expectBulk(true, 2, 1)},
+		{name: "both assigned", method: http.MethodGet, path: path("/reports?user_id=%d", f.UserID),
+			status: http.StatusOK, check: expectPage(2, 1, 10, 2)},
+		{name: "transition by filter", method: http.MethodPost, path: path("/reports/bulk"),
+			body:   map[string]any{"action": "transition", "filter": map[string]any{"user_id": f.UserID}, "status": "COMPLETED"},
+			status: http.StatusOK, check: expectBulk(true, 2, 0)},
+		{name: "assign without user", method: http.MethodPost, path: path("/reports/bulk"),
+			body:   map[string]any{"action": "assign", "ids": []uint{second}},
+			status: http.StatusBadRequest, check: expectDetail("user_id", "")},
+		{name: "assign null user", method: http.MethodPost, path: path("/reports/bulk"),
+			body:   map[string]any{"action": "assign", "ids": []uint{second}, "user_id": nil},
+			status: http.StatusBadRequest, check: expectDetail("user_id", "")},
+		{name: "unassign", method: http.MethodPost, path: path("/reports/bulk"),
+			body:   map[string]any{"action": "unassign", "ids": []uint{second}},
+			status: http.StatusOK, check: expectBulk(true, 1, 0)},
+		{name: "second report unassigned", method: http.MethodGet, path: path("/reports/%d", second),
+			status: http.StatusOK, check: expectField("user_id", nil)},
+		{name: "tag", method: http.MethodPost, path: path("/reports/bulk"),
+			body:   map[string]any{"action": "tag", "ids": []uint{second}, "tag_ids": []uint{f.TagID}},
+			status: http.StatusOK, check: expectBulk(true, 1, 0)},
+		{name: "delete", method: http.MethodPost, path: path("/reports/bulk"),
+			body:   map[string]any{"action": "delete", "filter": map[string]any{"status": "COMPLETED"}},
+			status: http.StatusOK, check: expectBulk(true, 2, 0)},
+		{name: "deleted", method: http.MethodGet, path: path("/reports"),
+			status: http.StatusOK, check: expectPage(0, 1, 10, 0)},
+
+		{name: "unknown action", method: http.MethodPost, path: path("/reports/bulk"),
+			body: map[string]any{"action": "archive", "ids": []uint{second}}, status: http.StatusBadRequest, check: expectDetail("action", "oneof")},
+		{name: "no selection", method: http.MethodPost, path: path("/reports/bulk"),
+			body: map[string]any{"action": "delete"}, status: http.StatusBadRequest, check: expectCode("VALIDATION_FAILED")},
+		{name: "unknown mode", method: http.MethodPost, path: path("/reports/bulk"),
+			body: map[string]any{"action": "delete", "ids": []uint{second}, "mode": "sometimes"}, status: http.StatusBadRequest, check: expectDetail("mode", "oneof")},
+
+		{name: "move components", method: http.MethodPost, path: path("/components/bulk-move"),
+			body: map[string]any{"ids": []uint{f.ComponentID}, "room_id": room}, status: http.StatusOK, check: expectBulk(true, 1, 0)},
+		{name: "component moved", method: http.MethodGet, path: path("/components/%d", f.ComponentID),
+			status: http.StatusOK, check: expectField("room_id", room)},
+		{name: "move to missing room", method: http.MethodPost, path: path("/components/bulk-move"),
+			body: map[string]any{"ids": []uint{f.ComponentID}, "room_id": 999}, status: http.StatusBadRequest, check: expectDetail("room_id", "")},
+		{name: "move without ids", method: http.MethodPost, path: path("/components/bulk-move"),
+			body: map[string]any{"room_id": room}, status: http.StatusBadRequest, check: expectDetail("ids", "required")},
+	})
+}
@@ -0,0 +1,159 @@
+package routes_test
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestComponentCategoryEndpoints(t *testing.T) {
+	router := newTestRouter(t)
+	f := seed(t, router)
+
+	runCases(t, router, []apiCase{
+		{name: "create", method: http.MethodPost, path: path("/component-categories"),
+			body: map[string]any{"code": "AC", "name": "Air conditioner", "description": "Split units"}, status: http.StatusCreated,
+			check: expectField("description", "Split units")},
+		{name: "create missing name", method: http.MethodPost, path: path("/component-categories"),
+			body: map[string]any{"code": "PC"}, status: http.StatusBadRequest},
+		{name: "create duplicate code", method: http.MethodPost, path: path("/component-categories"),
+			body: map[string]any{"code": "PRJ", "name": "Projector copy"}, status: http.StatusConflict,
+			check: expectConflict("code")},
+
+		{name: "list", method: http.MethodGet, path: path("/component-categories"),
+			status: http.StatusOK, check: expectPage(2, 1, 10, 2)},
+		{name: "list second page", method: http.MethodGet, path: path("/component-categories?page=2&page_size=1"),
+			status: http.StatusOK, check: expectPage(2, 2, 1, 1)},
+		{name: "list page size too large", method: http.MethodGet, path: path("/component-categories?page_size=101"),
+			status: http.StatusBadRequest},
+
+		{name: "get", method: http.MethodGet, path: path("/component-categories/%d", f.CategoryID),
+			status: http.StatusOK, check: expectField("code", "PRJ")},
+		{name: "get not found", method: http.MethodGet, path: path("/component-categories/999"),
+			status: http.StatusNotFound},
+		{name: "get invalid id", method: http.MethodGet, path: path("/component-categories/x"),
+			status: http.StatusBadRequest},
+
+		{name: "components", method: http.MethodGet, path: path("/component-categories/%d/components", f.CategoryID),
+			status: http.StatusOK, check: expectPage(1, 1, 10, 1)},
+		{name: "components invalid id", method: http.MethodGet, path: path("/component-categories/x/components"),
+			status: http.StatusBadRequest},
+
+		{name: "reliability", method: http.MethodGet, path: path("/component-categories/%d/reliability", f.CategoryID),
+			status: http.StatusOK, check: expectField("failures", 1)},
+		{name: "reliability not found", method: http.MethodGet, path: path("/component-categories/999/reliability"),
+			status: http.StatusNotFound},
+
+		{name: "update", method: http.MethodPut, path: path("/component-categories/%d", f.CategoryID),
+			body: map[string]any{"name": "Projectors"}, status: http.StatusOK,
+			check: expectField("name", "Projectors")},
+		{name: "update not found", method: http.MethodPut, path: path("/component-categories/999"),
+			body: map[string]any{"name": "Nothing"}, status: http.StatusNotFound},
+
+		{name: "delete impact", method: http.MethodGet, path: path("/component-categories/%d/delete-impact", f.CategoryID),
+			status: http.StatusOK, check: expectImpact(0, 0, 1, 1, 1)},
+		{name: "delete with open report", method: http.MethodDelete, path: path("/component-categories/%d?cascade=true", f.CategoryID),
+			status: http.StatusConflict},
+		{name: "complete report", method: http.MethodPut, path: path("/reports/%d", f.ReportID),
+			body: map[string]any{"status": "COMPLETED"}, status: http.StatusOK},
+		{name: "delete", method: http.MethodDelete, path: path("/component-categories/%d?cascade=true", f.CategoryID),
+			status: http.StatusNoContent},
+		{name: "get deleted", method: http.MethodGet, path: path("/component-categories/%d", f.CategoryID),
+			status: http.StatusNotFound},
+		{name: "delete not found", method: http.MethodDelete, path: path("/component-categories/999"),
+			status: http.StatusNotFound},
+	})
+}
+
+func TestComponentEndpoints(t *testing.T) {
+	router := newTestRouter(t)
+	f := seed(t, router)
+	otherRoom := create(t, router, "/api/v1/rooms", map[string]any{"floor_id": f.FloorID, "code": "R102", "name": "Lab 102"})
+
+	runCases(t, router, []apiCase{
+		{name: "create", method: http.MethodPost, path: path("/components"),
+			body: map[string]any{"category_id": f.CategoryID, "code": "PRJ-2", "name": "Projector 2"}, status: http.StatusCreated,
+			check: expectField("room_id", nil)},
+		{name: "create unknown category", method: http.MethodPost, path: path("/components"),
+			body: map[string]any{"category_id": 999, "code": "PRJ-3", "name": "Projector 3"}, status: http.StatusBadRequest,
+			check: expectError("component category not found")},
+		{name: "create unknown room", method: http.MethodPost, path: path("/components"),
+			body: map[string]any{"room_id": 999, "category_id": f.CategoryID, "code": "PRJ-3", "name": "Projector 3"}, status: http.StatusBadRequest,
+			check: expectError("room not found")},
+		{name: "create wrong type", method: http.MethodPost, path: path("/components"),
+			body: map[string]any{"category_id": "PRJ", "code": "PRJ-3", "name": "Projector 3"}, status: http.StatusBadRequest,
+			check: expectDetail("category_id", "type")},
+		{name: "create missing code", method: http.MethodPost, path: path("/components"),
+			body: map[string]any{"category_id": f.CategoryID, "name": "Projector 3"}, status: http.StatusBadRequest},
+
+		{name: "list", method: http.MethodGet, path: path("/components"),
+			status: http.StatusOK, check: expectPage(2, 1, 10, 2)},
+		{name: "list by room", method: http.MethodGet, path: path("/components?room_id=%d", f.RoomID),
+			status: http.StatusOK, check: expectPage(1, 1, 10, 1)},
+		{name: "list by category paginated", method: http.MethodGet, path: path("/components?category_id=%d&page=2&page_size=1", f.CategoryID),
+			status: http.StatusOK, check: expectPage(2, 2, 1, 1)},
+		{name: "list page size too large", method: http.MethodGet, path: path("/components?page_size=101"),
+			status: http.StatusBadRequest},
+		{name: "export csv", method: http.MethodGet, path: path("/components?format=csv"),
+			status: http.StatusOK, check: expectContentType("text/csv")},
+		{name: "export xlsx", method: http.MethodGet, path: path("/components?format=xlsx"),
+			status: http.StatusOK, check: expectContentType("application/vnd.openxmlformats-officedocument.spreadsheetml.sheet")},
+		{name: "export via accept header", method: http.MethodGet, path: path("/components"),
+			header: map[string]string{"Accept": "application/pdf"},
+			status: http.StatusOK, check: expectContentType("application/pdf")},
+		{name: "accept lists csv first", method: http.MethodGet, path: path("/components"),
+			header: map[string]string{"Accept": "text/csv, application/pdf"},
+			status: http.StatusOK, check: expectContentType("text/csv")},
+		{name: "accept lists pdf first", method: http.MethodGet, path: path("/components"),
+			header: map[string]string{"Accept": "application/pdf, text/csv"},
+			status: http.StatusOK, check: expectContentType("application/pdf")},
+		{name: "accept prefers by q-value", method: http.MethodGet, path: path("/components"),
+			header: map[string]string{"Accept": "text/csv;q=0.5, application/vnd.openxmlformats-officedocument.spreadsheetml.sheet;q=0.8"},
+			status: http.StatusOK, check: expectContentType("application/vnd.openxmlformats-officedocument.spreadsheetml.sheet")},
+		{name: "accept prefers json", method: http.MethodGet, path: path("/components"),
+			header: map[string]string{"Accept": "application/json, text/csv;q=0.9, */*"},
+			status: http.StatusOK, check: expectContentType("application/json")},
+		{name: "export unknown format", method: http.MethodGet, path: path("/components?format=doc"),
+			status: http.StatusBadRequest},
+
+		{name: "replacement candidates", method: http.MethodGet, path: path("/components/replacement-candidates?limit=1"),
+			status: http.StatusOK},
+		{name: "replacement candidates negative weight", method: http.MethodGet, path: path("/components/replacement-candidates?age_weight=-1"),
+			status: http.StatusBadRequest},
+
+		{name: "get", method: http.MethodGet, path: path("/components/%d", f.ComponentID),
+			status: http.StatusOK, check: expectField("code", "PRJ-1")},
+		{name: "get not found", method: http.MethodGet, path: path("/components/999"),
+			status: http.StatusNotFound},
+		{name: "get invalid id", method: http.MethodGet, path: path("/components/x"),
+			status: http.StatusBadRequest},
+
+		{name: "reliability", method: http.MethodGet, path: path("/components/%d/reliability", f.ComponentID),
+			status: http.StatusOK, check: expectField("failures", 1)},
+		{name: "reliability not found", method: http.MethodGet, path: path("/components/999/reliability"),
+			status: http.StatusNotFound},
+
+		{name: "update", method: http.MethodPut, path: path("/components/%d", f.ComponentID),
+			body: map[string]any{"brand": "Epson"}, status: http.StatusOK,
+			check: expectField("brand", "Epson")},
+		{name: "update not found", method: http.MethodPut, path: path("/components/999"),
+			body: map[string]any{"brand": "Epson"}, status: http.StatusNotFound},
+
+		{name: "assign room", method: http.MethodPut, path: path("/components/%d/assign-room", f.ComponentID),
+			body: map[string]any{"room_id": otherRoom}, status: http.StatusOK,
+			check: expectField("room_id", otherRoom)},
+		{name: "assign unknown room", method: http.MethodPut, path: path("/components/%d/assign-room", f.ComponentID),
+			body: map[string]any{"room_id": 999}, status: http.StatusBadRequest,
+			check: expectError("room not found")},
+		{name: "assign room missing body", method: http.MethodPut, path: path("/components/%d/assign-room", f.ComponentID),
+			body: map[string]any{}, status: http.StatusBadRequest},
+		{name: "components of new room", method: http.MethodGet, path: path("/rooms/%d/components", otherRoom),
+			status: http.StatusOK, check: expectPage(1, 1, 10, 1)},
+
+		{name: "delete", method: http.MethodDelete, path: path("/components/%d", f.ComponentID),
+			status: http.StatusNoContent},
+		{name: "get deleted", method: http.MethodGet, path: path("/components/%d", f.ComponentID),
+			status: http.StatusNotFound},
+		{name: "delete not found", method: http.MethodDelete, path: path("/components/999"),
+			status: http.StatusNotFound},
+	})
+}
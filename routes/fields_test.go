@@ -0,0 +1,106 @@
+package routes_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// expectKeys asserts the field names of the response data, or of every record of a page
+func expectKeys(want ...string) func(t *testing.T, rec *httptest.ResponseRecorder) {
+	return func(t *testing.T, rec *httptest.ResponseRecorder) {
+		t.Helper()
+
+		records := []map[string]any{}
+		var p page
+		if decodeData(t, rec, &p); p.Data != nil {
+			records = p.Data
+		} else {
+			var record map[string]any
+			decodeData(t, rec, &record)
+			records = append(records, record)
+		}
+		if len(records) == 0 {
+			t.Fatal("no records in the response")
+		}
+		for _, record := range records {
+			var got []string
+			for key := range record {
+				got = append(got, key)
+			}
+			slices.Sort(got)
+			if strings.Join(got, ",") != strings.Join(want, ",") {
+				t.Errorf("fields = %v, want %v", got, want)
+			}
+		}
+	}
+}
+
+// expectNested asserts a field of a nested object of the first record of a page
+func expectNested(want any, keys ...string) func(t *testing.T, rec *httptest.ResponseRecorder) {
+	return func(t *testing.T, rec *httptest.ResponseRecorder) {
+		t.Helper()
+
+		var p page
+		decodeData(t, rec, &p)
+		if len(p.Data) == 0 {
+			t.Fatal("no records in the response")
+		}
+		var value any = p.Data[0]
+		for _, key := range keys {
+			object, _ := value.(map[string]any)
+			value = object[key]
+		}
+		if value != want {
+			t.Errorf("%s = %v, want %v", strings.Join(keys, "."), value, want)
+		}
+	}
+}
+
+func TestSparseFieldsets(t *testing.T) {
+	router := newTestRouter(t)
+	f := seed(t, router)
+
+	runCases(t, router, []apiCase{
+		{name: "list", method: http.MethodGet, path: path("/components?fields=id,code"),
+			status: http.StatusOK, check: expectKeys("code", "id")},
+		{name: "single record", method: http.MethodGet, path: path("/reports/%d?fields=name,status", f.ReportID),
+			status: http.StatusOK, check: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				expectKeys("name", "status")(t, rec)
+				expectETag(`"1"`)(t, rec)
+			}},
+		{name: "page envelope is kept", method: http.MethodGet, path: path("/tags?fields=name"),
+			status: http.StatusOK, check: expectPage(1, 1, 10, 1)},
+		{name: "renamed column", method: http.MethodGet, path: path("/floors?fields=floor_number"),
+			status: http.StatusOK, check: expectKeys("floor_number")},
+		{name: "nested object", method: http.MethodGet, path: path("/rooms?fields=code,floor"),
+			status: http.StatusOK, check: expectNested("B1", "floor", "building", "code")},
+		{name: "analytics", method: http.MethodGet, path: path("/analytics/tags?fields=tag_name,reports"),
+			status: http.StatusOK},
+
+		{name: "unknown field", method: http.MethodGet, path: path("/components?fields=id,colour"),
+			status: http.StatusBadRequest, check: expectDetail("fields", "")},
+		{name: "malformed field", method: http.MethodGet, path: path("/components?fields=id,Drop"),
+			status: http.StatusBadRequest, check: expectDetail("fields", "")},
+		{name: "writes ignore fields", method: http.MethodPost, path: path("/tags?fields=id"),
+			body: map[string]any{"name": "urgent"}, status: http.StatusCreated, check: expectField("name", "urgent")},
+	})
+}
+
+func TestListsNestFullObjects(t *testing.T) {
+	router := newTestRouter(t)
+	seed(t, router)
+
+	runCases(t, router, []apiCase{
+		{name: "room building", method: http.MethodGet, path: path("/rooms"),
+			status: http.StatusOK, check: expectNested(float64(1), "floor", "building", "version")},
+		{name: "floor building", method: http.MethodGet, path: path("/floors"),
+			status: http.StatusOK, check: expectNested(float64(1), "building", "version")},
+		{name: "component room", method: http.MethodGet, path: path("/components"),
+			status: http.StatusOK, check: expectNested("R101", "room", "code")},
+		{name: "component building", method: http.MethodGet, path: path("/components"),
+			status: http.StatusOK, check: expectNested("B1", "room", "floor", "building", "code")},
+	})
+}
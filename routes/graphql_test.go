@@ -0,0 +1,360 @@
+package routes_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync/atomic"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// graphQLResult mirrors a GraphQL response with the data left raw
+type graphQLResult struct {
+	Data   json.RawMessage `json:"data"`
+	Errors []struct {
+		Message    string `json:"message"`
+		Extensions struct {
+			Code    string `json:"code"`
+			Details []struct {
+				Field string `json:"field"`
+				Rule  string `json:"rule"`
+			} `json:"details"`
+		} `json:"extensions"`
+	} `json:"errors"`
+}
+
+// graphQL posts a document to /graphql as the given user and decodes the response
+func graphQL(t *testing.T, router *gin.Engine, userID uint, query string, variables map[string]any) graphQLResult {
+	t.Helper()
+
+	rec := doRequest(t, router, http.MethodPost, "/graphql",
+		map[string]any{"query": query, "variables": variables}, asUser(userID))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("POST /graphql = %d: %s", rec.Code, rec.Body.String())
+	}
+	var result graphQLResult
+	if err := json.Unmarshal(rec.Body.Bytes(), &result); err != nil {
+		t.Fatalf("decode response: %v: %s", err, rec.Body.String())
+	}
+	return result
+}
+
+// graphQLData runs a document that must succeed and decodes its data into out
+func graphQLData(t *testing.T, router *gin.Engine, userID uint, query string, variables map[string]any, out any) {
+	t.Helper()
+
+	result := graphQL(t, router, userID, query, variables)
+	if len(result.Errors) > 0 {
+		t.Fatalf("errors = %+v", result.Errors)
+	}
+	if err := json.Unmarshal(result.Data, out); err != nil {
+		t.Fatalf("decode data: %v: %s", err, result.Data)
+	}
+}
+
+// expectGraphQLCode asserts the code of the first error of a response
+func expectGraphQLCode(t *testing.T, result graphQLResult, code string) {
+	t.Helper()
+
+	if len(result.Errors) == 0 {
+		t.Fatalf("no errors, want %s: %s", code, result.Data)
+	}
+	if got := result.Errors[0].Extensions.Code; got != code {
+		t.Errorf("code = %q, want %q (%s)", got, code, result.Errors[0].Message)
+	}
+}
+
+// countQueries counts the SELECTs run against db
+func countQueries(t *testing.T, db *gorm.DB) *atomic.Int64 {
+	t.Helper()
+
+	var n atomic.Int64
+	err := db.Callback().Query().After("gorm:query").Register("test:count_queries", func(*gorm.DB) {
+		n.Add(1)
+	})
+	if err != nil {
+		t.Fatalf("register callback: %v", err)
+	}
+	return &n
+}
+
+const hierarchyQuery = `{
+  buildings {
+    nodes {
+      code
+      floors {
+        name
+        rooms {
+          code
+          floor { building { code } }
+          components {
+            code
+            category { code }
+            reports(open: true) {
+              name
+              assignee { name }
+              tags { name }
+            }
+          }
+        }
+      }
+    }
+    pageInfo { total }
+  }
+}`
+
+type hierarchyData struct {
+	Buildings struct {
+		Nodes []struct {
+			Code   string
+			Floors []struct {
+				Name  string
+				Rooms []struct {
+					Code  string
+					Floor struct {
+						Building struct{ Code string }
+					}
+					Components []struct {
+						Code     string
+						Category struct{ Code string }
+						Reports  []struct {
+							Name     string
+							Assignee *struct{ Name string }
+							Tags     []struct{ Name string }
+						}
+					}
+				}
+			}
+		}
+		PageInfo struct{ Total int }
+	}
+}
+
+func TestGraphQLHierarchy(t *testing.T) {
+	router := newTestRouter(t)
+	f := seed(t, router)
+
+	rec := doRequest(t, router, http.MethodPost, path("/reports/%d/tags", f.ReportID),
+		map[string]any{"tag_ids": []uint{f.TagID}}, nil)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("tag report = %d: %s", rec.Code, rec.Body.String())
+	}
+	create(t, router, "/api/v1/reports", map[string]any{
+		"name": "Fixed projector", "room_id": f.RoomID, "component_id": f.ComponentID, "status": "COMPLETED",
+	})
+
+	var data hierarchyData
+	graphQLData(t, router, f.UserID, hierarchyQuery, nil, &data)
+
+	if len(data.Buildings.Nodes) != 1 || data.Buildings.PageInfo.Total != 1 {
+		t.Fatalf("buildings = %+v", data.Buildings)
+	}
+	building := data.Buildings.Nodes[0]
+	if building.Code != "B1" || len(building.Floors) != 1 || len(building.Floors[0].Rooms) != 1 {
+		t.Fatalf("building = %+v", building)
+	}
+	room := building.Floors[0].Rooms[0]
+	if room.Code != "R101" || room.Floor.Building.Code != "B1" || len(room.Components) != 1 {
+		t.Fatalf("room = %+v", room)
+	}
+	component := room.Components[0]
+	if component.Category.Code != "PRJ" {
+		t.Errorf("category = %q, want PRJ", component.Category.Code)
+	}
+	if len(component.Reports) != 1 {
+		t.Fatalf("open reports = %+v, want only the pending one", component.Reports)
+	}
+	report := component.Reports[0]
+	if report.Name != "Broken projector" || report.Assignee == nil || report.Assignee.Name != "Tech One" {
+		t.Errorf("report = %+v", report)
+	}
+	if len(report.Tags) != 1 || report.Tags[0].Name != "electrical" {
+		t.Errorf("tags = %+v", report.Tags)
+	}
+}
+
+func TestGraphQLBatchesRelations(t *testing.T) {
+	router, db := newTestServer(t)
+	f := seed(t, router)
+	rec := doRequest(t, router, http.MethodPost, path("/reports/%d/tags", f.ReportID),
+		map[string]any{"tag_ids": []uint{f.TagID}}, nil)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("tag report = %d: %s", rec.Code, rec.Body.String())
+	}
+	queries := countQueries(t, db)
+
+	run := func() int64 {
+		queries.Store(0)
+		var data hierarchyData
+		graphQLData(t, router, f.UserID, hierarchyQuery, nil, &data)
+		return queries.Load()
+	}
+	baseline := run()
+
+	// More records on every level must not add queries
+	for i := 2; i <= 4; i++ {
+		building := create(t, router, "/api/v1/buildings", map[string]any{"code": fmt.Sprintf("B%d", i), "name": "Annex"})
+		floor := create(t, router, "/api/v1/floors", map[string]any{"building_id": building, "floor_number": 1, "name": "Ground"})
+		for j := 1; j <= 2; j++ {
+			room := create(t, router, "/api/v1/rooms", map[string]any{"floor_id": floor, "code": fmt.Sprintf("R%d%02d", i, j), "name": "Office"})
+			component := create(t, router, "/api/v1/components", map[string]any{
+				"room_id": room, "category_id": f.CategoryID, "code": fmt.Sprintf("PRJ-%d%d", i, j), "name": "Projector", "procurement_year": 2020,
+			})
+			tag := create(t, router, "/api/v1/tags", map[string]any{"name": fmt.Sprintf("tag-%d%d", i, j)})
+			report := create(t, router, "/api/v1/reports", map[string]any{
+				"name": "Flickering", "room_id": room, "user_id": f.UserID, "component_id": component, "status": "PENDING",
+			})
+			rec := doRequest(t, router, http.MethodPost, path("/reports/%d/tags", report), map[string]any{"tag_ids": []uint{tag}}, nil)
+			if rec.Code != http.StatusOK {
+				t.Fatalf("tag report = %d: %s", rec.Code, rec.Body.String())
+			}
+		}
+	}
+
+	if got := run(); got != baseline {
+		t.Errorf("queries = %d with 4 buildings, want %d as with 1", got, baseline)
+	}
+}
+
+func TestGraphQLMutations(t *testing.T) {
+	router := newTestRouter(t)
+	f := seed(t, router)
+
+	type report struct {
+		ID       string
+		Status   string
+		Version  int
+		Assignee *struct{ ID string }
+		Tags     []struct{ Name string }
+	}
+	const fields = `{ id status version assignee { id } tags { name } }`
+
+	var created struct{ CreateReport report }
+	graphQLData(t, router, f.UserID, `mutation($room: ID!, $component: ID!) {
+  createReport(input: {name: "Dead pixel", roomId: $room, componentId: $component, status: PENDING}) `+fields+`
+}`, map[string]any{"room": fmt.Sprint(f.RoomID), "component": fmt.Sprint(f.ComponentID)}, &created)
+	if created.CreateReport.Status != "PENDING" || created.CreateReport.Assignee != nil || created.CreateReport.Version != 1 {
+		t.Fatalf("created = %+v", created.CreateReport)
+	}
+	id := created.CreateReport.ID
+
+	var assigned struct{ AssignReport report }
+	graphQLData(t, router, f.UserID, `mutation($id: ID!, $user: ID!) { assignReport(id: $id, userId: $user, version: 1) `+fields+` }`,
+		map[string]any{"id": id, "user": fmt.Sprint(f.UserID)}, &assigned)
+	if assigned.AssignReport.Assignee == nil || assigned.AssignReport.Assignee.ID != fmt.Sprint(f.UserID) {
+		t.Errorf("assigned = %+v", assigned.AssignReport)
+	}
+
+	t.Run("stale version", func(t *testing.T) {
+		result := graphQL(t, router, f.UserID, `mutation($id: ID!) { updateReport(id: $id, input: {status: IN_PROGRESS}, version: 1) { id } }`,
+			map[string]any{"id": id})
+		expectGraphQLCode(t, result, "PRECONDITION_FAILED")
+	})
+
+	t.Run("invalid input", func(t *testing.T) {
+		result := graphQL(t, router, f.UserID, `mutation($id: ID!, $cost: Float) { updateReport(id: $id, input: {repairCost: $cost}) { id } }`,
+			map[string]any{"id": id, "cost": -5})
+		expectGraphQLCode(t, result, "VALIDATION_FAILED")
+		if details := result.Errors[0].Extensions.Details; len(details) == 0 {
+			t.Errorf("no details in %+v", result.Errors[0])
+		}
+	})
+
+	t.Run("missing record", func(t *testing.T) {
+		result := graphQL(t, router, f.UserID, `mutation { deleteReport(id: "999") }`, nil)
+		expectGraphQLCode(t, result, "NOT_FOUND")
+	})
+
+	var tagged struct{ TagReport report }
+	graphQLData(t, router, f.UserID, `mutation($id: ID!, $tag: ID!) { tagReport(id: $id, tagIds: [$tag]) `+fields+` }`,
+		map[string]any{"id": id, "tag": fmt.Sprint(f.TagID)}, &tagged)
+	if len(tagged.TagReport.Tags) != 1 || tagged.TagReport.Tags[0].Name != "electrical" {
+		t.Errorf("tagged = %+v", tagged.TagReport)
+	}
+
+	// Both mutations run in order and the second sees the first
+	var changed struct {
+		UnassignReport report
+		UpdateReport   report
+	}
+	graphQLData(t, router, f.UserID, `mutation($id: ID!) {
+  unassignReport(id: $id) `+fields+`
+  updateReport(id: $id, input: {status: IN_PROGRESS}) `+fields+`
+}`, map[string]any{"id": id}, &changed)
+	if changed.UnassignReport.Assignee != nil || changed.UpdateReport.Assignee != nil {
+		t.Errorf("still assigned: %+v", changed)
+	}
+	if changed.UpdateReport.Status != "IN_PROGRESS" || changed.UpdateReport.Version != changed.UnassignReport.Version+1 {
+		t.Errorf("updated = %+v after %+v", changed.UpdateReport, changed.UnassignReport)
+	}
+
+	rec := doRequest(t, router, http.MethodGet, path("/reports/%s", id), nil, nil)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("GET report = %d", rec.Code)
+	}
+	expectField("status", "IN_PROGRESS")(t, rec)
+}
+
+func TestGraphQLPagination(t *testing.T) {
+	router := newTestRouter(t)
+	f := seed(t, router)
+	for i := 0; i < 4; i++ {
+		create(t, router, "/api/v1/tags", map[string]any{"name": fmt.Sprintf("tag-%d", i)})
+	}
+
+	type tags struct {
+		Tags struct {
+			Nodes    []struct{ Name string }
+			PageInfo struct {
+				Page       *int
+				Total      *int
+				TotalPages *int
+				NextCursor *string
+			}
+		}
+	}
+	const query = `query($page: PageInput) { tags(page: $page) { nodes { name } pageInfo { page total totalPages nextCursor } } }`
+
+	var first tags
+	graphQLData(t, router, f.UserID, query, map[string]any{"page": map[string]any{"page": 2, "pageSize": 2}}, &first)
+	info := first.Tags.PageInfo
+	if len(first.Tags.Nodes) != 2 || info.Page == nil || *info.Page != 2 || info.Total == nil || *info.Total != 5 || *info.TotalPages != 3 {
+		t.Fatalf("page 2 = %+v", first.Tags)
+	}
+
+	var head tags
+	graphQLData(t, router, f.UserID, query, map[string]any{"page": map[string]any{"pageSize": 3}}, &head)
+	if len(head.Tags.Nodes) != 3 || head.Tags.PageInfo.NextCursor == nil {
+		t.Fatalf("first page = %+v, want 3 tags and a next cursor", head.Tags)
+	}
+	var next tags
+	graphQLData(t, router, f.UserID, query, map[string]any{"page": map[string]any{"pageSize": 3, "after": *head.Tags.PageInfo.NextCursor}}, &next)
+	if len(next.Tags.Nodes) != 2 || next.Tags.PageInfo.Total != nil || next.Tags.PageInfo.NextCursor != nil {
+		t.Errorf("cursor page = %+v, want the last 2 tags uncounted", next.Tags)
+	}
+
+	result := graphQL(t, router, f.UserID, `{ tags(page: {page: 2, after: "x"}) { nodes { name } } }`, nil)
+	expectGraphQLCode(t, result, "VALIDATION_FAILED")
+}
+
+func TestGraphQLRequest(t *testing.T) {
+	router := newTestRouter(t)
+	f := seed(t, router)
+
+	runCases(t, router, []apiCase{
+		{name: "unknown user", method: http.MethodPost, path: "/graphql",
+			body: map[string]any{"query": "{ viewer { name } }"}, header: asUser(999),
+			status: http.StatusUnauthorized},
+		{name: "missing query", method: http.MethodPost, path: "/graphql",
+			body: map[string]any{}, status: http.StatusBadRequest, check: expectCode("VALIDATION_FAILED")},
+	})
+
+	var viewer struct{ Viewer *struct{ Name string } }
+	graphQLData(t, router, f.UserID, `{ viewer { name } }`, nil, &viewer)
+	if viewer.Viewer == nil || viewer.Viewer.Name != "Tech One" {
+		t.Errorf("viewer = %+v", viewer.Viewer)
+	}
+}
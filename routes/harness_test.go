@@ -0,0 +1,329 @@
+package routes_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"incident-report/routes"
+	"incident-report/testutil"
+	"incident-report/utils"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+func TestMain(m *testing.M) {
+	gin.SetMode(gin.TestMode)
+
+	// The Swagger routes serve files relative to the repository root
+	if err := os.Chdir(".."); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+
+	os.Exit(m.Run())
+}
+
+// apiResponse mirrors utils.ResponseData with the payload left raw
+type apiResponse struct {
+	Success bool            `json:"success"`
+	Message string          `json:"message"`
+	Data    json.RawMessage `json:"data"`
+	Code    string          `json:"code"`
+	Error   string          `json:"error"`
+	Details []struct {
+		Field string `json:"field"`
+		Rule  string `json:"rule"`
+	} `json:"details"`
+}
+
+// page mirrors utils.PaginatedResponse
+type page struct {
+	Data       []map[string]any `json:"data"`
+	Page       int              `json:"page"`
+	PageSize   int              `json:"page_size"`
+	Total      int64            `json:"total"`
+	TotalPage  int              `json:"total_page"`
+	NextCursor string           `json:"next_cursor"`
+	PrevCursor string           `json:"prev_cursor"`
+}
+
+// apiCase is a single request of a table-driven endpoint test
+// body is marshalled to JSON unless it is already a string; check runs after the status matched
+type apiCase struct {
+	name   string
+	method string
+	path   string
+	body   any
+	header map[string]string
+	status int
+	check  func(t *testing.T, rec *httptest.ResponseRecorder)
+}
+
+// newTestRouter boots the full route table against a freshly migrated database
+func newTestRouter(t *testing.T) *gin.Engine {
+	t.Helper()
+
+	router, _ := newTestServer(t)
+	return router
+}
+
+// newTestServer is newTestRouter that also returns the database, for setup the API does not offer
+func newTestServer(t *testing.T) (*gin.Engine, *gorm.DB) {
+	t.Helper()
+
+	db := testutil.OpenDB(t)
+	router := gin.New()
+	routes.RegisterRoutes(router, db)
+	return router, db
+}
+
+// promote makes a user an admin
+func promote(t *testing.T, db *gorm.DB, userID uint) {
+	t.Helper()
+
+	if err := db.Table("users").Where("id = ?", userID).Update("role", "admin").Error; err != nil {
+		t.Fatalf("promote user %d: %v", userID, err)
+	}
+}
+
+// asUser returns the header that identifies the caller
+func asUser(userID uint) map[string]string {
+	return map[string]string{"X-User-ID": fmt.Sprint(userID)}
+}
+
+// runCases executes every case in order as a subtest against the router
+func runCases(t *testing.T, router *gin.Engine, cases []apiCase) {
+	t.Helper()
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			rec := doRequest(t, router, tc.method, tc.path, tc.body, tc.header)
+			if rec.Code != tc.status {
+				t.Fatalf("%s %s = %d, want %d: %s", tc.method, tc.path, rec.Code, tc.status, rec.Body.String())
+			}
+			if tc.check != nil {
+				tc.check(t, rec)
+			}
+		})
+	}
+}
+
+// doRequest sends a request through the router and records the response
+func doRequest(t *testing.T, router *gin.Engine, method, path string, body any, header map[string]string) *httptest.ResponseRecorder {
+	t.Helper()
+
+	var reader *bytes.Reader
+	switch b := body.(type) {
+	case nil:
+		reader = bytes.NewReader(nil)
+	case string:
+		reader = bytes.NewReader([]byte(b))
+	default:
+		raw, err := json.Marshal(b)
+		if err != nil {
+			t.Fatalf("marshal body: %v", err)
+		}
+		reader = bytes.NewReader(raw)
+	}
+
+	req := httptest.NewRequest(method, path, reader)
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	for key, value := range header {
+		req.Header.Set(key, value)
+	}
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+	return rec
+}
+
+// decode parses the standard response envelope
+func decode(t *testing.T, rec *httptest.ResponseRecorder) apiResponse {
+	t.Helper()
+
+	var resp apiResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("decode response: %v: %s", err, rec.Body.String())
+	}
+	return resp
+}
+
+// decodeData parses the envelope's data field into out
+func decodeData(t *testing.T, rec *httptest.ResponseRecorder, out any) {
+	t.Helper()
+
+	if err := json.Unmarshal(decode(t, rec).Data, out); err != nil {
+		t.Fatalf("decode data: %v: %s", err, rec.Body.String())
+	}
+}
+
+// create posts a resource and returns the ID of the created record
+func create(t *testing.T, router *gin.Engine, path string, body any) uint {
+	t.Helper()
+
+	rec := doRequest(t, router, http.MethodPost, path, body, nil)
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("POST %s = %d: %s", path, rec.Code, rec.Body.String())
+	}
+	var created struct {
+		ID uint `json:"id"`
+	}
+	decodeData(t, rec, &created)
+	return created.ID
+}
+
+// fixture is a complete location hierarchy with one report
+type fixture struct {
+	UserID      uint
+	BuildingID  uint
+	FloorID     uint
+	RoomID      uint
+	CategoryID  uint
+	ComponentID uint
+	TagID       uint
+	ReportID    uint
+}
+
+// seed creates a user, a building → floor → room → component chain, a tag and a pending report
+func seed(t *testing.T, router *gin.Engine) fixture {
+	t.Helper()
+
+	var f fixture
+	f.UserID = create(t, router, "/api/v1/users", map[string]any{"name": "Tech One", "email": "tech@example.com"})
+	f.BuildingID = create(t, router, "/api/v1/buildings", map[string]any{"code": "B1", "name": "Main Building"})
+	f.FloorID = create(t, router, "/api/v1/floors", map[string]any{"building_id": f.BuildingID, "floor_number": 1, "name": "Ground"})
+	f.RoomID = create(t, router, "/api/v1/rooms", map[string]any{"floor_id": f.FloorID, "code": "R101", "name": "Lab 101"})
+	f.CategoryID = create(t, router, "/api/v1/component-categories", map[string]any{"code": "PRJ", "name": "Projector"})
+	f.ComponentID = create(t, router, "/api/v1/components", map[string]any{
+		"room_id": f.RoomID, "category_id": f.CategoryID, "code": "PRJ-1", "name": "Projector 1", "procurement_year": 2019,
+	})
+	f.TagID = create(t, router, "/api/v1/tags", map[string]any{"name": "electrical"})
+	f.ReportID = create(t, router, "/api/v1/reports", map[string]any{
+		"name": "Broken projector", "room_id": f.RoomID, "user_id": f.UserID, "component_id": f.ComponentID, "status": "PENDING",
+	})
+	return f
+}
+
+// path formats an API path below /api/v1
+func path(format string, args ...any) string {
+	return "/api/v1" + fmt.Sprintf(format, args...)
+}
+
+// expectPage asserts the pagination envelope of a list response
+func expectPage(total int64, pageNumber, pageSize, items int) func(t *testing.T, rec *httptest.ResponseRecorder) {
+	return func(t *testing.T, rec *httptest.ResponseRecorder) {
+		t.Helper()
+
+		var p page
+		decodeData(t, rec, &p)
+		if p.Total != total || p.Page != pageNumber || p.PageSize != pageSize || len(p.Data) != items {
+			t.Errorf("page = total %d, page %d, size %d, items %d; want %d, %d, %d, %d",
+				p.Total, p.Page, p.PageSize, len(p.Data), total, pageNumber, pageSize, items)
+		}
+	}
+}
+
+// expectField asserts a top-level field of the response data
+func expectField(field string, want any) func(t *testing.T, rec *httptest.ResponseRecorder) {
+	return func(t *testing.T, rec *httptest.ResponseRecorder) {
+		t.Helper()
+
+		var data map[string]any
+		decodeData(t, rec, &data)
+		if got := fmt.Sprint(data[field]); got != fmt.Sprint(want) {
+			t.Errorf("%s = %s, want %v", field, got, want)
+		}
+	}
+}
+
+// expectImpact asserts the counts of a delete impact preview
+func expectImpact(floors, rooms, components, reports, openReports int) func(t *testing.T, rec *httptest.ResponseRecorder) {
+	return func(t *testing.T, rec *httptest.ResponseRecorder) {
+		t.Helper()
+
+		var got, want utils.DeleteImpactResponse
+		decodeData(t, rec, &got)
+		want = utils.DeleteImpactResponse{
+			Floors: floors, Rooms: rooms, Components: components, Reports: reports, OpenReports: int64(openReports),
+		}
+		if got != want {
+			t.Errorf("impact = %+v, want %+v", got, want)
+		}
+	}
+}
+
+// expectError asserts that the error message mentions want
+func expectError(want string) func(t *testing.T, rec *httptest.ResponseRecorder) {
+	return func(t *testing.T, rec *httptest.ResponseRecorder) {
+		t.Helper()
+
+		resp := decode(t, rec)
+		if resp.Success || !strings.Contains(resp.Error, want) {
+			t.Errorf("error = %q, want it to mention %q", resp.Error, want)
+		}
+	}
+}
+
+// expectCode asserts the machine-readable error code
+func expectCode(want string) func(t *testing.T, rec *httptest.ResponseRecorder) {
+	return func(t *testing.T, rec *httptest.ResponseRecorder) {
+		t.Helper()
+
+		if resp := decode(t, rec); resp.Code != want {
+			t.Errorf("code = %q, want %q", resp.Code, want)
+		}
+	}
+}
+
+// expectDetail asserts a validation failure that names the field and the rule it broke
+func expectDetail(field, rule string) func(t *testing.T, rec *httptest.ResponseRecorder) {
+	return func(t *testing.T, rec *httptest.ResponseRecorder) {
+		t.Helper()
+
+		resp := decode(t, rec)
+		if resp.Code != "VALIDATION_FAILED" {
+			t.Errorf("code = %q, want VALIDATION_FAILED", resp.Code)
+		}
+		for _, d := range resp.Details {
+			if d.Field == field && d.Rule == rule {
+				return
+			}
+		}
+		t.Errorf("details = %+v, want %s failing %s", resp.Details, field, rule)
+	}
+}
+
+// expectConflict asserts a conflict that names the clashing field
+func expectConflict(field string) func(t *testing.T, rec *httptest.ResponseRecorder) {
+	return func(t *testing.T, rec *httptest.ResponseRecorder) {
+		t.Helper()
+
+		resp := decode(t, rec)
+		if resp.Code != "CONFLICT" {
+			t.Errorf("code = %q, want CONFLICT", resp.Code)
+		}
+		if len(resp.Details) != 1 || resp.Details[0].Field != field {
+			t.Errorf("details = %+v, want a conflict on %s", resp.Details, field)
+		}
+	}
+}
+
+// expectContentType asserts the response media type of a file export
+func expectContentType(want string) func(t *testing.T, rec *httptest.ResponseRecorder) {
+	return func(t *testing.T, rec *httptest.ResponseRecorder) {
+		t.Helper()
+
+		if got := rec.Header().Get("Content-Type"); !strings.HasPrefix(got, want) {
+			t.Errorf("Content-Type = %q, want %q", got, want)
+		}
+	}
+}
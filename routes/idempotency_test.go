@@ -0,0 +1,149 @@
+package routes_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"incident-report/models"
+
+	"gorm.io/gorm"
+)
+
+// withKey returns the headers of a request sent with an Idempotency-Key, plus any extra ones
+func withKey(key string, extra map[string]string) map[string]string {
+	header := map[string]string{"Idempotency-Key": key}
+	for name, value := range extra {
+		header[name] = value
+	}
+	return header
+}
+
+// expectReplayed asserts whether the response was replayed from an earlier request
+func expectReplayed(want bool) func(t *testing.T, rec *httptest.ResponseRecorder) {
+	return func(t *testing.T, rec *httptest.ResponseRecorder) {
+		t.Helper()
+
+		if got := rec.Header().Get("Idempotent-Replayed") == "true"; got != want {
+			t.Errorf("replayed = %v, want %v", got, want)
+		}
+	}
+}
+
+func TestIdempotencyKeys(t *testing.T) {
+	router, db := newTestServer(t)
+	f := seed(t, router)
+	other := create(t, router, "/api/v1/users", map[string]any{"name": "Second Tech", "email": "second@example.com"})
+	user := asUser(f.UserID)
+
+	report := map[string]any{"name": "Flickering light", "room_id": f.RoomID, "component_id": f.ComponentID, "status": "PENDING"}
+	socket := map[string]any{"name": "Dead socket", "room_id": f.RoomID, "component_id": f.ComponentID, "status": "PENDING"}
+
+	// A request that is still running holds its key
+	inFlight := models.IdempotencyKey{UserID: f.UserID, Key: "in-flight", RequestHash: "unknown", ExpiresAt: time.Now().Add(time.Hour)}
+	if err := db.Create(&inFlight).Error; err != nil {
+		t.Fatalf("create in-flight key: %v", err)
+	}
+
+	var first struct {
+		ID uint `json:"id"`
+	}
+	runCases(t, router, []apiCase{
+		{name: "first request", method: http.MethodPost, path: path("/reports"), body: report, header: withKey("retry-1", user),
+			status: http.StatusCreated, check: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				expectReplayed(false)(t, rec)
+				decodeData(t, rec, &first)
+			}},
+		{name: "retry replays", method: http.MethodPost, path: path("/reports"), body: report, header: withKey("retry-1", user),
+			status: http.StatusCreated, check: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				expectReplayed(true)(t, rec)
+				expectField("id", first.ID)(t, rec)
+			}},
+		{name: "no duplicate", method: http.MethodGet, path: path("/reports"),
+			status: http.StatusOK, check: expectPage(2, 1, 10, 2)},
+
+		{name: "reused for another body", method: http.MethodPost, path: path("/reports"), body: socket, header: withKey("retry-1", user),
+			status: http.StatusUnprocessableEntity, check: expectCode("UNPROCESSABLE_ENTITY")},
+		{name: "reused for another endpoint", method: http.MethodPost, path: path("/tags"),
+			body: map[string]any{"name": "lighting"}, header: withKey("retry-1", user),
+			status: http.StatusUnprocessableEntity},
+		{name: "keys are per caller", method: http.MethodPost, path: path("/reports"), body: report, header: withKey("retry-1", asUser(other)),
+			status: http.StatusCreated, check: expectReplayed(false)},
+		{name: "still running", method: http.MethodPost, path: path("/reports"), body: report, header: withKey("in-flight", user),
+			status: http.StatusConflict, check: expectError("still being processed")},
+
+		{name: "client errors are replayed", method: http.MethodPost, path: path("/tags"),
+			body: map[string]any{"name": "electrical"}, header: withKey("tag-1", user), status: http.StatusConflict},
+		{name: "replayed client error", method: http.MethodPost, path: path("/tags"),
+			body: map[string]any{"name": "electrical"}, header: withKey("tag-1", user),
+			status: http.StatusConflict, check: expectReplayed(true)},
+		{name: "without a key", method: http.MethodPost, path: path("/reports"), body: report, header: user,
+			status: http.StatusCreated, check: expectReplayed(false)},
+		{name: "key too long", method: http.MethodPost, path: path("/reports"), body: report,
+			header: withKey(strings.Repeat("k", 256), user), status: http.StatusBadRequest},
+
+		// Anonymous callers share one scope, so their keys only match a retry of the same request
+		{name: "anonymous request", method: http.MethodPost, path: path("/reports"), body: report, header: withKey("anonymous-1", nil),
+			status: http.StatusCreated, check: expectReplayed(false)},
+		{name: "anonymous retry replays", method: http.MethodPost, path: path("/reports"), body: report, header: withKey("anonymous-1", nil),
+			status: http.StatusCreated, check: expectReplayed(true)},
+		{name: "anonymous key reused for another body", method: http.MethodPost, path: path("/reports"), body: socket, header: withKey("anonymous-1", nil),
+			status: http.StatusCreated, check: expectReplayed(false)},
+		{name: "one report per anonymous request", method: http.MethodGet, path: path("/reports"),
+			status: http.StatusOK, check: expectPage(6, 1, 10, 6)},
+	})
+}
+
+func TestIdempotencyKeyStoredAfterDisconnect(t *testing.T) {
+	router, db := newTestServer(t)
+	f := seed(t, router)
+
+	// The client goes away once the report is stored, cancelling the request's context
+	ctx, disconnect := context.WithCancel(context.Background())
+	err := db.Callback().Create().After("gorm:create").Register("test:disconnect", func(tx *gorm.DB) {
+		if tx.Statement.Table == "reports" {
+			disconnect()
+		}
+	})
+	if err != nil {
+		t.Fatalf("register callback: %v", err)
+	}
+
+	report := map[string]any{"name": "Flickering light", "room_id": f.RoomID, "component_id": f.ComponentID, "status": "PENDING"}
+	body, _ := json.Marshal(report)
+	req := httptest.NewRequest(http.MethodPost, path("/reports"), bytes.NewReader(body)).WithContext(ctx)
+	req.Header.Set("Content-Type", "application/json")
+	for name, value := range withKey("retry-1", asUser(f.UserID)) {
+		req.Header.Set(name, value)
+	}
+	router.ServeHTTP(httptest.NewRecorder(), req)
+	db.Callback().Create().Remove("test:disconnect")
+
+	// The response was stored all the same, so the retry is answered instead of waiting for the key to expire
+	rec := doRequest(t, router, http.MethodPost, path("/reports"), report, withKey("retry-1", asUser(f.UserID)))
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("retry = %d, want 201: %s", rec.Code, rec.Body.String())
+	}
+	expectReplayed(true)(t, rec)
+}
+
+func TestIdempotencyKeysExpire(t *testing.T) {
+	t.Setenv("IDEMPOTENCY_TTL", "1ns")
+	router := newTestRouter(t)
+	f := seed(t, router)
+
+	report := map[string]any{"name": "Flickering light", "room_id": f.RoomID, "component_id": f.ComponentID, "status": "PENDING"}
+	runCases(t, router, []apiCase{
+		{name: "first request", method: http.MethodPost, path: path("/reports"), body: report, header: withKey("retry-1", nil),
+			status: http.StatusCreated},
+		{name: "expired key runs again", method: http.MethodPost, path: path("/reports"), body: report, header: withKey("retry-1", nil),
+			status: http.StatusCreated, check: expectReplayed(false)},
+		{name: "both created", method: http.MethodGet, path: path("/reports"),
+			status: http.StatusOK, check: expectPage(3, 1, 10, 3)},
+	})
+}
@@ -0,0 +1,242 @@
+package routes_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestBuildingEndpoints(t *testing.T) {
+	router := newTestRouter(t)
+	f := seed(t, router)
+	create(t, router, "/api/v1/buildings", map[string]any{"code": "B2", "name": "Annex"})
+
+	runCases(t, router, []apiCase{
+		{name: "create", method: http.MethodPost, path: path("/buildings"),
+			body: map[string]any{"code": "B3", "name": "Library", "location": "North campus"}, status: http.StatusCreated,
+			check: expectField("location", "North campus")},
+		{name: "create missing code", method: http.MethodPost, path: path("/buildings"),
+			body: map[string]any{"name": "Library"}, status: http.StatusBadRequest},
+		{name: "create duplicate code", method: http.MethodPost, path: path("/buildings"),
+			body: map[string]any{"code": "B1", "name": "Copy"}, status: http.StatusConflict,
+			check: expectConflict("code")},
+		{name: "create malformed body", method: http.MethodPost, path: path("/buildings"),
+			body: "[]", status: http.StatusBadRequest},
+
+		{name: "list", method: http.MethodGet, path: path("/buildings"),
+			status: http.StatusOK, check: expectPage(3, 1, 10, 3)},
+		{name: "list last page", method: http.MethodGet, path: path("/buildings?page=2&page_size=2"),
+			status: http.StatusOK, check: expectPage(3, 2, 2, 1)},
+		{name: "list page size too large", method: http.MethodGet, path: path("/buildings?page_size=101"),
+			status: http.StatusBadRequest},
+
+		{name: "get", method: http.MethodGet, path: path("/buildings/%d", f.BuildingID),
+			status: http.StatusOK, check: expectField("code", "B1")},
+		{name: "get not found", method: http.MethodGet, path: path("/buildings/999"),
+			status: http.StatusNotFound},
+		{name: "get invalid id", method: http.MethodGet, path: path("/buildings/x"),
+			status: http.StatusBadRequest},
+
+		{name: "floors", method: http.MethodGet, path: path("/buildings/%d/floors", f.BuildingID),
+			status: http.StatusOK, check: expectPage(1, 1, 10, 1)},
+		{name: "floors of empty building", method: http.MethodGet, path: path("/buildings/999/floors"),
+			status: http.StatusOK, check: expectPage(0, 1, 10, 0)},
+		{name: "floors invalid id", method: http.MethodGet, path: path("/buildings/x/floors"),
+			status: http.StatusBadRequest},
+
+		{name: "update", method: http.MethodPut, path: path("/buildings/%d", f.BuildingID),
+			body: map[string]any{"name": "Main Hall"}, status: http.StatusOK,
+			check: expectField("name", "Main Hall")},
+		{name: "update invalid name", method: http.MethodPut, path: path("/buildings/%d", f.BuildingID),
+			body: map[string]any{"name": "M"}, status: http.StatusBadRequest},
+		{name: "update duplicate code", method: http.MethodPut, path: path("/buildings/%d", f.BuildingID),
+			body: map[string]any{"code": "B2"}, status: http.StatusConflict,
+			check: expectConflict("code")},
+		{name: "update keeps own code", method: http.MethodPut, path: path("/buildings/%d", f.BuildingID),
+			body: map[string]any{"code": "B1"}, status: http.StatusOK},
+		{name: "update not found", method: http.MethodPut, path: path("/buildings/999"),
+			body: map[string]any{"name": "Nowhere"}, status: http.StatusNotFound,
+			check: expectError("building not found")},
+
+		{name: "delete not found", method: http.MethodDelete, path: path("/buildings/999"),
+			status: http.StatusNotFound},
+		{name: "delete invalid id", method: http.MethodDelete, path: path("/buildings/x"),
+			status: http.StatusBadRequest},
+		{name: "delete impact", method: http.MethodGet, path: path("/buildings/%d/delete-impact", f.BuildingID),
+			status: http.StatusOK, check: expectImpact(1, 1, 1, 1, 1)},
+		{name: "delete impact not found", method: http.MethodGet, path: path("/buildings/999/delete-impact"),
+			status: http.StatusNotFound},
+		{name: "delete with open report", method: http.MethodDelete, path: path("/buildings/%d?cascade=true", f.BuildingID),
+			status: http.StatusConflict, check: expectError("open reports")},
+		{name: "complete report", method: http.MethodPut, path: path("/reports/%d", f.ReportID),
+			body: map[string]any{"status": "COMPLETED"}, status: http.StatusOK},
+		{name: "delete without cascade", method: http.MethodDelete, path: path("/buildings/%d", f.BuildingID),
+			status: http.StatusConflict, check: expectError("cascade=true")},
+		{name: "delete invalid cascade", method: http.MethodDelete, path: path("/buildings/%d?cascade=maybe", f.BuildingID),
+			status: http.StatusBadRequest},
+		{name: "delete", method: http.MethodDelete, path: path("/buildings/%d?cascade=true", f.BuildingID),
+			status: http.StatusNoContent},
+		{name: "get deleted", method: http.MethodGet, path: path("/buildings/%d", f.BuildingID),
+			status: http.StatusNotFound},
+		{name: "create floor in deleted building", method: http.MethodPost, path: path("/floors"),
+			body: map[string]any{"building_id": f.BuildingID, "floor_number": 2, "name": "First"}, status: http.StatusBadRequest,
+			check: expectError("building not found")},
+		{name: "reuse code of deleted building", method: http.MethodPost, path: path("/buildings"),
+			body: map[string]any{"code": "B1", "name": "New Main Building"}, status: http.StatusCreated},
+	})
+}
+
+func TestFloorEndpoints(t *testing.T) {
+	router := newTestRouter(t)
+	f := seed(t, router)
+
+	runCases(t, router, []apiCase{
+		{name: "create", method: http.MethodPost, path: path("/floors"),
+			body: map[string]any{"building_id": f.BuildingID, "floor_number": 2, "name": "First"}, status: http.StatusCreated,
+			check: expectField("floor_number", 2)},
+		{name: "create missing building", method: http.MethodPost, path: path("/floors"),
+			body: map[string]any{"floor_number": 3, "name": "Second"}, status: http.StatusBadRequest},
+		{name: "create unknown building", method: http.MethodPost, path: path("/floors"),
+			body: map[string]any{"building_id": 999, "floor_number": 3, "name": "Second"}, status: http.StatusBadRequest,
+			check: expectError("building not found")},
+
+		{name: "list", method: http.MethodGet, path: path("/floors"),
+			status: http.StatusOK, check: expectPage(2, 1, 10, 2)},
+		{name: "list single item pages", method: http.MethodGet, path: path("/floors?page=2&page_size=1"),
+			status: http.StatusOK, check: expectPage(2, 2, 1, 1)},
+		{name: "list page size too large", method: http.MethodGet, path: path("/floors?page_size=1000"),
+			status: http.StatusBadRequest},
+
+		{name: "get", method: http.MethodGet, path: path("/floors/%d", f.FloorID),
+			status: http.StatusOK, check: expectField("name", "Ground")},
+		{name: "get not found", method: http.MethodGet, path: path("/floors/999"),
+			status: http.StatusNotFound},
+		{name: "get invalid id", method: http.MethodGet, path: path("/floors/-1"),
+			status: http.StatusBadRequest},
+
+		{name: "rooms", method: http.MethodGet, path: path("/floors/%d/rooms", f.FloorID),
+			status: http.StatusOK, check: expectPage(1, 1, 10, 1)},
+		{name: "rooms paginated", method: http.MethodGet, path: path("/floors/%d/rooms?page=2", f.FloorID),
+			status: http.StatusOK, check: expectPage(1, 2, 10, 0)},
+		{name: "rooms invalid id", method: http.MethodGet, path: path("/floors/x/rooms"),
+			status: http.StatusBadRequest},
+
+		{name: "update", method: http.MethodPut, path: path("/floors/%d", f.FloorID),
+			body: map[string]any{"name": "Lobby"}, status: http.StatusOK,
+			check: expectField("name", "Lobby")},
+		{name: "update not found", method: http.MethodPut, path: path("/floors/999"),
+			body: map[string]any{"name": "Nowhere"}, status: http.StatusNotFound},
+
+		{name: "delete impact", method: http.MethodGet, path: path("/floors/%d/delete-impact", f.FloorID),
+			status: http.StatusOK, check: expectImpact(0, 1, 1, 1, 1)},
+		{name: "complete report", method: http.MethodPut, path: path("/reports/%d", f.ReportID),
+			body: map[string]any{"status": "COMPLETED"}, status: http.StatusOK},
+		{name: "delete without cascade", method: http.MethodDelete, path: path("/floors/%d", f.FloorID),
+			status: http.StatusConflict},
+		{name: "delete", method: http.MethodDelete, path: path("/floors/%d?cascade=true", f.FloorID),
+			status: http.StatusNoContent},
+		{name: "get deleted", method: http.MethodGet, path: path("/floors/%d", f.FloorID),
+			status: http.StatusNotFound},
+		{name: "delete again", method: http.MethodDelete, path: path("/floors/%d", f.FloorID),
+			status: http.StatusNotFound},
+	})
+}
+
+func TestRoomEndpoints(t *testing.T) {
+	router := newTestRouter(t)
+	f := seed(t, router)
+
+	runCases(t, router, []apiCase{
+		{name: "create", method: http.MethodPost, path: path("/rooms"),
+			body: map[string]any{"floor_id": f.FloorID, "code": "R102", "name": "Lab 102"}, status: http.StatusCreated,
+			check: expectField("code", "R102")},
+		{name: "create unknown floor", method: http.MethodPost, path: path("/rooms"),
+			body: map[string]any{"floor_id": 999, "code": "R103", "name": "Lab 103"}, status: http.StatusBadRequest,
+			check: expectError("floor not found")},
+		{name: "create duplicate code", method: http.MethodPost, path: path("/rooms"),
+			body: map[string]any{"floor_id": f.FloorID, "code": "R101", "name": "Lab copy"}, status: http.StatusConflict,
+			check: expectConflict("code")},
+		{name: "create missing name", method: http.MethodPost, path: path("/rooms"),
+			body: map[string]any{"floor_id": f.FloorID, "code": "R104"}, status: http.StatusBadRequest},
+
+		{name: "list", method: http.MethodGet, path: path("/rooms"),
+			status: http.StatusOK, check: expectPage(2, 1, 10, 2)},
+		{name: "list page size one", method: http.MethodGet, path: path("/rooms?page_size=1"),
+			status: http.StatusOK, check: expectPage(2, 1, 1, 1)},
+		{name: "list page size zero uses default", method: http.MethodGet, path: path("/rooms?page_size=0"),
+			status: http.StatusOK, check: expectPage(2, 1, 10, 2)},
+
+		{name: "get", method: http.MethodGet, path: path("/rooms/%d", f.RoomID),
+			status: http.StatusOK, check: expectField("name", "Lab 101")},
+		{name: "get not found", method: http.MethodGet, path: path("/rooms/999"),
+			status: http.StatusNotFound},
+		{name: "get invalid id", method: http.MethodGet, path: path("/rooms/x"),
+			status: http.StatusBadRequest},
+
+		{name: "components", method: http.MethodGet, path: path("/rooms/%d/components", f.RoomID),
+			status: http.StatusOK, check: expectPage(1, 1, 10, 1)},
+		{name: "components invalid id", method: http.MethodGet, path: path("/rooms/x/components"),
+			status: http.StatusBadRequest},
+
+		{name: "update", method: http.MethodPut, path: path("/rooms/%d", f.RoomID),
+			body: map[string]any{"name": "Physics Lab"}, status: http.StatusOK,
+			check: expectField("name", "Physics Lab")},
+		{name: "update not found", method: http.MethodPut, path: path("/rooms/999"),
+			body: map[string]any{"name": "Nowhere"}, status: http.StatusNotFound},
+
+		{name: "delete impact", method: http.MethodGet, path: path("/rooms/%d/delete-impact", f.RoomID),
+			status: http.StatusOK, check: expectImpact(0, 0, 1, 1, 1)},
+		{name: "complete report", method: http.MethodPut, path: path("/reports/%d", f.ReportID),
+			body: map[string]any{"status": "COMPLETED"}, status: http.StatusOK},
+		{name: "delete impact after completing", method: http.MethodGet, path: path("/rooms/%d/delete-impact", f.RoomID),
+			status: http.StatusOK, check: expectImpact(0, 0, 1, 1, 0)},
+		{name: "delete", method: http.MethodDelete, path: path("/rooms/%d?cascade=true", f.RoomID),
+			status: http.StatusNoContent},
+		{name: "get deleted", method: http.MethodGet, path: path("/rooms/%d", f.RoomID),
+			status: http.StatusNotFound},
+		{name: "delete not found", method: http.MethodDelete, path: path("/rooms/999"),
+			status: http.StatusNotFound},
+	})
+}
+
+func TestTreeEndpoints(t *testing.T) {
+	router := newTestRouter(t)
+	f := seed(t, router)
+
+	treeSize := func(want int) func(t *testing.T, rec *httptest.ResponseRecorder) {
+		return func(t *testing.T, rec *httptest.ResponseRecorder) {
+			t.Helper()
+
+			var tree []map[string]any
+			decodeData(t, rec, &tree)
+			if len(tree) != want {
+				t.Errorf("tree has %d buildings, want %d", len(tree), want)
+			}
+		}
+	}
+
+	runCases(t, router, []apiCase{
+		{name: "tree", method: http.MethodGet, path: path("/tree"),
+			status: http.StatusOK, check: treeSize(1)},
+		{name: "tree with counts", method: http.MethodGet, path: path("/tree?depth=1&include=component_count,open_report_count"),
+			status: http.StatusOK, check: treeSize(1)},
+		{name: "tree depth too deep", method: http.MethodGet, path: path("/tree?depth=4"),
+			status: http.StatusBadRequest},
+		{name: "tree unknown include", method: http.MethodGet, path: path("/tree?include=everything"),
+			status: http.StatusBadRequest},
+
+		{name: "building tree", method: http.MethodGet, path: path("/buildings/%d/tree?include=open_report_count", f.BuildingID),
+			status: http.StatusOK, check: expectField("open_report_count", 1)},
+		{name: "building tree not found", method: http.MethodGet, path: path("/buildings/999/tree"),
+			status: http.StatusNotFound},
+		{name: "building tree invalid id", method: http.MethodGet, path: path("/buildings/x/tree"),
+			status: http.StatusBadRequest},
+
+		{name: "complete report", method: http.MethodPut, path: path("/reports/%d", f.ReportID),
+			body: map[string]any{"status": "COMPLETED"}, status: http.StatusOK},
+		{name: "delete building", method: http.MethodDelete, path: path("/buildings/%d?cascade=true", f.BuildingID),
+			status: http.StatusNoContent},
+		{name: "tree without deleted building", method: http.MethodGet, path: path("/tree"),
+			status: http.StatusOK, check: treeSize(0)},
+	})
+}
@@ -0,0 +1,171 @@
+package routes_test
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"sync"
+	"testing"
+
+	"incident-report/config"
+	"incident-report/routes"
+	"incident-report/testutil"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// logBuffer collects the JSON lines of a logger
+type logBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *logBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+// lines returns the logged lines that carry a request ID
+func (b *logBuffer) lines(t *testing.T, requestID string) []map[string]any {
+	t.Helper()
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	var lines []map[string]any
+	scanner := bufio.NewScanner(bytes.NewReader(b.buf.Bytes()))
+	for scanner.Scan() {
+		var line map[string]any
+		if err := json.Unmarshal(scanner.Bytes(), &line); err != nil {
+			t.Fatalf("log line is not JSON: %v: %s", err, scanner.Text())
+		}
+		if line["request_id"] == requestID {
+			lines = append(lines, line)
+		}
+	}
+	return lines
+}
+
+// newLoggedRouter is newTestServer logging JSON lines, queries included, to the returned buffer
+func newLoggedRouter(t *testing.T) (*gin.Engine, *gorm.DB, *logBuffer) {
+	t.Helper()
+
+	logs := &logBuffer{}
+	previous := slog.Default()
+	slog.SetDefault(config.NewLogger(logs))
+	t.Cleanup(func() { slog.SetDefault(previous) })
+
+	db := testutil.OpenDB(t)
+	router := gin.New()
+	routes.RegisterRoutes(router, db.Session(&gorm.Session{Logger: config.NewGormLogger()}))
+	return router, db, logs
+}
+
+// requestLine returns the access log line of a request
+func requestLine(t *testing.T, logs *logBuffer, requestID string) map[string]any {
+	t.Helper()
+
+	for _, line := range logs.lines(t, requestID) {
+		if line["msg"] == "request" {
+			return line
+		}
+	}
+	t.Fatalf("no request line logged for request %s", requestID)
+	return nil
+}
+
+func TestRequestLogging(t *testing.T) {
+	router, db, logs := newLoggedRouter(t)
+	f := seed(t, router)
+
+	t.Run("request line", func(t *testing.T) {
+		header := asUser(f.UserID)
+		header["X-Request-ID"] = "trace-list"
+		rec := doRequest(t, router, http.MethodGet, path("/buildings?page_size=5"), nil, header)
+		if rec.Code != http.StatusOK || rec.Header().Get("X-Request-ID") != "trace-list" {
+			t.Fatalf("GET /buildings = %d with request ID %q", rec.Code, rec.Header().Get("X-Request-ID"))
+		}
+
+		line := requestLine(t, logs, "trace-list")
+		want := map[string]any{
+			"level": "INFO", "method": "GET", "route": "/api/v1/buildings", "path": "/api/v1/buildings",
+			"status": float64(http.StatusOK), "user_id": float64(f.UserID),
+		}
+		for key, value := range want {
+			if line[key] != value {
+				t.Errorf("%s = %v, want %v", key, line[key], value)
+			}
+		}
+		if _, ok := line["latency_ms"].(float64); !ok {
+			t.Errorf("latency_ms = %v, want a number", line["latency_ms"])
+		}
+	})
+
+	t.Run("generated request ID", func(t *testing.T) {
+		rec := doRequest(t, router, http.MethodGet, path("/rooms/%d", f.RoomID), nil, nil)
+		id := rec.Header().Get("X-Request-ID")
+		if id == "" {
+			t.Fatal("no X-Request-ID in the response")
+		}
+		line := requestLine(t, logs, id)
+		if line["route"] != "/api/v1/rooms/:id" || line["user_id"] != nil {
+			t.Errorf("line = %v, want the route pattern and no user", line)
+		}
+	})
+
+	t.Run("client errors are warnings", func(t *testing.T) {
+		doRequest(t, router, http.MethodGet, "/api/v1/nowhere", nil, map[string]string{"X-Request-ID": "trace-404"})
+		line := requestLine(t, logs, "trace-404")
+		if line["level"] != "WARN" || line["route"] != "unmatched" || line["status"] != float64(http.StatusNotFound) {
+			t.Errorf("line = %v, want an unmatched 404 warning", line)
+		}
+	})
+
+	t.Run("service logs carry the request ID", func(t *testing.T) {
+		header := asUser(f.UserID)
+		header["X-Request-ID"] = "trace-bulk"
+		rec := doRequest(t, router, http.MethodPost, path("/reports/bulk"),
+			map[string]any{"action": "transition", "ids": []uint{f.ReportID}, "status": "IN_PROGRESS"}, header)
+		if rec.Code != http.StatusOK {
+			t.Fatalf("POST /reports/bulk = %d: %s", rec.Code, rec.Body.String())
+		}
+
+		var bulk map[string]any
+		for _, line := range logs.lines(t, "trace-bulk") {
+			if line["msg"] == "bulk request" {
+				bulk = line
+			}
+		}
+		if bulk == nil || bulk["succeeded"] != float64(1) || bulk["user_id"] != float64(f.UserID) {
+			t.Errorf("bulk line = %v, want the outcome under the request and user", bulk)
+		}
+	})
+
+	t.Run("database errors carry the request ID", func(t *testing.T) {
+		if err := db.Migrator().DropTable("report_tags", "tags"); err != nil {
+			t.Fatalf("drop tags: %v", err)
+		}
+
+		rec := doRequest(t, router, http.MethodGet, path("/tags"), nil, map[string]string{"X-Request-ID": "trace-db"})
+		if rec.Code != http.StatusInternalServerError {
+			t.Fatalf("GET /tags = %d, want 500: %s", rec.Code, rec.Body.String())
+		}
+
+		var dbError map[string]any
+		for _, line := range logs.lines(t, "trace-db") {
+			if line["msg"] == "database error" {
+				dbError = line
+			}
+		}
+		if dbError == nil || dbError["level"] != "ERROR" || dbError["sql"] == "" {
+			t.Fatalf("database error line = %v", dbError)
+		}
+		if line := requestLine(t, logs, "trace-db"); line["level"] != "ERROR" || line["error"] == nil {
+			t.Errorf("request line = %v, want an error naming the cause", line)
+		}
+	})
+}
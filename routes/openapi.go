@@ -0,0 +1,130 @@
+package routes
+
+import (
+	"incident-report/openapi"
+	"incident-report/utils"
+	"net/http"
+)
+
+// healthResponse is the bare response of the health check
+type healthResponse struct {
+	Status  string `json:"status"`
+	Message string `json:"message"`
+}
+
+// Operations documents every route of the API v1 group, keyed like the route table
+// The OpenAPI spec served at /openapi.json is generated from it and the DTOs it names; a route
+// registered in RegisterRoutes without an entry here fails TestOpenAPIMatchesRoutes.
+var Operations = openapi.Operations{
+	"GET /api/v1/health": {Summary: "Check server health", Response: healthResponse{}, Bare: true},
+	"GET /api/v1/tree":   {Summary: "Get the hierarchy tree", Query: []any{utils.TreeQuery{}}, Response: []utils.BuildingTreeNode{}},
+
+	"POST /api/v1/users":             {Summary: "Create a user", Body: utils.CreateUserRequest{}, Response: utils.UserResponse{}, Status: http.StatusCreated},
+	"GET /api/v1/users":              {Summary: "List users", Query: []any{utils.PaginationQuery{}}, Response: utils.UserResponse{}, List: true},
+	"GET /api/v1/users/:id":          {Summary: "Get a user", Response: utils.UserResponse{}},
+	"PUT /api/v1/users/:id":          {Summary: "Update a user", Body: utils.UpdateUserRequest{}, Response: utils.UserResponse{}},
+	"PATCH /api/v1/users/:id":        {Summary: "Patch a user", Patch: utils.PatchUserRequest{}, Response: utils.UserResponse{}},
+	"DELETE /api/v1/users/:id":       {Summary: "Delete a user"},
+	"POST /api/v1/users/:id/restore": {Summary: "Restore a user", Response: utils.TrashItemResponse{}},
+	"DELETE /api/v1/users/:id/purge": {Summary: "Purge a user from the trash", Status: http.StatusNoContent},
+
+	"POST /api/v1/buildings":                  {Summary: "Create a building", Body: utils.CreateBuildingRequest{}, Response: utils.BuildingResponse{}, Status: http.StatusCreated},
+	"GET /api/v1/buildings":                   {Summary: "List buildings", Query: []any{utils.PaginationQuery{}}, Response: utils.BuildingResponse{}, List: true},
+	"GET /api/v1/buildings/:id":               {Summary: "Get a building", Response: utils.BuildingResponse{}},
+	"PUT /api/v1/buildings/:id":               {Summary: "Update a building", Body: utils.UpdateBuildingRequest{}, Response: utils.BuildingResponse{}},
+	"PATCH /api/v1/buildings/:id":             {Summary: "Patch a building", Patch: utils.PatchBuildingRequest{}, Response: utils.BuildingResponse{}},
+	"DELETE /api/v1/buildings/:id":            {Summary: "Delete a building", Query: []any{utils.DeleteQuery{}}, Status: http.StatusNoContent},
+	"GET /api/v1/buildings/:id/delete-impact": {Summary: "Count what deleting a building would remove", Response: utils.DeleteImpactResponse{}},
+	"POST /api/v1/buildings/:id/restore":      {Summary: "Restore a building", Response: utils.TrashItemResponse{}},
+	"DELETE /api/v1/buildings/:id/purge":      {Summary: "Purge a building from the trash", Status: http.StatusNoContent},
+	"GET /api/v1/buildings/:id/floors":        {Summary: "List the floors of a building", Query: []any{utils.PaginationQuery{}}, Response: utils.FloorResponse{}, List: true},
+	"GET /api/v1/buildings/:id/tree":          {Summary: "Get the hierarchy tree of a building", Query: []any{utils.TreeQuery{}}, Response: utils.BuildingTreeNode{}},
+
+	"POST /api/v1/floors":                  {Summary: "Create a floor", Body: utils.CreateFloorRequest{}, Response: utils.FloorResponse{}, Status: http.StatusCreated},
+	"GET /api/v1/floors":                   {Summary: "List floors", Query: []any{utils.PaginationQuery{}}, Response: utils.FloorResponse{}, List: true},
+	"GET /api/v1/floors/:id":               {Summary: "Get a floor", Response: utils.FloorResponse{}},
+	"PUT /api/v1/floors/:id":               {Summary: "Update a floor", Body: utils.UpdateFloorRequest{}, Response: utils.FloorResponse{}},
+	"PATCH /api/v1/floors/:id":             {Summary: "Patch a floor", Patch: utils.PatchFloorRequest{}, Response: utils.FloorResponse{}},
+	"DELETE /api/v1/floors/:id":            {Summary: "Delete a floor", Query: []any{utils.DeleteQuery{}}, Status: http.StatusNoContent},
+	"GET /api/v1/floors/:id/delete-impact": {Summary: "Count what deleting a floor would remove", Response: utils.DeleteImpactResponse{}},
+	"POST /api/v1/floors/:id/restore":      {Summary: "Restore a floor", Response: utils.TrashItemResponse{}},
+	"DELETE /api/v1/floors/:id/purge":      {Summary: "Purge a floor from the trash", Status: http.StatusNoContent},
+	"GET /api/v1/floors/:id/rooms":         {Summary: "List the rooms of a floor", Query: []any{utils.PaginationQuery{}}, Response: utils.RoomResponse{}, List: true},
+
+	"POST /api/v1/rooms":                  {Summary: "Create a room", Body: utils.CreateRoomRequest{}, Response: utils.RoomResponse{}, Status: http.StatusCreated},
+	"GET /api/v1/rooms":                   {Summary: "List rooms", Query: []any{utils.PaginationQuery{}}, Response: utils.RoomResponse{}, List: true},
+	"GET /api/v1/rooms/:id":               {Summary: "Get a room", Response: utils.RoomResponse{}},
+	"PUT /api/v1/rooms/:id":               {Summary: "Update a room", Body: utils.UpdateRoomRequest{}, Response: utils.RoomResponse{}},
+	"PATCH /api/v1/rooms/:id":             {Summary: "Patch a room", Patch: utils.PatchRoomRequest{}, Response: utils.RoomResponse{}},
+	"DELETE /api/v1/rooms/:id":            {Summary: "Delete a room", Query: []any{utils.DeleteQuery{}}, Status: http.StatusNoContent},
+	"GET /api/v1/rooms/:id/delete-impact": {Summary: "Count what deleting a room would remove", Response: utils.DeleteImpactResponse{}},
+	"POST /api/v1/rooms/:id/restore":      {Summary: "Restore a room", Response: utils.TrashItemResponse{}},
+	"DELETE /api/v1/rooms/:id/purge":      {Summary: "Purge a room from the trash", Status: http.StatusNoContent},
+	"GET /api/v1/rooms/:id/components":    {Summary: "List the components of a room", Query: []any{utils.PaginationQuery{}}, Response: utils.ComponentResponse{}, List: true},
+
+	"POST /api/v1/component-categories":                  {Summary: "Create a component category", Body: utils.CreateComponentCategoryRequest{}, Response: utils.ComponentCategoryResponse{}, Status: http.StatusCreated},
+	"GET /api/v1/component-categories":                   {Summary: "List component categories", Query: []any{utils.PaginationQuery{}}, Response: utils.ComponentCategoryResponse{}, List: true},
+	"GET /api/v1/component-categories/:id":               {Summary: "Get a component category", Response: utils.ComponentCategoryResponse{}},
+	"PUT /api/v1/component-categories/:id":               {Summary: "Update a component category", Body: utils.UpdateComponentCategoryRequest{}, Response: utils.ComponentCategoryResponse{}},
+	"PATCH /api/v1/component-categories/:id":             {Summary: "Patch a component category", Patch: utils.PatchComponentCategoryRequest{}, Response: utils.ComponentCategoryResponse{}},
+	"DELETE /api/v1/component-categories/:id":            {Summary: "Delete a component category", Query: []any{utils.DeleteQuery{}}, Status: http.StatusNoContent},
+	"GET /api/v1/component-categories/:id/delete-impact": {Summary: "Count what deleting a component category would remove", Response: utils.DeleteImpactResponse{}},
+	"POST /api/v1/component-categories/:id/restore":      {Summary: "Restore a component category", Response: utils.TrashItemResponse{}},
+	"DELETE /api/v1/component-categories/:id/purge":      {Summary: "Purge a component category from the trash", Status: http.StatusNoContent},
+	"GET /api/v1/component-categories/:id/components":    {Summary: "List the components of a category", Query: []any{utils.PaginationQuery{}}, Response: utils.ComponentResponse{}, List: true},
+	"GET /api/v1/component-categories/:id/reliability":   {Summary: "Get the reliability of a category", Response: utils.CategoryReliability{}},
+
+	"POST /api/v1/components":                       {Summary: "Create a component", Body: utils.CreateComponentRequest{}, Response: utils.ComponentResponse{}, Status: http.StatusCreated},
+	"GET /api/v1/components":                        {Summary: "List components", Query: []any{utils.PaginationQuery{}, utils.ComponentFilterQuery{}, utils.ExportQuery{}}, Response: utils.ComponentResponse{}, List: true, Exports: exportContentTypes(utils.ExportFormatCSV, utils.ExportFormatXLSX, utils.ExportFormatPDF)},
+	"GET /api/v1/components/:id":                    {Summary: "Get a component", Response: utils.ComponentResponse{}},
+	"PUT /api/v1/components/:id":                    {Summary: "Update a component", Body: utils.UpdateComponentRequest{}, Response: utils.ComponentResponse{}},
+	"PATCH /api/v1/components/:id":                  {Summary: "Patch a component", Patch: utils.PatchComponentRequest{}, Response: utils.ComponentResponse{}},
+	"DELETE /api/v1/components/:id":                 {Summary: "Delete a component", Status: http.StatusNoContent},
+	"POST /api/v1/components/:id/restore":           {Summary: "Restore a component", Response: utils.TrashItemResponse{}},
+	"DELETE /api/v1/components/:id/purge":           {Summary: "Purge a component from the trash", Status: http.StatusNoContent},
+	"GET /api/v1/components/:id/reliability":        {Summary: "Get the reliability of a component", Response: utils.ComponentReliability{}},
+	"GET /api/v1/components/replacement-candidates": {Summary: "Rank components for replacement", Query: []any{utils.ReplacementCandidatesQuery{}}, Response: []utils.ReplacementCandidate{}},
+	"PUT /api/v1/components/:id/assign-room":        {Summary: "Assign a room to a component", Body: utils.AssignRoomRequest{}, Response: utils.ComponentResponse{}},
+	"POST /api/v1/components/bulk-move":             {Summary: "Move many components to a room", Body: utils.BulkMoveComponentsRequest{}, Response: utils.BulkResponse{}},
+
+	"POST /api/v1/reports":                   {Summary: "Create a report", Body: utils.CreateReportRequest{}, Response: utils.ReportResponse{}, Status: http.StatusCreated},
+	"GET /api/v1/reports":                    {Summary: "List reports", Query: []any{utils.PaginationQuery{}, utils.ReportFilterQuery{}, utils.ExportQuery{}}, Response: utils.ReportResponse{}, List: true, Exports: exportContentTypes(utils.ExportFormatCSV, utils.ExportFormatXLSX, utils.ExportFormatPDF)},
+	"GET /api/v1/reports/:id":                {Summary: "Get a report", Query: []any{utils.ExportQuery{}}, Response: utils.ReportResponse{}, Exports: exportContentTypes(utils.ExportFormatPDF)},
+	"PUT /api/v1/reports/:id":                {Summary: "Update a report", Body: utils.UpdateReportRequest{}, Response: utils.ReportResponse{}},
+	"PATCH /api/v1/reports/:id":              {Summary: "Patch a report", Patch: utils.PatchReportRequest{}, Response: utils.ReportResponse{}},
+	"DELETE /api/v1/reports/:id":             {Summary: "Delete a report"},
+	"POST /api/v1/reports/:id/restore":       {Summary: "Restore a report", Response: utils.TrashItemResponse{}},
+	"DELETE /api/v1/reports/:id/purge":       {Summary: "Purge a report from the trash", Status: http.StatusNoContent},
+	"PUT /api/v1/reports/:id/assign-user":    {Summary: "Assign a user to a report", Body: utils.AssignUserRequest{}, Response: utils.ReportResponse{}},
+	"POST /api/v1/reports/:id/tags":          {Summary: "Attach tags to a report", Body: utils.ReportTagsRequest{}, Response: utils.ReportResponse{}},
+	"DELETE /api/v1/reports/:id/tags/:tagId": {Summary: "Detach a tag from a report", Response: utils.ReportResponse{}},
+	"POST /api/v1/reports/bulk":              {Summary: "Assign, transition, tag or delete many reports", Body: utils.BulkReportRequest{}, Response: utils.BulkResponse{}},
+
+	"POST /api/v1/tags":       {Summary: "Create a tag", Body: utils.CreateTagRequest{}, Response: utils.TagResponse{}, Status: http.StatusCreated},
+	"GET /api/v1/tags":        {Summary: "List tags", Query: []any{utils.PaginationQuery{}}, Response: utils.TagResponse{}, List: true},
+	"GET /api/v1/tags/:id":    {Summary: "Get a tag", Response: utils.TagResponse{}},
+	"PUT /api/v1/tags/:id":    {Summary: "Update a tag", Body: utils.UpdateTagRequest{}, Response: utils.TagResponse{}},
+	"PATCH /api/v1/tags/:id":  {Summary: "Patch a tag", Patch: utils.PatchTagRequest{}, Response: utils.TagResponse{}},
+	"DELETE /api/v1/tags/:id": {Summary: "Delete a tag", Status: http.StatusNoContent},
+
+	"GET /api/v1/trash": {Summary: "List deleted records", Query: []any{utils.TrashQuery{}}, Response: utils.TrashItemResponse{}, List: true},
+	"GET /api/v1/audit": {Summary: "List the audit log", Query: []any{utils.AuditQuery{}}, Response: utils.AuditLogResponse{}, List: true},
+
+	"GET /api/v1/analytics/volume":             {Summary: "Get reports opened and closed per period", Query: []any{utils.VolumeQuery{}}, Response: []utils.VolumeBucket{}},
+	"GET /api/v1/analytics/resolution-time":    {Summary: "Get the time to resolve reports", Query: []any{utils.AnalyticsQuery{}}, Response: utils.ResolutionTimeResponse{}},
+	"GET /api/v1/analytics/top-components":     {Summary: "Rank components by reports", Query: []any{utils.TopNQuery{}}, Response: []utils.ComponentFailureCount{}},
+	"GET /api/v1/analytics/top-categories":     {Summary: "Rank categories by reports", Query: []any{utils.TopNQuery{}}, Response: []utils.CategoryFailureCount{}},
+	"GET /api/v1/analytics/hotspots/buildings": {Summary: "Rank buildings by reports", Query: []any{utils.TopNQuery{}}, Response: []utils.BuildingHotspot{}},
+	"GET /api/v1/analytics/hotspots/floors":    {Summary: "Rank floors by reports", Query: []any{utils.TopNQuery{}}, Response: []utils.FloorHotspot{}},
+	"GET /api/v1/analytics/technicians":        {Summary: "Get per-technician throughput", Query: []any{utils.AnalyticsQuery{}}, Response: []utils.TechnicianThroughput{}},
+	"GET /api/v1/analytics/tags":               {Summary: "Count reports per tag", Query: []any{utils.AnalyticsQuery{}}, Response: []utils.TagCount{}},
+}
+
+// exportContentTypes returns the content types of file export formats
+func exportContentTypes(formats ...string) []string {
+	contentTypes := make([]string, len(formats))
+	for i, format := range formats {
+		contentTypes[i] = utils.ExportContentType(format)
+	}
+	return contentTypes
+}
@@ -0,0 +1,152 @@
+package routes_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"testing"
+
+	"incident-report/openapi"
+	"incident-report/routes"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// ginParam matches the path parameters of the route table
+var ginParam = regexp.MustCompile(`:(\w+)`)
+
+// loadSpec fetches and validates the spec the router serves
+func loadSpec(t *testing.T, router http.Handler) *openapi3.T {
+	t.Helper()
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/openapi.json", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("GET /openapi.json = %d: %s", rec.Code, rec.Body.String())
+	}
+
+	spec, err := openapi3.NewLoader().LoadFromData(rec.Body.Bytes())
+	if err != nil {
+		t.Fatalf("load spec: %v", err)
+	}
+	if err := spec.Validate(context.Background()); err != nil {
+		t.Fatalf("invalid spec: %v", err)
+	}
+	return spec
+}
+
+func TestOpenAPIMatchesRoutes(t *testing.T) {
+	router := newTestRouter(t)
+	spec := loadSpec(t, router)
+
+	for _, drift := range openapi.Drift(router.Routes(), routes.Operations) {
+		t.Errorf("route table and spec disagree: %s", drift)
+	}
+
+	documented := 0
+	for _, route := range router.Routes() {
+		if !strings.HasPrefix(route.Path, openapi.Prefix) {
+			continue
+		}
+		documented++
+		item := spec.Paths.Find(ginParam.ReplaceAllString(route.Path, "{$1}"))
+		if item == nil || item.GetOperation(route.Method) == nil {
+			t.Errorf("%s %s is missing from the spec", route.Method, route.Path)
+		}
+	}
+	if got := len(spec.Paths.InMatchingOrder()); got == 0 || documented != len(routes.Operations) {
+		t.Errorf("spec has %d paths for %d routes and %d operations", got, documented, len(routes.Operations))
+	}
+
+	t.Run("drift is reported both ways", func(t *testing.T) {
+		operations := openapi.Operations{"GET /api/v1/gone": {Summary: "Gone"}}
+		for key, op := range routes.Operations {
+			if key != "PUT /api/v1/reports/:id/assign-user" {
+				operations[key] = op
+			}
+		}
+
+		drift := openapi.Drift(router.Routes(), operations)
+		want := []string{
+			"GET /api/v1/gone has an operation but no route",
+			"PUT /api/v1/reports/:id/assign-user is routed but has no operation",
+		}
+		if strings.Join(drift, "\n") != strings.Join(want, "\n") {
+			t.Errorf("drift = %q, want %q", drift, want)
+		}
+	})
+
+	t.Run("schemas follow the DTOs", func(t *testing.T) {
+		report := spec.Components.Schemas["CreateReportRequest"].Value
+		if status := report.Properties["status"].Value; len(status.Enum) != 3 {
+			t.Errorf("report status enum = %v, want the three statuses", status.Enum)
+		}
+		if strings.Join(report.Required, ",") != "name,room_id,component_id,status" {
+			t.Errorf("report required = %v", report.Required)
+		}
+
+		assign := spec.Paths.Find("/api/v1/reports/{id}/assign-user").Put
+		if ref := assign.RequestBody.Value.Content.Get("application/json").Schema.Ref; ref != "#/components/schemas/AssignUserRequest" {
+			t.Errorf("assign-user body = %q", ref)
+		}
+
+		patch := spec.Paths.Find("/api/v1/tags/{id}").Patch.RequestBody.Value.Content.Get("application/merge-patch+json")
+		if patch == nil || len(patch.Schema.Value.Required) != 0 || !patch.Schema.Value.Properties["color"].Value.Nullable {
+			t.Errorf("tag merge patch schema = %+v, want nothing required and nullable members", patch)
+		}
+
+		for _, nested := range []string{"/api/v1/floors/{id}/rooms", "/api/v1/component-categories/{id}/components"} {
+			if item := spec.Paths.Find(nested); item == nil || item.Get == nil {
+				t.Errorf("GET %s is missing", nested)
+			}
+		}
+	})
+}
+
+func TestRequestValidation(t *testing.T) {
+	t.Setenv("VALIDATE_REQUESTS", "true")
+	router := newTestRouter(t)
+	// Every request of the fixture matches the spec
+	f := seed(t, router)
+
+	expectSpecError := func(field, rule string) func(t *testing.T, rec *httptest.ResponseRecorder) {
+		return func(t *testing.T, rec *httptest.ResponseRecorder) {
+			t.Helper()
+
+			if resp := decode(t, rec); resp.Message != "Request does not match the API specification" {
+				t.Errorf("message = %q, want the request to be rejected by the spec", resp.Message)
+			}
+			expectDetail(field, rule)(t, rec)
+		}
+	}
+
+	runCases(t, router, []apiCase{
+		{name: "unknown status", method: http.MethodPost, path: path("/reports"),
+			body:   map[string]any{"name": "Flicker", "room_id": f.RoomID, "component_id": f.ComponentID, "status": "BROKEN"},
+			status: http.StatusBadRequest, check: expectSpecError("status", "oneof")},
+		{name: "missing member", method: http.MethodPut, path: path("/reports/%d/assign-user", f.ReportID),
+			body: map[string]any{}, status: http.StatusBadRequest, check: expectSpecError("user_id", "required")},
+		{name: "query out of range", method: http.MethodGet, path: path("/rooms?page_size=500"),
+			status: http.StatusBadRequest, check: expectSpecError("page_size", "max")},
+		{name: "zero leaves a query unset", method: http.MethodGet, path: path("/rooms?page_size=0"),
+			status: http.StatusOK},
+		{name: "path parameter", method: http.MethodGet, path: path("/buildings/abc"),
+			status: http.StatusBadRequest, check: expectSpecError("id", "type")},
+		{name: "merge patch", method: http.MethodPatch, path: path("/tags/%d", f.TagID),
+			body: `{"color": "red"}`, header: mergePatch(nil),
+			status: http.StatusBadRequest, check: expectSpecError("color", "hexcolor")},
+		{name: "valid merge patch", method: http.MethodPatch, path: path("/tags/%d", f.TagID),
+			body: `{"name": "power"}`, header: mergePatch(nil), status: http.StatusOK, check: expectField("name", "power")},
+		{name: "body still read by the handler", method: http.MethodPut, path: path("/buildings/%d", f.BuildingID),
+			body: map[string]any{"name": "Main Hall"}, status: http.StatusOK, check: expectField("name", "Main Hall")},
+		{name: "unsupported patch format", method: http.MethodPatch, path: path("/tags/%d", f.TagID),
+			body: `name=urgent`, header: map[string]string{"Content-Type": "text/plain"},
+			status: http.StatusUnsupportedMediaType},
+		{name: "malformed body", method: http.MethodPost, path: path("/buildings"),
+			body: `{"code":`, status: http.StatusBadRequest, check: expectCode("BAD_REQUEST")},
+		{name: "undocumented route", method: http.MethodPost, path: "/graphql",
+			body: map[string]any{"query": "{ buildings { totalCount } }"}, status: http.StatusOK},
+	})
+}
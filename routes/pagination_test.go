@@ -0,0 +1,78 @@
+package routes_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+// getPage loads a page of a list and fails unless it is a 200
+func getPage(t *testing.T, router *gin.Engine, path string) page {
+	t.Helper()
+
+	rec := doRequest(t, router, http.MethodGet, path, nil, nil)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("GET %s = %d: %s", path, rec.Code, rec.Body.String())
+	}
+	var p page
+	decodeData(t, rec, &p)
+	return p
+}
+
+// expectUncounted asserts that a list response has no total
+func expectUncounted(t *testing.T, rec *httptest.ResponseRecorder) {
+	t.Helper()
+
+	var data map[string]any
+	decodeData(t, rec, &data)
+	if _, ok := data["total"]; ok {
+		t.Errorf("total = %v, want it left out", data["total"])
+	}
+}
+
+func TestCursorPagination(t *testing.T) {
+	router := newTestRouter(t)
+	f := seed(t, router)
+	for _, name := range []string{"Dead socket", "Loose cable", "Cracked screen"} {
+		create(t, router, "/api/v1/reports", map[string]any{
+			"name": name, "room_id": f.RoomID, "component_id": f.ComponentID, "status": "PENDING",
+		})
+	}
+
+	first := getPage(t, router, path("/reports?page_size=3"))
+	if first.Total != 4 || first.Page != 1 || len(first.Data) != 3 || first.NextCursor == "" || first.PrevCursor != "" {
+		t.Fatalf("first page = %+v, want 3 of 4 reports and a next cursor", first)
+	}
+
+	last := getPage(t, router, path("/reports?page_size=3&after=%s", url.QueryEscape(first.NextCursor)))
+	if last.Total != 0 || last.Page != 0 || len(last.Data) != 1 || last.NextCursor != "" || last.PrevCursor == "" {
+		t.Fatalf("last page = %+v, want the fourth report, uncounted", last)
+	}
+	if last.Data[0]["name"] != "Cracked screen" {
+		t.Errorf("last report = %v, want the newest one", last.Data[0]["name"])
+	}
+
+	back := getPage(t, router, path("/reports?page_size=3&before=%s", url.QueryEscape(last.PrevCursor)))
+	if len(back.Data) != 3 || back.Data[0]["id"] != first.Data[0]["id"] || back.PrevCursor != "" {
+		t.Errorf("page before the last = %+v, want the first page again", back)
+	}
+
+	after := url.QueryEscape(first.NextCursor)
+	runCases(t, router, []apiCase{
+		{name: "uncounted by default", method: http.MethodGet, path: path("/reports?after=%s", after),
+			status: http.StatusOK, check: expectUncounted},
+		{name: "counted on request", method: http.MethodGet, path: path("/reports?after=%s&total=true", after),
+			status: http.StatusOK, check: expectPage(4, 0, 10, 1)},
+		{name: "page numbers can skip the count", method: http.MethodGet, path: path("/tags?total=false"),
+			status: http.StatusOK, check: expectUncounted},
+		{name: "cursor with a page number", method: http.MethodGet, path: path("/reports?after=%s&page=2", after),
+			status: http.StatusBadRequest, check: expectDetail("page", "excluded_with")},
+		{name: "after and before", method: http.MethodGet, path: path("/reports?after=%s&before=%s", after, after),
+			status: http.StatusBadRequest, check: expectDetail("after", "excluded_with")},
+		{name: "malformed cursor", method: http.MethodGet, path: path("/rooms?after=nope"),
+			status: http.StatusBadRequest, check: expectDetail("after", "")},
+	})
+}
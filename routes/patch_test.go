@@ -0,0 +1,75 @@
+package routes_test
+
+import (
+	"net/http"
+	"testing"
+)
+
+// mergePatch returns the headers of a JSON Merge Patch request, plus any extra ones
+func mergePatch(extra map[string]string) map[string]string {
+	header := map[string]string{"Content-Type": "application/merge-patch+json"}
+	for key, value := range extra {
+		header[key] = value
+	}
+	return header
+}
+
+func TestPatchEndpoints(t *testing.T) {
+	router := newTestRouter(t)
+	f := seed(t, router)
+
+	runCases(t, router, []apiCase{
+		{name: "set brand", method: http.MethodPut, path: path("/components/%d", f.ComponentID),
+			body: map[string]any{"brand": "Epson"}, status: http.StatusOK, check: expectField("brand", "Epson")},
+		{name: "clear brand", method: http.MethodPatch, path: path("/components/%d", f.ComponentID),
+			body: `{"brand": null}`, header: mergePatch(nil),
+			status: http.StatusOK, check: expectField("brand", "")},
+		{name: "other fields kept", method: http.MethodGet, path: path("/components/%d", f.ComponentID),
+			status: http.StatusOK, check: expectField("procurement_year", 2019)},
+		{name: "ground floor", method: http.MethodPatch, path: path("/floors/%d", f.FloorID),
+			body: map[string]any{"floor_number": 0}, status: http.StatusOK, check: expectField("floor_number", 0)},
+		{name: "clear building location", method: http.MethodPatch, path: path("/buildings/%d", f.BuildingID),
+			body: `{"location": null}`, header: mergePatch(nil), status: http.StatusOK, check: expectField("location", "")},
+		{name: "rename room", method: http.MethodPatch, path: path("/rooms/%d", f.RoomID),
+			body: `{"name": "Lab 101A"}`, header: mergePatch(nil), status: http.StatusOK, check: expectField("code", "R101")},
+		{name: "clear category description", method: http.MethodPatch, path: path("/component-categories/%d", f.CategoryID),
+			body: `{"description": null}`, header: mergePatch(nil), status: http.StatusOK, check: expectField("description", "")},
+		{name: "recolor tag", method: http.MethodPatch, path: path("/tags/%d", f.TagID),
+			body: `{"color": "#FF8800"}`, header: mergePatch(nil), status: http.StatusOK, check: expectField("name", "electrical")},
+		{name: "rename user", method: http.MethodPatch, path: path("/users/%d", f.UserID),
+			body: `{"name": "Tech Two"}`, header: mergePatch(nil), status: http.StatusOK, check: expectField("email", "tech@example.com")},
+
+		{name: "assign report", method: http.MethodPut, path: path("/reports/%d/assign-user", f.ReportID),
+			body: map[string]any{"user_id": f.UserID}, status: http.StatusOK},
+		{name: "unassign report", method: http.MethodPatch, path: path("/reports/%d", f.ReportID),
+			body: `{"user_id": null}`, header: mergePatch(map[string]string{"If-Match": `"2"`}),
+			status: http.StatusOK, check: expectETag(`"3"`)},
+		{name: "report unassigned", method: http.MethodGet, path: path("/reports/%d", f.ReportID),
+			status: http.StatusOK, check: expectField("user_id", nil)},
+		{name: "stale patch", method: http.MethodPatch, path: path("/reports/%d", f.ReportID),
+			body: `{"status": "IN_PROGRESS"}`, header: mergePatch(map[string]string{"If-Match": `"2"`}),
+			status: http.StatusPreconditionFailed, check: expectCode("PRECONDITION_FAILED")},
+
+		{name: "required field cleared", method: http.MethodPatch, path: path("/rooms/%d", f.RoomID),
+			body: `{"name": null}`, header: mergePatch(nil),
+			status: http.StatusBadRequest, check: expectDetail("name", "required")},
+		{name: "unknown field", method: http.MethodPatch, path: path("/rooms/%d", f.RoomID),
+			body: `{"floor_id": 9}`, header: mergePatch(nil),
+			status: http.StatusBadRequest, check: expectDetail("floor_id", "unknown")},
+		{name: "change code", method: http.MethodPatch, path: path("/components/%d", f.ComponentID),
+			body: `{"code": "PRJ-2"}`, header: mergePatch(nil), status: http.StatusOK, check: expectField("code", "PRJ-2")},
+		{name: "taken code", method: http.MethodPost, path: path("/components"),
+			body:   map[string]any{"room_id": f.RoomID, "category_id": f.CategoryID, "code": "PRJ-3", "name": "Projector 3"},
+			status: http.StatusCreated},
+		{name: "patch to a taken code", method: http.MethodPatch, path: path("/components/%d", f.ComponentID),
+			body: `{"code": "PRJ-3"}`, header: mergePatch(nil), status: http.StatusConflict, check: expectConflict("code")},
+		{name: "malformed patch", method: http.MethodPatch, path: path("/tags/%d", f.TagID),
+			body: `{"color":`, header: mergePatch(nil), status: http.StatusBadRequest, check: expectCode("VALIDATION_FAILED")},
+		{name: "json patch is not supported", method: http.MethodPatch, path: path("/tags/%d", f.TagID),
+			body:   `[{"op": "replace", "path": "/color", "value": "#000000"}]`,
+			header: map[string]string{"Content-Type": "application/json-patch+json"},
+			status: http.StatusUnsupportedMediaType, check: expectCode("UNSUPPORTED_MEDIA_TYPE")},
+		{name: "missing record", method: http.MethodPatch, path: path("/components/999"),
+			body: `{"brand": null}`, header: mergePatch(nil), status: http.StatusNotFound},
+	})
+}
@@ -0,0 +1,183 @@
+package routes_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestReportEndpoints(t *testing.T) {
+	router := newTestRouter(t)
+	f := seed(t, router)
+	urgent := create(t, router, "/api/v1/tags", map[string]any{"name": "urgent", "color": "#FF0000"})
+
+	tagCount := func(want int) func(t *testing.T, rec *httptest.ResponseRecorder) {
+		return func(t *testing.T, rec *httptest.ResponseRecorder) {
+			t.Helper()
+
+			var report struct {
+				Tags []map[string]any `json:"tags"`
+			}
+			decodeData(t, rec, &report)
+			if len(report.Tags) != want {
+				t.Errorf("report has %d tags, want %d", len(report.Tags), want)
+			}
+		}
+	}
+
+	report := map[string]any{
+		"name": "Flickering lights", "room_id": f.RoomID, "component_id": f.ComponentID, "status": "IN_PROGRESS", "repair_cost": 12.5,
+	}
+
+	runCases(t, router, []apiCase{
+		{name: "create", method: http.MethodPost, path: path("/reports"),
+			body: report, status: http.StatusCreated, check: expectField("status", "IN_PROGRESS")},
+		{name: "create completed stamps completion", method: http.MethodPost, path: path("/reports"),
+			body:   map[string]any{"name": "Fixed socket", "room_id": f.RoomID, "component_id": f.ComponentID, "status": "COMPLETED"},
+			status: http.StatusCreated, check: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				var created map[string]any
+				decodeData(t, rec, &created)
+				if created["completed_at"] == nil {
+					t.Error("completed_at is not set")
+				}
+			}},
+		{name: "create invalid status", method: http.MethodPost, path: path("/reports"),
+			body:   map[string]any{"name": "Bad", "room_id": f.RoomID, "component_id": f.ComponentID, "status": "DONE"},
+			status: http.StatusBadRequest, check: expectDetail("status", "oneof")},
+		{name: "create negative cost", method: http.MethodPost, path: path("/reports"),
+			body:   map[string]any{"name": "Bad", "room_id": f.RoomID, "component_id": f.ComponentID, "status": "PENDING", "repair_cost": -1},
+			status: http.StatusBadRequest, check: expectDetail("repair_cost", "min")},
+		{name: "create missing component", method: http.MethodPost, path: path("/reports"),
+			body:   map[string]any{"name": "Bad", "room_id": f.RoomID, "status": "PENDING"},
+			status: http.StatusBadRequest},
+
+		{name: "list", method: http.MethodGet, path: path("/reports"),
+			status: http.StatusOK, check: expectPage(3, 1, 10, 3)},
+		{name: "list by status", method: http.MethodGet, path: path("/reports?status=PENDING"),
+			status: http.StatusOK, check: expectPage(1, 1, 10, 1)},
+		{name: "list by user", method: http.MethodGet, path: path("/reports?user_id=%d", f.UserID),
+			status: http.StatusOK, check: expectPage(1, 1, 10, 1)},
+		{name: "list last page", method: http.MethodGet, path: path("/reports?page=3&page_size=1"),
+			status: http.StatusOK, check: expectPage(3, 3, 1, 1)},
+		{name: "list invalid status", method: http.MethodGet, path: path("/reports?status=DONE"),
+			status: http.StatusBadRequest},
+		{name: "list invalid tag match", method: http.MethodGet, path: path("/reports?tags=urgent&tag_match=some"),
+			status: http.StatusBadRequest},
+		{name: "export csv", method: http.MethodGet, path: path("/reports?format=csv"),
+			status: http.StatusOK, check: expectContentType("text/csv")},
+		{name: "export xlsx", method: http.MethodGet, path: path("/reports?format=xlsx"),
+			status: http.StatusOK, check: expectContentType("application/vnd.openxmlformats-officedocument.spreadsheetml.sheet")},
+		{name: "export pdf", method: http.MethodGet, path: path("/reports?format=pdf"),
+			status: http.StatusOK, check: expectContentType("application/pdf")},
+
+		{name: "get", method: http.MethodGet, path: path("/reports/%d", f.ReportID),
+			status: http.StatusOK, check: expectField("name", "Broken projector")},
+		{name: "get pdf", method: http.MethodGet, path: path("/reports/%d?format=pdf", f.ReportID),
+			status: http.StatusOK, check: expectContentType("application/pdf")},
+		{name: "get csv unsupported", method: http.MethodGet, path: path("/reports/%d?format=csv", f.ReportID),
+			status: http.StatusBadRequest},
+		{name: "get pdf not found", method: http.MethodGet, path: path("/reports/999?format=pdf"),
+			status: http.StatusNotFound},
+		{name: "get not found", method: http.MethodGet, path: path("/reports/999"),
+			status: http.StatusNotFound},
+		{name: "get invalid id", method: http.MethodGet, path: path("/reports/x"),
+			status: http.StatusBadRequest},
+
+		{name: "update", method: http.MethodPut, path: path("/reports/%d", f.ReportID),
+			body: map[string]any{"status": "COMPLETED", "repair_cost": 40}, status: http.StatusOK,
+			check: expectField("repair_cost", 40)},
+		{name: "update invalid status", method: http.MethodPut, path: path("/reports/%d", f.ReportID),
+			body: map[string]any{"status": "DONE"}, status: http.StatusBadRequest},
+		{name: "update not found", method: http.MethodPut, path: path("/reports/999"),
+			body: map[string]any{"name": "Nothing"}, status: http.StatusNotFound,
+			check: expectError("report not found")},
+
+		{name: "assign user", method: http.MethodPut, path: path("/reports/%d/assign-user", f.ReportID),
+			body: map[string]any{"user_id": f.UserID}, status: http.StatusOK,
+			check: expectField("user_id", f.UserID)},
+		{name: "assign unknown user", method: http.MethodPut, path: path("/reports/%d/assign-user", f.ReportID),
+			body: map[string]any{"user_id": 999}, status: http.StatusBadRequest,
+			check: expectError("user not found")},
+		{name: "assign user to unknown report", method: http.MethodPut, path: path("/reports/999/assign-user"),
+			body: map[string]any{"user_id": f.UserID}, status: http.StatusNotFound,
+			check: expectError("report not found")},
+		{name: "assign missing user", method: http.MethodPut, path: path("/reports/%d/assign-user", f.ReportID),
+			body: map[string]any{}, status: http.StatusBadRequest},
+
+		{name: "add tags", method: http.MethodPost, path: path("/reports/%d/tags", f.ReportID),
+			body: map[string]any{"tag_ids": []uint{f.TagID, urgent}}, status: http.StatusOK, check: tagCount(2)},
+		{name: "add tags again is idempotent", method: http.MethodPost, path: path("/reports/%d/tags", f.ReportID),
+			body: map[string]any{"tag_ids": []uint{urgent, urgent}}, status: http.StatusOK, check: tagCount(2)},
+		{name: "add unknown tag", method: http.MethodPost, path: path("/reports/%d/tags", f.ReportID),
+			body: map[string]any{"tag_ids": []uint{999}}, status: http.StatusBadRequest,
+			check: expectError("tag not found")},
+		{name: "add no tags", method: http.MethodPost, path: path("/reports/%d/tags", f.ReportID),
+			body: map[string]any{"tag_ids": []uint{}}, status: http.StatusBadRequest},
+		{name: "filter all tags", method: http.MethodGet, path: path("/reports?tags=electrical,urgent&tag_match=all"),
+			status: http.StatusOK, check: expectPage(1, 1, 10, 1)},
+		{name: "filter all tags repeated", method: http.MethodGet, path: path("/reports?tags=urgent,%%20urgent,electrical&tag_match=all"),
+			status: http.StatusOK, check: expectPage(1, 1, 10, 1)},
+		{name: "filter all tags in two cases", method: http.MethodGet, path: path("/reports?tags=urgent,URGENT,electrical&tag_match=all"),
+			status: http.StatusOK, check: expectPage(1, 1, 10, 1)},
+		{name: "remove tag", method: http.MethodDelete, path: path("/reports/%d/tags/%d", f.ReportID, urgent),
+			status: http.StatusOK, check: tagCount(1)},
+		{name: "remove unknown tag", method: http.MethodDelete, path: path("/reports/%d/tags/999", f.ReportID),
+			status: http.StatusNotFound},
+		{name: "remove invalid tag id", method: http.MethodDelete, path: path("/reports/%d/tags/x", f.ReportID),
+			status: http.StatusBadRequest},
+
+		{name: "delete", method: http.MethodDelete, path: path("/reports/%d", f.ReportID),
+			status: http.StatusOK},
+		{name: "get deleted", method: http.MethodGet, path: path("/reports/%d", f.ReportID),
+			status: http.StatusNotFound},
+		{name: "delete again", method: http.MethodDelete, path: path("/reports/%d", f.ReportID),
+			status: http.StatusNotFound, check: expectError("report not found")},
+	})
+}
+
+func TestTagEndpoints(t *testing.T) {
+	router := newTestRouter(t)
+	f := seed(t, router)
+
+	runCases(t, router, []apiCase{
+		{name: "create with default color", method: http.MethodPost, path: path("/tags"),
+			body: map[string]any{"name": "hvac"}, status: http.StatusCreated,
+			check: expectField("color", "#808080")},
+		{name: "create invalid color", method: http.MethodPost, path: path("/tags"),
+			body: map[string]any{"name": "water", "color": "blue"}, status: http.StatusBadRequest},
+		{name: "create duplicate name", method: http.MethodPost, path: path("/tags"),
+			body: map[string]any{"name": "electrical"}, status: http.StatusConflict,
+			check: expectConflict("name")},
+		{name: "create missing name", method: http.MethodPost, path: path("/tags"),
+			body: map[string]any{}, status: http.StatusBadRequest},
+
+		{name: "list", method: http.MethodGet, path: path("/tags"),
+			status: http.StatusOK, check: expectPage(2, 1, 10, 2)},
+		{name: "list second page", method: http.MethodGet, path: path("/tags?page=2&page_size=1"),
+			status: http.StatusOK, check: expectPage(2, 2, 1, 1)},
+		{name: "list page size too large", method: http.MethodGet, path: path("/tags?page_size=101"),
+			status: http.StatusBadRequest},
+
+		{name: "get", method: http.MethodGet, path: path("/tags/%d", f.TagID),
+			status: http.StatusOK, check: expectField("name", "electrical")},
+		{name: "get not found", method: http.MethodGet, path: path("/tags/999"),
+			status: http.StatusNotFound},
+		{name: "get invalid id", method: http.MethodGet, path: path("/tags/x"),
+			status: http.StatusBadRequest},
+
+		{name: "update", method: http.MethodPut, path: path("/tags/%d", f.TagID),
+			body: map[string]any{"color": "#FFD700"}, status: http.StatusOK,
+			check: expectField("color", "#FFD700")},
+		{name: "update not found", method: http.MethodPut, path: path("/tags/999"),
+			body: map[string]any{"name": "nothing"}, status: http.StatusNotFound},
+
+		{name: "tag report", method: http.MethodPost, path: path("/reports/%d/tags", f.ReportID),
+			body: map[string]any{"tag_ids": []uint{f.TagID}}, status: http.StatusOK},
+		{name: "delete", method: http.MethodDelete, path: path("/tags/%d", f.TagID),
+			status: http.StatusNoContent},
+		{name: "report detached", method: http.MethodGet, path: path("/reports/%d", f.ReportID),
+			status: http.StatusOK, check: expectField("tags", nil)},
+		{name: "delete not found", method: http.MethodDelete, path: path("/tags/%d", f.TagID),
+			status: http.StatusNotFound},
+	})
+}
@@ -1,18 +1,27 @@
 package routes
 
 import (
+	"incident-report/config"
 	"incident-report/controllers"
+	"incident-report/graph"
 	"incident-report/middleware"
+	"incident-report/models"
+	"incident-report/openapi"
+	"incident-report/repositories"
 	"incident-report/services"
+	"log/slog"
+	"net/http"
 
 	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
 )
 
 // RegisterRoutes sets up all API routes for the application
 // It organizes routes using versioning (/api/v1) for better API management
-func RegisterRoutes(router *gin.Engine) {
+// All repositories, services and controllers are wired against the given database connection
+func RegisterRoutes(router *gin.Engine, db *gorm.DB) {
 	// Apply global middleware
-	router.Use(middleware.ErrorHandlerMiddleware())
+	router.Use(middleware.RequestIDMiddleware(), middleware.RequestLoggingMiddleware(slog.Default()), middleware.ErrorHandlerMiddleware())
 
 	// Serve Swagger UI - main endpoint
 	router.GET("/swagger", func(c *gin.Context) {
@@ -24,28 +33,84 @@ func RegisterRoutes(router *gin.Engine) {
 		c.File("templates/swagger.html")
 	})
 
-	// Serve swagger.yaml file for Swagger UI to load
-	router.StaticFile("/swagger.yaml", "swagger.yaml")
+	// Create the data access layer shared by all services (dependency injection)
+	repos := repositories.New(db)
 
-	// Create a service layer instance (dependency injection)
-	userService := services.NewUserService()
+	// Create service layer instances
+	userService := services.NewUserService(repos)
+	buildingService := services.NewBuildingService(repos)
+	floorService := services.NewFloorService(repos)
+	roomService := services.NewRoomService(repos)
+	componentCategoryService := services.NewComponentCategoryService(repos)
+	componentService := services.NewComponentService(repos)
+	reportService := services.NewReportService(repos)
+	tagService := services.NewTagService(repos)
+	trashService := services.NewTrashService(repos)
+	auditService := services.NewAuditService(repos)
 
-	// Create a controller instance with the service
+	// Read-model services query the database directly
+	treeService := services.NewTreeService(db)
+	analyticsService := services.NewAnalyticsService(db)
+	exportService := services.NewExportService(db)
+	reliabilityService := services.NewReliabilityService(db)
+	batchService := services.NewBatchService(db)
+
+	// The GraphQL schema resolves through the same services as the REST API
+	schema := graph.NewSchema(graph.Services{
+		Users:               userService,
+		Buildings:           buildingService,
+		Floors:              floorService,
+		Rooms:               roomService,
+		ComponentCategories: componentCategoryService,
+		Components:          componentService,
+		Reports:             reportService,
+		Tags:                tagService,
+		Audit:               auditService,
+		Batch:               batchService,
+	})
+
+	// Create controller instances with their services
 	userController := controllers.NewUserController(userService)
+	buildingController := controllers.NewBuildingController(buildingService)
+	floorController := controllers.NewFloorController(floorService)
+	roomController := controllers.NewRoomController(roomService)
+	componentCategoryController := controllers.NewComponentCategoryController(componentCategoryService, reliabilityService)
+	componentController := controllers.NewComponentController(componentService, exportService, reliabilityService)
+	treeController := controllers.NewTreeController(treeService)
+	analyticsController := controllers.NewAnalyticsController(analyticsService)
+	tagController := controllers.NewTagController(tagService)
+	reportController := controllers.NewReportController(reportService, exportService)
+	trashController := controllers.NewTrashController(trashService)
+	auditController := controllers.NewAuditController(auditService)
+	graphqlController := controllers.NewGraphQLController(schema)
 
-	// Create building management controllers
-	buildingController := controllers.NewBuildingController()
-	floorController := controllers.NewFloorController()
-	roomController := controllers.NewRoomController()
-	componentCategoryController := controllers.NewComponentCategoryController()
-	componentController := controllers.NewComponentController()
+	// Purging the trash is reserved to admins
+	requireAdmin := middleware.RequireRole(models.RoleAdmin)
 
-	// Create report management controller
-	reportService := services.NewReportService()
-	reportController := controllers.NewReportController(reportService)
+	// GraphQL endpoint
+	// POST   /graphql                - Run a query or mutation (see graph/schema.graphql)
+	// The caller is loaded from X-User-ID like for the REST API; versions are passed as arguments
+	router.POST("/graphql", middleware.CurrentUserMiddleware(repos.Users), graphqlController.Execute)
+
+	// The OpenAPI spec is generated from the route table once every route is registered
+	spec := openapi.NewSpec(Operations)
 
 	// API v1 routes
-	v1 := router.Group("/api/v1")
+	// The caller, if it identifies itself with X-User-ID, is loaded for every request,
+	// the version a write names with If-Match is handed on to the services, as are the
+	// fields a read asks for, and a POST with an Idempotency-Key is run only once per key.
+	// With VALIDATE_REQUESTS, requests that do not match the spec are rejected first.
+	var v1Middleware []gin.HandlerFunc
+	if config.ValidateRequests() {
+		v1Middleware = append(v1Middleware, middleware.RequestValidationMiddleware(spec))
+	}
+	v1Middleware = append(v1Middleware,
+		middleware.CurrentUserMiddleware(repos.Users),
+		middleware.IfMatchMiddleware(),
+		middleware.FieldsMiddleware(),
+		middleware.IdempotencyMiddleware(repos.IdempotencyKeys, config.IdempotencyTTL()),
+	)
+	v1 := router.Group("/api/v1", v1Middleware...)
 	{
 		// Health check endpoint
 		v1.GET("/health", func(c *gin.Context) {
@@ -55,11 +120,16 @@ func RegisterRoutes(router *gin.Engine) {
 			})
 		})
 
+		// Location hierarchy tree
+		// GET    /api/v1/tree           - Get buildings → floors → rooms (?depth=&include=)
+		v1.GET("/tree", treeController.GetTree)
+
 		// User routes with RESTful conventions
 		// POST   /api/v1/users           - Create a new user
 		// GET    /api/v1/users           - Get all users (with pagination)
 		// GET    /api/v1/users/:id       - Get a specific user
 		// PUT    /api/v1/users/:id       - Update a specific user
+		// PATCH  /api/v1/users/:id       - Patch a specific user (JSON Merge Patch)
 		// DELETE /api/v1/users/:id       - Delete a specific user
 		users := v1.Group("/users")
 		{
@@ -75,16 +145,24 @@ func RegisterRoutes(router *gin.Engine) {
 			// Update user - PUT request with ID parameter
 			users.PUT("/:id", userController.UpdateUser)
 
+			// Patch user - PATCH request with a JSON Merge Patch
+			users.PATCH("/:id", userController.PatchUser)
+
 			// Delete user - DELETE request with ID parameter
 			users.DELETE("/:id", userController.DeleteUser)
+			users.POST("/:id/restore", trashController.Restore("users"))
+			users.DELETE("/:id/purge", requireAdmin, trashController.Purge("users"))
 		}
 
 		// Building routes
 		// POST   /api/v1/buildings           - Create a new building
 		// GET    /api/v1/buildings           - Get all buildings
 		// GET    /api/v1/buildings/:id       - Get a specific building
+		// GET    /api/v1/buildings/:id/tree  - Get the hierarchy tree of a building
 		// PUT    /api/v1/buildings/:id       - Update a specific building
-		// DELETE /api/v1/buildings/:id       - Delete a specific building
+		// PATCH  /api/v1/buildings/:id       - Patch a specific building (JSON Merge Patch)
+		// GET    /api/v1/buildings/:id/delete-impact - Count what deleting a building would remove
+		// DELETE /api/v1/buildings/:id       - Delete a specific building (?cascade=true takes its dependents too)
 		buildings := v1.Group("/buildings")
 		{
 			buildings.POST("", buildingController.CreateBuilding)
@@ -93,10 +171,15 @@ func RegisterRoutes(router *gin.Engine) {
 			// Floors within a building - Register nested routes BEFORE wildcard routes
 			// GET    /api/v1/buildings/:id/floors           - Get all floors in a building
 			buildings.GET("/:id/floors", floorController.GetFloorsByBuilding)
+			buildings.GET("/:id/tree", treeController.GetBuildingTree)
 
 			buildings.GET("/:id", buildingController.GetBuilding)
 			buildings.PUT("/:id", buildingController.UpdateBuilding)
+			buildings.PATCH("/:id", buildingController.PatchBuilding)
+			buildings.GET("/:id/delete-impact", buildingController.GetBuildingDeleteImpact)
 			buildings.DELETE("/:id", buildingController.DeleteBuilding)
+			buildings.POST("/:id/restore", trashController.Restore("buildings"))
+			buildings.DELETE("/:id/purge", requireAdmin, trashController.Purge("buildings"))
 		}
 
 		// Floor routes
@@ -104,7 +187,9 @@ func RegisterRoutes(router *gin.Engine) {
 		// GET    /api/v1/floors           - Get all floors (with pagination)
 		// GET    /api/v1/floors/:id       - Get a specific floor
 		// PUT    /api/v1/floors/:id       - Update a specific floor
-		// DELETE /api/v1/floors/:id       - Delete a specific floor
+		// PATCH  /api/v1/floors/:id       - Patch a specific floor (JSON Merge Patch)
+		// GET    /api/v1/floors/:id/delete-impact - Count what deleting a floor would remove
+		// DELETE /api/v1/floors/:id       - Delete a specific floor (?cascade=true takes its dependents too)
 		floors := v1.Group("/floors")
 		{
 			floors.POST("", floorController.CreateFloor)
@@ -116,7 +201,11 @@ func RegisterRoutes(router *gin.Engine) {
 
 			floors.GET("/:id", floorController.GetFloor)
 			floors.PUT("/:id", floorController.UpdateFloor)
+			floors.PATCH("/:id", floorController.PatchFloor)
+			floors.GET("/:id/delete-impact", floorController.GetFloorDeleteImpact)
 			floors.DELETE("/:id", floorController.DeleteFloor)
+			floors.POST("/:id/restore", trashController.Restore("floors"))
+			floors.DELETE("/:id/purge", requireAdmin, trashController.Purge("floors"))
 		}
 
 		// Room routes
@@ -124,7 +213,9 @@ func RegisterRoutes(router *gin.Engine) {
 		// GET    /api/v1/rooms           - Get all rooms (with pagination)
 		// GET    /api/v1/rooms/:id       - Get a specific room
 		// PUT    /api/v1/rooms/:id       - Update a specific room
-		// DELETE /api/v1/rooms/:id       - Delete a specific room
+		// PATCH  /api/v1/rooms/:id       - Patch a specific room (JSON Merge Patch)
+		// GET    /api/v1/rooms/:id/delete-impact - Count what deleting a room would remove
+		// DELETE /api/v1/rooms/:id       - Delete a specific room (?cascade=true takes its dependents too)
 		rooms := v1.Group("/rooms")
 		{
 			rooms.POST("", roomController.CreateRoom)
@@ -136,15 +227,22 @@ func RegisterRoutes(router *gin.Engine) {
 
 			rooms.GET("/:id", roomController.GetRoom)
 			rooms.PUT("/:id", roomController.UpdateRoom)
+			rooms.PATCH("/:id", roomController.PatchRoom)
+			rooms.GET("/:id/delete-impact", roomController.GetRoomDeleteImpact)
 			rooms.DELETE("/:id", roomController.DeleteRoom)
+			rooms.POST("/:id/restore", trashController.Restore("rooms"))
+			rooms.DELETE("/:id/purge", requireAdmin, trashController.Purge("rooms"))
 		}
 
 		// Component Category routes
 		// POST   /api/v1/component-categories           - Create a new component category
 		// GET    /api/v1/component-categories           - Get all component categories
 		// GET    /api/v1/component-categories/:id       - Get a specific component category
+		// GET    /api/v1/component-categories/:id/reliability - Get failure metrics of a category
 		// PUT    /api/v1/component-categories/:id       - Update a specific component category
-		// DELETE /api/v1/component-categories/:id       - Delete a specific component category
+		// PATCH  /api/v1/component-categories/:id       - Patch a specific component category (JSON Merge Patch)
+		// GET    /api/v1/component-categories/:id/delete-impact - Count what deleting a category would remove
+		// DELETE /api/v1/component-categories/:id       - Delete a specific component category (?cascade=true takes its dependents too)
 		categories := v1.Group("/component-categories")
 		{
 			categories.POST("", componentCategoryController.CreateComponentCategory)
@@ -153,43 +251,126 @@ func RegisterRoutes(router *gin.Engine) {
 			// Components within a category - Register nested routes BEFORE wildcard routes
 			// GET    /api/v1/component-categories/:id/components           - Get all components in a category
 			categories.GET("/:id/components", componentController.GetComponentsByCategory)
+			categories.GET("/:id/reliability", componentCategoryController.GetCategoryReliability)
 
 			categories.GET("/:id", componentCategoryController.GetComponentCategory)
 			categories.PUT("/:id", componentCategoryController.UpdateComponentCategory)
+			categories.PATCH("/:id", componentCategoryController.PatchComponentCategory)
+			categories.GET("/:id/delete-impact", componentCategoryController.GetComponentCategoryDeleteImpact)
 			categories.DELETE("/:id", componentCategoryController.DeleteComponentCategory)
+			categories.POST("/:id/restore", trashController.Restore("component-categories"))
+			categories.DELETE("/:id/purge", requireAdmin, trashController.Purge("component-categories"))
 		}
 
 		// Component routes
 		// POST   /api/v1/components           - Create a new component
-		// GET    /api/v1/components           - Get all components (with pagination and nested info)
+		// GET    /api/v1/components           - Get all components (with pagination, filters and nested info; ?format=csv|xlsx|pdf exports)
 		// GET    /api/v1/components/:id       - Get a specific component
+		// GET    /api/v1/components/:id/reliability - Get failure count, MTBF and age of a component
+		// GET    /api/v1/components/replacement-candidates - Rank components for replacement
 		// PUT    /api/v1/components/:id       - Update a specific component
+		// PATCH  /api/v1/components/:id       - Patch a specific component (JSON Merge Patch)
 		// PUT    /api/v1/components/:id/assign-room - Assign room to component
+		// POST   /api/v1/components/bulk-move - Move many components to a room
 		// DELETE /api/v1/components/:id       - Delete a specific component
 		components := v1.Group("/components")
 		{
 			components.POST("", componentController.CreateComponent)
 			components.GET("", componentController.GetAllComponents)
+			components.POST("/bulk-move", componentController.BulkMoveComponents)
+			components.GET("/replacement-candidates", componentController.GetReplacementCandidates)
 			components.GET("/:id", componentController.GetComponent)
+			components.GET("/:id/reliability", componentController.GetComponentReliability)
 			components.PUT("/:id", componentController.UpdateComponent)
+			components.PATCH("/:id", componentController.PatchComponent)
 			components.PUT("/:id/assign-room", componentController.AssignRoomToComponent)
 			components.DELETE("/:id", componentController.DeleteComponent)
+			components.POST("/:id/restore", trashController.Restore("components"))
+			components.DELETE("/:id/purge", requireAdmin, trashController.Purge("components"))
 		}
 
 		// Report routes
 		// POST   /api/v1/reports           - Create a new report
-		// GET    /api/v1/reports           - Get all reports (with pagination)
-		// GET    /api/v1/reports/:id       - Get a specific report
+		// GET    /api/v1/reports           - Get all reports (with pagination and filters incl. ?tags=&tag_match=any|all; ?format=csv|xlsx|pdf exports)
+		// GET    /api/v1/reports/:id       - Get a specific report (?format=pdf prints a summary)
 		// PUT    /api/v1/reports/:id       - Update a specific report
+		// PATCH  /api/v1/reports/:id       - Patch a specific report (JSON Merge Patch)
 		// DELETE /api/v1/reports/:id       - Delete a specific report
+		// POST   /api/v1/reports/:id/tags  - Attach tags to a report
+		// DELETE /api/v1/reports/:id/tags/:tagId - Detach a tag from a report
+		// POST   /api/v1/reports/bulk      - Assign, transition, tag or delete many reports
 		reports := v1.Group("/reports")
 		{
 			reports.POST("", reportController.CreateReport)
 			reports.GET("", reportController.GetAllReports)
+			reports.POST("/bulk", reportController.BulkReports)
 			reports.GET("/:id", reportController.GetReport)
 			reports.PUT("/:id", reportController.UpdateReport)
+			reports.PATCH("/:id", reportController.PatchReport)
 			reports.DELETE("/:id", reportController.DeleteReport)
+			reports.POST("/:id/restore", trashController.Restore("reports"))
+			reports.DELETE("/:id/purge", requireAdmin, trashController.Purge("reports"))
 			reports.PUT("/:id/assign-user", reportController.AssignUserToReport)
+			reports.POST("/:id/tags", reportController.AddTagsToReport)
+			reports.DELETE("/:id/tags/:tagId", reportController.RemoveTagFromReport)
+		}
+
+		// Tag routes
+		// POST   /api/v1/tags           - Create a new tag
+		// GET    /api/v1/tags           - Get all tags (with pagination)
+		// GET    /api/v1/tags/:id       - Get a specific tag
+		// PUT    /api/v1/tags/:id       - Update a specific tag
+		// PATCH  /api/v1/tags/:id       - Patch a specific tag (JSON Merge Patch)
+		// DELETE /api/v1/tags/:id       - Delete a specific tag
+		tags := v1.Group("/tags")
+		{
+			tags.POST("", tagController.CreateTag)
+			tags.GET("", tagController.GetAllTags)
+			tags.GET("/:id", tagController.GetTag)
+			tags.PUT("/:id", tagController.UpdateTag)
+			tags.PATCH("/:id", tagController.PatchTag)
+			tags.DELETE("/:id", tagController.DeleteTag)
+		}
+
+		// Trash of soft-deleted records
+		// GET    /api/v1/trash           - List deleted records (?type= narrows to one type)
+		// Every soft-deletable resource also has
+		// POST   /api/v1/{resource}/:id/restore - Restore a record and the children deleted with it
+		// DELETE /api/v1/{resource}/:id/purge   - Permanently delete a record from the trash (admin only)
+		v1.GET("/trash", trashController.ListTrash)
+
+		// Audit log of every create, update and delete
+		// GET    /api/v1/audit           - List entries (?entity=&entity_id=&actor=&from=&to=)
+		v1.GET("/audit", auditController.ListAuditLogs)
+
+		// Analytics routes (all accept from, to and building_id filters)
+		// GET    /api/v1/analytics/volume              - Reports opened/closed per day or week
+		// GET    /api/v1/analytics/resolution-time     - Mean and median time to resolve
+		// GET    /api/v1/analytics/top-components      - Components with the most reports
+		// GET    /api/v1/analytics/top-categories      - Categories with the most reports
+		// GET    /api/v1/analytics/hotspots/buildings  - Buildings ranked by reports
+		// GET    /api/v1/analytics/hotspots/floors     - Floors ranked by reports
+		// GET    /api/v1/analytics/technicians         - Per-technician throughput
+		// GET    /api/v1/analytics/tags                - Reports per tag
+		analytics := v1.Group("/analytics")
+		{
+			analytics.GET("/volume", analyticsController.GetVolume)
+			analytics.GET("/resolution-time", analyticsController.GetResolutionTime)
+			analytics.GET("/top-components", analyticsController.GetTopComponents)
+			analytics.GET("/top-categories", analyticsController.GetTopCategories)
+			analytics.GET("/hotspots/buildings", analyticsController.GetBuildingHotspots)
+			analytics.GET("/hotspots/floors", analyticsController.GetFloorHotspots)
+			analytics.GET("/technicians", analyticsController.GetTechnicianThroughput)
+			analytics.GET("/tags", analyticsController.GetTagCounts)
 		}
 	}
+	// OpenAPI spec of the API v1 routes, generated from the route table and the DTOs
+	// GET    /openapi.json           - The spec Swagger UI loads (see Operations)
+	for _, drift := range openapi.Drift(router.Routes(), Operations) {
+		slog.Warn("route table and OpenAPI spec disagree", "drift", drift)
+	}
+	spec.Build(router.Routes())
+	router.GET("/openapi.json", func(c *gin.Context) {
+		c.JSON(http.StatusOK, spec.Document())
+	})
 }
@@ -0,0 +1,88 @@
+package routes_test
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestTrashEndpoints(t *testing.T) {
+	router, db := newTestServer(t)
+	f := seed(t, router)
+	admin := create(t, router, "/api/v1/users", map[string]any{"name": "Admin", "email": "admin@example.com"})
+	promote(t, db, admin)
+
+	runCases(t, router, []apiCase{
+		{name: "empty", method: http.MethodGet, path: path("/trash"),
+			status: http.StatusOK, check: expectPage(0, 1, 10, 0)},
+		{name: "invalid type", method: http.MethodGet, path: path("/trash?type=tags"),
+			status: http.StatusBadRequest, check: expectDetail("type", "oneof")},
+
+		{name: "delete report", method: http.MethodDelete, path: path("/reports/%d", f.ReportID),
+			status: http.StatusOK},
+		{name: "delete component", method: http.MethodDelete, path: path("/components/%d", f.ComponentID),
+			status: http.StatusNoContent},
+		{name: "delete room", method: http.MethodDelete, path: path("/rooms/%d", f.RoomID),
+			status: http.StatusNoContent},
+		{name: "list", method: http.MethodGet, path: path("/trash"),
+			status: http.StatusOK, check: expectPage(3, 1, 10, 3)},
+		{name: "list by type", method: http.MethodGet, path: path("/trash?type=reports"),
+			status: http.StatusOK, check: expectPage(1, 1, 10, 1)},
+
+		{name: "restore under deleted room", method: http.MethodPost, path: path("/components/%d/restore", f.ComponentID),
+			status: http.StatusConflict, check: expectError("restore it first")},
+		{name: "restore room", method: http.MethodPost, path: path("/rooms/%d/restore", f.RoomID),
+			status: http.StatusOK, check: expectField("deleted_at", nil)},
+		{name: "restore component", method: http.MethodPost, path: path("/components/%d/restore", f.ComponentID),
+			status: http.StatusOK, check: expectField("name", "Projector 1")},
+		{name: "restored component is live", method: http.MethodGet, path: path("/components/%d", f.ComponentID),
+			status: http.StatusOK},
+		{name: "restore live record", method: http.MethodPost, path: path("/rooms/%d/restore", f.RoomID),
+			status: http.StatusConflict, check: expectCode("CONFLICT")},
+		{name: "restore unknown", method: http.MethodPost, path: path("/buildings/999/restore"),
+			status: http.StatusNotFound},
+
+		{name: "purge anonymously", method: http.MethodDelete, path: path("/reports/%d/purge", f.ReportID),
+			status: http.StatusUnauthorized, check: expectCode("UNAUTHORIZED")},
+		{name: "purge as technician", method: http.MethodDelete, path: path("/reports/%d/purge", f.ReportID),
+			header: asUser(f.UserID), status: http.StatusForbidden, check: expectCode("FORBIDDEN")},
+		{name: "purge as unknown user", method: http.MethodDelete, path: path("/reports/%d/purge", f.ReportID),
+			header: asUser(999), status: http.StatusUnauthorized},
+		{name: "purge live record", method: http.MethodDelete, path: path("/components/%d/purge", f.ComponentID),
+			header: asUser(admin), status: http.StatusConflict},
+		{name: "purge", method: http.MethodDelete, path: path("/reports/%d/purge", f.ReportID),
+			header: asUser(admin), status: http.StatusNoContent},
+		{name: "purge again", method: http.MethodDelete, path: path("/reports/%d/purge", f.ReportID),
+			header: asUser(admin), status: http.StatusNotFound},
+		{name: "trash emptied", method: http.MethodGet, path: path("/trash"),
+			status: http.StatusOK, check: expectPage(0, 1, 10, 0)},
+
+		{name: "delete technician", method: http.MethodDelete, path: path("/users/%d", f.UserID),
+			status: http.StatusOK},
+		{name: "deleted user cannot identify", method: http.MethodGet, path: path("/trash"),
+			header: asUser(f.UserID), status: http.StatusUnauthorized},
+		{name: "purge technician", method: http.MethodDelete, path: path("/users/%d/purge", f.UserID),
+			header: asUser(admin), status: http.StatusNoContent},
+	})
+}
+
+func TestUserRoles(t *testing.T) {
+	router, db := newTestServer(t)
+	f := seed(t, router)
+	admin := create(t, router, "/api/v1/users", map[string]any{"name": "Admin", "email": "admin@example.com"})
+	promote(t, db, admin)
+
+	runCases(t, router, []apiCase{
+		{name: "technician by default", method: http.MethodGet, path: path("/users/%d", f.UserID),
+			status: http.StatusOK, check: expectField("role", "technician")},
+		{name: "promote anonymously", method: http.MethodPut, path: path("/users/%d", f.UserID),
+			body: map[string]any{"role": "admin"}, status: http.StatusForbidden},
+		{name: "promote self", method: http.MethodPut, path: path("/users/%d", f.UserID),
+			body: map[string]any{"role": "admin"}, header: asUser(f.UserID), status: http.StatusForbidden},
+		{name: "unknown role", method: http.MethodPut, path: path("/users/%d", f.UserID),
+			body: map[string]any{"role": "owner"}, header: asUser(admin), status: http.StatusBadRequest,
+			check: expectDetail("role", "oneof")},
+		{name: "promote as admin", method: http.MethodPut, path: path("/users/%d", f.UserID),
+			body: map[string]any{"role": "admin"}, header: asUser(admin), status: http.StatusOK,
+			check: expectField("role", "admin")},
+	})
+}
@@ -0,0 +1,76 @@
+package routes_test
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestUserEndpoints(t *testing.T) {
+	router := newTestRouter(t)
+
+	id := create(t, router, "/api/v1/users", map[string]any{"name": "Alice", "email": "alice@example.com"})
+	create(t, router, "/api/v1/users", map[string]any{"name": "Bob", "email": "bob@example.com"})
+	create(t, router, "/api/v1/users", map[string]any{"name": "Carol", "email": "carol@example.com"})
+
+	runCases(t, router, []apiCase{
+		{name: "create", method: http.MethodPost, path: path("/users"),
+			body: map[string]any{"name": "Dave", "email": "dave@example.com"}, status: http.StatusCreated,
+			check: expectField("email", "dave@example.com")},
+		{name: "create missing email", method: http.MethodPost, path: path("/users"),
+			body: map[string]any{"name": "Eve"}, status: http.StatusBadRequest,
+			check: expectDetail("email", "required")},
+		{name: "create invalid email", method: http.MethodPost, path: path("/users"),
+			body: map[string]any{"name": "Eve", "email": "not-an-email"}, status: http.StatusBadRequest,
+			check: expectDetail("email", "email")},
+		{name: "create short name", method: http.MethodPost, path: path("/users"),
+			body: map[string]any{"name": "E", "email": "eve@example.com"}, status: http.StatusBadRequest,
+			check: expectDetail("name", "min")},
+		{name: "create duplicate email", method: http.MethodPost, path: path("/users"),
+			body: map[string]any{"name": "Alice Again", "email": "alice@example.com"}, status: http.StatusConflict,
+			check: expectConflict("email")},
+		{name: "create malformed body", method: http.MethodPost, path: path("/users"),
+			body: "{", status: http.StatusBadRequest, check: expectCode("BAD_REQUEST")},
+
+		{name: "list defaults", method: http.MethodGet, path: path("/users"),
+			status: http.StatusOK, check: expectPage(4, 1, 10, 4)},
+		{name: "list second page", method: http.MethodGet, path: path("/users?page=2&page_size=3"),
+			status: http.StatusOK, check: expectPage(4, 2, 3, 1)},
+		{name: "list past last page", method: http.MethodGet, path: path("/users?page=5&page_size=3"),
+			status: http.StatusOK, check: expectPage(4, 5, 3, 0)},
+		{name: "list largest page size", method: http.MethodGet, path: path("/users?page_size=100"),
+			status: http.StatusOK, check: expectPage(4, 1, 100, 4)},
+		{name: "list page size too large", method: http.MethodGet, path: path("/users?page_size=101"),
+			status: http.StatusBadRequest},
+		{name: "list negative page", method: http.MethodGet, path: path("/users?page=-1"),
+			status: http.StatusBadRequest},
+
+		{name: "get", method: http.MethodGet, path: path("/users/%d", id),
+			status: http.StatusOK, check: expectField("name", "Alice")},
+		{name: "get not found", method: http.MethodGet, path: path("/users/999"),
+			status: http.StatusNotFound, check: expectCode("NOT_FOUND")},
+		{name: "get invalid id", method: http.MethodGet, path: path("/users/abc"),
+			status: http.StatusBadRequest},
+
+		{name: "update", method: http.MethodPut, path: path("/users/%d", id),
+			body: map[string]any{"name": "Alice Smith"}, status: http.StatusOK,
+			check: expectField("name", "Alice Smith")},
+		{name: "update invalid email", method: http.MethodPut, path: path("/users/%d", id),
+			body: map[string]any{"email": "nope"}, status: http.StatusBadRequest},
+		{name: "update not found", method: http.MethodPut, path: path("/users/999"),
+			body: map[string]any{"name": "Nobody"}, status: http.StatusNotFound,
+			check: expectError("user not found")},
+		{name: "update invalid id", method: http.MethodPut, path: path("/users/abc"),
+			body: map[string]any{"name": "Nobody"}, status: http.StatusBadRequest},
+
+		{name: "delete", method: http.MethodDelete, path: path("/users/%d", id),
+			status: http.StatusOK},
+		{name: "get deleted", method: http.MethodGet, path: path("/users/%d", id),
+			status: http.StatusNotFound},
+		{name: "delete again", method: http.MethodDelete, path: path("/users/%d", id),
+			status: http.StatusNotFound, check: expectError("user not found")},
+		{name: "delete invalid id", method: http.MethodDelete, path: path("/users/abc"),
+			status: http.StatusBadRequest},
+		{name: "list after delete", method: http.MethodGet, path: path("/users"),
+			status: http.StatusOK, check: expectPage(3, 1, 10, 3)},
+	})
+}
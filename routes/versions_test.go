@@ -0,0 +1,79 @@
+package routes_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// expectETag asserts the entity tag of the returned record
+func expectETag(want string) func(t *testing.T, rec *httptest.ResponseRecorder) {
+	return func(t *testing.T, rec *httptest.ResponseRecorder) {
+		t.Helper()
+
+		if got := rec.Header().Get("ETag"); got != want {
+			t.Errorf("ETag = %q, want %q", got, want)
+		}
+	}
+}
+
+// ifMatch returns the header that makes a write conditional on the record's version
+func ifMatch(tag string) map[string]string {
+	return map[string]string{"If-Match": tag}
+}
+
+func TestETagPreconditions(t *testing.T) {
+	router := newTestRouter(t)
+	f := seed(t, router)
+
+	runCases(t, router, []apiCase{
+		{name: "get returns etag", method: http.MethodGet, path: path("/buildings/%d", f.BuildingID),
+			status: http.StatusOK, check: expectETag(`"1"`)},
+		{name: "get reports version", method: http.MethodGet, path: path("/rooms/%d", f.RoomID),
+			status: http.StatusOK, check: expectField("version", 1)},
+
+		{name: "update with current version", method: http.MethodPut, path: path("/buildings/%d", f.BuildingID),
+			body: map[string]any{"name": "Main Hall"}, header: ifMatch(`"1"`),
+			status: http.StatusOK, check: expectETag(`"2"`)},
+		{name: "update with stale version", method: http.MethodPut, path: path("/buildings/%d", f.BuildingID),
+			body: map[string]any{"name": "Old Hall"}, header: ifMatch(`"1"`),
+			status: http.StatusPreconditionFailed, check: expectCode("PRECONDITION_FAILED")},
+		{name: "stale update left record alone", method: http.MethodGet, path: path("/buildings/%d", f.BuildingID),
+			status: http.StatusOK, check: expectField("name", "Main Hall")},
+		{name: "weak etag", method: http.MethodPut, path: path("/tags/%d", f.TagID),
+			body: map[string]any{"color": "#FF8800"}, header: ifMatch(`W/"1"`),
+			status: http.StatusOK, check: expectETag(`"2"`)},
+		{name: "any version", method: http.MethodPut, path: path("/tags/%d", f.TagID),
+			body: map[string]any{"color": "#00FF00"}, header: ifMatch("*"),
+			status: http.StatusOK, check: expectETag(`"3"`)},
+		{name: "without if-match", method: http.MethodPut, path: path("/tags/%d", f.TagID),
+			body:   map[string]any{"color": "#0000FF"},
+			status: http.StatusOK, check: expectETag(`"4"`)},
+		{name: "malformed if-match", method: http.MethodPut, path: path("/tags/%d", f.TagID),
+			body: map[string]any{"color": "#FF0000"}, header: ifMatch("4"),
+			status: http.StatusPreconditionFailed, check: expectError("If-Match")},
+		{name: "reads ignore if-match", method: http.MethodGet, path: path("/tags/%d", f.TagID), header: ifMatch("4"),
+			status: http.StatusOK, check: expectETag(`"4"`)},
+
+		{name: "assign with stale version", method: http.MethodPut, path: path("/reports/%d/assign-user", f.ReportID),
+			body: map[string]any{"user_id": f.UserID}, header: ifMatch(`"9"`),
+			status: http.StatusPreconditionFailed, check: expectError("current version is 1")},
+		{name: "add tags with stale version", method: http.MethodPost, path: path("/reports/%d/tags", f.ReportID),
+			body: map[string]any{"tag_ids": []uint{f.TagID}}, header: ifMatch(`"99"`),
+			status: http.StatusPreconditionFailed, check: expectError("current version is 1")},
+		{name: "stale tags left report alone", method: http.MethodGet, path: path("/reports/%d", f.ReportID),
+			status: http.StatusOK, check: expectETag(`"1"`)},
+		{name: "add tags with current version", method: http.MethodPost, path: path("/reports/%d/tags", f.ReportID),
+			body: map[string]any{"tag_ids": []uint{f.TagID}}, header: ifMatch(`"1"`),
+			status: http.StatusOK, check: expectETag(`"2"`)},
+		{name: "remove tag with stale version", method: http.MethodDelete, path: path("/reports/%d/tags/%d", f.ReportID, f.TagID),
+			header: ifMatch(`"1"`), status: http.StatusPreconditionFailed, check: expectError("current version is 2")},
+		{name: "create ignores if-match", method: http.MethodPost, path: path("/tags"),
+			body: map[string]any{"name": "conditional"}, header: ifMatch(`"99"`), status: http.StatusCreated},
+
+		{name: "delete with stale version", method: http.MethodDelete, path: path("/tags/%d", f.TagID),
+			header: ifMatch(`"3"`), status: http.StatusPreconditionFailed, check: expectCode("PRECONDITION_FAILED")},
+		{name: "delete with current version", method: http.MethodDelete, path: path("/tags/%d", f.TagID),
+			header: ifMatch(`"4"`), status: http.StatusNoContent},
+	})
+}
@@ -0,0 +1,111 @@
+package rpc
+
+import (
+	"context"
+	"incident-report/services"
+	"incident-report/utils"
+
+	incidentv1 "incident-report/proto/incident/v1"
+)
+
+// componentServer implements ComponentService on the component service
+type componentServer struct {
+	incidentv1.UnimplementedComponentServiceServer
+
+	components *services.ComponentService
+}
+
+func (s *componentServer) CreateComponent(ctx context.Context, req *incidentv1.CreateComponentRequest) (*incidentv1.Component, error) {
+	create := &utils.CreateComponentRequest{
+		RoomID:          optionalID(req.RoomId),
+		CategoryID:      uint(req.CategoryId),
+		Code:            req.Code,
+		Name:            req.Name,
+		Brand:           req.Brand,
+		Specification:   req.Specification,
+		ProcurementYear: int(req.ProcurementYear),
+	}
+	if err := utils.Validate(create); err != nil {
+		return nil, statusError(err)
+	}
+
+	component, err := s.components.CreateComponent(ctx, create)
+	if err != nil {
+		return nil, statusError(err)
+	}
+	return toComponent(component), nil
+}
+
+func (s *componentServer) GetComponent(ctx context.Context, req *incidentv1.GetComponentRequest) (*incidentv1.Component, error) {
+	component, err := s.components.GetComponentByID(ctx, uint(req.Id))
+	if err != nil {
+		return nil, statusError(err)
+	}
+	return toComponent(component), nil
+}
+
+func (s *componentServer) ListComponents(ctx context.Context, req *incidentv1.ListComponentsRequest) (*incidentv1.ListComponentsResponse, error) {
+	q, err := paginationOf(req.Page)
+	if err != nil {
+		return nil, statusError(err)
+	}
+
+	filter := &utils.ComponentFilterQuery{RoomID: uint(req.RoomId), CategoryID: uint(req.CategoryId)}
+	components, info, err := s.components.GetAllComponents(ctx, q, filter)
+	if err != nil {
+		return nil, statusError(err)
+	}
+	return &incidentv1.ListComponentsResponse{Components: convertAll(components, toComponent), PageInfo: toPageInfo(q, info)}, nil
+}
+
+func (s *componentServer) UpdateComponent(ctx context.Context, req *incidentv1.UpdateComponentRequest) (*incidentv1.Component, error) {
+	update := &utils.UpdateComponentRequest{
+		Code:            req.Code,
+		Name:            req.Name,
+		Brand:           req.Brand,
+		Specification:   req.Specification,
+		ProcurementYear: int(req.ProcurementYear),
+	}
+	if err := utils.Validate(update); err != nil {
+		return nil, statusError(err)
+	}
+
+	component, err := s.components.UpdateComponent(withVersion(ctx, req.ExpectedVersion), uint(req.Id), update)
+	if err != nil {
+		return nil, statusError(err)
+	}
+	return toComponent(component), nil
+}
+
+func (s *componentServer) DeleteComponent(ctx context.Context, req *incidentv1.DeleteComponentRequest) (*incidentv1.DeleteComponentResponse, error) {
+	if err := s.components.DeleteComponent(withVersion(ctx, req.ExpectedVersion), uint(req.Id)); err != nil {
+		return nil, statusError(err)
+	}
+	return &incidentv1.DeleteComponentResponse{}, nil
+}
+
+func (s *componentServer) AssignRoom(ctx context.Context, req *incidentv1.AssignRoomRequest) (*incidentv1.Component, error) {
+	assign := &utils.AssignRoomRequest{RoomID: uint(req.RoomId)}
+	if err := utils.Validate(assign); err != nil {
+		return nil, statusError(err)
+	}
+
+	component, err := s.components.AssignRoomToComponent(withVersion(ctx, req.ExpectedVersion), uint(req.Id), assign)
+	if err != nil {
+		return nil, statusError(err)
+	}
+	return toComponent(component), nil
+}
+
+func (s *componentServer) BulkMoveComponents(ctx context.Context, req *incidentv1.BulkMoveComponentsRequest) (*incidentv1.BulkResponse, error) {
+	move := &utils.BulkMoveComponentsRequest{IDs: ids(req.Ids), RoomID: uint(req.RoomId), Mode: bulkModeOf(req.Mode)}
+	if err := utils.Validate(move); err != nil {
+		return nil, statusError(err)
+	}
+
+	result, err := s.components.BulkMoveComponents(ctx, move)
+	if err != nil {
+		return nil, statusError(err)
+	}
+	return toBulkResponse(result), nil
+}
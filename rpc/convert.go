@@ -0,0 +1,254 @@
+package rpc
+
+import (
+	"context"
+	"incident-report/utils"
+	"strings"
+	"time"
+
+	incidentv1 "incident-report/proto/incident/v1"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// withVersion returns a copy of ctx that requires the record to be at the expected version, if any
+func withVersion(ctx context.Context, version *uint32) context.Context {
+	if version == nil {
+		return ctx
+	}
+	return utils.WithExpectedVersion(ctx, uint(*version))
+}
+
+// paginationOf converts a page request, validated and defaulted like the REST list query
+func paginationOf(page *incidentv1.PageRequest) (*utils.PaginationQuery, error) {
+	q := &utils.PaginationQuery{
+		Page:     int(page.GetPage()),
+		PageSize: int(page.GetPageSize()),
+		After:    page.GetAfter(),
+		Before:   page.GetBefore(),
+	}
+	if page != nil {
+		q.Total = page.Total
+	}
+	if err := utils.Validate(q); err != nil {
+		return nil, err
+	}
+
+	if q.Page == 0 {
+		q.Page = 1
+	}
+	if q.PageSize == 0 {
+		q.PageSize = 10
+	}
+	return q, nil
+}
+
+// toPageInfo converts the page a list returned
+func toPageInfo(q *utils.PaginationQuery, info utils.PageInfo) *incidentv1.PageInfo {
+	page := utils.NewPaginatedResponse(nil, q, info)
+	return &incidentv1.PageInfo{
+		Page:       int32(page.Page),
+		PageSize:   int32(page.PageSize),
+		Total:      page.Total,
+		TotalPages: int32(page.TotalPage),
+		NextCursor: page.NextCursor,
+		PrevCursor: page.PrevCursor,
+	}
+}
+
+// optionalID converts an optional ID
+func optionalID(id *uint32) *uint {
+	if id == nil {
+		return nil
+	}
+	converted := uint(*id)
+	return &converted
+}
+
+// toOptionalID converts an optional ID of a response
+func toOptionalID(id *uint) *uint32 {
+	if id == nil {
+		return nil
+	}
+	converted := uint32(*id)
+	return &converted
+}
+
+// ids converts a list of IDs
+func ids(values []uint32) []uint {
+	converted := make([]uint, len(values))
+	for i, value := range values {
+		converted[i] = uint(value)
+	}
+	return converted
+}
+
+// unixTimestamp converts the Unix seconds of a response
+func unixTimestamp(seconds int64) *timestamppb.Timestamp {
+	return timestamppb.New(time.Unix(seconds, 0))
+}
+
+// parseTimestamp converts the RFC 3339 time of a response; nil and unparsable times are unset
+func parseTimestamp(value *string) *timestamppb.Timestamp {
+	if value == nil {
+		return nil
+	}
+	t, err := time.Parse(time.RFC3339, *value)
+	if err != nil {
+		return nil
+	}
+	return timestamppb.New(t)
+}
+
+// bulkModeOf converts a bulk mode; unspecified leaves the service default
+func bulkModeOf(mode incidentv1.BulkMode) string {
+	switch mode {
+	case incidentv1.BulkMode_BULK_MODE_ATOMIC:
+		return utils.BulkModeAtomic
+	case incidentv1.BulkMode_BULK_MODE_BEST_EFFORT:
+		return utils.BulkModeBestEffort
+	default:
+		return ""
+	}
+}
+
+// toBulkResponse converts the outcome of a bulk request
+// Unlike the REST endpoints, a rolled back atomic request is not an error: committed is false.
+func toBulkResponse(result *utils.BulkResponse) *incidentv1.BulkResponse {
+	response := &incidentv1.BulkResponse{
+		Mode:      incidentv1.BulkMode_BULK_MODE_ATOMIC,
+		Committed: result.Committed,
+		Succeeded: int32(result.Succeeded),
+		Failed:    int32(result.Failed),
+		Results:   make([]*incidentv1.BulkItemResult, len(result.Results)),
+	}
+	if result.Mode == utils.BulkModeBestEffort {
+		response.Mode = incidentv1.BulkMode_BULK_MODE_BEST_EFFORT
+	}
+	for i, item := range result.Results {
+		response.Results[i] = &incidentv1.BulkItemResult{
+			Id:     uint32(item.ID),
+			Status: item.Status,
+			Code:   item.Code,
+			Error:  item.Error,
+		}
+	}
+	return response
+}
+
+// reportStatusOf converts a report status; unspecified is the empty status
+func reportStatusOf(status incidentv1.ReportStatus) string {
+	if status == incidentv1.ReportStatus_REPORT_STATUS_UNSPECIFIED {
+		return ""
+	}
+	return strings.TrimPrefix(status.String(), "REPORT_STATUS_")
+}
+
+// toReportStatus converts the status of a report
+func toReportStatus(status string) incidentv1.ReportStatus {
+	return incidentv1.ReportStatus(incidentv1.ReportStatus_value["REPORT_STATUS_"+status])
+}
+
+// reportFilterOf converts a report filter
+func reportFilterOf(filter *incidentv1.ReportFilter) *utils.ReportFilterQuery {
+	if filter == nil {
+		return &utils.ReportFilterQuery{}
+	}
+
+	query := &utils.ReportFilterQuery{
+		Status:      reportStatusOf(filter.Status),
+		RoomID:      uint(filter.RoomId),
+		UserID:      uint(filter.UserId),
+		ComponentID: uint(filter.ComponentId),
+		Tags:        strings.Join(filter.Tags, ","),
+	}
+	switch filter.TagMatch {
+	case incidentv1.TagMatch_TAG_MATCH_ANY:
+		query.TagMatch = "any"
+	case incidentv1.TagMatch_TAG_MATCH_ALL:
+		query.TagMatch = "all"
+	}
+	return query
+}
+
+func toBuilding(building *utils.BuildingResponse) *incidentv1.Building {
+	return &incidentv1.Building{
+		Id:        uint32(building.ID),
+		Code:      building.Code,
+		Name:      building.Name,
+		Location:  building.Location,
+		CreatedAt: unixTimestamp(building.CreatedAt),
+		UpdatedAt: unixTimestamp(building.UpdatedAt),
+		Version:   uint32(building.Version),
+	}
+}
+
+func toFloor(floor *utils.FloorResponse) *incidentv1.Floor {
+	return &incidentv1.Floor{
+		Id:          uint32(floor.ID),
+		BuildingId:  uint32(floor.BuildingID),
+		FloorNumber: int32(floor.FloorNumber),
+		Name:        floor.Name,
+		CreatedAt:   unixTimestamp(floor.CreatedAt),
+		UpdatedAt:   unixTimestamp(floor.UpdatedAt),
+		Version:     uint32(floor.Version),
+	}
+}
+
+func toRoom(room *utils.RoomResponse) *incidentv1.Room {
+	return &incidentv1.Room{
+		Id:        uint32(room.ID),
+		FloorId:   uint32(room.FloorID),
+		Code:      room.Code,
+		Name:      room.Name,
+		CreatedAt: unixTimestamp(room.CreatedAt),
+		UpdatedAt: unixTimestamp(room.UpdatedAt),
+		Version:   uint32(room.Version),
+	}
+}
+
+func toComponent(component *utils.ComponentResponse) *incidentv1.Component {
+	return &incidentv1.Component{
+		Id:              uint32(component.ID),
+		RoomId:          toOptionalID(component.RoomID),
+		CategoryId:      uint32(component.CategoryID),
+		Code:            component.Code,
+		Name:            component.Name,
+		Brand:           component.Brand,
+		Specification:   component.Specification,
+		ProcurementYear: int32(component.ProcurementYear),
+		CreatedAt:       unixTimestamp(component.CreatedAt),
+		UpdatedAt:       unixTimestamp(component.UpdatedAt),
+		Version:         uint32(component.Version),
+	}
+}
+
+func toReport(report *utils.ReportResponse) *incidentv1.Report {
+	converted := &incidentv1.Report{
+		Id:          uint32(report.ID),
+		Name:        report.Name,
+		RoomId:      uint32(report.RoomID),
+		UserId:      toOptionalID(report.UserID),
+		ComponentId: uint32(report.ComponentID),
+		Status:      toReportStatus(report.Status),
+		RepairCost:  report.RepairCost,
+		Tags:        make([]*incidentv1.Tag, len(report.Tags)),
+		CreatedAt:   parseTimestamp(&report.CreatedAt),
+		UpdatedAt:   parseTimestamp(&report.UpdatedAt),
+		CompletedAt: parseTimestamp(report.CompletedAt),
+		Version:     uint32(report.Version),
+	}
+	for i, tag := range report.Tags {
+		converted.Tags[i] = &incidentv1.Tag{Id: uint32(tag.ID), Name: tag.Name, Color: tag.Color}
+	}
+	return converted
+}
+
+// convertAll converts a list of responses
+func convertAll[T any, R any](items []T, convert func(*T) R) []R {
+	converted := make([]R, len(items))
+	for i := range items {
+		converted[i] = convert(&items[i])
+	}
+	return converted
+}
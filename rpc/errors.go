@@ -0,0 +1,57 @@
+package rpc
+
+import (
+	"errors"
+	"incident-report/utils"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/protoadapt"
+)
+
+// errorDomain is the domain of the ErrorInfo detail of every error
+const errorDomain = "incident-report"
+
+// statusError converts a service error into a gRPC status error
+// The status carries the REST error code as the reason of an ErrorInfo detail and the rejected
+// fields of validation and conflict errors as a BadRequest detail.
+func statusError(err error) error {
+	st := status.New(statusCode(err), err.Error())
+
+	details := []protoadapt.MessageV1{&errdetails.ErrorInfo{Reason: utils.ErrorCode(err), Domain: errorDomain}}
+	var appErr *utils.AppError
+	if errors.As(err, &appErr) && len(appErr.Fields) > 0 {
+		violations := make([]*errdetails.BadRequest_FieldViolation, len(appErr.Fields))
+		for i, field := range appErr.Fields {
+			violations[i] = &errdetails.BadRequest_FieldViolation{Field: field.Field, Description: field.Message}
+		}
+		details = append(details, &errdetails.BadRequest{FieldViolations: violations})
+	}
+
+	withDetails, detailErr := st.WithDetails(details...)
+	if detailErr != nil {
+		return st.Err()
+	}
+	return withDetails.Err()
+}
+
+// statusCode returns the gRPC code an error maps to, matching utils.HTTPStatus
+func statusCode(err error) codes.Code {
+	switch {
+	case errors.Is(err, utils.ErrNotFound):
+		return codes.NotFound
+	case errors.Is(err, utils.ErrConflict):
+		return codes.Aborted
+	case errors.Is(err, utils.ErrValidation):
+		return codes.InvalidArgument
+	case errors.Is(err, utils.ErrForbidden):
+		return codes.PermissionDenied
+	case errors.Is(err, utils.ErrStale):
+		return codes.FailedPrecondition
+	case errors.Is(err, utils.ErrUnprocessable):
+		return codes.OutOfRange
+	default:
+		return codes.Internal
+	}
+}
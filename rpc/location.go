@@ -0,0 +1,252 @@
+package rpc
+
+import (
+	"context"
+	"incident-report/services"
+	"incident-report/utils"
+
+	incidentv1 "incident-report/proto/incident/v1"
+)
+
+// locationServer implements LocationService on the building, floor, room and tree services
+type locationServer struct {
+	incidentv1.UnimplementedLocationServiceServer
+
+	buildings *services.BuildingService
+	floors    *services.FloorService
+	rooms     *services.RoomService
+	tree      *services.TreeService
+}
+
+func (s *locationServer) CreateBuilding(ctx context.Context, req *incidentv1.CreateBuildingRequest) (*incidentv1.Building, error) {
+	create := &utils.CreateBuildingRequest{Code: req.Code, Name: req.Name, Location: req.Location}
+	if err := utils.Validate(create); err != nil {
+		return nil, statusError(err)
+	}
+
+	building, err := s.buildings.CreateBuilding(ctx, create)
+	if err != nil {
+		return nil, statusError(err)
+	}
+	return toBuilding(building), nil
+}
+
+func (s *locationServer) GetBuilding(ctx context.Context, req *incidentv1.GetBuildingRequest) (*incidentv1.Building, error) {
+	building, err := s.buildings.GetBuildingByID(ctx, uint(req.Id))
+	if err != nil {
+		return nil, statusError(err)
+	}
+	return toBuilding(building), nil
+}
+
+func (s *locationServer) ListBuildings(ctx context.Context, req *incidentv1.ListBuildingsRequest) (*incidentv1.ListBuildingsResponse, error) {
+	q, err := paginationOf(req.Page)
+	if err != nil {
+		return nil, statusError(err)
+	}
+
+	buildings, info, err := s.buildings.GetAllBuildings(ctx, q)
+	if err != nil {
+		return nil, statusError(err)
+	}
+	return &incidentv1.ListBuildingsResponse{Buildings: convertAll(buildings, toBuilding), PageInfo: toPageInfo(q, info)}, nil
+}
+
+func (s *locationServer) UpdateBuilding(ctx context.Context, req *incidentv1.UpdateBuildingRequest) (*incidentv1.Building, error) {
+	update := &utils.UpdateBuildingRequest{Code: req.Code, Name: req.Name, Location: req.Location}
+	if err := utils.Validate(update); err != nil {
+		return nil, statusError(err)
+	}
+
+	building, err := s.buildings.UpdateBuilding(withVersion(ctx, req.ExpectedVersion), uint(req.Id), update)
+	if err != nil {
+		return nil, statusError(err)
+	}
+	return toBuilding(building), nil
+}
+
+func (s *locationServer) DeleteBuilding(ctx context.Context, req *incidentv1.DeleteBuildingRequest) (*incidentv1.DeleteBuildingResponse, error) {
+	if err := s.buildings.DeleteBuilding(withVersion(ctx, req.ExpectedVersion), uint(req.Id), req.Cascade); err != nil {
+		return nil, statusError(err)
+	}
+	return &incidentv1.DeleteBuildingResponse{}, nil
+}
+
+func (s *locationServer) CreateFloor(ctx context.Context, req *incidentv1.CreateFloorRequest) (*incidentv1.Floor, error) {
+	create := &utils.CreateFloorRequest{BuildingID: uint(req.BuildingId), FloorNumber: int(req.FloorNumber), Name: req.Name}
+	if err := utils.Validate(create); err != nil {
+		return nil, statusError(err)
+	}
+
+	floor, err := s.floors.CreateFloor(ctx, create)
+	if err != nil {
+		return nil, statusError(err)
+	}
+	return toFloor(floor), nil
+}
+
+func (s *locationServer) GetFloor(ctx context.Context, req *incidentv1.GetFloorRequest) (*incidentv1.Floor, error) {
+	floor, err := s.floors.GetFloorByID(ctx, uint(req.Id))
+	if err != nil {
+		return nil, statusError(err)
+	}
+	return toFloor(floor), nil
+}
+
+func (s *locationServer) ListFloors(ctx context.Context, req *incidentv1.ListFloorsRequest) (*incidentv1.ListFloorsResponse, error) {
+	q, err := paginationOf(req.Page)
+	if err != nil {
+		return nil, statusError(err)
+	}
+
+	var floors []utils.FloorResponse
+	var info utils.PageInfo
+	if req.BuildingId != 0 {
+		floors, info, err = s.floors.GetFloorsByBuildingID(ctx, uint(req.BuildingId), q)
+	} else {
+		floors, info, err = s.floors.GetAllFloors(ctx, q)
+	}
+	if err != nil {
+		return nil, statusError(err)
+	}
+	return &incidentv1.ListFloorsResponse{Floors: convertAll(floors, toFloor), PageInfo: toPageInfo(q, info)}, nil
+}
+
+func (s *locationServer) UpdateFloor(ctx context.Context, req *incidentv1.UpdateFloorRequest) (*incidentv1.Floor, error) {
+	update := &utils.UpdateFloorRequest{FloorNumber: int(req.FloorNumber), Name: req.Name}
+	if err := utils.Validate(update); err != nil {
+		return nil, statusError(err)
+	}
+
+	floor, err := s.floors.UpdateFloor(withVersion(ctx, req.ExpectedVersion), uint(req.Id), update)
+	if err != nil {
+		return nil, statusError(err)
+	}
+	return toFloor(floor), nil
+}
+
+func (s *locationServer) DeleteFloor(ctx context.Context, req *incidentv1.DeleteFloorRequest) (*incidentv1.DeleteFloorResponse, error) {
+	if err := s.floors.DeleteFloor(withVersion(ctx, req.ExpectedVersion), uint(req.Id), req.Cascade); err != nil {
+		return nil, statusError(err)
+	}
+	return &incidentv1.DeleteFloorResponse{}, nil
+}
+
+func (s *locationServer) CreateRoom(ctx context.Context, req *incidentv1.CreateRoomRequest) (*incidentv1.Room, error) {
+	create := &utils.CreateRoomRequest{FloorID: uint(req.FloorId), Code: req.Code, Name: req.Name}
+	if err := utils.Validate(create); err != nil {
+		return nil, statusError(err)
+	}
+
+	room, err := s.rooms.CreateRoom(ctx, create)
+	if err != nil {
+		return nil, statusError(err)
+	}
+	return toRoom(room), nil
+}
+
+func (s *locationServer) GetRoom(ctx context.Context, req *incidentv1.GetRoomRequest) (*incidentv1.Room, error) {
+	room, err := s.rooms.GetRoomByID(ctx, uint(req.Id))
+	if err != nil {
+		return nil, statusError(err)
+	}
+	return toRoom(room), nil
+}
+
+func (s *locationServer) ListRooms(ctx context.Context, req *incidentv1.ListRoomsRequest) (*incidentv1.ListRoomsResponse, error) {
+	q, err := paginationOf(req.Page)
+	if err != nil {
+		return nil, statusError(err)
+	}
+
+	var rooms []utils.RoomResponse
+	var info utils.PageInfo
+	if req.FloorId != 0 {
+		rooms, info, err = s.rooms.GetRoomsByFloorID(ctx, uint(req.FloorId), q)
+	} else {
+		rooms, info, err = s.rooms.GetAllRooms(ctx, q)
+	}
+	if err != nil {
+		return nil, statusError(err)
+	}
+	return &incidentv1.ListRoomsResponse{Rooms: convertAll(rooms, toRoom), PageInfo: toPageInfo(q, info)}, nil
+}
+
+func (s *locationServer) UpdateRoom(ctx context.Context, req *incidentv1.UpdateRoomRequest) (*incidentv1.Room, error) {
+	update := &utils.UpdateRoomRequest{Code: req.Code, Name: req.Name}
+	if err := utils.Validate(update); err != nil {
+		return nil, statusError(err)
+	}
+
+	room, err := s.rooms.UpdateRoom(withVersion(ctx, req.ExpectedVersion), uint(req.Id), update)
+	if err != nil {
+		return nil, statusError(err)
+	}
+	return toRoom(room), nil
+}
+
+func (s *locationServer) DeleteRoom(ctx context.Context, req *incidentv1.DeleteRoomRequest) (*incidentv1.DeleteRoomResponse, error) {
+	if err := s.rooms.DeleteRoom(withVersion(ctx, req.ExpectedVersion), uint(req.Id), req.Cascade); err != nil {
+		return nil, statusError(err)
+	}
+	return &incidentv1.DeleteRoomResponse{}, nil
+}
+
+// GetTree returns the hierarchy of every building, or only of the requested one
+func (s *locationServer) GetTree(ctx context.Context, req *incidentv1.GetTreeRequest) (*incidentv1.GetTreeResponse, error) {
+	query := &utils.TreeQuery{Depth: int(req.Depth)}
+	if err := utils.Validate(query); err != nil {
+		return nil, statusError(err)
+	}
+	options, err := services.ParseTreeOptions(query)
+	if err != nil {
+		return nil, statusError(err)
+	}
+	options.IncludeComponentCount = req.IncludeComponentCount
+	options.IncludeOpenReportCount = req.IncludeOpenReportCount
+
+	var tree []utils.BuildingTreeNode
+	if req.BuildingId != 0 {
+		building, err := s.tree.GetBuildingTree(ctx, uint(req.BuildingId), options)
+		if err != nil {
+			return nil, statusError(err)
+		}
+		tree = []utils.BuildingTreeNode{*building}
+	} else if tree, err = s.tree.GetTree(ctx, options); err != nil {
+		return nil, statusError(err)
+	}
+	return &incidentv1.GetTreeResponse{Buildings: convertAll(tree, toBuildingNode)}, nil
+}
+
+func toBuildingNode(building *utils.BuildingTreeNode) *incidentv1.BuildingNode {
+	return &incidentv1.BuildingNode{
+		Id:              uint32(building.ID),
+		Code:            building.Code,
+		Name:            building.Name,
+		Location:        building.Location,
+		ComponentCount:  building.ComponentCount,
+		OpenReportCount: building.OpenReportCount,
+		Floors:          convertAll(building.Floors, toFloorNode),
+	}
+}
+
+func toFloorNode(floor *utils.FloorTreeNode) *incidentv1.FloorNode {
+	return &incidentv1.FloorNode{
+		Id:              uint32(floor.ID),
+		FloorNumber:     int32(floor.FloorNumber),
+		Name:            floor.Name,
+		ComponentCount:  floor.ComponentCount,
+		OpenReportCount: floor.OpenReportCount,
+		Rooms:           convertAll(floor.Rooms, toRoomNode),
+	}
+}
+
+func toRoomNode(room *utils.RoomTreeNode) *incidentv1.RoomNode {
+	return &incidentv1.RoomNode{
+		Id:              uint32(room.ID),
+		Code:            room.Code,
+		Name:            room.Name,
+		ComponentCount:  room.ComponentCount,
+		OpenReportCount: room.OpenReportCount,
+	}
+}
@@ -0,0 +1,152 @@
+package rpc
+
+import (
+	"context"
+	"incident-report/services"
+	"incident-report/utils"
+	"strings"
+
+	incidentv1 "incident-report/proto/incident/v1"
+)
+
+// reportServer implements ReportService on the report service
+type reportServer struct {
+	incidentv1.UnimplementedReportServiceServer
+
+	reports *services.ReportService
+}
+
+func (s *reportServer) CreateReport(ctx context.Context, req *incidentv1.CreateReportRequest) (*incidentv1.Report, error) {
+	create := &utils.CreateReportRequest{
+		Name:        req.Name,
+		RoomID:      uint(req.RoomId),
+		UserID:      optionalID(req.UserId),
+		ComponentID: uint(req.ComponentId),
+		Status:      reportStatusOf(req.Status),
+		RepairCost:  req.RepairCost,
+	}
+	if err := utils.Validate(create); err != nil {
+		return nil, statusError(err)
+	}
+
+	report, err := s.reports.CreateReport(ctx, create)
+	if err != nil {
+		return nil, statusError(err)
+	}
+	return toReport(report), nil
+}
+
+func (s *reportServer) GetReport(ctx context.Context, req *incidentv1.GetReportRequest) (*incidentv1.Report, error) {
+	report, err := s.reports.GetReportByID(ctx, uint(req.Id))
+	if err != nil {
+		return nil, statusError(err)
+	}
+	return toReport(report), nil
+}
+
+func (s *reportServer) ListReports(ctx context.Context, req *incidentv1.ListReportsRequest) (*incidentv1.ListReportsResponse, error) {
+	q, err := paginationOf(req.Page)
+	if err != nil {
+		return nil, statusError(err)
+	}
+
+	reports, info, err := s.reports.GetAllReports(ctx, q, reportFilterOf(req.Filter))
+	if err != nil {
+		return nil, statusError(err)
+	}
+	return &incidentv1.ListReportsResponse{Reports: convertAll(reports, toReport), PageInfo: toPageInfo(q, info)}, nil
+}
+
+func (s *reportServer) UpdateReport(ctx context.Context, req *incidentv1.UpdateReportRequest) (*incidentv1.Report, error) {
+	update := &utils.UpdateReportRequest{
+		Name:        req.Name,
+		RoomID:      uint(req.RoomId),
+		ComponentID: uint(req.ComponentId),
+		Status:      reportStatusOf(req.Status),
+		RepairCost:  req.RepairCost,
+	}
+	if err := utils.Validate(update); err != nil {
+		return nil, statusError(err)
+	}
+
+	report, err := s.reports.UpdateReport(withVersion(ctx, req.ExpectedVersion), uint(req.Id), update)
+	if err != nil {
+		return nil, statusError(err)
+	}
+	return toReport(report), nil
+}
+
+func (s *reportServer) DeleteReport(ctx context.Context, req *incidentv1.DeleteReportRequest) (*incidentv1.DeleteReportResponse, error) {
+	if err := s.reports.DeleteReport(withVersion(ctx, req.ExpectedVersion), uint(req.Id)); err != nil {
+		return nil, statusError(err)
+	}
+	return &incidentv1.DeleteReportResponse{}, nil
+}
+
+func (s *reportServer) AssignUser(ctx context.Context, req *incidentv1.AssignUserRequest) (*incidentv1.Report, error) {
+	assign := &utils.AssignUserRequest{UserID: uint(req.UserId)}
+	if err := utils.Validate(assign); err != nil {
+		return nil, statusError(err)
+	}
+
+	report, err := s.reports.AssignUserToReport(withVersion(ctx, req.ExpectedVersion), uint(req.Id), assign.UserID)
+	if err != nil {
+		return nil, statusError(err)
+	}
+	return toReport(report), nil
+}
+
+// UnassignUser takes a report back from its user, like a merge patch setting user_id to null
+func (s *reportServer) UnassignUser(ctx context.Context, req *incidentv1.UnassignUserRequest) (*incidentv1.Report, error) {
+	report, err := s.reports.PatchReport(withVersion(ctx, req.ExpectedVersion), uint(req.Id), []byte(`{"user_id":null}`))
+	if err != nil {
+		return nil, statusError(err)
+	}
+	return toReport(report), nil
+}
+
+func (s *reportServer) AddTags(ctx context.Context, req *incidentv1.AddTagsRequest) (*incidentv1.Report, error) {
+	tags := &utils.ReportTagsRequest{TagIDs: ids(req.TagIds)}
+	if err := utils.Validate(tags); err != nil {
+		return nil, statusError(err)
+	}
+
+	report, err := s.reports.AddTagsToReport(withVersion(ctx, req.ExpectedVersion), uint(req.Id), tags.TagIDs)
+	if err != nil {
+		return nil, statusError(err)
+	}
+	return toReport(report), nil
+}
+
+func (s *reportServer) RemoveTag(ctx context.Context, req *incidentv1.RemoveTagRequest) (*incidentv1.Report, error) {
+	report, err := s.reports.RemoveTagFromReport(withVersion(ctx, req.ExpectedVersion), uint(req.Id), uint(req.TagId))
+	if err != nil {
+		return nil, statusError(err)
+	}
+	return toReport(report), nil
+}
+
+func (s *reportServer) BulkReports(ctx context.Context, req *incidentv1.BulkReportsRequest) (*incidentv1.BulkResponse, error) {
+	bulk := &utils.BulkReportRequest{
+		IDs:    ids(req.Ids),
+		Mode:   bulkModeOf(req.Mode),
+		UserID: optionalID(req.UserId),
+		Status: reportStatusOf(req.Status),
+		TagIDs: ids(req.TagIds),
+	}
+	if req.Action != incidentv1.BulkReportAction_BULK_REPORT_ACTION_UNSPECIFIED {
+		bulk.Action = strings.ToLower(strings.TrimPrefix(req.Action.String(), "BULK_REPORT_ACTION_"))
+	}
+	if req.Filter != nil {
+		bulk.Filter = reportFilterOf(req.Filter)
+	}
+	if err := utils.Validate(bulk); err != nil {
+		return nil, statusError(err)
+	}
+
+	result, err := s.reports.BulkReports(ctx, bulk)
+	if err != nil {
+		return nil, statusError(err)
+	}
+	return toBulkResponse(result), nil
+}
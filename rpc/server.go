@@ -0,0 +1,181 @@
+// Package rpc serves the gRPC API
+//
+// The services defined in proto/incident/v1 run on the same service layer as the REST API, so
+// validation, versions, authorization and the audit log behave the same way. Calls identify
+// their user with the x-user-id metadata key, as REST requests do with the X-User-ID header.
+package rpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"incident-report/middleware"
+	"incident-report/repositories"
+	"incident-report/services"
+	"incident-report/utils"
+	"log/slog"
+	"net"
+	"runtime/debug"
+	"strconv"
+	"strings"
+	"time"
+
+	incidentv1 "incident-report/proto/incident/v1"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/reflection"
+	"google.golang.org/grpc/status"
+	"gorm.io/gorm"
+)
+
+// Metadata keys of a call; gRPC metadata keys are lower case forms of the REST headers
+var (
+	userIDKey    = strings.ToLower(middleware.UserIDHeader)
+	requestIDKey = strings.ToLower(middleware.RequestIDHeader)
+)
+
+// NewServer creates a gRPC server with every service registered against the given database
+// The server also offers the standard health service and server reflection.
+func NewServer(db *gorm.DB) *grpc.Server {
+	// Wired like routes.RegisterRoutes, so both APIs share the same behaviour
+	repos := repositories.New(db)
+
+	server := grpc.NewServer(grpc.ChainUnaryInterceptor(
+		requestInfoInterceptor,
+		accessLogInterceptor,
+		recoverInterceptor,
+		currentUserInterceptor(repos.Users),
+	))
+
+	incidentv1.RegisterLocationServiceServer(server, &locationServer{
+		buildings: services.NewBuildingService(repos),
+		floors:    services.NewFloorService(repos),
+		rooms:     services.NewRoomService(repos),
+		tree:      services.NewTreeService(db),
+	})
+	incidentv1.RegisterComponentServiceServer(server, &componentServer{
+		components: services.NewComponentService(repos),
+	})
+	incidentv1.RegisterReportServiceServer(server, &reportServer{
+		reports: services.NewReportService(repos),
+	})
+
+	healthServer := health.NewServer()
+	for name := range server.GetServiceInfo() {
+		healthServer.SetServingStatus(name, healthpb.HealthCheckResponse_SERVING)
+	}
+	healthpb.RegisterHealthServer(server, healthServer)
+	reflection.Register(server)
+
+	return server
+}
+
+// recoverInterceptor turns a panic in a handler into an internal error, as gin's recovery does
+func recoverInterceptor(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp any, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			slog.ErrorContext(ctx, "panic", "method", info.FullMethod, "panic", fmt.Sprint(r), "stack", string(debug.Stack()))
+			err = status.Error(codes.Internal, "internal error")
+		}
+	}()
+	return handler(ctx, req)
+}
+
+// requestInfoInterceptor gives every call a request ID and puts it, with the client IP and a logger
+// tagged with the ID, in the context
+// Like RequestIDMiddleware, it keeps an ID sent in x-request-id and returns the ID in the response header.
+func requestInfoInterceptor(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	id := middleware.RequestID(firstMetadata(ctx, requestIDKey))
+	if err := grpc.SetHeader(ctx, metadata.Pairs(requestIDKey, id)); err != nil {
+		return nil, err
+	}
+
+	var ip string
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		ip = p.Addr.String()
+		if host, _, err := net.SplitHostPort(ip); err == nil {
+			ip = host
+		}
+	}
+
+	ctx = utils.WithRequestInfo(ctx, utils.RequestInfo{ID: id, IP: ip})
+	ctx = utils.WithLogger(ctx, slog.Default().With(slog.String("request_id", id), slog.String("method", info.FullMethod)))
+	return handler(ctx, req)
+}
+
+// accessLogInterceptor logs one line per call, like RequestLoggingMiddleware does per request
+// The line carries the gRPC code in place of the HTTP status. Calls that succeed are logged at
+// info, calls the server failed at error and the rest at warn. It runs outside currentUserInterceptor,
+// so that rejected callers are logged too, and takes the user from the metadata that interceptor checked.
+func accessLogInterceptor(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	start := time.Now()
+	resp, err := handler(ctx, req)
+
+	code := status.Code(err)
+	request, _ := utils.RequestInfoFromContext(ctx)
+	attrs := []slog.Attr{
+		slog.String("code", code.String()),
+		slog.Float64("latency_ms", float64(time.Since(start).Microseconds())/1000),
+		slog.String("ip", request.IP),
+	}
+	if code != codes.Unauthenticated {
+		if id, err := strconv.ParseUint(firstMetadata(ctx, userIDKey), 10, 32); err == nil {
+			attrs = append(attrs, slog.Uint64("user_id", id))
+		}
+	}
+	if err != nil {
+		attrs = append(attrs, slog.String("error", status.Convert(err).Message()))
+	}
+
+	level := slog.LevelInfo
+	switch code {
+	case codes.OK:
+	case codes.Unknown, codes.Internal, codes.DataLoss, codes.Unavailable, codes.Unimplemented, codes.DeadlineExceeded:
+		level = slog.LevelError
+	default:
+		level = slog.LevelWarn
+	}
+	utils.Logger(ctx).LogAttrs(ctx, level, "request", attrs...)
+	return resp, err
+}
+
+// currentUserInterceptor loads the user named by the x-user-id metadata into the context
+// Like CurrentUserMiddleware, calls without it run anonymously and an unknown user is rejected.
+func currentUserInterceptor(users repositories.UserRepository) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		value := firstMetadata(ctx, userIDKey)
+		if value == "" {
+			return handler(ctx, req)
+		}
+
+		id, err := strconv.ParseUint(value, 10, 32)
+		if err != nil {
+			return nil, status.Errorf(codes.Unauthenticated, "invalid %s metadata: %v", userIDKey, err)
+		}
+
+		user, err := users.FindByID(ctx, uint(id))
+		if err != nil {
+			if errors.Is(err, repositories.ErrNotFound) {
+				return nil, status.Error(codes.Unauthenticated, "unknown user")
+			}
+			return nil, statusError(fmt.Errorf("failed to identify user: %w", err))
+		}
+
+		ctx = utils.WithActor(ctx, utils.Actor{UserID: user.ID, Role: user.Role})
+		ctx = utils.WithLogger(ctx, utils.Logger(ctx).With(slog.Uint64("user_id", uint64(user.ID))))
+		return handler(ctx, req)
+	}
+}
+
+// firstMetadata returns the first value of an incoming metadata key
+func firstMetadata(ctx context.Context, key string) string {
+	if values := metadata.ValueFromIncomingContext(ctx, key); len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
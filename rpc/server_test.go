@@ -0,0 +1,456 @@
+package rpc_test
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net"
+	"strconv"
+	"testing"
+
+	"incident-report/config"
+	"incident-report/models"
+	incidentv1 "incident-report/proto/incident/v1"
+	"incident-report/rpc"
+	"incident-report/testutil"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	reflectionpb "google.golang.org/grpc/reflection/grpc_reflection_v1"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/proto"
+	"gorm.io/gorm"
+)
+
+// clients are the gRPC clients of a test server
+type clients struct {
+	conn       *grpc.ClientConn
+	locations  incidentv1.LocationServiceClient
+	components incidentv1.ComponentServiceClient
+	reports    incidentv1.ReportServiceClient
+}
+
+// newTestServer serves the gRPC API over an in-memory connection against a freshly migrated database
+func newTestServer(t *testing.T) (clients, *gorm.DB) {
+	t.Helper()
+
+	db := testutil.OpenDB(t)
+	listener := bufconn.Listen(1 << 20)
+	server := rpc.NewServer(db)
+	go server.Serve(listener)
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return listener.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatalf("dial: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+
+	return clients{
+		conn:       conn,
+		locations:  incidentv1.NewLocationServiceClient(conn),
+		components: incidentv1.NewComponentServiceClient(conn),
+		reports:    incidentv1.NewReportServiceClient(conn),
+	}, db
+}
+
+// fixture is a location hierarchy with a component and a pending report
+type fixture struct {
+	UserID      uint32
+	BuildingID  uint32
+	FloorID     uint32
+	RoomID      uint32
+	CategoryID  uint32
+	ComponentID uint32
+	ReportID    uint32
+}
+
+// seed creates the fixture, the user and category directly and everything else through the API
+func seed(t *testing.T, c clients, db *gorm.DB) fixture {
+	t.Helper()
+
+	user := models.User{Name: "Tech One", Email: "tech@example.com", Role: models.RoleTechnician}
+	if err := db.Create(&user).Error; err != nil {
+		t.Fatalf("create user: %v", err)
+	}
+	category := models.ComponentCategory{Code: "PRJ", Name: "Projector"}
+	if err := db.Create(&category).Error; err != nil {
+		t.Fatalf("create category: %v", err)
+	}
+	f := fixture{UserID: uint32(user.ID), CategoryID: uint32(category.ID)}
+
+	ctx := asUser(f.UserID)
+	building, err := c.locations.CreateBuilding(ctx, &incidentv1.CreateBuildingRequest{Code: "B1", Name: "Main Building"})
+	if err != nil {
+		t.Fatalf("create building: %v", err)
+	}
+	f.BuildingID = building.Id
+	floor, err := c.locations.CreateFloor(ctx, &incidentv1.CreateFloorRequest{BuildingId: f.BuildingID, FloorNumber: 1, Name: "Ground"})
+	if err != nil {
+		t.Fatalf("create floor: %v", err)
+	}
+	f.FloorID = floor.Id
+	room, err := c.locations.CreateRoom(ctx, &incidentv1.CreateRoomRequest{FloorId: f.FloorID, Code: "R101", Name: "Lab 101"})
+	if err != nil {
+		t.Fatalf("create room: %v", err)
+	}
+	f.RoomID = room.Id
+	component, err := c.components.CreateComponent(ctx, &incidentv1.CreateComponentRequest{
+		RoomId: proto.Uint32(f.RoomID), CategoryId: f.CategoryID, Code: "PRJ-1", Name: "Projector 1", ProcurementYear: 2019,
+	})
+	if err != nil {
+		t.Fatalf("create component: %v", err)
+	}
+	f.ComponentID = component.Id
+	report, err := c.reports.CreateReport(ctx, &incidentv1.CreateReportRequest{
+		Name: "Broken projector", RoomId: f.RoomID, ComponentId: f.ComponentID, Status: incidentv1.ReportStatus_REPORT_STATUS_PENDING,
+	})
+	if err != nil {
+		t.Fatalf("create report: %v", err)
+	}
+	f.ReportID = report.Id
+	return f
+}
+
+// asUser returns a context whose calls identify the caller
+func asUser(userID uint32) context.Context {
+	return metadata.AppendToOutgoingContext(context.Background(), "x-user-id", strconv.FormatUint(uint64(userID), 10))
+}
+
+// expectStatus asserts the code of an error and the error code in its ErrorInfo detail
+func expectStatus(t *testing.T, err error, code codes.Code, reason string) *status.Status {
+	t.Helper()
+
+	st, ok := status.FromError(err)
+	if !ok || st.Code() != code {
+		t.Fatalf("error = %v, want %s", err, code)
+	}
+	for _, detail := range st.Details() {
+		if info, ok := detail.(*errdetails.ErrorInfo); ok {
+			if info.Reason != reason {
+				t.Errorf("reason = %q, want %q", info.Reason, reason)
+			}
+			return st
+		}
+	}
+	t.Errorf("no ErrorInfo detail in %v", st.Details())
+	return st
+}
+
+func TestLocationService(t *testing.T) {
+	c, db := newTestServer(t)
+	f := seed(t, c, db)
+	ctx := asUser(f.UserID)
+
+	tree, err := c.locations.GetTree(ctx, &incidentv1.GetTreeRequest{IncludeOpenReportCount: true})
+	if err != nil {
+		t.Fatalf("get tree: %v", err)
+	}
+	if len(tree.Buildings) != 1 || len(tree.Buildings[0].Floors) != 1 || len(tree.Buildings[0].Floors[0].Rooms) != 1 {
+		t.Fatalf("tree = %v", tree)
+	}
+	if count := tree.Buildings[0].OpenReportCount; count == nil || *count != 1 {
+		t.Errorf("open reports = %v, want 1", count)
+	}
+
+	annex, err := c.locations.CreateBuilding(ctx, &incidentv1.CreateBuildingRequest{Code: "B2", Name: "Annex"})
+	if err != nil {
+		t.Fatalf("create building: %v", err)
+	}
+	page, err := c.locations.ListBuildings(ctx, &incidentv1.ListBuildingsRequest{Page: &incidentv1.PageRequest{PageSize: 1}})
+	if err != nil {
+		t.Fatalf("list buildings: %v", err)
+	}
+	if len(page.Buildings) != 1 || page.PageInfo.GetTotal() != 2 || page.PageInfo.TotalPages != 2 || page.PageInfo.NextCursor == "" {
+		t.Errorf("page = %v", page)
+	}
+	next, err := c.locations.ListBuildings(ctx, &incidentv1.ListBuildingsRequest{Page: &incidentv1.PageRequest{After: page.PageInfo.NextCursor}})
+	if err != nil {
+		t.Fatalf("list buildings after cursor: %v", err)
+	}
+	if len(next.Buildings) != 1 || next.Buildings[0].Code != "B2" || next.PageInfo.Total != nil {
+		t.Errorf("next page = %v", next)
+	}
+
+	floors, err := c.locations.ListFloors(ctx, &incidentv1.ListFloorsRequest{BuildingId: f.BuildingID})
+	if err != nil || len(floors.Floors) != 1 {
+		t.Errorf("floors = %v, %v", floors, err)
+	}
+
+	updated, err := c.locations.UpdateRoom(ctx, &incidentv1.UpdateRoomRequest{Id: f.RoomID, Name: "Lab 101A", ExpectedVersion: proto.Uint32(1)})
+	if err != nil {
+		t.Fatalf("update room: %v", err)
+	}
+	if updated.Name != "Lab 101A" || updated.Code != "R101" || updated.Version != 2 {
+		t.Errorf("room = %v", updated)
+	}
+	_, err = c.locations.UpdateRoom(ctx, &incidentv1.UpdateRoomRequest{Id: f.RoomID, Name: "Old Lab", ExpectedVersion: proto.Uint32(1)})
+	expectStatus(t, err, codes.FailedPrecondition, "PRECONDITION_FAILED")
+
+	_, err = c.locations.DeleteBuilding(ctx, &incidentv1.DeleteBuildingRequest{Id: f.BuildingID, Cascade: true})
+	expectStatus(t, err, codes.Aborted, "CONFLICT")
+	if _, err := c.locations.DeleteBuilding(ctx, &incidentv1.DeleteBuildingRequest{Id: annex.Id, ExpectedVersion: proto.Uint32(1)}); err != nil {
+		t.Fatalf("delete building: %v", err)
+	}
+	_, err = c.locations.GetBuilding(ctx, &incidentv1.GetBuildingRequest{Id: annex.Id})
+	expectStatus(t, err, codes.NotFound, "NOT_FOUND")
+}
+
+func TestComponentService(t *testing.T) {
+	c, db := newTestServer(t)
+	f := seed(t, c, db)
+	ctx := asUser(f.UserID)
+
+	spare, err := c.components.CreateComponent(ctx, &incidentv1.CreateComponentRequest{CategoryId: f.CategoryID, Code: "PRJ-2", Name: "Spare projector"})
+	if err != nil {
+		t.Fatalf("create component: %v", err)
+	}
+	if spare.RoomId != nil {
+		t.Errorf("room = %d, want none", spare.GetRoomId())
+	}
+
+	moved, err := c.components.AssignRoom(ctx, &incidentv1.AssignRoomRequest{Id: spare.Id, RoomId: f.RoomID})
+	if err != nil {
+		t.Fatalf("assign room: %v", err)
+	}
+	if moved.GetRoomId() != f.RoomID {
+		t.Errorf("room = %d, want %d", moved.GetRoomId(), f.RoomID)
+	}
+
+	list, err := c.components.ListComponents(ctx, &incidentv1.ListComponentsRequest{RoomId: f.RoomID})
+	if err != nil || len(list.Components) != 2 {
+		t.Errorf("components = %v, %v", list, err)
+	}
+
+	result, err := c.components.BulkMoveComponents(ctx, &incidentv1.BulkMoveComponentsRequest{
+		Ids: []uint32{f.ComponentID, 999}, RoomId: f.RoomID, Mode: incidentv1.BulkMode_BULK_MODE_BEST_EFFORT,
+	})
+	if err != nil {
+		t.Fatalf("bulk move: %v", err)
+	}
+	if !result.Committed || result.Succeeded != 1 || result.Failed != 1 || result.Results[1].Code != "NOT_FOUND" {
+		t.Errorf("bulk result = %v", result)
+	}
+
+	_, err = c.components.CreateComponent(ctx, &incidentv1.CreateComponentRequest{CategoryId: f.CategoryID, Code: "PRJ-1", Name: "Duplicate"})
+	expectStatus(t, err, codes.Aborted, "CONFLICT")
+}
+
+func TestReportService(t *testing.T) {
+	c, db := newTestServer(t)
+	f := seed(t, c, db)
+	ctx := asUser(f.UserID)
+
+	assigned, err := c.reports.AssignUser(ctx, &incidentv1.AssignUserRequest{Id: f.ReportID, UserId: f.UserID, ExpectedVersion: proto.Uint32(1)})
+	if err != nil {
+		t.Fatalf("assign user: %v", err)
+	}
+	if assigned.GetUserId() != f.UserID || assigned.Version != 2 {
+		t.Errorf("report = %v", assigned)
+	}
+
+	updated, err := c.reports.UpdateReport(ctx, &incidentv1.UpdateReportRequest{Id: f.ReportID, Status: incidentv1.ReportStatus_REPORT_STATUS_COMPLETED})
+	if err != nil {
+		t.Fatalf("update report: %v", err)
+	}
+	if updated.Status != incidentv1.ReportStatus_REPORT_STATUS_COMPLETED || updated.CompletedAt == nil || updated.Name != "Broken projector" {
+		t.Errorf("report = %v", updated)
+	}
+
+	unassigned, err := c.reports.UnassignUser(ctx, &incidentv1.UnassignUserRequest{Id: f.ReportID})
+	if err != nil {
+		t.Fatalf("unassign user: %v", err)
+	}
+	if unassigned.UserId != nil {
+		t.Errorf("user = %d, want none", unassigned.GetUserId())
+	}
+
+	tag := models.Tag{Name: "urgent"}
+	if err := db.Create(&tag).Error; err != nil {
+		t.Fatalf("create tag: %v", err)
+	}
+	_, err = c.reports.AddTags(ctx, &incidentv1.AddTagsRequest{Id: f.ReportID, TagIds: []uint32{uint32(tag.ID)}, ExpectedVersion: proto.Uint32(unassigned.Version - 1)})
+	expectStatus(t, err, codes.FailedPrecondition, "PRECONDITION_FAILED")
+	tagged, err := c.reports.AddTags(ctx, &incidentv1.AddTagsRequest{Id: f.ReportID, TagIds: []uint32{uint32(tag.ID)}, ExpectedVersion: proto.Uint32(unassigned.Version)})
+	if err != nil {
+		t.Fatalf("add tags: %v", err)
+	}
+	_, err = c.reports.RemoveTag(ctx, &incidentv1.RemoveTagRequest{Id: f.ReportID, TagId: uint32(tag.ID), ExpectedVersion: proto.Uint32(unassigned.Version)})
+	expectStatus(t, err, codes.FailedPrecondition, "PRECONDITION_FAILED")
+	if _, err := c.reports.RemoveTag(ctx, &incidentv1.RemoveTagRequest{Id: f.ReportID, TagId: uint32(tag.ID), ExpectedVersion: proto.Uint32(tagged.Version)}); err != nil {
+		t.Fatalf("remove tag: %v", err)
+	}
+
+	list, err := c.reports.ListReports(ctx, &incidentv1.ListReportsRequest{
+		Filter: &incidentv1.ReportFilter{Status: incidentv1.ReportStatus_REPORT_STATUS_PENDING},
+	})
+	if err != nil || len(list.Reports) != 0 {
+		t.Errorf("pending reports = %v, %v", list, err)
+	}
+
+	_, err = c.reports.CreateReport(ctx, &incidentv1.CreateReportRequest{Name: "No status", RoomId: f.RoomID, ComponentId: f.ComponentID, RepairCost: -1})
+	st := expectStatus(t, err, codes.InvalidArgument, "VALIDATION_FAILED")
+	var fields []string
+	for _, detail := range st.Details() {
+		if badRequest, ok := detail.(*errdetails.BadRequest); ok {
+			for _, violation := range badRequest.FieldViolations {
+				fields = append(fields, violation.Field)
+			}
+		}
+	}
+	if len(fields) != 2 {
+		t.Errorf("rejected fields = %v, want status and repair_cost", fields)
+	}
+
+	_, err = c.reports.ListReports(ctx, &incidentv1.ListReportsRequest{Page: &incidentv1.PageRequest{Page: 2, After: "x"}})
+	expectStatus(t, err, codes.InvalidArgument, "VALIDATION_FAILED")
+}
+
+func TestServerInfrastructure(t *testing.T) {
+	c, db := newTestServer(t)
+	f := seed(t, c, db)
+
+	t.Run("unknown user", func(t *testing.T) {
+		_, err := c.reports.GetReport(asUser(999), &incidentv1.GetReportRequest{Id: f.ReportID})
+		if status.Code(err) != codes.Unauthenticated {
+			t.Errorf("error = %v, want Unauthenticated", err)
+		}
+	})
+
+	t.Run("anonymous", func(t *testing.T) {
+		if _, err := c.reports.GetReport(context.Background(), &incidentv1.GetReportRequest{Id: f.ReportID}); err != nil {
+			t.Errorf("get report: %v", err)
+		}
+	})
+
+	t.Run("audit", func(t *testing.T) {
+		var entry struct {
+			ActorID   *uint
+			RequestID string
+		}
+		err := db.Table("audit_logs").Select("actor_id, request_id").
+			Where("entity_type = ? AND entity_id = ?", "reports", f.ReportID).Take(&entry).Error
+		if err != nil {
+			t.Fatalf("find audit entry: %v", err)
+		}
+		if entry.ActorID == nil || *entry.ActorID != uint(f.UserID) || entry.RequestID == "" {
+			t.Errorf("audit entry = actor %v, request %q", entry.ActorID, entry.RequestID)
+		}
+	})
+
+	t.Run("request id", func(t *testing.T) {
+		var header metadata.MD
+		ctx := metadata.AppendToOutgoingContext(context.Background(), "x-request-id", "trace-123")
+		if _, err := c.reports.GetReport(ctx, &incidentv1.GetReportRequest{Id: f.ReportID}, grpc.Header(&header)); err != nil {
+			t.Fatalf("get report: %v", err)
+		}
+		if got := header.Get("x-request-id"); len(got) != 1 || got[0] != "trace-123" {
+			t.Errorf("x-request-id = %v, want trace-123", got)
+		}
+	})
+
+	t.Run("health", func(t *testing.T) {
+		health := healthpb.NewHealthClient(c.conn)
+		for _, service := range []string{"", "incident.v1.ReportService"} {
+			resp, err := health.Check(context.Background(), &healthpb.HealthCheckRequest{Service: service})
+			if err != nil || resp.Status != healthpb.HealthCheckResponse_SERVING {
+				t.Errorf("health of %q = %v, %v", service, resp, err)
+			}
+		}
+	})
+
+	t.Run("reflection", func(t *testing.T) {
+		stream, err := reflectionpb.NewServerReflectionClient(c.conn).ServerReflectionInfo(context.Background())
+		if err != nil {
+			t.Fatalf("open reflection stream: %v", err)
+		}
+		if err := stream.Send(&reflectionpb.ServerReflectionRequest{
+			MessageRequest: &reflectionpb.ServerReflectionRequest_ListServices{},
+		}); err != nil {
+			t.Fatalf("send: %v", err)
+		}
+		resp, err := stream.Recv()
+		if err != nil {
+			t.Fatalf("receive: %v", err)
+		}
+		listed := map[string]bool{}
+		for _, service := range resp.GetListServicesResponse().Service {
+			listed[service.Name] = true
+		}
+		for _, want := range []string{"incident.v1.LocationService", "incident.v1.ComponentService", "incident.v1.ReportService", "grpc.health.v1.Health"} {
+			if !listed[want] {
+				t.Errorf("%s not listed in %v", want, listed)
+			}
+		}
+	})
+}
+
+func TestAccessLogging(t *testing.T) {
+	var logs bytes.Buffer
+	previous := slog.Default()
+	slog.SetDefault(config.NewLogger(&logs))
+	t.Cleanup(func() { slog.SetDefault(previous) })
+
+	c, db := newTestServer(t)
+	f := seed(t, c, db)
+
+	// call runs a GetReport tagged with a request ID and returns its access log line
+	call := func(t *testing.T, ctx context.Context, requestID string, id uint32) map[string]any {
+		t.Helper()
+
+		ctx = metadata.AppendToOutgoingContext(ctx, "x-request-id", requestID)
+		c.reports.GetReport(ctx, &incidentv1.GetReportRequest{Id: id})
+
+		scanner := bufio.NewScanner(bytes.NewReader(logs.Bytes()))
+		for scanner.Scan() {
+			var line map[string]any
+			if err := json.Unmarshal(scanner.Bytes(), &line); err != nil {
+				t.Fatalf("log line is not JSON: %v: %s", err, scanner.Text())
+			}
+			if line["request_id"] == requestID && line["msg"] == "request" {
+				return line
+			}
+		}
+		t.Fatalf("no request line logged for call %s", requestID)
+		return nil
+	}
+
+	t.Run("request line", func(t *testing.T) {
+		line := call(t, asUser(f.UserID), "trace-get", f.ReportID)
+		want := map[string]any{
+			"level": "INFO", "method": "/incident.v1.ReportService/GetReport", "code": "OK", "user_id": float64(f.UserID),
+		}
+		for key, value := range want {
+			if line[key] != value {
+				t.Errorf("%s = %v, want %v", key, line[key], value)
+			}
+		}
+		if _, ok := line["latency_ms"].(float64); !ok {
+			t.Errorf("latency_ms = %v, want a number", line["latency_ms"])
+		}
+	})
+
+	t.Run("client errors are warnings", func(t *testing.T) {
+		line := call(t, context.Background(), "trace-missing", 999)
+		if line["level"] != "WARN" || line["code"] != "NotFound" || line["user_id"] != nil {
+			t.Errorf("line = %v, want an anonymous NotFound warning", line)
+		}
+	})
+
+	t.Run("rejected callers", func(t *testing.T) {
+		line := call(t, asUser(999), "trace-unknown", f.ReportID)
+		if line["level"] != "WARN" || line["code"] != "Unauthenticated" || line["user_id"] != nil {
+			t.Errorf("line = %v, want an Unauthenticated warning without a user", line)
+		}
+	})
+}
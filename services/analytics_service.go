@@ -0,0 +1,276 @@
+package services
+
+import (
+	"context"
+	"incident-report/models"
+	"incident-report/utils"
+	"sort"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// AnalyticsService computes incident metrics in SQL over the reports table
+type AnalyticsService struct {
+	db *gorm.DB
+}
+
+// NewAnalyticsService creates a new instance of AnalyticsService
+func NewAnalyticsService(db *gorm.DB) *AnalyticsService {
+	return &AnalyticsService{db: db}
+}
+
+// defaultTopN is the ranking size used when no limit is given
+const defaultTopN = 10
+
+// scopedReports starts a reports query joined with its location and narrowed by the analytics filters
+// The date range is applied to timeColumn, which is the timestamp the metric is about.
+// Reports in the trash are left out.
+func scopedReports(db *gorm.DB, query *utils.AnalyticsQuery, timeColumn string) (*gorm.DB, error) {
+	db = db.Table("reports").
+		Joins("JOIN rooms ON rooms.id = reports.room_id").
+		Joins("JOIN floors ON floors.id = rooms.floor_id").
+		Where("reports.deleted_at IS NULL")
+
+	if query.BuildingID != 0 {
+		db = db.Where("floors.building_id = ?", query.BuildingID)
+	}
+
+	if query.From != "" {
+		from, err := time.ParseInLocation("2006-01-02", query.From, time.Local)
+		if err != nil {
+			return nil, err
+		}
+		db = db.Where(timeColumn+" >= ?", from)
+	}
+	if query.To != "" {
+		to, err := time.ParseInLocation("2006-01-02", query.To, time.Local)
+		if err != nil {
+			return nil, err
+		}
+		// To is inclusive, so compare against the start of the following day
+		db = db.Where(timeColumn+" < ?", to.AddDate(0, 0, 1))
+	}
+
+	return db, nil
+}
+
+// periodCount is a per-period aggregate row
+type periodCount struct {
+	Period string
+	Count  int64
+}
+
+// GetVolume returns the number of reports opened and closed per day or week
+func (as *AnalyticsService) GetVolume(ctx context.Context, query *utils.VolumeQuery) ([]utils.VolumeBucket, error) {
+	interval := query.Interval
+	if interval == "" {
+		interval = IntervalDay
+	}
+
+	opened, err := as.countPerPeriod(ctx, &query.AnalyticsQuery, interval, "reports.created_at")
+	if err != nil {
+		return nil, err
+	}
+	closed, err := as.countPerPeriod(ctx, &query.AnalyticsQuery, interval, "reports.completed_at")
+	if err != nil {
+		return nil, err
+	}
+
+	buckets := make(map[string]*utils.VolumeBucket)
+	for _, row := range opened {
+		buckets[row.Period] = &utils.VolumeBucket{Period: row.Period, Opened: row.Count}
+	}
+	for _, row := range closed {
+		if bucket, ok := buckets[row.Period]; ok {
+			bucket.Closed = row.Count
+		} else {
+			buckets[row.Period] = &utils.VolumeBucket{Period: row.Period, Closed: row.Count}
+		}
+	}
+
+	volume := make([]utils.VolumeBucket, 0, len(buckets))
+	for _, bucket := range buckets {
+		volume = append(volume, *bucket)
+	}
+	sort.Slice(volume, func(i, j int) bool {
+		return volume[i].Period < volume[j].Period
+	})
+
+	return volume, nil
+}
+
+// countPerPeriod groups reports by the period of a timestamp column
+func (as *AnalyticsService) countPerPeriod(ctx context.Context, query *utils.AnalyticsQuery, interval string, column string) ([]periodCount, error) {
+	db, err := scopedReports(as.db.WithContext(ctx), query, column)
+	if err != nil {
+		return nil, err
+	}
+
+	var rows []periodCount
+	err = db.Select(dateBucketExpr(as.db, interval, column) + " AS period, COUNT(*) AS count").
+		Where(column + " IS NOT NULL").
+		Group("period").
+		Order("period").
+		Scan(&rows).Error
+	return rows, err
+}
+
+// GetResolutionTime returns the mean and median time to resolve of reports completed in the range
+func (as *AnalyticsService) GetResolutionTime(ctx context.Context, query *utils.AnalyticsQuery) (*utils.ResolutionTimeResponse, error) {
+	durations, err := scopedReports(as.db.WithContext(ctx), query, "reports.completed_at")
+	if err != nil {
+		return nil, err
+	}
+	durations = durations.
+		Select(secondsBetweenExpr(as.db, "reports.created_at", "reports.completed_at") + " AS duration").
+		Where("reports.completed_at IS NOT NULL")
+
+	var response utils.ResolutionTimeResponse
+	err = as.db.WithContext(ctx).Table("(?) AS durations", durations).
+		Select("COUNT(*) AS resolved, COALESCE(AVG(duration), 0) AS mean_seconds").
+		Scan(&response).Error
+	if err != nil {
+		return nil, err
+	}
+
+	// The median is the middle row (or the mean of the two middle rows) of the ordered durations
+	ranked := as.db.WithContext(ctx).Table("(?) AS durations", durations).
+		Select("duration, ROW_NUMBER() OVER (ORDER BY duration) AS rn, COUNT(*) OVER () AS cnt")
+	err = as.db.WithContext(ctx).Table("(?) AS ranked", ranked).
+		Select("COALESCE(AVG(duration), 0)").
+		Where("rn * 2 IN (cnt, cnt + 1, cnt + 2)").
+		Scan(&response.MedianSeconds).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return &response, nil
+}
+
+// GetTopComponents returns the components with the most reports
+func (as *AnalyticsService) GetTopComponents(ctx context.Context, query *utils.TopNQuery) ([]utils.ComponentFailureCount, error) {
+	db, err := scopedReports(as.db.WithContext(ctx), &query.AnalyticsQuery, "reports.created_at")
+	if err != nil {
+		return nil, err
+	}
+
+	rows := []utils.ComponentFailureCount{}
+	err = db.Joins("JOIN components ON components.id = reports.component_id").
+		Select("components.id AS component_id, components.code AS component_code, components.name AS component_name, COUNT(*) AS reports").
+		Group("components.id, components.code, components.name").
+		Order("COUNT(*) DESC").
+		Limit(topNLimit(query.Limit)).
+		Scan(&rows).Error
+	return rows, err
+}
+
+// GetTopCategories returns the component categories with the most reports
+func (as *AnalyticsService) GetTopCategories(ctx context.Context, query *utils.TopNQuery) ([]utils.CategoryFailureCount, error) {
+	db, err := scopedReports(as.db.WithContext(ctx), &query.AnalyticsQuery, "reports.created_at")
+	if err != nil {
+		return nil, err
+	}
+
+	rows := []utils.CategoryFailureCount{}
+	err = db.Joins("JOIN components ON components.id = reports.component_id").
+		Joins("JOIN component_categories ON component_categories.id = components.category_id").
+		Select("component_categories.id AS category_id, component_categories.code AS category_code, " +
+			"component_categories.name AS category_name, COUNT(*) AS reports").
+		Group("component_categories.id, component_categories.code, component_categories.name").
+		Order("COUNT(*) DESC").
+		Limit(topNLimit(query.Limit)).
+		Scan(&rows).Error
+	return rows, err
+}
+
+// GetBuildingHotspots ranks buildings by number of reports
+func (as *AnalyticsService) GetBuildingHotspots(ctx context.Context, query *utils.TopNQuery) ([]utils.BuildingHotspot, error) {
+	db, err := scopedReports(as.db.WithContext(ctx), &query.AnalyticsQuery, "reports.created_at")
+	if err != nil {
+		return nil, err
+	}
+
+	rows := []utils.BuildingHotspot{}
+	err = db.Joins("JOIN buildings ON buildings.id = floors.building_id").
+		Select("buildings.id AS building_id, buildings.code AS building_code, buildings.name AS building_name, "+
+			"COUNT(*) AS reports, SUM(CASE WHEN reports.status IN ? THEN 1 ELSE 0 END) AS open_reports",
+			models.OpenReportStatuses).
+		Group("buildings.id, buildings.code, buildings.name").
+		Order("COUNT(*) DESC").
+		Limit(topNLimit(query.Limit)).
+		Scan(&rows).Error
+	return rows, err
+}
+
+// GetFloorHotspots ranks floors by number of reports
+func (as *AnalyticsService) GetFloorHotspots(ctx context.Context, query *utils.TopNQuery) ([]utils.FloorHotspot, error) {
+	db, err := scopedReports(as.db.WithContext(ctx), &query.AnalyticsQuery, "reports.created_at")
+	if err != nil {
+		return nil, err
+	}
+
+	rows := []utils.FloorHotspot{}
+	err = db.Select("floors.id AS floor_id, floors.number AS floor_number, floors.name AS floor_name, "+
+		"floors.building_id AS building_id, COUNT(*) AS reports, "+
+		"SUM(CASE WHEN reports.status IN ? THEN 1 ELSE 0 END) AS open_reports",
+		models.OpenReportStatuses).
+		Group("floors.id, floors.number, floors.name, floors.building_id").
+		Order("COUNT(*) DESC").
+		Limit(topNLimit(query.Limit)).
+		Scan(&rows).Error
+	return rows, err
+}
+
+// GetTechnicianThroughput returns assigned and completed reports per assigned user
+func (as *AnalyticsService) GetTechnicianThroughput(ctx context.Context, query *utils.AnalyticsQuery) ([]utils.TechnicianThroughput, error) {
+	db, err := scopedReports(as.db.WithContext(ctx), query, "reports.created_at")
+	if err != nil {
+		return nil, err
+	}
+
+	rows := []utils.TechnicianThroughput{}
+	err = db.Joins("JOIN users ON users.id = reports.user_id").
+		Select("users.id AS user_id, users.name AS user_name, COUNT(*) AS assigned, "+
+			"SUM(CASE WHEN reports.status = ? THEN 1 ELSE 0 END) AS completed, "+
+			"COALESCE(AVG(CASE WHEN reports.completed_at IS NOT NULL THEN "+
+			secondsBetweenExpr(as.db, "reports.created_at", "reports.completed_at")+" END), 0) AS mean_resolve_seconds",
+			models.ReportStatusCompleted).
+		Group("users.id, users.name").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	sort.SliceStable(rows, func(i, j int) bool {
+		return rows[i].Completed > rows[j].Completed
+	})
+	return rows, nil
+}
+
+// GetTagCounts returns the number of total and open reports per tag
+func (as *AnalyticsService) GetTagCounts(ctx context.Context, query *utils.AnalyticsQuery) ([]utils.TagCount, error) {
+	db, err := scopedReports(as.db.WithContext(ctx), query, "reports.created_at")
+	if err != nil {
+		return nil, err
+	}
+
+	rows := []utils.TagCount{}
+	err = db.Joins("JOIN report_tags ON report_tags.report_id = reports.id").
+		Joins("JOIN tags ON tags.id = report_tags.tag_id").
+		Select("tags.id AS tag_id, tags.name AS tag_name, tags.color AS tag_color, COUNT(*) AS reports, "+
+			"SUM(CASE WHEN reports.status IN ? THEN 1 ELSE 0 END) AS open_reports",
+			models.OpenReportStatuses).
+		Group("tags.id, tags.name, tags.color").
+		Order("COUNT(*) DESC").
+		Scan(&rows).Error
+	return rows, err
+}
+
+// topNLimit applies the default ranking size
+func topNLimit(limit int) int {
+	if limit <= 0 {
+		return defaultTopN
+	}
+	return limit
+}
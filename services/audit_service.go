@@ -0,0 +1,68 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"incident-report/repositories"
+	"incident-report/utils"
+	"time"
+)
+
+// AuditService reads the audit log
+type AuditService struct {
+	repos *repositories.Repositories
+}
+
+// NewAuditService creates a new instance of AuditService
+func NewAuditService(repos *repositories.Repositories) *AuditService {
+	return &AuditService{repos: repos}
+}
+
+// ListAuditLogs retrieves the audit log entries matching the query, most recent first
+func (as *AuditService) ListAuditLogs(ctx context.Context, query *utils.AuditQuery) ([]utils.AuditLogResponse, utils.PageInfo, error) {
+	filter := repositories.AuditFilter{
+		EntityType: query.Entity,
+		EntityID:   query.EntityID,
+		ActorID:    query.Actor,
+	}
+	if query.From != "" {
+		from, err := time.ParseInLocation("2006-01-02", query.From, time.Local)
+		if err != nil {
+			return nil, utils.PageInfo{}, utils.InvalidField("from", "from must be a date (YYYY-MM-DD)")
+		}
+		filter.From = from
+	}
+	if query.To != "" {
+		to, err := time.ParseInLocation("2006-01-02", query.To, time.Local)
+		if err != nil {
+			return nil, utils.PageInfo{}, utils.InvalidField("to", "to must be a date (YYYY-MM-DD)")
+		}
+		// To is inclusive, so compare against the start of the following day
+		filter.To = to.AddDate(0, 0, 1)
+	}
+
+	page, err := pageOf(&query.PaginationQuery)
+	if err != nil {
+		return nil, utils.PageInfo{}, err
+	}
+	entries, info, err := as.repos.Audit.List(ctx, filter, page)
+	if err != nil {
+		return nil, utils.PageInfo{}, err
+	}
+
+	responses := make([]utils.AuditLogResponse, len(entries))
+	for i, entry := range entries {
+		responses[i] = utils.AuditLogResponse{
+			ID:         entry.ID,
+			ActorID:    entry.ActorID,
+			EntityType: entry.EntityType,
+			EntityID:   entry.EntityID,
+			Action:     entry.Action,
+			Changes:    json.RawMessage(entry.Changes),
+			IP:         entry.IP,
+			RequestID:  entry.RequestID,
+			CreatedAt:  entry.CreatedAt,
+		}
+	}
+	return responses, info, nil
+}
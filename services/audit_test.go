@@ -0,0 +1,210 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"incident-report/models"
+	"incident-report/repositories"
+	"incident-report/utils"
+)
+
+// auditEntries lists the audit log of one record, oldest first
+func auditEntries(t *testing.T, repos *repositories.Repositories, entity string, id uint) []utils.AuditLogResponse {
+	t.Helper()
+
+	entries, _, err := NewAuditService(repos).ListAuditLogs(context.Background(),
+		&utils.AuditQuery{Entity: entity, EntityID: id, PaginationQuery: utils.PaginationQuery{PageSize: 100}})
+	if err != nil {
+		t.Fatalf("list audit log: %v", err)
+	}
+	for i, j := 0, len(entries)-1; i < j; i, j = i+1, j-1 {
+		entries[i], entries[j] = entries[j], entries[i]
+	}
+	return entries
+}
+
+// changesOf decodes the change set of an entry
+func changesOf(t *testing.T, entry utils.AuditLogResponse) map[string]struct{ Before, After any } {
+	t.Helper()
+
+	var changes map[string]struct{ Before, After any }
+	if err := json.Unmarshal(entry.Changes, &changes); err != nil {
+		t.Fatalf("decode changes %s: %v", entry.Changes, err)
+	}
+	return changes
+}
+
+func TestAuditLogRecordsChanges(t *testing.T) {
+	db := setupTestDB(t)
+	repos := repositories.New(db)
+	ctx := utils.WithActor(context.Background(), utils.Actor{UserID: 7, Role: models.RoleTechnician})
+	ctx = utils.WithRequestInfo(ctx, utils.RequestInfo{ID: "req-1", IP: "10.0.0.1"})
+	buildings := NewBuildingService(repos)
+
+	building, err := buildings.CreateBuilding(ctx, &utils.CreateBuildingRequest{Code: "B1", Name: "Main Building"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := buildings.UpdateBuilding(ctx, building.ID, &utils.UpdateBuildingRequest{Name: "Main Hall"}); err != nil {
+		t.Fatal(err)
+	}
+	// Saving unchanged values is not a change
+	if _, err := buildings.UpdateBuilding(ctx, building.ID, &utils.UpdateBuildingRequest{Name: "Main Hall"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := buildings.DeleteBuilding(context.Background(), building.ID, false); err != nil {
+		t.Fatal(err)
+	}
+
+	entries := auditEntries(t, repos, "buildings", building.ID)
+	if len(entries) != 3 {
+		t.Fatalf("entries = %+v, want create, update and delete", entries)
+	}
+	for i, action := range []string{models.AuditActionCreate, models.AuditActionUpdate, models.AuditActionDelete} {
+		if entries[i].Action != action {
+			t.Errorf("entry %d action = %s, want %s", i, entries[i].Action, action)
+		}
+	}
+
+	created := entries[0]
+	if created.ActorID == nil || *created.ActorID != 7 || created.IP != "10.0.0.1" || created.RequestID != "req-1" {
+		t.Errorf("create entry = %+v, want actor 7 from 10.0.0.1 in req-1", created)
+	}
+	if change := changesOf(t, created)["code"]; change.Before != nil || change.After != "B1" {
+		t.Errorf("create code change = %+v, want nil to B1", change)
+	}
+
+	updated := changesOf(t, entries[1])
+	if len(updated) != 1 || updated["name"].Before != "Main Building" || updated["name"].After != "Main Hall" {
+		t.Errorf("update changes = %+v, want only the name", updated)
+	}
+
+	if entries[2].ActorID != nil || entries[2].RequestID != "" {
+		t.Errorf("delete outside a request = %+v, want no actor and no request", entries[2])
+	}
+	if change := changesOf(t, entries[2])["name"]; change.Before != "Main Hall" || change.After != nil {
+		t.Errorf("delete name change = %+v, want Main Hall to nil", change)
+	}
+}
+
+func TestAuditLogCoversTrash(t *testing.T) {
+	ctx := context.Background()
+	db := setupTestDB(t)
+	repos := repositories.New(db)
+	h := seedHierarchy(t, repos)
+	completeReport(t, repos, h.report)
+
+	if err := NewBuildingService(repos).DeleteBuilding(ctx, h.building, true); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := NewTrashService(repos).Restore(ctx, "buildings", h.building); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, record := range []struct {
+		entity string
+		id     uint
+	}{
+		{"buildings", h.building},
+		{"floors", h.floor},
+		{"rooms", h.room},
+		{"components", h.component},
+		{"reports", h.report},
+	} {
+		entries := auditEntries(t, repos, record.entity, record.id)
+		n := len(entries)
+		if n < 3 || entries[n-2].Action != models.AuditActionDelete || entries[n-1].Action != models.AuditActionRestore {
+			t.Errorf("%s %d: entries = %+v, want it created, deleted and restored", record.entity, record.id, entries)
+		}
+	}
+}
+
+func TestAuditLogRecordsReportTags(t *testing.T) {
+	db := setupTestDB(t)
+	repos := repositories.New(db)
+	h := seedHierarchy(t, repos)
+	ctx := utils.WithActor(context.Background(), utils.Actor{UserID: 7, Role: models.RoleTechnician})
+	reports := NewReportService(repos)
+
+	urgent, err := NewTagService(repos).CreateTag(ctx, &utils.CreateTagRequest{Name: "urgent"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	before := len(auditEntries(t, repos, "reports", h.report))
+	if _, err := reports.AddTagsToReport(ctx, h.report, []uint{urgent.ID}); err != nil {
+		t.Fatal(err)
+	}
+	// Attaching a tag that is already attached is not a change
+	if _, err := reports.AddTagsToReport(ctx, h.report, []uint{urgent.ID}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := reports.RemoveTagFromReport(ctx, h.report, h.tag); err != nil {
+		t.Fatal(err)
+	}
+
+	entries := auditEntries(t, repos, "reports", h.report)[before:]
+	if len(entries) != 2 {
+		t.Fatalf("entries = %+v, want one per tag change", entries)
+	}
+	for i, want := range []struct{ before, after string }{
+		{"[electrical]", "[electrical urgent]"},
+		{"[electrical urgent]", "[urgent]"},
+	} {
+		entry := entries[i]
+		changes := changesOf(t, entry)
+		if entry.Action != models.AuditActionUpdate || entry.ActorID == nil || *entry.ActorID != 7 || len(changes) != 1 {
+			t.Errorf("entry %d = %+v, want an update of the tags by actor 7", i, entry)
+		}
+		if got := changes["tags"]; fmt.Sprint(got.Before) != want.before || fmt.Sprint(got.After) != want.after {
+			t.Errorf("entry %d tags = %v to %v, want %s to %s", i, got.Before, got.After, want.before, want.after)
+		}
+	}
+}
+
+func TestAuditLogFilters(t *testing.T) {
+	db := setupTestDB(t)
+	repos := repositories.New(db)
+	audit := NewAuditService(repos)
+	tags := NewTagService(repos)
+
+	for actor, name := range map[uint]string{1: "electrical", 2: "plumbing"} {
+		ctx := utils.WithActor(context.Background(), utils.Actor{UserID: actor, Role: models.RoleTechnician})
+		if _, err := tags.CreateTag(ctx, &utils.CreateTagRequest{Name: name}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// A rejected change leaves no trace
+	if _, err := tags.CreateTag(context.Background(), &utils.CreateTagRequest{Name: "electrical"}); err == nil {
+		t.Fatal("created a duplicate tag")
+	}
+
+	today := time.Now().Format("2006-01-02")
+	tomorrow := time.Now().AddDate(0, 0, 1).Format("2006-01-02")
+	tests := []struct {
+		name  string
+		query utils.AuditQuery
+		want  int64
+	}{
+		{"all", utils.AuditQuery{}, 2},
+		{"by entity", utils.AuditQuery{Entity: "tags"}, 2},
+		{"by other entity", utils.AuditQuery{Entity: "rooms"}, 0},
+		{"by actor", utils.AuditQuery{Actor: 2}, 1},
+		{"up to today", utils.AuditQuery{To: today}, 2},
+		{"from tomorrow", utils.AuditQuery{From: tomorrow}, 0},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			_, info, err := audit.ListAuditLogs(context.Background(), &tc.query)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if *info.Total != tc.want {
+				t.Errorf("total = %d, want %d", *info.Total, tc.want)
+			}
+		})
+	}
+}
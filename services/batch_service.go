@@ -0,0 +1,193 @@
+package services
+
+import (
+	"context"
+	"incident-report/models"
+	"incident-report/utils"
+
+	"gorm.io/gorm"
+)
+
+// BatchService loads the records related to many parents at once
+// It backs the GraphQL dataloaders: every method answers for a whole batch of IDs with one
+// query (two for the report tags), however many IDs there are.
+type BatchService struct {
+	db *gorm.DB
+}
+
+// NewBatchService creates a new instance of BatchService
+func NewBatchService(db *gorm.DB) *BatchService {
+	return &BatchService{db: db}
+}
+
+// Buildings loads buildings by ID
+func (bs *BatchService) Buildings(ctx context.Context, ids []uint) (map[uint]*utils.BuildingResponse, error) {
+	return findByIDs(ctx, bs.db, ids, func(b *models.Building) uint { return b.ID }, toBuildingResponse)
+}
+
+// Floors loads floors by ID
+func (bs *BatchService) Floors(ctx context.Context, ids []uint) (map[uint]*utils.FloorResponse, error) {
+	return findByIDs(ctx, bs.db, ids, func(f *models.Floor) uint { return f.ID }, toFloorResponse)
+}
+
+// Rooms loads rooms by ID
+func (bs *BatchService) Rooms(ctx context.Context, ids []uint) (map[uint]*utils.RoomResponse, error) {
+	return findByIDs(ctx, bs.db, ids, func(r *models.Room) uint { return r.ID }, toRoomResponse)
+}
+
+// ComponentCategories loads component categories by ID
+func (bs *BatchService) ComponentCategories(ctx context.Context, ids []uint) (map[uint]*utils.ComponentCategoryResponse, error) {
+	return findByIDs(ctx, bs.db, ids, func(c *models.ComponentCategory) uint { return c.ID }, toComponentCategoryResponse)
+}
+
+// Components loads components by ID
+func (bs *BatchService) Components(ctx context.Context, ids []uint) (map[uint]*utils.ComponentResponse, error) {
+	return findByIDs(ctx, bs.db, ids, func(c *models.Component) uint { return c.ID }, toComponentResponse)
+}
+
+// Users loads users by ID
+func (bs *BatchService) Users(ctx context.Context, ids []uint) (map[uint]*utils.UserResponse, error) {
+	return findByIDs(ctx, bs.db, ids, func(u *models.User) uint { return u.ID }, toUserResponse)
+}
+
+// FloorsByBuilding loads the floors of buildings, keyed by building ID
+func (bs *BatchService) FloorsByBuilding(ctx context.Context, buildingIDs []uint) (map[uint][]utils.FloorResponse, error) {
+	return findGrouped(ctx, bs.db, "building_id", buildingIDs, func(f *models.Floor) uint { return f.BuildingID }, toFloorResponse)
+}
+
+// RoomsByFloor loads the rooms of floors, keyed by floor ID
+func (bs *BatchService) RoomsByFloor(ctx context.Context, floorIDs []uint) (map[uint][]utils.RoomResponse, error) {
+	return findGrouped(ctx, bs.db, "floor_id", floorIDs, func(r *models.Room) uint { return r.FloorID }, toRoomResponse)
+}
+
+// ComponentsByRoom loads the components in rooms, keyed by room ID
+func (bs *BatchService) ComponentsByRoom(ctx context.Context, roomIDs []uint) (map[uint][]utils.ComponentResponse, error) {
+	return findGrouped(ctx, bs.db, "room_id", roomIDs, func(c *models.Component) uint { return *c.RoomID }, toComponentResponse)
+}
+
+// ComponentsByCategory loads the components of categories, keyed by category ID
+func (bs *BatchService) ComponentsByCategory(ctx context.Context, categoryIDs []uint) (map[uint][]utils.ComponentResponse, error) {
+	return findGrouped(ctx, bs.db, "category_id", categoryIDs, func(c *models.Component) uint { return c.CategoryID }, toComponentResponse)
+}
+
+// ReportsByRoom loads the reports filed for rooms, keyed by room ID
+func (bs *BatchService) ReportsByRoom(ctx context.Context, roomIDs []uint) (map[uint][]utils.ReportResponse, error) {
+	return findGrouped(ctx, bs.db, "room_id", roomIDs, func(r *models.Report) uint { return r.RoomID }, toReportResponse)
+}
+
+// ReportsByComponent loads the reports filed for components, keyed by component ID
+func (bs *BatchService) ReportsByComponent(ctx context.Context, componentIDs []uint) (map[uint][]utils.ReportResponse, error) {
+	return findGrouped(ctx, bs.db, "component_id", componentIDs, func(r *models.Report) uint { return r.ComponentID }, toReportResponse)
+}
+
+// ReportsByUser loads the reports assigned to users, keyed by user ID
+func (bs *BatchService) ReportsByUser(ctx context.Context, userIDs []uint) (map[uint][]utils.ReportResponse, error) {
+	return findGrouped(ctx, bs.db, "user_id", userIDs, func(r *models.Report) uint { return *r.UserID }, toReportResponse)
+}
+
+// reportTag is a row of the report_tags join table
+type reportTag struct {
+	ReportID uint
+	TagID    uint
+}
+
+// reportTags loads the links of the report_tags join table whose column holds one of ids
+// It maps every record linked on the other side to the IDs among ids it is linked to.
+func (bs *BatchService) reportTags(ctx context.Context, column string, ids []uint) (map[uint][]uint, error) {
+	var links []reportTag
+	if err := bs.db.WithContext(ctx).Table("report_tags").Where(column+" IN ?", uniqueIDs(ids)).Find(&links).Error; err != nil {
+		return nil, err
+	}
+
+	linked := make(map[uint][]uint)
+	for _, link := range links {
+		if column == "tag_id" {
+			linked[link.ReportID] = append(linked[link.ReportID], link.TagID)
+		} else {
+			linked[link.TagID] = append(linked[link.TagID], link.ReportID)
+		}
+	}
+	return linked, nil
+}
+
+// ReportsByTag loads the reports a tag is attached to, keyed by tag ID
+// Deleted reports keep their tags until they are purged, but are left out.
+func (bs *BatchService) ReportsByTag(ctx context.Context, tagIDs []uint) (map[uint][]utils.ReportResponse, error) {
+	tagsOf, err := bs.reportTags(ctx, "tag_id", tagIDs)
+	if err != nil || len(tagsOf) == 0 {
+		return nil, err
+	}
+
+	reportIDs := make([]uint, 0, len(tagsOf))
+	for id := range tagsOf {
+		reportIDs = append(reportIDs, id)
+	}
+	var reports []models.Report
+	if err := bs.db.WithContext(ctx).Where("id IN ?", reportIDs).Order("id").Find(&reports).Error; err != nil {
+		return nil, err
+	}
+
+	grouped := make(map[uint][]utils.ReportResponse)
+	for i := range reports {
+		for _, tagID := range tagsOf[reports[i].ID] {
+			grouped[tagID] = append(grouped[tagID], *toReportResponse(&reports[i]))
+		}
+	}
+	return grouped, nil
+}
+
+// TagsByReport loads the tags attached to reports, keyed by report ID and sorted by name
+func (bs *BatchService) TagsByReport(ctx context.Context, reportIDs []uint) (map[uint][]utils.TagResponse, error) {
+	reportsOf, err := bs.reportTags(ctx, "report_id", reportIDs)
+	if err != nil || len(reportsOf) == 0 {
+		return nil, err
+	}
+
+	tagIDs := make([]uint, 0, len(reportsOf))
+	for id := range reportsOf {
+		tagIDs = append(tagIDs, id)
+	}
+	var tags []models.Tag
+	if err := bs.db.WithContext(ctx).Where("id IN ?", tagIDs).Order("name").Find(&tags).Error; err != nil {
+		return nil, err
+	}
+
+	grouped := make(map[uint][]utils.TagResponse)
+	for i := range tags {
+		for _, reportID := range reportsOf[tags[i].ID] {
+			grouped[reportID] = append(grouped[reportID], *toTagResponse(&tags[i]))
+		}
+	}
+	return grouped, nil
+}
+
+// findByIDs loads the records of T with the given IDs and converts them, keyed by ID
+// Missing and deleted records are left out of the map.
+func findByIDs[T, R any](ctx context.Context, db *gorm.DB, ids []uint, id func(*T) uint, convert func(*T) *R) (map[uint]*R, error) {
+	var records []T
+	if err := db.WithContext(ctx).Where("id IN ?", uniqueIDs(ids)).Find(&records).Error; err != nil {
+		return nil, err
+	}
+
+	found := make(map[uint]*R, len(records))
+	for i := range records {
+		found[id(&records[i])] = convert(&records[i])
+	}
+	return found, nil
+}
+
+// findGrouped loads the records of T whose column holds one of ids and converts them,
+// grouped by that column and in ID order within a group
+func findGrouped[T, R any](ctx context.Context, db *gorm.DB, column string, ids []uint, key func(*T) uint, convert func(*T) *R) (map[uint][]R, error) {
+	var records []T
+	if err := db.WithContext(ctx).Where(column+" IN ?", uniqueIDs(ids)).Order("id").Find(&records).Error; err != nil {
+		return nil, err
+	}
+
+	grouped := make(map[uint][]R)
+	for i := range records {
+		k := key(&records[i])
+		grouped[k] = append(grouped[k], *convert(&records[i]))
+	}
+	return grouped, nil
+}
@@ -1,26 +1,31 @@
 package services
 
 import (
+	"context"
 	"errors"
-	"incident-report/config"
 	"incident-report/models"
+	"incident-report/repositories"
 	"incident-report/utils"
-
-	"gorm.io/gorm"
 )
 
 // BuildingService handles all building-related business logic
-type BuildingService struct{}
+type BuildingService struct {
+	repos *repositories.Repositories
+}
 
 // NewBuildingService creates a new instance of BuildingService
-func NewBuildingService() *BuildingService {
-	return &BuildingService{}
+func NewBuildingService(repos *repositories.Repositories) *BuildingService {
+	return &BuildingService{repos: repos}
 }
 
 // CreateBuilding creates a new building in the database
-func (bs *BuildingService) CreateBuilding(req *utils.CreateBuildingRequest) (*utils.BuildingResponse, error) {
+func (bs *BuildingService) CreateBuilding(ctx context.Context, req *utils.CreateBuildingRequest) (*utils.BuildingResponse, error) {
 	if req.Code == "" || req.Name == "" {
-		return nil, errors.New("code and name are required")
+		return nil, utils.Validation("code and name are required")
+	}
+
+	if err := ensureUnique(ctx, bs.repos.Buildings.CodeTaken, "code", req.Code, 0); err != nil {
+		return nil, err
 	}
 
 	building := models.Building{
@@ -29,10 +34,10 @@ func (bs *BuildingService) CreateBuilding(req *utils.CreateBuildingRequest) (*ut
 		Location: req.Location,
 	}
 
-	result := config.DB.Create(&building)
-	if result.Error != nil {
-		return nil, result.Error
+	if err := bs.repos.Buildings.Create(ctx, &building); err != nil {
+		return nil, err
 	}
+	invalidateHierarchyTree()
 
 	return &utils.BuildingResponse{
 		ID:        building.ID,
@@ -41,19 +46,18 @@ func (bs *BuildingService) CreateBuilding(req *utils.CreateBuildingRequest) (*ut
 		Location:  building.Location,
 		CreatedAt: building.CreatedAt,
 		UpdatedAt: building.UpdatedAt,
+		Version:   building.Version,
 	}, nil
 }
 
 // GetBuildingByID retrieves a building by its ID
-func (bs *BuildingService) GetBuildingByID(id uint) (*utils.BuildingResponse, error) {
-	var building models.Building
-
-	result := config.DB.First(&building, id)
-	if result.Error != nil {
-		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
-			return nil, errors.New("building not found")
+func (bs *BuildingService) GetBuildingByID(ctx context.Context, id uint) (*utils.BuildingResponse, error) {
+	building, err := bs.repos.Buildings.FindByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, repositories.ErrNotFound) {
+			return nil, utils.NotFound("building")
 		}
-		return nil, result.Error
+		return nil, err
 	}
 
 	return &utils.BuildingResponse{
@@ -63,29 +67,19 @@ func (bs *BuildingService) GetBuildingByID(id uint) (*utils.BuildingResponse, er
 		Location:  building.Location,
 		CreatedAt: building.CreatedAt,
 		UpdatedAt: building.UpdatedAt,
+		Version:   building.Version,
 	}, nil
 }
 
 // GetAllBuildings retrieves all buildings with pagination
-func (bs *BuildingService) GetAllBuildings(page, pageSize int) ([]utils.BuildingResponse, int64, error) {
-	if page <= 0 {
-		page = 1
+func (bs *BuildingService) GetAllBuildings(ctx context.Context, pagination *utils.PaginationQuery) ([]utils.BuildingResponse, utils.PageInfo, error) {
+	page, err := pageOf(pagination)
+	if err != nil {
+		return nil, utils.PageInfo{}, err
 	}
-	if pageSize <= 0 || pageSize > 100 {
-		pageSize = 10
-	}
-
-	var buildings []models.Building
-	var total int64
-
-	if err := config.DB.Model(&models.Building{}).Count(&total).Error; err != nil {
-		return nil, 0, err
-	}
-
-	offset := (page - 1) * pageSize
-	result := config.DB.Offset(offset).Limit(pageSize).Find(&buildings)
-	if result.Error != nil {
-		return nil, 0, result.Error
+	buildings, info, err := bs.repos.Buildings.List(ctx, page)
+	if err != nil {
+		return nil, utils.PageInfo{}, err
 	}
 
 	var responses []utils.BuildingResponse
@@ -97,25 +91,31 @@ func (bs *BuildingService) GetAllBuildings(page, pageSize int) ([]utils.Building
 			Location:  building.Location,
 			CreatedAt: building.CreatedAt,
 			UpdatedAt: building.UpdatedAt,
+			Version:   building.Version,
 		})
 	}
 
-	return responses, total, nil
+	return responses, info, nil
 }
 
 // UpdateBuilding updates an existing building
-func (bs *BuildingService) UpdateBuilding(id uint, req *utils.UpdateBuildingRequest) (*utils.BuildingResponse, error) {
-	var building models.Building
-
-	result := config.DB.First(&building, id)
-	if result.Error != nil {
-		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
-			return nil, errors.New("building not found")
+func (bs *BuildingService) UpdateBuilding(ctx context.Context, id uint, req *utils.UpdateBuildingRequest) (*utils.BuildingResponse, error) {
+	building, err := bs.repos.Buildings.FindByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, repositories.ErrNotFound) {
+			return nil, utils.NotFound("building")
 		}
-		return nil, result.Error
+		return nil, err
+	}
+
+	if err := checkVersion(ctx, "building", building.Version); err != nil {
+		return nil, err
 	}
 
 	if req.Code != "" {
+		if err := ensureUnique(ctx, bs.repos.Buildings.CodeTaken, "code", req.Code, building.ID); err != nil {
+			return nil, err
+		}
 		building.Code = req.Code
 	}
 	if req.Name != "" {
@@ -125,10 +125,56 @@ func (bs *BuildingService) UpdateBuilding(id uint, req *utils.UpdateBuildingRequ
 		building.Location = req.Location
 	}
 
-	if err := config.DB.Save(&building).Error; err != nil {
+	if err := bs.repos.Buildings.Save(ctx, building); err != nil {
+		return nil, versionConflict(ctx, "building", err)
+	}
+	invalidateHierarchyTree()
+
+	return &utils.BuildingResponse{
+		ID:        building.ID,
+		Code:      building.Code,
+		Name:      building.Name,
+		Location:  building.Location,
+		CreatedAt: building.CreatedAt,
+		UpdatedAt: building.UpdatedAt,
+		Version:   building.Version,
+	}, nil
+}
+
+// PatchBuilding applies a JSON Merge Patch to a building
+// Unlike UpdateBuilding, it tells absent fields from null ones, so optional fields can be cleared.
+func (bs *BuildingService) PatchBuilding(ctx context.Context, id uint, patch []byte) (*utils.BuildingResponse, error) {
+	building, err := bs.repos.Buildings.FindByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, repositories.ErrNotFound) {
+			return nil, utils.NotFound("building")
+		}
+		return nil, err
+	}
+
+	if err := checkVersion(ctx, "building", building.Version); err != nil {
+		return nil, err
+	}
+
+	req := utils.PatchBuildingRequest{Code: building.Code, Name: building.Name, Location: building.Location}
+	if err := utils.ApplyMergePatch(&req, patch); err != nil {
 		return nil, err
 	}
 
+	if req.Code != building.Code {
+		if err := ensureUnique(ctx, bs.repos.Buildings.CodeTaken, "code", req.Code, building.ID); err != nil {
+			return nil, err
+		}
+	}
+	building.Code = req.Code
+	building.Name = req.Name
+	building.Location = req.Location
+
+	if err := bs.repos.Buildings.Save(ctx, building); err != nil {
+		return nil, versionConflict(ctx, "building", err)
+	}
+	invalidateHierarchyTree()
+
 	return &utils.BuildingResponse{
 		ID:        building.ID,
 		Code:      building.Code,
@@ -136,36 +182,63 @@ func (bs *BuildingService) UpdateBuilding(id uint, req *utils.UpdateBuildingRequ
 		Location:  building.Location,
 		CreatedAt: building.CreatedAt,
 		UpdatedAt: building.UpdatedAt,
+		Version:   building.Version,
 	}, nil
 }
 
-// DeleteBuilding performs a soft delete of a building
-func (bs *BuildingService) DeleteBuilding(id uint) error {
-	var building models.Building
+// GetBuildingDeleteImpact counts what deleting a building would take with it
+func (bs *BuildingService) GetBuildingDeleteImpact(ctx context.Context, id uint) (*utils.DeleteImpactResponse, error) {
+	if _, err := bs.repos.Buildings.FindByID(ctx, id); err != nil {
+		if errors.Is(err, repositories.ErrNotFound) {
+			return nil, utils.NotFound("building")
+		}
+		return nil, err
+	}
+
+	impact, err := deleteImpact(ctx, bs.repos, "buildings", id)
+	return impact, err
+}
 
-	result := config.DB.First(&building, id)
-	if result.Error != nil {
-		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
-			return errors.New("building not found")
+// DeleteBuilding performs a soft delete of a building
+// Its dependents are deleted with it only when cascade is set.
+func (bs *BuildingService) DeleteBuilding(ctx context.Context, id uint, cascade bool) error {
+	building, err := bs.repos.Buildings.FindByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, repositories.ErrNotFound) {
+			return utils.NotFound("building")
 		}
-		return result.Error
+		return err
+	}
+
+	if err := checkVersion(ctx, "building", building.Version); err != nil {
+		return err
 	}
 
-	result = config.DB.Delete(&building)
-	return result.Error
+	return cascadeDelete(ctx, bs.repos, "buildings", building.ID, building.Version, cascade)
 }
 
 // GetBuildingWithFloors retrieves a building with all its floors
-func (bs *BuildingService) GetBuildingWithFloors(id uint) (*models.Building, error) {
-	var building models.Building
-
-	result := config.DB.Preload("Floors").First(&building, id)
-	if result.Error != nil {
-		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
-			return nil, errors.New("building not found")
+func (bs *BuildingService) GetBuildingWithFloors(ctx context.Context, id uint) (*models.Building, error) {
+	building, err := bs.repos.Buildings.FindWithFloors(ctx, id)
+	if err != nil {
+		if errors.Is(err, repositories.ErrNotFound) {
+			return nil, utils.NotFound("building")
 		}
-		return nil, result.Error
+		return nil, err
 	}
 
-	return &building, nil
+	return building, nil
+}
+
+// toBuildingResponse converts a building model into its response DTO
+func toBuildingResponse(building *models.Building) *utils.BuildingResponse {
+	return &utils.BuildingResponse{
+		ID:        building.ID,
+		Code:      building.Code,
+		Name:      building.Name,
+		Location:  building.Location,
+		CreatedAt: building.CreatedAt,
+		UpdatedAt: building.UpdatedAt,
+		Version:   building.Version,
+	}
 }
@@ -0,0 +1,67 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"incident-report/repositories"
+	"incident-report/utils"
+)
+
+// errBulkRolledBack rolls back an atomic bulk request in which a record failed
+var errBulkRolledBack = errors.New("bulk request rolled back")
+
+// runBulk applies a change to every record of a bulk request within one transaction
+// Each record runs in a savepoint of its own, so a record that fails leaves no partial change.
+// In atomic mode one failure rolls back the whole request; in best-effort mode the records that
+// succeeded are committed. Errors that are not domain errors abort the request in either mode.
+func runBulk(ctx context.Context, repos *repositories.Repositories, mode string, ids []uint, apply func(tx *repositories.Repositories, id uint) error) (*utils.BulkResponse, error) {
+	if mode == "" {
+		mode = utils.BulkModeAtomic
+	}
+	response := &utils.BulkResponse{Mode: mode, Results: make([]utils.BulkItemResult, 0, len(ids))}
+
+	err := repos.Transaction(ctx, func(tx *repositories.Repositories) error {
+		for _, id := range ids {
+			err := tx.Transaction(ctx, func(item *repositories.Repositories) error {
+				return apply(item, id)
+			})
+
+			result := utils.BulkItemResult{ID: id, Status: utils.BulkItemOK}
+			if err != nil {
+				var appErr *utils.AppError
+				if !errors.As(err, &appErr) {
+					return err
+				}
+				result = utils.BulkItemResult{ID: id, Status: utils.BulkItemFailed, Code: utils.ErrorCode(err), Error: err.Error()}
+				response.Failed++
+			} else {
+				response.Succeeded++
+			}
+			response.Results = append(response.Results, result)
+		}
+
+		if mode == utils.BulkModeAtomic && response.Failed > 0 {
+			return errBulkRolledBack
+		}
+		return nil
+	})
+
+	switch {
+	case errors.Is(err, errBulkRolledBack):
+		for i := range response.Results {
+			if response.Results[i].Status == utils.BulkItemOK {
+				response.Results[i].Status = utils.BulkItemRolledBack
+			}
+		}
+		response.Succeeded = 0
+	case err != nil:
+		return nil, err
+	default:
+		response.Committed = true
+	}
+
+	utils.Logger(ctx).InfoContext(ctx, "bulk request", "mode", mode, "records", len(ids),
+		"succeeded", response.Succeeded, "failed", response.Failed, "committed", response.Committed)
+	invalidateHierarchyTree()
+	return response, nil
+}
@@ -0,0 +1,216 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"incident-report/repositories"
+	"incident-report/utils"
+)
+
+// addReports tops the hierarchy up to n pending reports in its room and returns their IDs
+func addReports(t *testing.T, repos *repositories.Repositories, h hierarchy, n int) []uint {
+	t.Helper()
+
+	ids := []uint{h.report}
+	for i := 1; i < n; i++ {
+		report, err := NewReportService(repos).CreateReport(context.Background(), &utils.CreateReportRequest{
+			Name: "Broken projector", RoomID: h.room, ComponentID: h.component, Status: "PENDING",
+		})
+		if err != nil {
+			t.Fatalf("create report: %v", err)
+		}
+		ids = append(ids, report.ID)
+	}
+	return ids
+}
+
+// statuses lists the outcome of every record of a bulk response
+func statuses(resp *utils.BulkResponse) []string {
+	var out []string
+	for _, result := range resp.Results {
+		out = append(out, result.Status)
+	}
+	return out
+}
+
+func TestBulkReportModes(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name      string
+		mode      string
+		committed bool
+		want      []string
+		completed int
+	}{
+		{"atomic", utils.BulkModeAtomic, false, []string{"rolled_back", "failed", "rolled_back"}, 0},
+		{"best effort", utils.BulkModeBestEffort, true, []string{"ok", "failed", "ok"}, 2},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			repos := repositories.New(setupTestDB(t))
+			h := seedHierarchy(t, repos)
+			ids := addReports(t, repos, h, 2)
+			svc := NewReportService(repos)
+
+			resp, err := svc.BulkReports(ctx, &utils.BulkReportRequest{
+				Action: utils.BulkActionTransition, Status: "COMPLETED", Mode: tc.mode,
+				IDs: []uint{ids[0], 999, ids[1]},
+			})
+			if err != nil {
+				t.Fatalf("bulk: %v", err)
+			}
+			if resp.Committed != tc.committed || resp.Succeeded != tc.completed || resp.Failed != 1 {
+				t.Errorf("response = committed %v, %d succeeded, %d failed; want %v, %d, 1",
+					resp.Committed, resp.Succeeded, resp.Failed, tc.committed, tc.completed)
+			}
+			if got := statuses(resp); len(got) != 3 || got[0] != tc.want[0] || got[1] != tc.want[1] || got[2] != tc.want[2] {
+				t.Errorf("statuses = %v, want %v", got, tc.want)
+			}
+			if resp.Results[1].Code != utils.CodeNotFound {
+				t.Errorf("missing report code = %q, want %s", resp.Results[1].Code, utils.CodeNotFound)
+			}
+
+			completed, err := repos.Reports.IDs(ctx, &utils.ReportFilterQuery{Status: "COMPLETED"}, 10)
+			if err != nil {
+				t.Fatalf("list completed: %v", err)
+			}
+			if len(completed) != tc.completed {
+				t.Errorf("completed reports = %v, want %d", completed, tc.completed)
+			}
+		})
+	}
+}
+
+func TestBulkReportActions(t *testing.T) {
+	ctx := context.Background()
+	repos := repositories.New(setupTestDB(t))
+	h := seedHierarchy(t, repos)
+	ids := addReports(t, repos, h, 3)
+	svc := NewReportService(repos)
+
+	user, err := NewUserService(repos).CreateUser(ctx, &utils.CreateUserRequest{Name: "Tech One", Email: "tech@example.com"})
+	if err != nil {
+		t.Fatalf("create user: %v", err)
+	}
+	tag, err := NewTagService(repos).CreateTag(ctx, &utils.CreateTagRequest{Name: "vendor-visit"})
+	if err != nil {
+		t.Fatalf("create tag: %v", err)
+	}
+
+	// Select by filter: every report of the room
+	byRoom := &utils.ReportFilterQuery{RoomID: h.room}
+	for _, req := range []*utils.BulkReportRequest{
+		{Action: utils.BulkActionAssign, Filter: byRoom, UserID: &user.ID},
+		{Action: utils.BulkActionTag, Filter: byRoom, TagIDs: []uint{tag.ID}},
+		{Action: utils.BulkActionTransition, Filter: byRoom, Status: "COMPLETED"},
+	} {
+		resp, err := svc.BulkReports(ctx, req)
+		if err != nil {
+			t.Fatalf("bulk %s: %v", req.Action, err)
+		}
+		if !resp.Committed || resp.Succeeded != len(ids) {
+			t.Errorf("bulk %s = committed %v, %d succeeded; want all %d", req.Action, resp.Committed, resp.Succeeded, len(ids))
+		}
+	}
+
+	for _, id := range ids {
+		report, err := svc.GetReportByID(ctx, id)
+		if err != nil {
+			t.Fatalf("get report: %v", err)
+		}
+		tagged := false
+		for _, tg := range report.Tags {
+			tagged = tagged || tg.ID == tag.ID
+		}
+		if report.UserID == nil || *report.UserID != user.ID || report.Status != "COMPLETED" || !tagged {
+			t.Errorf("report %d = user %v, status %s, tags %+v; want assigned, completed and tagged", id, report.UserID, report.Status, report.Tags)
+		}
+		// Every record is audited on its own; the seeded report was tagged once more
+		want := 4
+		if id == h.report {
+			want = 5
+		}
+		if entries := auditEntries(t, repos, "reports", id); len(entries) != want {
+			t.Errorf("report %d has %d audit entries, want %d: create, assign, tag and transition", id, len(entries), want)
+		}
+	}
+
+	resp, err := svc.BulkReports(ctx, &utils.BulkReportRequest{Action: utils.BulkActionDelete, IDs: ids})
+	if err != nil || !resp.Committed {
+		t.Fatalf("bulk delete = %+v, %v", resp, err)
+	}
+	if _, err := svc.GetReportByID(ctx, ids[0]); !errors.Is(err, utils.ErrNotFound) {
+		t.Errorf("deleted report = %v, want not found", err)
+	}
+}
+
+func TestBulkReportValidation(t *testing.T) {
+	ctx := context.Background()
+	repos := repositories.New(setupTestDB(t))
+	h := seedHierarchy(t, repos)
+	missing := uint(999)
+
+	tests := []struct {
+		name  string
+		req   utils.BulkReportRequest
+		field string
+	}{
+		{"no selection", utils.BulkReportRequest{Action: utils.BulkActionDelete}, "ids"},
+		{"empty filter", utils.BulkReportRequest{Action: utils.BulkActionDelete, Filter: &utils.ReportFilterQuery{TagMatch: "all"}}, "ids"},
+		{"both selections", utils.BulkReportRequest{Action: utils.BulkActionDelete, IDs: []uint{h.report}, Filter: &utils.ReportFilterQuery{RoomID: h.room}}, ""},
+		{"assign without user", utils.BulkReportRequest{Action: utils.BulkActionAssign, IDs: []uint{h.report}}, "user_id"},
+		{"assign to missing user", utils.BulkReportRequest{Action: utils.BulkActionAssign, IDs: []uint{h.report}, UserID: &missing}, "user_id"},
+		{"transition without status", utils.BulkReportRequest{Action: utils.BulkActionTransition, IDs: []uint{h.report}}, "status"},
+		{"tag without tags", utils.BulkReportRequest{Action: utils.BulkActionTag, IDs: []uint{h.report}}, "tag_ids"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := NewReportService(repos).BulkReports(ctx, &tc.req)
+			var appErr *utils.AppError
+			if !errors.As(err, &appErr) || !errors.Is(err, utils.ErrValidation) {
+				t.Fatalf("bulk = %v, want a validation error", err)
+			}
+			if tc.field != "" && (len(appErr.Fields) == 0 || appErr.Fields[0].Field != tc.field) {
+				t.Errorf("fields = %+v, want %s", appErr.Fields, tc.field)
+			}
+		})
+	}
+}
+
+func TestBulkMoveComponents(t *testing.T) {
+	ctx := context.Background()
+	repos := repositories.New(setupTestDB(t))
+	h := seedHierarchy(t, repos)
+
+	room, err := NewRoomService(repos).CreateRoom(ctx, &utils.CreateRoomRequest{FloorID: h.floor, Code: "R102", Name: "Lab 102"})
+	if err != nil {
+		t.Fatalf("create room: %v", err)
+	}
+
+	svc := NewComponentService(repos)
+	resp, err := svc.BulkMoveComponents(ctx, &utils.BulkMoveComponentsRequest{
+		IDs: []uint{h.component, h.component, 999}, RoomID: room.ID, Mode: utils.BulkModeBestEffort,
+	})
+	if err != nil {
+		t.Fatalf("bulk move: %v", err)
+	}
+	if !resp.Committed || resp.Succeeded != 1 || resp.Failed != 1 || len(resp.Results) != 2 {
+		t.Errorf("response = %+v, want the component moved once and the missing one failed", resp)
+	}
+
+	component, err := svc.GetComponentByID(ctx, h.component)
+	if err != nil {
+		t.Fatalf("get component: %v", err)
+	}
+	if component.RoomID == nil || *component.RoomID != room.ID {
+		t.Errorf("component room = %v, want %d", component.RoomID, room.ID)
+	}
+
+	_, err = svc.BulkMoveComponents(ctx, &utils.BulkMoveComponentsRequest{IDs: []uint{h.component}, RoomID: 999})
+	if !errors.Is(err, utils.ErrValidation) {
+		t.Errorf("move to a missing room = %v, want a validation error", err)
+	}
+}
@@ -0,0 +1,76 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"incident-report/repositories"
+	"incident-report/utils"
+)
+
+// deleteImpact counts the live records a cascading delete of a record would take with it
+func deleteImpact(ctx context.Context, repos *repositories.Repositories, entityType string, id uint) (*utils.DeleteImpactResponse, error) {
+	descendants, err := repos.Trash.Descendants(ctx, entityType, id)
+	if err != nil {
+		return nil, err
+	}
+	return impactOf(ctx, repos, descendants)
+}
+
+// impactOf counts collected descendants and the open reports among them
+func impactOf(ctx context.Context, repos *repositories.Repositories, descendants map[string][]uint) (*utils.DeleteImpactResponse, error) {
+	openReports, err := repos.Reports.CountOpen(ctx, descendants["reports"])
+	if err != nil {
+		return nil, err
+	}
+
+	return &utils.DeleteImpactResponse{
+		Floors:      len(descendants["floors"]),
+		Rooms:       len(descendants["rooms"]),
+		Components:  len(descendants["components"]),
+		Reports:     len(descendants["reports"]),
+		OpenReports: openReports,
+	}, nil
+}
+
+// cascadeDelete soft-deletes a record together with its live descendants
+// A record with dependents is only deleted when cascade is set, and never while one of its
+// reports is still open, so no outstanding work disappears into the trash. The record is only
+// deleted while it is still at the version it was read at. The descendants are collected, checked
+// and deleted in one transaction that locks them, so none can be added or reopened in between.
+func cascadeDelete(ctx context.Context, repos *repositories.Repositories, entityType string, id, version uint, cascade bool) error {
+	noun := trashNouns[entityType]
+	var impact *utils.DeleteImpactResponse
+	var dependents int
+	err := repos.Transaction(ctx, func(tx *repositories.Repositories) error {
+		descendants, err := tx.Trash.LockDescendants(ctx, entityType, id)
+		if err != nil {
+			return err
+		}
+		if impact, err = impactOf(ctx, tx, descendants); err != nil {
+			return err
+		}
+
+		if impact.OpenReports > 0 {
+			return utils.ConflictState(fmt.Sprintf("%s has %d open reports; resolve them before deleting it", noun, impact.OpenReports))
+		}
+		dependents = impact.Floors + impact.Rooms + impact.Components + impact.Reports
+		if dependents > 0 && !cascade {
+			return utils.ConflictState(fmt.Sprintf("%s has %d dependent records; pass cascade=true to delete them too", noun, dependents))
+		}
+
+		if err := tx.Trash.DeleteWithDescendants(ctx, entityType, id, version, descendants); err != nil {
+			return versionConflict(ctx, noun, err)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	if dependents > 0 {
+		utils.Logger(ctx).InfoContext(ctx, "cascading delete", "entity", entityType, "id", id,
+			"floors", impact.Floors, "rooms", impact.Rooms, "components", impact.Components, "reports", impact.Reports)
+	}
+
+	invalidateHierarchyTree()
+	return nil
+}
@@ -0,0 +1,453 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"incident-report/models"
+	"incident-report/repositories"
+	"incident-report/utils"
+
+	"gorm.io/gorm"
+)
+
+// hierarchy is one record at every level of Building → Floor → Room → Component → Report
+type hierarchy struct {
+	building, floor, room, component, report, tag uint
+}
+
+// seedHierarchy creates a single tagged report with its full location chain
+func seedHierarchy(t *testing.T, repos *repositories.Repositories) hierarchy {
+	t.Helper()
+	ctx := context.Background()
+
+	var h hierarchy
+	building, err := NewBuildingService(repos).CreateBuilding(ctx, &utils.CreateBuildingRequest{Code: "B1", Name: "Main Building"})
+	if err != nil {
+		t.Fatalf("create building: %v", err)
+	}
+	h.building = building.ID
+	floor, err := NewFloorService(repos).CreateFloor(ctx, &utils.CreateFloorRequest{BuildingID: h.building, FloorNumber: 1, Name: "Ground"})
+	if err != nil {
+		t.Fatalf("create floor: %v", err)
+	}
+	h.floor = floor.ID
+	room, err := NewRoomService(repos).CreateRoom(ctx, &utils.CreateRoomRequest{FloorID: h.floor, Code: "R101", Name: "Lab 101"})
+	if err != nil {
+		t.Fatalf("create room: %v", err)
+	}
+	h.room = room.ID
+	category, err := NewComponentCategoryService(repos).CreateComponentCategory(ctx, &utils.CreateComponentCategoryRequest{Code: "PRJ", Name: "Projector"})
+	if err != nil {
+		t.Fatalf("create category: %v", err)
+	}
+	component, err := NewComponentService(repos).CreateComponent(ctx, &utils.CreateComponentRequest{
+		RoomID: &h.room, CategoryID: category.ID, Code: "PRJ-1", Name: "Projector 1",
+	})
+	if err != nil {
+		t.Fatalf("create component: %v", err)
+	}
+	h.component = component.ID
+	tag, err := NewTagService(repos).CreateTag(ctx, &utils.CreateTagRequest{Name: "electrical"})
+	if err != nil {
+		t.Fatalf("create tag: %v", err)
+	}
+	h.tag = tag.ID
+	report, err := NewReportService(repos).CreateReport(ctx, &utils.CreateReportRequest{
+		Name: "Broken projector", RoomID: h.room, ComponentID: h.component, Status: "PENDING",
+	})
+	if err != nil {
+		t.Fatalf("create report: %v", err)
+	}
+	h.report = report.ID
+	if _, err := NewReportService(repos).AddTagsToReport(ctx, h.report, []uint{h.tag}); err != nil {
+		t.Fatalf("tag report: %v", err)
+	}
+
+	return h
+}
+
+// completeReport resolves a report, so it no longer blocks deleting its location
+func completeReport(t *testing.T, repos *repositories.Repositories, id uint) {
+	t.Helper()
+
+	if _, err := NewReportService(repos).UpdateReport(context.Background(), id, &utils.UpdateReportRequest{Status: "COMPLETED"}); err != nil {
+		t.Fatalf("complete report: %v", err)
+	}
+}
+
+// childRows selects the rows below a deleted record
+type childRows struct {
+	model any
+	query string
+	id    func(h hierarchy) uint
+}
+
+// countRows counts the rows of model matching the condition, soft-deleted ones included
+func countRows(t *testing.T, db *gorm.DB, model any, query string, args ...any) int64 {
+	t.Helper()
+
+	var count int64
+	if err := db.Unscoped().Model(model).Where(query, args...).Count(&count).Error; err != nil {
+		t.Fatalf("count: %v", err)
+	}
+	return count
+}
+
+// countLive counts the rows of model matching the condition that are not soft-deleted
+func countLive(t *testing.T, db *gorm.DB, model any, query string, args ...any) int64 {
+	t.Helper()
+
+	var count int64
+	if err := db.Model(model).Where(query, args...).Count(&count).Error; err != nil {
+		t.Fatalf("count: %v", err)
+	}
+	return count
+}
+
+func TestSoftDeleteKeepsRowsAndDescendants(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name    string
+		model   any
+		deleted func(h hierarchy) uint
+		remove  func(repos *repositories.Repositories, id uint) error
+		get     func(repos *repositories.Repositories, id uint) error
+		// descendants survive the soft delete untouched, unless it cascades
+		cascades    bool
+		descendants []childRows
+	}{
+		{
+			name:     "building",
+			cascades: true,
+			model:    &models.Building{},
+			deleted:  func(h hierarchy) uint { return h.building },
+			remove: func(repos *repositories.Repositories, id uint) error {
+				return NewBuildingService(repos).DeleteBuilding(ctx, id, true)
+			},
+			get: func(repos *repositories.Repositories, id uint) error {
+				_, err := NewBuildingService(repos).GetBuildingByID(ctx, id)
+				return err
+			},
+			descendants: []childRows{
+				{&models.Floor{}, "building_id = ?", func(h hierarchy) uint { return h.building }},
+				{&models.Room{}, "floor_id = ?", func(h hierarchy) uint { return h.floor }},
+				{&models.Component{}, "room_id = ?", func(h hierarchy) uint { return h.room }},
+				{&models.Report{}, "component_id = ?", func(h hierarchy) uint { return h.component }},
+			},
+		},
+		{
+			name:     "floor",
+			cascades: true,
+			model:    &models.Floor{},
+			deleted:  func(h hierarchy) uint { return h.floor },
+			remove: func(repos *repositories.Repositories, id uint) error {
+				return NewFloorService(repos).DeleteFloor(ctx, id, true)
+			},
+			get: func(repos *repositories.Repositories, id uint) error {
+				_, err := NewFloorService(repos).GetFloorByID(ctx, id)
+				return err
+			},
+			descendants: []childRows{
+				{&models.Room{}, "floor_id = ?", func(h hierarchy) uint { return h.floor }},
+				{&models.Component{}, "room_id = ?", func(h hierarchy) uint { return h.room }},
+				{&models.Report{}, "room_id = ?", func(h hierarchy) uint { return h.room }},
+			},
+		},
+		{
+			name:     "room",
+			cascades: true,
+			model:    &models.Room{},
+			deleted:  func(h hierarchy) uint { return h.room },
+			remove: func(repos *repositories.Repositories, id uint) error {
+				return NewRoomService(repos).DeleteRoom(ctx, id, true)
+			},
+			get: func(repos *repositories.Repositories, id uint) error {
+				_, err := NewRoomService(repos).GetRoomByID(ctx, id)
+				return err
+			},
+			descendants: []childRows{
+				{&models.Component{}, "room_id = ?", func(h hierarchy) uint { return h.room }},
+				{&models.Report{}, "room_id = ?", func(h hierarchy) uint { return h.room }},
+			},
+		},
+		{
+			name:    "component",
+			model:   &models.Component{},
+			deleted: func(h hierarchy) uint { return h.component },
+			remove: func(repos *repositories.Repositories, id uint) error {
+				return NewComponentService(repos).DeleteComponent(ctx, id)
+			},
+			get: func(repos *repositories.Repositories, id uint) error {
+				_, err := NewComponentService(repos).GetComponentByID(ctx, id)
+				return err
+			},
+			descendants: []childRows{
+				{&models.Report{}, "component_id = ?", func(h hierarchy) uint { return h.component }},
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			db := setupTestDB(t)
+			repos := repositories.New(db)
+			h := seedHierarchy(t, repos)
+			completeReport(t, repos, h.report)
+			id := tc.deleted(h)
+
+			if err := tc.remove(repos, id); err != nil {
+				t.Fatalf("delete: %v", err)
+			}
+
+			if err := tc.get(repos, id); err == nil {
+				t.Error("soft-deleted record is still returned")
+			}
+			if countLive(t, db, tc.model, "id = ?", id) != 0 {
+				t.Error("soft-deleted record is still visible to scoped queries")
+			}
+			if countRows(t, db, tc.model, "id = ? AND deleted_at IS NOT NULL", id) != 1 {
+				t.Error("soft delete removed the row instead of stamping deleted_at")
+			}
+			for _, d := range tc.descendants {
+				live := countLive(t, db, d.model, d.query, d.id(h))
+				if tc.cascades && live != 0 {
+					t.Errorf("%T child survived a cascading delete", d.model)
+				}
+				if !tc.cascades && live != 1 {
+					t.Errorf("%T child was removed by a soft delete", d.model)
+				}
+				if countRows(t, db, d.model, d.query, d.id(h)) != 1 {
+					t.Errorf("%T child row was removed instead of soft-deleted", d.model)
+				}
+			}
+
+			if err := tc.remove(repos, id); err == nil {
+				t.Error("deleting an already soft-deleted record succeeded")
+			}
+		})
+	}
+}
+
+func TestDeleteImpactAndCascade(t *testing.T) {
+	ctx := context.Background()
+	db := setupTestDB(t)
+	repos := repositories.New(db)
+	h := seedHierarchy(t, repos)
+	buildings := NewBuildingService(repos)
+
+	impact, err := buildings.GetBuildingDeleteImpact(ctx, h.building)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := utils.DeleteImpactResponse{Floors: 1, Rooms: 1, Components: 1, Reports: 1, OpenReports: 1}
+	if *impact != want {
+		t.Errorf("impact = %+v, want %+v", *impact, want)
+	}
+	if impact, _ := NewComponentCategoryService(repos).GetComponentCategoryDeleteImpact(ctx, 1); impact.Components != 1 || impact.Reports != 1 {
+		t.Errorf("category impact = %+v, want its component and report", impact)
+	}
+	if _, err := buildings.GetBuildingDeleteImpact(ctx, 999); !errors.Is(err, utils.ErrNotFound) {
+		t.Errorf("impact of an unknown building: err = %v, want not found", err)
+	}
+
+	if err := buildings.DeleteBuilding(ctx, h.building, true); !errors.Is(err, utils.ErrConflict) {
+		t.Errorf("cascade over an open report: err = %v, want a conflict", err)
+	}
+	completeReport(t, repos, h.report)
+	if err := buildings.DeleteBuilding(ctx, h.building, false); !errors.Is(err, utils.ErrConflict) {
+		t.Errorf("delete with dependents and no cascade: err = %v, want a conflict", err)
+	}
+	if countLive(t, db, &models.Building{}, "id = ?", h.building) != 1 {
+		t.Fatal("a refused delete removed the building")
+	}
+
+	if err := buildings.DeleteBuilding(ctx, h.building, true); err != nil {
+		t.Fatal(err)
+	}
+	var stamps []time.Time
+	for _, table := range []string{"buildings", "floors", "rooms", "components", "reports"} {
+		var stamp time.Time
+		if err := db.Table(table).Select("deleted_at").Where("deleted_at IS NOT NULL").Scan(&stamp).Error; err != nil {
+			t.Fatal(err)
+		}
+		stamps = append(stamps, stamp)
+	}
+	for i, stamp := range stamps {
+		if !stamp.Equal(stamps[0]) {
+			t.Errorf("record %d deleted at %v, want the building's %v", i, stamp, stamps[0])
+		}
+	}
+
+	// Restoring the building brings back everything the cascade took
+	if _, err := NewTrashService(repos).Restore(ctx, "buildings", h.building); err != nil {
+		t.Fatal(err)
+	}
+	if countLive(t, db, &models.Report{}, "id = ?", h.report) != 1 {
+		t.Error("report deleted by the cascade was not restored")
+	}
+	if countLive(t, db, &models.Component{}, "id = ?", h.component) != 1 {
+		t.Error("component deleted by the cascade was not restored")
+	}
+}
+
+func TestSoftDeletedParentRejectsNewChildren(t *testing.T) {
+	ctx := context.Background()
+	db := setupTestDB(t)
+	repos := repositories.New(db)
+	h := seedHierarchy(t, repos)
+	completeReport(t, repos, h.report)
+
+	// A component outside the building, so it is not deleted with the room
+	spare, err := NewComponentService(repos).CreateComponent(ctx, &utils.CreateComponentRequest{
+		CategoryID: 1, Code: "PRJ-2", Name: "Spare projector",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := NewRoomService(repos).DeleteRoom(ctx, h.room, true); err != nil {
+		t.Fatal(err)
+	}
+	if err := NewFloorService(repos).DeleteFloor(ctx, h.floor, false); err != nil {
+		t.Fatal(err)
+	}
+	if err := NewBuildingService(repos).DeleteBuilding(ctx, h.building, false); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := NewFloorService(repos).CreateFloor(ctx, &utils.CreateFloorRequest{BuildingID: h.building, FloorNumber: 2, Name: "First"}); err == nil {
+		t.Error("created a floor in a deleted building")
+	}
+	if _, err := NewRoomService(repos).CreateRoom(ctx, &utils.CreateRoomRequest{FloorID: h.floor, Code: "R102", Name: "Lab 102"}); err == nil {
+		t.Error("created a room on a deleted floor")
+	}
+	if _, err := NewComponentService(repos).AssignRoomToComponent(ctx, spare.ID, &utils.AssignRoomRequest{RoomID: h.room}); err == nil {
+		t.Error("assigned a component to a deleted room")
+	}
+
+	tree, err := NewTreeService(db).GetTree(ctx, TreeOptions{Depth: TreeDepthRooms})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(tree) != 0 {
+		t.Errorf("tree still lists the deleted building: %+v", tree)
+	}
+}
+
+func TestReportDeleteIsSoft(t *testing.T) {
+	ctx := context.Background()
+	db := setupTestDB(t)
+	repos := repositories.New(db)
+	h := seedHierarchy(t, repos)
+
+	if err := NewReportService(repos).DeleteReport(ctx, h.report); err != nil {
+		t.Fatal(err)
+	}
+
+	if countLive(t, db, &models.Report{}, "id = ?", h.report) != 0 {
+		t.Error("deleted report is still visible to scoped queries")
+	}
+	if countRows(t, db, &models.Report{}, "id = ? AND deleted_at IS NOT NULL", h.report) != 1 {
+		t.Error("report delete removed the row instead of stamping deleted_at")
+	}
+	if countRows(t, db.Table("report_tags"), nil, "report_id = ?", h.report) != 1 {
+		t.Error("report tags were dropped by a soft delete")
+	}
+	if countLive(t, db, &models.Component{}, "id = ?", h.component) != 1 {
+		t.Error("deleting a report removed its component")
+	}
+}
+
+func TestTagDeleteDetachesReports(t *testing.T) {
+	ctx := context.Background()
+	db := setupTestDB(t)
+	repos := repositories.New(db)
+	h := seedHierarchy(t, repos)
+
+	if err := NewTagService(repos).DeleteTag(ctx, h.tag); err != nil {
+		t.Fatal(err)
+	}
+
+	report, err := NewReportService(repos).GetReportByID(ctx, h.report)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(report.Tags) != 0 {
+		t.Errorf("report still has tags: %+v", report.Tags)
+	}
+	if countRows(t, db.Table("report_tags"), nil, "tag_id = ?", h.tag) != 0 {
+		t.Error("tag links survived the tag delete")
+	}
+}
+
+// TestHardDeleteCascades checks the ON DELETE CASCADE foreign keys that back the hierarchy
+func TestHardDeleteCascades(t *testing.T) {
+	db := setupTestDB(t)
+	repos := repositories.New(db)
+	h := seedHierarchy(t, repos)
+
+	if err := db.Unscoped().Delete(&models.Building{}, h.building).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	checks := []struct {
+		model any
+		id    uint
+	}{
+		{&models.Floor{}, h.floor},
+		{&models.Room{}, h.room},
+		{&models.Component{}, h.component},
+		{&models.Report{}, h.report},
+	}
+	for _, c := range checks {
+		if countRows(t, db, c.model, "id = ?", c.id) != 0 {
+			t.Errorf("%T %d survived the building delete", c.model, c.id)
+		}
+	}
+	if countRows(t, db.Table("report_tags"), nil, "report_id = ?", h.report) != 0 {
+		t.Error("report tags survived the building delete")
+	}
+	if countLive(t, db, &models.Tag{}, "id = ?", h.tag) != 1 {
+		t.Error("the building delete removed a tag")
+	}
+}
+
+func TestCascadeDeleteLosesRace(t *testing.T) {
+	ctx := context.Background()
+	repos := repositories.New(setupTestDB(t))
+	h := seedHierarchy(t, repos)
+
+	created, err := NewRoomService(repos).CreateRoom(ctx, &utils.CreateRoomRequest{FloorID: h.floor, Code: "R102", Name: "Lab 102"})
+	if err != nil {
+		t.Fatalf("create room: %v", err)
+	}
+
+	// The delete read version 1, then an update landed before it wrote
+	room, err := repos.Rooms.FindByID(ctx, created.ID)
+	if err != nil {
+		t.Fatalf("find room: %v", err)
+	}
+	read := room.Version
+	room.Name = "Lab 102A"
+	if err := repos.Rooms.Save(ctx, room); err != nil {
+		t.Fatalf("save room: %v", err)
+	}
+
+	if err := cascadeDelete(ctx, repos, "rooms", room.ID, read, false); !errors.Is(err, utils.ErrConflict) {
+		t.Errorf("delete at a stale version = %v, want a conflict", err)
+	}
+	if err := cascadeDelete(utils.WithExpectedVersion(ctx, read), repos, "rooms", room.ID, read, false); !errors.Is(err, utils.ErrStale) {
+		t.Errorf("delete at a stale version with If-Match = %v, want a failed precondition", err)
+	}
+	if _, err := repos.Rooms.FindByID(ctx, room.ID); err != nil {
+		t.Fatalf("room after the rejected deletes: %v", err)
+	}
+
+	if err := cascadeDelete(ctx, repos, "rooms", room.ID, room.Version, false); err != nil {
+		t.Errorf("delete at the current version: %v", err)
+	}
+}
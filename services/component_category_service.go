@@ -1,26 +1,31 @@
 package services
 
 import (
+	"context"
 	"errors"
-	"incident-report/config"
 	"incident-report/models"
+	"incident-report/repositories"
 	"incident-report/utils"
-
-	"gorm.io/gorm"
 )
 
 // ComponentCategoryService handles all component category-related business logic
-type ComponentCategoryService struct{}
+type ComponentCategoryService struct {
+	repos *repositories.Repositories
+}
 
 // NewComponentCategoryService creates a new instance of ComponentCategoryService
-func NewComponentCategoryService() *ComponentCategoryService {
-	return &ComponentCategoryService{}
+func NewComponentCategoryService(repos *repositories.Repositories) *ComponentCategoryService {
+	return &ComponentCategoryService{repos: repos}
 }
 
 // CreateComponentCategory creates a new component category in the database
-func (ccs *ComponentCategoryService) CreateComponentCategory(req *utils.CreateComponentCategoryRequest) (*utils.ComponentCategoryResponse, error) {
+func (ccs *ComponentCategoryService) CreateComponentCategory(ctx context.Context, req *utils.CreateComponentCategoryRequest) (*utils.ComponentCategoryResponse, error) {
 	if req.Code == "" || req.Name == "" {
-		return nil, errors.New("code and name are required")
+		return nil, utils.Validation("code and name are required")
+	}
+
+	if err := ensureUnique(ctx, ccs.repos.ComponentCategories.CodeTaken, "code", req.Code, 0); err != nil {
+		return nil, err
 	}
 
 	category := models.ComponentCategory{
@@ -29,9 +34,8 @@ func (ccs *ComponentCategoryService) CreateComponentCategory(req *utils.CreateCo
 		Description: req.Description,
 	}
 
-	result := config.DB.Create(&category)
-	if result.Error != nil {
-		return nil, result.Error
+	if err := ccs.repos.ComponentCategories.Create(ctx, &category); err != nil {
+		return nil, err
 	}
 
 	return &utils.ComponentCategoryResponse{
@@ -41,19 +45,18 @@ func (ccs *ComponentCategoryService) CreateComponentCategory(req *utils.CreateCo
 		Description: category.Description,
 		CreatedAt:   category.CreatedAt,
 		UpdatedAt:   category.UpdatedAt,
+		Version:     category.Version,
 	}, nil
 }
 
 // GetComponentCategoryByID retrieves a component category by its ID
-func (ccs *ComponentCategoryService) GetComponentCategoryByID(id uint) (*utils.ComponentCategoryResponse, error) {
-	var category models.ComponentCategory
-
-	result := config.DB.First(&category, id)
-	if result.Error != nil {
-		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
-			return nil, errors.New("component category not found")
+func (ccs *ComponentCategoryService) GetComponentCategoryByID(ctx context.Context, id uint) (*utils.ComponentCategoryResponse, error) {
+	category, err := ccs.repos.ComponentCategories.FindByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, repositories.ErrNotFound) {
+			return nil, utils.NotFound("component category")
 		}
-		return nil, result.Error
+		return nil, err
 	}
 
 	return &utils.ComponentCategoryResponse{
@@ -63,29 +66,19 @@ func (ccs *ComponentCategoryService) GetComponentCategoryByID(id uint) (*utils.C
 		Description: category.Description,
 		CreatedAt:   category.CreatedAt,
 		UpdatedAt:   category.UpdatedAt,
+		Version:     category.Version,
 	}, nil
 }
 
 // GetAllComponentCategories retrieves all component categories with pagination
-func (ccs *ComponentCategoryService) GetAllComponentCategories(page, pageSize int) ([]utils.ComponentCategoryResponse, int64, error) {
-	if page <= 0 {
-		page = 1
+func (ccs *ComponentCategoryService) GetAllComponentCategories(ctx context.Context, pagination *utils.PaginationQuery) ([]utils.ComponentCategoryResponse, utils.PageInfo, error) {
+	page, err := pageOf(pagination)
+	if err != nil {
+		return nil, utils.PageInfo{}, err
 	}
-	if pageSize <= 0 || pageSize > 100 {
-		pageSize = 10
-	}
-
-	var categories []models.ComponentCategory
-	var total int64
-
-	if err := config.DB.Model(&models.ComponentCategory{}).Count(&total).Error; err != nil {
-		return nil, 0, err
-	}
-
-	offset := (page - 1) * pageSize
-	result := config.DB.Offset(offset).Limit(pageSize).Find(&categories)
-	if result.Error != nil {
-		return nil, 0, result.Error
+	categories, info, err := ccs.repos.ComponentCategories.List(ctx, page)
+	if err != nil {
+		return nil, utils.PageInfo{}, err
 	}
 
 	var responses []utils.ComponentCategoryResponse
@@ -97,25 +90,31 @@ func (ccs *ComponentCategoryService) GetAllComponentCategories(page, pageSize in
 			Description: category.Description,
 			CreatedAt:   category.CreatedAt,
 			UpdatedAt:   category.UpdatedAt,
+			Version:     category.Version,
 		})
 	}
 
-	return responses, total, nil
+	return responses, info, nil
 }
 
 // UpdateComponentCategory updates an existing component category
-func (ccs *ComponentCategoryService) UpdateComponentCategory(id uint, req *utils.UpdateComponentCategoryRequest) (*utils.ComponentCategoryResponse, error) {
-	var category models.ComponentCategory
-
-	result := config.DB.First(&category, id)
-	if result.Error != nil {
-		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
-			return nil, errors.New("component category not found")
+func (ccs *ComponentCategoryService) UpdateComponentCategory(ctx context.Context, id uint, req *utils.UpdateComponentCategoryRequest) (*utils.ComponentCategoryResponse, error) {
+	category, err := ccs.repos.ComponentCategories.FindByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, repositories.ErrNotFound) {
+			return nil, utils.NotFound("component category")
 		}
-		return nil, result.Error
+		return nil, err
+	}
+
+	if err := checkVersion(ctx, "component category", category.Version); err != nil {
+		return nil, err
 	}
 
 	if req.Code != "" {
+		if err := ensureUnique(ctx, ccs.repos.ComponentCategories.CodeTaken, "code", req.Code, category.ID); err != nil {
+			return nil, err
+		}
 		category.Code = req.Code
 	}
 	if req.Name != "" {
@@ -125,10 +124,54 @@ func (ccs *ComponentCategoryService) UpdateComponentCategory(id uint, req *utils
 		category.Description = req.Description
 	}
 
-	if err := config.DB.Save(&category).Error; err != nil {
+	if err := ccs.repos.ComponentCategories.Save(ctx, category); err != nil {
+		return nil, versionConflict(ctx, "component category", err)
+	}
+
+	return &utils.ComponentCategoryResponse{
+		ID:          category.ID,
+		Code:        category.Code,
+		Name:        category.Name,
+		Description: category.Description,
+		CreatedAt:   category.CreatedAt,
+		UpdatedAt:   category.UpdatedAt,
+		Version:     category.Version,
+	}, nil
+}
+
+// PatchComponentCategory applies a JSON Merge Patch to a component category
+// Unlike UpdateComponentCategory, it tells absent fields from null ones, so optional fields can be cleared.
+func (ccs *ComponentCategoryService) PatchComponentCategory(ctx context.Context, id uint, patch []byte) (*utils.ComponentCategoryResponse, error) {
+	category, err := ccs.repos.ComponentCategories.FindByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, repositories.ErrNotFound) {
+			return nil, utils.NotFound("component category")
+		}
+		return nil, err
+	}
+
+	if err := checkVersion(ctx, "component category", category.Version); err != nil {
+		return nil, err
+	}
+
+	req := utils.PatchComponentCategoryRequest{Code: category.Code, Name: category.Name, Description: category.Description}
+	if err := utils.ApplyMergePatch(&req, patch); err != nil {
 		return nil, err
 	}
 
+	if req.Code != category.Code {
+		if err := ensureUnique(ctx, ccs.repos.ComponentCategories.CodeTaken, "code", req.Code, category.ID); err != nil {
+			return nil, err
+		}
+	}
+	category.Code = req.Code
+	category.Name = req.Name
+	category.Description = req.Description
+
+	if err := ccs.repos.ComponentCategories.Save(ctx, category); err != nil {
+		return nil, versionConflict(ctx, "component category", err)
+	}
+
 	return &utils.ComponentCategoryResponse{
 		ID:          category.ID,
 		Code:        category.Code,
@@ -136,36 +179,63 @@ func (ccs *ComponentCategoryService) UpdateComponentCategory(id uint, req *utils
 		Description: category.Description,
 		CreatedAt:   category.CreatedAt,
 		UpdatedAt:   category.UpdatedAt,
+		Version:     category.Version,
 	}, nil
 }
 
-// DeleteComponentCategory performs a soft delete of a component category
-func (ccs *ComponentCategoryService) DeleteComponentCategory(id uint) error {
-	var category models.ComponentCategory
+// GetComponentCategoryDeleteImpact counts what deleting a component category would take with it
+func (ccs *ComponentCategoryService) GetComponentCategoryDeleteImpact(ctx context.Context, id uint) (*utils.DeleteImpactResponse, error) {
+	if _, err := ccs.repos.ComponentCategories.FindByID(ctx, id); err != nil {
+		if errors.Is(err, repositories.ErrNotFound) {
+			return nil, utils.NotFound("component category")
+		}
+		return nil, err
+	}
+
+	impact, err := deleteImpact(ctx, ccs.repos, "component-categories", id)
+	return impact, err
+}
 
-	result := config.DB.First(&category, id)
-	if result.Error != nil {
-		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
-			return errors.New("component category not found")
+// DeleteComponentCategory performs a soft delete of a component category
+// Its dependents are deleted with it only when cascade is set.
+func (ccs *ComponentCategoryService) DeleteComponentCategory(ctx context.Context, id uint, cascade bool) error {
+	category, err := ccs.repos.ComponentCategories.FindByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, repositories.ErrNotFound) {
+			return utils.NotFound("component category")
 		}
-		return result.Error
+		return err
+	}
+
+	if err := checkVersion(ctx, "component category", category.Version); err != nil {
+		return err
 	}
 
-	result = config.DB.Delete(&category)
-	return result.Error
+	return cascadeDelete(ctx, ccs.repos, "component-categories", category.ID, category.Version, cascade)
 }
 
 // GetCategoryWithComponents retrieves a category with all its components
-func (ccs *ComponentCategoryService) GetCategoryWithComponents(id uint) (*models.ComponentCategory, error) {
-	var category models.ComponentCategory
-
-	result := config.DB.Preload("Components").First(&category, id)
-	if result.Error != nil {
-		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
-			return nil, errors.New("component category not found")
+func (ccs *ComponentCategoryService) GetCategoryWithComponents(ctx context.Context, id uint) (*models.ComponentCategory, error) {
+	category, err := ccs.repos.ComponentCategories.FindWithComponents(ctx, id)
+	if err != nil {
+		if errors.Is(err, repositories.ErrNotFound) {
+			return nil, utils.NotFound("component category")
 		}
-		return nil, result.Error
+		return nil, err
 	}
 
-	return &category, nil
+	return category, nil
+}
+
+// toComponentCategoryResponse converts a component category model into its response DTO
+func toComponentCategoryResponse(category *models.ComponentCategory) *utils.ComponentCategoryResponse {
+	return &utils.ComponentCategoryResponse{
+		ID:          category.ID,
+		Code:        category.Code,
+		Name:        category.Name,
+		Description: category.Description,
+		CreatedAt:   category.CreatedAt,
+		UpdatedAt:   category.UpdatedAt,
+		Version:     category.Version,
+	}
 }
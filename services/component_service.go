@@ -1,40 +1,43 @@
 package services
 
 import (
+	"context"
 	"errors"
-	"incident-report/config"
 	"incident-report/models"
+	"incident-report/repositories"
 	"incident-report/utils"
-
-	"gorm.io/gorm"
 )
 
 // ComponentService handles all component-related business logic
-type ComponentService struct{}
+type ComponentService struct {
+	repos *repositories.Repositories
+}
 
 // NewComponentService creates a new instance of ComponentService
-func NewComponentService() *ComponentService {
-	return &ComponentService{}
+func NewComponentService(repos *repositories.Repositories) *ComponentService {
+	return &ComponentService{repos: repos}
 }
 
 // CreateComponent creates a new component in the database
-func (cs *ComponentService) CreateComponent(req *utils.CreateComponentRequest) (*utils.ComponentResponse, error) {
+func (cs *ComponentService) CreateComponent(ctx context.Context, req *utils.CreateComponentRequest) (*utils.ComponentResponse, error) {
 	if req.CategoryID == 0 || req.Code == "" || req.Name == "" {
-		return nil, errors.New("category_id, code, and name are required")
+		return nil, utils.Validation("category_id, code, and name are required")
 	}
 
 	// Verify room exists if provided
 	if req.RoomID != nil {
-		var room models.Room
-		if err := config.DB.First(&room, *req.RoomID).Error; err != nil {
-			return nil, errors.New("room not found")
+		if _, err := cs.repos.Rooms.FindByID(ctx, *req.RoomID); err != nil {
+			return nil, utils.InvalidField("room_id", "room not found")
 		}
 	}
 
 	// Verify category exists
-	var category models.ComponentCategory
-	if err := config.DB.First(&category, req.CategoryID).Error; err != nil {
-		return nil, errors.New("component category not found")
+	if _, err := cs.repos.ComponentCategories.FindByID(ctx, req.CategoryID); err != nil {
+		return nil, utils.InvalidField("category_id", "component category not found")
+	}
+
+	if err := ensureUnique(ctx, cs.repos.Components.CodeTaken, "code", req.Code, 0); err != nil {
+		return nil, err
 	}
 
 	component := models.Component{
@@ -47,10 +50,10 @@ func (cs *ComponentService) CreateComponent(req *utils.CreateComponentRequest) (
 		ProcurementYear: req.ProcurementYear,
 	}
 
-	result := config.DB.Create(&component)
-	if result.Error != nil {
-		return nil, result.Error
+	if err := cs.repos.Components.Create(ctx, &component); err != nil {
+		return nil, err
 	}
+	invalidateHierarchyTree()
 
 	return &utils.ComponentResponse{
 		ID:              component.ID,
@@ -63,19 +66,18 @@ func (cs *ComponentService) CreateComponent(req *utils.CreateComponentRequest) (
 		ProcurementYear: component.ProcurementYear,
 		CreatedAt:       component.CreatedAt,
 		UpdatedAt:       component.UpdatedAt,
+		Version:         component.Version,
 	}, nil
 }
 
 // GetComponentByID retrieves a component by its ID
-func (cs *ComponentService) GetComponentByID(id uint) (*utils.ComponentResponse, error) {
-	var component models.Component
-
-	result := config.DB.First(&component, id)
-	if result.Error != nil {
-		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
-			return nil, errors.New("component not found")
+func (cs *ComponentService) GetComponentByID(ctx context.Context, id uint) (*utils.ComponentResponse, error) {
+	component, err := cs.repos.Components.FindByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, repositories.ErrNotFound) {
+			return nil, utils.NotFound("component")
 		}
-		return nil, result.Error
+		return nil, err
 	}
 
 	return &utils.ComponentResponse{
@@ -89,29 +91,19 @@ func (cs *ComponentService) GetComponentByID(id uint) (*utils.ComponentResponse,
 		ProcurementYear: component.ProcurementYear,
 		CreatedAt:       component.CreatedAt,
 		UpdatedAt:       component.UpdatedAt,
+		Version:         component.Version,
 	}, nil
 }
 
 // GetComponentsByRoomID retrieves all components in a room
-func (cs *ComponentService) GetComponentsByRoomID(roomID uint, page, pageSize int) ([]utils.ComponentResponse, int64, error) {
-	if page <= 0 {
-		page = 1
-	}
-	if pageSize <= 0 || pageSize > 100 {
-		pageSize = 10
-	}
-
-	var components []models.Component
-	var total int64
-
-	if err := config.DB.Where("room_id = ?", roomID).Count(&total).Error; err != nil {
-		return nil, 0, err
+func (cs *ComponentService) GetComponentsByRoomID(ctx context.Context, roomID uint, pagination *utils.PaginationQuery) ([]utils.ComponentResponse, utils.PageInfo, error) {
+	page, err := pageOf(pagination)
+	if err != nil {
+		return nil, utils.PageInfo{}, err
 	}
-
-	offset := (page - 1) * pageSize
-	result := config.DB.Where("room_id = ?", roomID).Offset(offset).Limit(pageSize).Find(&components)
-	if result.Error != nil {
-		return nil, 0, result.Error
+	components, info, err := cs.repos.Components.ListByRoom(ctx, roomID, page)
+	if err != nil {
+		return nil, utils.PageInfo{}, err
 	}
 
 	var responses []utils.ComponentResponse
@@ -127,32 +119,22 @@ func (cs *ComponentService) GetComponentsByRoomID(roomID uint, page, pageSize in
 			ProcurementYear: component.ProcurementYear,
 			CreatedAt:       component.CreatedAt,
 			UpdatedAt:       component.UpdatedAt,
+			Version:         component.Version,
 		})
 	}
 
-	return responses, total, nil
+	return responses, info, nil
 }
 
 // GetComponentsByCategoryID retrieves all components in a category
-func (cs *ComponentService) GetComponentsByCategoryID(categoryID uint, page, pageSize int) ([]utils.ComponentResponse, int64, error) {
-	if page <= 0 {
-		page = 1
-	}
-	if pageSize <= 0 || pageSize > 100 {
-		pageSize = 10
-	}
-
-	var components []models.Component
-	var total int64
-
-	if err := config.DB.Where("category_id = ?", categoryID).Count(&total).Error; err != nil {
-		return nil, 0, err
+func (cs *ComponentService) GetComponentsByCategoryID(ctx context.Context, categoryID uint, pagination *utils.PaginationQuery) ([]utils.ComponentResponse, utils.PageInfo, error) {
+	page, err := pageOf(pagination)
+	if err != nil {
+		return nil, utils.PageInfo{}, err
 	}
-
-	offset := (page - 1) * pageSize
-	result := config.DB.Where("category_id = ?", categoryID).Offset(offset).Limit(pageSize).Find(&components)
-	if result.Error != nil {
-		return nil, 0, result.Error
+	components, info, err := cs.repos.Components.ListByCategory(ctx, categoryID, page)
+	if err != nil {
+		return nil, utils.PageInfo{}, err
 	}
 
 	var responses []utils.ComponentResponse
@@ -168,32 +150,22 @@ func (cs *ComponentService) GetComponentsByCategoryID(categoryID uint, page, pag
 			ProcurementYear: component.ProcurementYear,
 			CreatedAt:       component.CreatedAt,
 			UpdatedAt:       component.UpdatedAt,
+			Version:         component.Version,
 		})
 	}
 
-	return responses, total, nil
+	return responses, info, nil
 }
 
-// GetAllComponents retrieves all components with pagination and nested building, floor, and room info
-func (cs *ComponentService) GetAllComponents(page, pageSize int) ([]utils.ComponentResponse, int64, error) {
-	if page <= 0 {
-		page = 1
+// GetAllComponents retrieves all components matching the filter with pagination and nested building, floor, and room info
+func (cs *ComponentService) GetAllComponents(ctx context.Context, pagination *utils.PaginationQuery, filter *utils.ComponentFilterQuery) ([]utils.ComponentResponse, utils.PageInfo, error) {
+	page, err := pageOf(pagination)
+	if err != nil {
+		return nil, utils.PageInfo{}, err
 	}
-	if pageSize <= 0 || pageSize > 100 {
-		pageSize = 10
-	}
-
-	var components []models.Component
-	var total int64
-
-	if err := config.DB.Model(&models.Component{}).Count(&total).Error; err != nil {
-		return nil, 0, err
-	}
-
-	offset := (page - 1) * pageSize
-	result := config.DB.Preload("Room.Floor.Building").Offset(offset).Limit(pageSize).Find(&components)
-	if result.Error != nil {
-		return nil, 0, result.Error
+	components, info, err := cs.repos.Components.List(ctx, filter, page)
+	if err != nil {
+		return nil, utils.PageInfo{}, err
 	}
 
 	var responses []utils.ComponentResponse
@@ -209,56 +181,35 @@ func (cs *ComponentService) GetAllComponents(page, pageSize int) ([]utils.Compon
 			ProcurementYear: component.ProcurementYear,
 			CreatedAt:       component.CreatedAt,
 			UpdatedAt:       component.UpdatedAt,
+			Version:         component.Version,
 		}
 
-		// if component.Room != nil && component.Room.ID != 0 {
-		// 	response.Room = &utils.RoomResponse{
-		// 		ID:        component.Room.ID,
-		// 		FloorID:   component.Room.FloorID,
-		// 		Code:      component.Room.Code,
-		// 		Name:      component.Room.Name,
-		// 		CreatedAt: component.Room.CreatedAt,
-		// 		UpdatedAt: component.Room.UpdatedAt,
-		// 	}
-		// 	if component.Room.Floor != nil && component.Room.Floor.ID != 0 {
-		// 		response.Room.Floor = &utils.FloorResponse{
-		// 			ID:          component.Room.Floor.ID,
-		// 			BuildingID:  component.Room.Floor.BuildingID,
-		// 			FloorNumber: component.Room.Floor.Number,
-		// 			Name:        component.Room.Floor.Name,
-		// 			CreatedAt:   component.Room.Floor.CreatedAt,
-		// 			UpdatedAt:   component.Room.Floor.UpdatedAt,
-		// 		}
-		// 		if component.Room.Floor.Building != nil && component.Room.Floor.Building.ID != 0 {
-		// 			response.Room.Floor.Building = &utils.BuildingResponse{
-		// 				ID:        component.Room.Floor.Building.ID,
-		// 				Code:      component.Room.Floor.Building.Code,
-		// 				Name:      component.Room.Floor.Building.Name,
-		// 				Location:  component.Room.Floor.Building.Location,
-		// 				CreatedAt: component.Room.Floor.Building.CreatedAt,
-		// 				UpdatedAt: component.Room.Floor.Building.UpdatedAt,
-		// 			}
-		// 		}
-		// 	}
-		// }
+		if component.Room != nil && component.Room.ID != 0 {
+			response.Room = toRoomResponse(component.Room)
+		}
 
 		responses = append(responses, response)
 	}
 
-	return responses, total, nil
+	return responses, info, nil
 }
-func (cs *ComponentService) UpdateComponent(id uint, req *utils.UpdateComponentRequest) (*utils.ComponentResponse, error) {
-	var component models.Component
-
-	result := config.DB.First(&component, id)
-	if result.Error != nil {
-		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
-			return nil, errors.New("component not found")
+func (cs *ComponentService) UpdateComponent(ctx context.Context, id uint, req *utils.UpdateComponentRequest) (*utils.ComponentResponse, error) {
+	component, err := cs.repos.Components.FindByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, repositories.ErrNotFound) {
+			return nil, utils.NotFound("component")
 		}
-		return nil, result.Error
+		return nil, err
+	}
+
+	if err := checkVersion(ctx, "component", component.Version); err != nil {
+		return nil, err
 	}
 
 	if req.Code != "" {
+		if err := ensureUnique(ctx, cs.repos.Components.CodeTaken, "code", req.Code, component.ID); err != nil {
+			return nil, err
+		}
 		component.Code = req.Code
 	}
 	if req.Name != "" {
@@ -274,10 +225,68 @@ func (cs *ComponentService) UpdateComponent(id uint, req *utils.UpdateComponentR
 		component.ProcurementYear = req.ProcurementYear
 	}
 
-	if err := config.DB.Save(&component).Error; err != nil {
+	if err := cs.repos.Components.Save(ctx, component); err != nil {
+		return nil, versionConflict(ctx, "component", err)
+	}
+	invalidateHierarchyTree()
+
+	return &utils.ComponentResponse{
+		ID:              component.ID,
+		RoomID:          component.RoomID,
+		CategoryID:      component.CategoryID,
+		Code:            component.Code,
+		Name:            component.Name,
+		Brand:           component.Brand,
+		Specification:   component.Specification,
+		ProcurementYear: component.ProcurementYear,
+		CreatedAt:       component.CreatedAt,
+		UpdatedAt:       component.UpdatedAt,
+		Version:         component.Version,
+	}, nil
+}
+
+// PatchComponent applies a JSON Merge Patch to a component
+// Unlike UpdateComponent, it tells absent fields from null ones, so optional fields can be cleared.
+func (cs *ComponentService) PatchComponent(ctx context.Context, id uint, patch []byte) (*utils.ComponentResponse, error) {
+	component, err := cs.repos.Components.FindByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, repositories.ErrNotFound) {
+			return nil, utils.NotFound("component")
+		}
 		return nil, err
 	}
 
+	if err := checkVersion(ctx, "component", component.Version); err != nil {
+		return nil, err
+	}
+
+	req := utils.PatchComponentRequest{
+		Code:            component.Code,
+		Name:            component.Name,
+		Brand:           component.Brand,
+		Specification:   component.Specification,
+		ProcurementYear: component.ProcurementYear,
+	}
+	if err := utils.ApplyMergePatch(&req, patch); err != nil {
+		return nil, err
+	}
+
+	if req.Code != component.Code {
+		if err := ensureUnique(ctx, cs.repos.Components.CodeTaken, "code", req.Code, component.ID); err != nil {
+			return nil, err
+		}
+	}
+	component.Code = req.Code
+	component.Name = req.Name
+	component.Brand = req.Brand
+	component.Specification = req.Specification
+	component.ProcurementYear = req.ProcurementYear
+
+	if err := cs.repos.Components.Save(ctx, component); err != nil {
+		return nil, versionConflict(ctx, "component", err)
+	}
+	invalidateHierarchyTree()
+
 	return &utils.ComponentResponse{
 		ID:              component.ID,
 		RoomID:          component.RoomID,
@@ -289,47 +298,56 @@ func (cs *ComponentService) UpdateComponent(id uint, req *utils.UpdateComponentR
 		ProcurementYear: component.ProcurementYear,
 		CreatedAt:       component.CreatedAt,
 		UpdatedAt:       component.UpdatedAt,
+		Version:         component.Version,
 	}, nil
 }
 
 // DeleteComponent performs a soft delete of a component
-func (cs *ComponentService) DeleteComponent(id uint) error {
-	var component models.Component
-
-	result := config.DB.First(&component, id)
-	if result.Error != nil {
-		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
-			return errors.New("component not found")
+func (cs *ComponentService) DeleteComponent(ctx context.Context, id uint) error {
+	component, err := cs.repos.Components.FindByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, repositories.ErrNotFound) {
+			return utils.NotFound("component")
 		}
-		return result.Error
+		return err
+	}
+
+	if err := checkVersion(ctx, "component", component.Version); err != nil {
+		return err
+	}
+
+	if err := cs.repos.Components.Delete(ctx, component); err != nil {
+		return versionConflict(ctx, "component", err)
 	}
 
-	result = config.DB.Delete(&component)
-	return result.Error
+	invalidateHierarchyTree()
+	return nil
 }
 
 // AssignRoomToComponent assigns a room to an existing component
-func (cs *ComponentService) AssignRoomToComponent(componentID uint, req *utils.AssignRoomRequest) (*utils.ComponentResponse, error) {
-	var component models.Component
-
-	result := config.DB.First(&component, componentID)
-	if result.Error != nil {
-		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
-			return nil, errors.New("component not found")
+func (cs *ComponentService) AssignRoomToComponent(ctx context.Context, componentID uint, req *utils.AssignRoomRequest) (*utils.ComponentResponse, error) {
+	component, err := cs.repos.Components.FindByID(ctx, componentID)
+	if err != nil {
+		if errors.Is(err, repositories.ErrNotFound) {
+			return nil, utils.NotFound("component")
 		}
-		return nil, result.Error
+		return nil, err
+	}
+
+	if err := checkVersion(ctx, "component", component.Version); err != nil {
+		return nil, err
 	}
 
 	// Verify room exists
-	var room models.Room
-	if err := config.DB.First(&room, req.RoomID).Error; err != nil {
-		return nil, errors.New("room not found")
+	if _, err := cs.repos.Rooms.FindByID(ctx, req.RoomID); err != nil {
+		return nil, utils.InvalidField("room_id", "room not found")
 	}
 
 	component.RoomID = &req.RoomID
-	if err := config.DB.Save(&component).Error; err != nil {
-		return nil, err
+	if err := cs.repos.Components.Save(ctx, component); err != nil {
+		return nil, versionConflict(ctx, "component", err)
 	}
+	invalidateHierarchyTree()
 
 	return &utils.ComponentResponse{
 		ID:              component.ID,
@@ -342,5 +360,44 @@ func (cs *ComponentService) AssignRoomToComponent(componentID uint, req *utils.A
 		ProcurementYear: component.ProcurementYear,
 		CreatedAt:       component.CreatedAt,
 		UpdatedAt:       component.UpdatedAt,
+		Version:         component.Version,
 	}, nil
 }
+
+// BulkMoveComponents moves many components to one room; see runBulk for the modes
+func (cs *ComponentService) BulkMoveComponents(ctx context.Context, req *utils.BulkMoveComponentsRequest) (*utils.BulkResponse, error) {
+	if _, err := cs.repos.Rooms.FindByID(ctx, req.RoomID); err != nil {
+		if errors.Is(err, repositories.ErrNotFound) {
+			return nil, utils.InvalidField("room_id", "room not found")
+		}
+		return nil, err
+	}
+
+	move := &utils.AssignRoomRequest{RoomID: req.RoomID}
+	return runBulk(ctx, cs.repos, req.Mode, uniqueIDs(req.IDs), func(tx *repositories.Repositories, id uint) error {
+		_, err := NewComponentService(tx).AssignRoomToComponent(ctx, id, move)
+		return err
+	})
+}
+
+// toComponentResponse converts a component model into its response DTO
+// The room, with its floor and building, is nested when it was loaded with the component.
+func toComponentResponse(component *models.Component) *utils.ComponentResponse {
+	response := &utils.ComponentResponse{
+		ID:              component.ID,
+		RoomID:          component.RoomID,
+		CategoryID:      component.CategoryID,
+		Code:            component.Code,
+		Name:            component.Name,
+		Brand:           component.Brand,
+		Specification:   component.Specification,
+		ProcurementYear: component.ProcurementYear,
+		CreatedAt:       component.CreatedAt,
+		UpdatedAt:       component.UpdatedAt,
+		Version:         component.Version,
+	}
+	if component.Room != nil && component.Room.ID != 0 {
+		response.Room = toRoomResponse(component.Room)
+	}
+	return response
+}
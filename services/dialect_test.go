@@ -0,0 +1,205 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"incident-report/repositories"
+	"incident-report/utils"
+)
+
+// seedReports creates a small hierarchy with tagged reports in every status
+func seedReports(t *testing.T, repos *repositories.Repositories) (buildingID, componentID, categoryID uint) {
+	t.Helper()
+	ctx := context.Background()
+
+	user, err := NewUserService(repos).CreateUser(ctx, &utils.CreateUserRequest{Name: "Tech One", Email: "tech@example.com"})
+	if err != nil {
+		t.Fatalf("create user: %v", err)
+	}
+	building, err := NewBuildingService(repos).CreateBuilding(ctx, &utils.CreateBuildingRequest{Code: "B1", Name: "Main Building"})
+	if err != nil {
+		t.Fatalf("create building: %v", err)
+	}
+	floor, err := NewFloorService(repos).CreateFloor(ctx, &utils.CreateFloorRequest{BuildingID: building.ID, FloorNumber: 1, Name: "Ground"})
+	if err != nil {
+		t.Fatalf("create floor: %v", err)
+	}
+	room, err := NewRoomService(repos).CreateRoom(ctx, &utils.CreateRoomRequest{FloorID: floor.ID, Code: "R101", Name: "Lab 101"})
+	if err != nil {
+		t.Fatalf("create room: %v", err)
+	}
+	category, err := NewComponentCategoryService(repos).CreateComponentCategory(ctx, &utils.CreateComponentCategoryRequest{Code: "PRJ", Name: "Projector"})
+	if err != nil {
+		t.Fatalf("create category: %v", err)
+	}
+	component, err := NewComponentService(repos).CreateComponent(ctx, &utils.CreateComponentRequest{
+		RoomID: &room.ID, CategoryID: category.ID, Code: "PRJ-1", Name: "Projector 1", ProcurementYear: 2019,
+	})
+	if err != nil {
+		t.Fatalf("create component: %v", err)
+	}
+
+	tagService := NewTagService(repos)
+	electrical, err := tagService.CreateTag(ctx, &utils.CreateTagRequest{Name: "electrical"})
+	if err != nil {
+		t.Fatalf("create tag: %v", err)
+	}
+	urgent, err := tagService.CreateTag(ctx, &utils.CreateTagRequest{Name: "urgent", Color: "#FF0000"})
+	if err != nil {
+		t.Fatalf("create tag: %v", err)
+	}
+
+	reportService := NewReportService(repos)
+	for i, status := range []string{"PENDING", "IN_PROGRESS", "COMPLETED"} {
+		report, err := reportService.CreateReport(ctx, &utils.CreateReportRequest{
+			Name: "Broken projector", RoomID: room.ID, UserID: &user.ID, ComponentID: component.ID,
+			Status: status, RepairCost: float64(i) * 10,
+		})
+		if err != nil {
+			t.Fatalf("create report: %v", err)
+		}
+		tagIDs := []uint{electrical.ID}
+		if i > 0 {
+			tagIDs = append(tagIDs, urgent.ID)
+		}
+		if _, err := reportService.AddTagsToReport(ctx, report.ID, tagIDs); err != nil {
+			t.Fatalf("tag report: %v", err)
+		}
+	}
+
+	return building.ID, component.ID, category.ID
+}
+
+// TestQueriesRunOnDialect runs every hand-written SQL query against the test database
+func TestQueriesRunOnDialect(t *testing.T) {
+	db := setupTestDB(t)
+	repos := repositories.New(db)
+	ctx := context.Background()
+	buildingID, componentID, categoryID := seedReports(t, repos)
+
+	today := time.Now().Format("2006-01-02")
+	scope := utils.AnalyticsQuery{From: today, To: today, BuildingID: buildingID}
+
+	t.Run("report tag filter", func(t *testing.T) {
+		_, info, err := NewReportService(repos).GetAllReports(ctx, nil, &utils.ReportFilterQuery{Tags: "electrical,urgent", TagMatch: "all"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if *info.Total != 2 {
+			t.Errorf("reports with all tags = %d, want 2", *info.Total)
+		}
+		_, info, err = NewReportService(repos).GetAllReports(ctx, nil, &utils.ReportFilterQuery{Tags: "electrical,urgent"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if *info.Total != 3 {
+			t.Errorf("reports with any tag = %d, want 3", *info.Total)
+		}
+	})
+
+	analytics := NewAnalyticsService(db)
+
+	t.Run("volume", func(t *testing.T) {
+		for _, interval := range []string{IntervalDay, IntervalWeek} {
+			volume, err := analytics.GetVolume(ctx, &utils.VolumeQuery{AnalyticsQuery: scope, Interval: interval})
+			if err != nil {
+				t.Fatalf("%s: %v", interval, err)
+			}
+			if len(volume) != 1 || volume[0].Opened != 3 || volume[0].Closed != 1 {
+				t.Errorf("%s volume = %+v, want one bucket with 3 opened and 1 closed", interval, volume)
+			}
+		}
+	})
+
+	t.Run("resolution time", func(t *testing.T) {
+		resolution, err := analytics.GetResolutionTime(ctx, &scope)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if resolution.Resolved != 1 {
+			t.Errorf("resolved = %d, want 1", resolution.Resolved)
+		}
+	})
+
+	t.Run("rankings", func(t *testing.T) {
+		top := &utils.TopNQuery{AnalyticsQuery: scope}
+		components, err := analytics.GetTopComponents(ctx, top)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(components) != 1 || components[0].Reports != 3 {
+			t.Errorf("top components = %+v", components)
+		}
+		if _, err := analytics.GetTopCategories(ctx, top); err != nil {
+			t.Fatal(err)
+		}
+		buildings, err := analytics.GetBuildingHotspots(ctx, top)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(buildings) != 1 || buildings[0].OpenReports != 2 {
+			t.Errorf("building hotspots = %+v", buildings)
+		}
+		if _, err := analytics.GetFloorHotspots(ctx, top); err != nil {
+			t.Fatal(err)
+		}
+		technicians, err := analytics.GetTechnicianThroughput(ctx, &scope)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(technicians) != 1 || technicians[0].Completed != 1 {
+			t.Errorf("technicians = %+v", technicians)
+		}
+		tags, err := analytics.GetTagCounts(ctx, &scope)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(tags) != 2 || tags[0].Reports != 3 {
+			t.Errorf("tag counts = %+v", tags)
+		}
+	})
+
+	t.Run("reliability", func(t *testing.T) {
+		reliability := NewReliabilityService(db)
+		component, err := reliability.GetComponentReliability(ctx, componentID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if component.Failures != 3 || component.RepairCost != 30 {
+			t.Errorf("component reliability = %+v", component)
+		}
+		if _, err := reliability.GetCategoryReliability(ctx, categoryID); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := reliability.GetReplacementCandidates(ctx, &utils.ReplacementCandidatesQuery{}); err != nil {
+			t.Fatal(err)
+		}
+	})
+
+	t.Run("tree", func(t *testing.T) {
+		tree, err := NewTreeService(db).GetTree(ctx, TreeOptions{Depth: TreeDepthRooms, IncludeComponentCount: true, IncludeOpenReportCount: true})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(tree) != 1 || *tree[0].OpenReportCount != 2 || *tree[0].ComponentCount != 1 {
+			t.Errorf("tree = %+v", tree)
+		}
+	})
+
+	t.Run("exports", func(t *testing.T) {
+		exports := NewExportService(db)
+		var buf bytes.Buffer
+		if err := exports.ExportReports(ctx, &buf, utils.ExportFormatCSV, &utils.ReportFilterQuery{Tags: "urgent"}); err != nil {
+			t.Fatal(err)
+		}
+		if err := exports.ExportComponents(ctx, &buf, utils.ExportFormatXLSX, &utils.ComponentFilterQuery{}); err != nil {
+			t.Fatal(err)
+		}
+		if err := exports.ExportReportPDF(ctx, &buf, 1); err != nil {
+			t.Fatal(err)
+		}
+	})
+}
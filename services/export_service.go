@@ -0,0 +1,551 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"incident-report/models"
+	"incident-report/repositories"
+	"incident-report/utils"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-pdf/fpdf"
+	"github.com/xuri/excelize/v2"
+	"gorm.io/gorm"
+)
+
+// ExportService renders reports and components as CSV, XLSX and PDF files
+// Rows are streamed from the database cursor so large result sets are never loaded at once.
+// PDF lists are the exception: they are assembled in memory, so they are capped at pdfExportRowLimit.
+type ExportService struct {
+	db *gorm.DB
+}
+
+// NewExportService creates a new instance of ExportService
+func NewExportService(db *gorm.DB) *ExportService {
+	return &ExportService{db: db}
+}
+
+// reportExportColumns lists the header row of a report export
+var reportExportColumns = []string{
+	"ID", "Name", "Status", "Building", "Floor", "Room", "Component Code", "Component", "Assignee", "Created At", "Updated At",
+}
+
+// componentExportColumns lists the header row of a component export
+var componentExportColumns = []string{
+	"ID", "Code", "Name", "Category", "Brand", "Specification", "Procurement Year", "Building", "Floor", "Room", "Created At",
+}
+
+// reportExportRow is the flattened shape of a report joined with its location, component and assignee
+type reportExportRow struct {
+	ID            uint
+	Name          string
+	Status        string
+	BuildingName  *string
+	FloorName     *string
+	RoomCode      *string
+	ComponentCode *string
+	ComponentName *string
+	AssigneeName  *string
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+}
+
+// componentExportRow is the flattened shape of a component joined with its category and location
+type componentExportRow struct {
+	ID              uint
+	Code            string
+	Name            string
+	CategoryName    *string
+	Brand           string
+	Specification   string
+	ProcurementYear int
+	BuildingName    *string
+	FloorName       *string
+	RoomCode        *string
+	CreatedAt       int64
+}
+
+// pdfExportRowLimit is the most rows a PDF list export renders, see utils.MaxPDFExportRows
+var pdfExportRowLimit = utils.MaxPDFExportRows
+
+// tableWriter is implemented by every export format
+type tableWriter interface {
+	WriteRow(values []string) error
+	Close() error
+}
+
+// newTableWriter creates a writer for the requested format and writes the header row
+func newTableWriter(w io.Writer, format string, title string, header []string) (tableWriter, error) {
+	var tw tableWriter
+	switch format {
+	case utils.ExportFormatCSV:
+		tw = &csvTableWriter{writer: csv.NewWriter(w)}
+	case utils.ExportFormatXLSX:
+		xw, err := newXLSXTableWriter(w, title)
+		if err != nil {
+			return nil, err
+		}
+		tw = xw
+	case utils.ExportFormatPDF:
+		tw = newPDFTableWriter(w, title, len(header))
+	default:
+		return nil, utils.InvalidField("format", "unsupported export format: "+format)
+	}
+
+	if err := tw.WriteRow(header); err != nil {
+		return nil, err
+	}
+	return tw, nil
+}
+
+// ExportReports writes all reports matching the filter in the given format
+func (es *ExportService) ExportReports(ctx context.Context, w io.Writer, format string, filter *utils.ReportFilterQuery) error {
+	query := repositories.ApplyReportFilter(es.db.WithContext(ctx).Model(&models.Report{}), filter).
+		Select("reports.id, reports.name, reports.status, " +
+			"buildings.name AS building_name, floors.name AS floor_name, rooms.code AS room_code, " +
+			"components.code AS component_code, components.name AS component_name, " +
+			"users.name AS assignee_name, reports.created_at, reports.updated_at").
+		Joins("LEFT JOIN rooms ON rooms.id = reports.room_id").
+		Joins("LEFT JOIN floors ON floors.id = rooms.floor_id").
+		Joins("LEFT JOIN buildings ON buildings.id = floors.building_id").
+		Joins("LEFT JOIN components ON components.id = reports.component_id").
+		Joins("LEFT JOIN users ON users.id = reports.user_id").
+		Order("reports.id")
+
+	tw, err := newTableWriter(w, format, "Reports", reportExportColumns)
+	if err != nil {
+		return err
+	}
+
+	err = streamRows(query, func(db *gorm.DB, rows *sql.Rows) error {
+		var row reportExportRow
+		if err := db.ScanRows(rows, &row); err != nil {
+			return err
+		}
+		return tw.WriteRow([]string{
+			strconv.FormatUint(uint64(row.ID), 10),
+			row.Name,
+			row.Status,
+			stringValue(row.BuildingName),
+			stringValue(row.FloorName),
+			stringValue(row.RoomCode),
+			stringValue(row.ComponentCode),
+			stringValue(row.ComponentName),
+			stringValue(row.AssigneeName),
+			row.CreatedAt.Format(time.RFC3339),
+			row.UpdatedAt.Format(time.RFC3339),
+		})
+	})
+	if err != nil {
+		return err
+	}
+
+	return tw.Close()
+}
+
+// ExportComponents writes all components matching the filter in the given format
+func (es *ExportService) ExportComponents(ctx context.Context, w io.Writer, format string, filter *utils.ComponentFilterQuery) error {
+	query := repositories.ApplyComponentFilter(es.db.WithContext(ctx).Model(&models.Component{}), filter).
+		Select("components.id, components.code, components.name, component_categories.name AS category_name, " +
+			"components.brand, components.specification, components.procurement_year, " +
+			"buildings.name AS building_name, floors.name AS floor_name, rooms.code AS room_code, components.created_at").
+		Joins("LEFT JOIN component_categories ON component_categories.id = components.category_id").
+		Joins("LEFT JOIN rooms ON rooms.id = components.room_id").
+		Joins("LEFT JOIN floors ON floors.id = rooms.floor_id").
+		Joins("LEFT JOIN buildings ON buildings.id = floors.building_id").
+		Order("components.id")
+
+	tw, err := newTableWriter(w, format, "Components", componentExportColumns)
+	if err != nil {
+		return err
+	}
+
+	err = streamRows(query, func(db *gorm.DB, rows *sql.Rows) error {
+		var row componentExportRow
+		if err := db.ScanRows(rows, &row); err != nil {
+			return err
+		}
+		procurementYear := ""
+		if row.ProcurementYear != 0 {
+			procurementYear = strconv.Itoa(row.ProcurementYear)
+		}
+		return tw.WriteRow([]string{
+			strconv.FormatUint(uint64(row.ID), 10),
+			row.Code,
+			row.Name,
+			stringValue(row.CategoryName),
+			row.Brand,
+			row.Specification,
+			procurementYear,
+			stringValue(row.BuildingName),
+			stringValue(row.FloorName),
+			stringValue(row.RoomCode),
+			time.Unix(row.CreatedAt, 0).Format(time.RFC3339),
+		})
+	})
+	if err != nil {
+		return err
+	}
+
+	return tw.Close()
+}
+
+// ExportReportPDF writes a printable summary of a single report
+// The summary contains the location breadcrumb, the affected component, the current state and the
+// timeline of status and assignee changes read from the audit log. Reports have no comments to print.
+func (es *ExportService) ExportReportPDF(ctx context.Context, w io.Writer, id uint) error {
+	var report models.Report
+	result := es.db.WithContext(ctx).Preload("Room.Floor.Building").Preload("Component.Category").Preload("User").First(&report, id)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return utils.NotFound("report")
+		}
+		return result.Error
+	}
+	timeline, err := es.reportTimeline(ctx, report.ID)
+	if err != nil {
+		return err
+	}
+
+	pdf := fpdf.New("P", "mm", "A4", "")
+	tr := pdf.UnicodeTranslatorFromDescriptor("")
+	pdf.AddPage()
+
+	pdf.SetFont("Helvetica", "B", 16)
+	pdf.MultiCell(0, 8, tr(fmt.Sprintf("Report #%d: %s", report.ID, report.Name)), "", "L", false)
+	pdf.Ln(2)
+
+	section := func(title string, lines [][2]string) {
+		pdf.SetFont("Helvetica", "B", 12)
+		pdf.CellFormat(0, 8, tr(title), "B", 1, "L", false, 0, "")
+		pdf.Ln(1)
+		for _, line := range lines {
+			pdf.SetFont("Helvetica", "B", 10)
+			pdf.CellFormat(45, 6, tr(line[0]), "", 0, "L", false, 0, "")
+			pdf.SetFont("Helvetica", "", 10)
+			pdf.MultiCell(0, 6, tr(line[1]), "", "L", false)
+		}
+		pdf.Ln(3)
+	}
+
+	assignee := "Unassigned"
+	if report.User != nil {
+		assignee = fmt.Sprintf("%s <%s>", report.User.Name, report.User.Email)
+	}
+
+	section("Location", [][2]string{
+		{"Breadcrumb", fmt.Sprintf("%s > %s > %s",
+			report.Room.Floor.Building.Name, report.Room.Floor.Name, report.Room.Name)},
+		{"Building", fmt.Sprintf("%s (%s)", report.Room.Floor.Building.Name, report.Room.Floor.Building.Code)},
+		{"Floor", fmt.Sprintf("%s (no. %d)", report.Room.Floor.Name, report.Room.Floor.Number)},
+		{"Room", fmt.Sprintf("%s (%s)", report.Room.Name, report.Room.Code)},
+	})
+
+	procurementYear := "-"
+	if report.Component.ProcurementYear != 0 {
+		procurementYear = strconv.Itoa(report.Component.ProcurementYear)
+	}
+	section("Component", [][2]string{
+		{"Code", report.Component.Code},
+		{"Name", report.Component.Name},
+		{"Category", report.Component.Category.Name},
+		{"Brand", report.Component.Brand},
+		{"Specification", report.Component.Specification},
+		{"Procurement Year", procurementYear},
+	})
+
+	section("Current State", [][2]string{
+		{"Status", string(report.Status)},
+		{"Assignee", assignee},
+		{"Reported At", report.CreatedAt.Format(time.RFC1123)},
+		{"Last Updated", report.UpdatedAt.Format(time.RFC1123)},
+	})
+
+	pdf.SetFont("Helvetica", "B", 12)
+	pdf.CellFormat(0, 8, tr("Timeline"), "B", 1, "L", false, 0, "")
+	pdf.Ln(1)
+	pdf.SetFont("Helvetica", "", 10)
+	if len(timeline) == 0 {
+		pdf.MultiCell(0, 6, tr("No recorded changes"), "", "L", false)
+	}
+	for _, event := range timeline {
+		pdf.CellFormat(45, 6, tr(event.At.Format("2006-01-02 15:04")), "", 0, "L", false, 0, "")
+		pdf.CellFormat(40, 6, tr(event.Actor), "", 0, "L", false, 0, "")
+		pdf.MultiCell(0, 6, tr(event.Event), "", "L", false)
+	}
+
+	return pdf.Output(w)
+}
+
+// reportTimelineEvent is a line of the timeline of a report
+type reportTimelineEvent struct {
+	At    time.Time
+	Actor string
+	Event string
+}
+
+// reportTimeline reads the history of a report from the audit log, oldest first
+func (es *ExportService) reportTimeline(ctx context.Context, id uint) ([]reportTimelineEvent, error) {
+	var entries []models.AuditLog
+	err := es.db.WithContext(ctx).Where("entity_type = ? AND entity_id = ?", "reports", id).
+		Order("created_at, id").Find(&entries).Error
+	if err != nil {
+		return nil, err
+	}
+
+	changes := make([]map[string]auditChange, len(entries))
+	var userIDs []uint
+	for i, entry := range entries {
+		if err := json.Unmarshal([]byte(entry.Changes), &changes[i]); err != nil {
+			return nil, fmt.Errorf("decode audit log entry %d: %w", entry.ID, err)
+		}
+		if entry.ActorID != nil {
+			userIDs = append(userIDs, *entry.ActorID)
+		}
+		if id, ok := auditUserID(changes[i]["user_id"].After); ok {
+			userIDs = append(userIDs, id)
+		}
+	}
+
+	// Users that were deleted since still name the changes they made
+	names := map[uint]string{}
+	if len(userIDs) > 0 {
+		var users []models.User
+		if err := es.db.WithContext(ctx).Unscoped().Where("id IN ?", uniqueIDs(userIDs)).Find(&users).Error; err != nil {
+			return nil, err
+		}
+		for _, user := range users {
+			names[user.ID] = user.Name
+		}
+	}
+	userName := func(id uint) string {
+		if name, ok := names[id]; ok {
+			return name
+		}
+		return fmt.Sprintf("user #%d", id)
+	}
+
+	timeline := make([]reportTimelineEvent, len(entries))
+	for i, entry := range entries {
+		actor := "system"
+		if entry.ActorID != nil {
+			actor = userName(*entry.ActorID)
+		}
+		timeline[i] = reportTimelineEvent{At: entry.CreatedAt, Actor: actor, Event: describeReportChange(entry.Action, changes[i], userName)}
+	}
+	return timeline, nil
+}
+
+// auditChange is a column change of an audit log entry
+type auditChange struct {
+	Before any `json:"before"`
+	After  any `json:"after"`
+}
+
+// auditUserID reads a user ID out of an audit log value, which JSON decodes as a number
+func auditUserID(value any) (uint, bool) {
+	id, ok := value.(float64)
+	return uint(id), ok && id > 0
+}
+
+// describeReportChange summarizes an audit log entry of a report for its timeline
+// Status and assignee changes are spelled out; other columns are only named.
+func describeReportChange(action string, changes map[string]auditChange, userName func(uint) string) string {
+	switch action {
+	case models.AuditActionCreate:
+		event := fmt.Sprintf("Reported as %v", changes["status"].After)
+		if id, ok := auditUserID(changes["user_id"].After); ok {
+			event += ", assigned to " + userName(id)
+		}
+		return event
+	case models.AuditActionDelete:
+		return "Deleted"
+	case models.AuditActionRestore:
+		return "Restored"
+	case models.AuditActionPurge:
+		return "Purged"
+	}
+
+	var parts, other []string
+	if change, ok := changes["status"]; ok {
+		parts = append(parts, fmt.Sprintf("Status %v -> %v", change.Before, change.After))
+	}
+	if change, ok := changes["user_id"]; ok {
+		if id, assigned := auditUserID(change.After); assigned {
+			parts = append(parts, "Assigned to "+userName(id))
+		} else {
+			parts = append(parts, "Unassigned")
+		}
+	}
+	for column := range changes {
+		if column != "status" && column != "user_id" && column != "completed_at" {
+			other = append(other, column)
+		}
+	}
+	if len(other) > 0 {
+		sort.Strings(other)
+		parts = append(parts, "Updated "+strings.Join(other, ", "))
+	}
+	if len(parts) == 0 {
+		return "Updated"
+	}
+	return strings.Join(parts, "; ")
+}
+
+// streamRows iterates a query row by row and hands every row to fn
+func streamRows(query *gorm.DB, fn func(db *gorm.DB, rows *sql.Rows) error) error {
+	rows, err := query.Rows()
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		if err := fn(query, rows); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
+
+// stringValue dereferences a nullable string column
+func stringValue(value *string) string {
+	if value == nil {
+		return ""
+	}
+	return *value
+}
+
+// csvTableWriter writes rows as comma separated values
+type csvTableWriter struct {
+	writer *csv.Writer
+}
+
+func (cw *csvTableWriter) WriteRow(values []string) error {
+	return cw.writer.Write(values)
+}
+
+func (cw *csvTableWriter) Close() error {
+	cw.writer.Flush()
+	return cw.writer.Error()
+}
+
+// xlsxTableWriter writes rows through excelize's stream writer, which spills to disk instead of memory
+type xlsxTableWriter struct {
+	out    io.Writer
+	file   *excelize.File
+	stream *excelize.StreamWriter
+	row    int
+}
+
+func newXLSXTableWriter(w io.Writer, title string) (*xlsxTableWriter, error) {
+	file := excelize.NewFile()
+	if err := file.SetSheetName("Sheet1", title); err != nil {
+		return nil, err
+	}
+	stream, err := file.NewStreamWriter(title)
+	if err != nil {
+		return nil, err
+	}
+	return &xlsxTableWriter{out: w, file: file, stream: stream}, nil
+}
+
+func (xw *xlsxTableWriter) WriteRow(values []string) error {
+	xw.row++
+	cell, err := excelize.CoordinatesToCellName(1, xw.row)
+	if err != nil {
+		return err
+	}
+	cells := make([]interface{}, len(values))
+	for i, value := range values {
+		cells[i] = value
+	}
+	return xw.stream.SetRow(cell, cells)
+}
+
+func (xw *xlsxTableWriter) Close() error {
+	defer xw.file.Close()
+	if err := xw.stream.Flush(); err != nil {
+		return err
+	}
+	return xw.file.Write(xw.out)
+}
+
+// pdfTableWriter renders rows as a landscape table
+// Nothing is written to out before Close, so a table over the row limit fails before the response starts.
+type pdfTableWriter struct {
+	out        io.Writer
+	pdf        *fpdf.Fpdf
+	translate  func(string) string
+	cellWidth  float64
+	headerRow  []string
+	wroteFirst bool
+	rows       int
+}
+
+func newPDFTableWriter(w io.Writer, title string, columns int) *pdfTableWriter {
+	pdf := fpdf.New("L", "mm", "A4", "")
+	pdf.SetMargins(10, 10, 10)
+	pdf.SetAutoPageBreak(true, 10)
+	pdf.SetFillColor(230, 230, 230)
+	pw := &pdfTableWriter{
+		out:       w,
+		pdf:       pdf,
+		translate: pdf.UnicodeTranslatorFromDescriptor(""),
+	}
+
+	pageWidth, _ := pdf.GetPageSize()
+	left, _, right, _ := pdf.GetMargins()
+	pw.cellWidth = (pageWidth - left - right) / float64(columns)
+
+	// Repeat the title and header row on every page
+	pdf.SetHeaderFunc(func() {
+		pdf.SetFont("Helvetica", "B", 14)
+		pdf.CellFormat(0, 8, pw.translate(title), "", 1, "L", false, 0, "")
+		if pw.headerRow != nil {
+			pw.writeCells(pw.headerRow, true)
+		}
+	})
+	pdf.AddPage()
+	return pw
+}
+
+func (pw *pdfTableWriter) writeCells(values []string, bold bool) {
+	style := ""
+	if bold {
+		style = "B"
+	}
+	pw.pdf.SetFont("Helvetica", style, 7)
+	for _, value := range values {
+		pw.pdf.CellFormat(pw.cellWidth, 6, pw.translate(value), "1", 0, "L", bold, 0, "")
+	}
+	pw.pdf.Ln(-1)
+}
+
+func (pw *pdfTableWriter) WriteRow(values []string) error {
+	if !pw.wroteFirst {
+		pw.wroteFirst = true
+		pw.headerRow = values
+		pw.writeCells(values, true)
+		return nil
+	}
+	pw.rows++
+	if pw.rows > pdfExportRowLimit {
+		return utils.Unprocessable(fmt.Sprintf(
+			"a PDF export holds at most %d rows; narrow the filter or export as csv or xlsx", pdfExportRowLimit))
+	}
+	pw.writeCells(values, false)
+	return pw.pdf.Error()
+}
+
+func (pw *pdfTableWriter) Close() error {
+	return pw.pdf.Output(pw.out)
+}
@@ -0,0 +1,101 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+
+	"incident-report/models"
+	"incident-report/repositories"
+	"incident-report/utils"
+)
+
+func TestPDFExportRowLimit(t *testing.T) {
+	ctx := context.Background()
+	db := setupTestDB(t)
+	h := seedHierarchy(t, repositories.New(db))
+	exports := NewExportService(db)
+
+	previous := pdfExportRowLimit
+	pdfExportRowLimit = 1
+	t.Cleanup(func() { pdfExportRowLimit = previous })
+
+	var buf bytes.Buffer
+	if err := exports.ExportReports(ctx, &buf, utils.ExportFormatPDF, &utils.ReportFilterQuery{}); err != nil {
+		t.Fatalf("export at the limit: %v", err)
+	}
+
+	_, err := NewReportService(repositories.New(db)).CreateReport(ctx, &utils.CreateReportRequest{
+		Name: "Flickering projector", RoomID: h.room, ComponentID: h.component, Status: "PENDING",
+	})
+	if err != nil {
+		t.Fatalf("create report: %v", err)
+	}
+
+	buf.Reset()
+	err = exports.ExportReports(ctx, &buf, utils.ExportFormatPDF, &utils.ReportFilterQuery{})
+	if !errors.Is(err, utils.ErrUnprocessable) || utils.HTTPStatus(err) != 422 {
+		t.Fatalf("export over the limit = %v, want an unprocessable error", err)
+	}
+	if buf.Len() != 0 {
+		t.Errorf("export over the limit wrote %d bytes, want none", buf.Len())
+	}
+
+	if err := exports.ExportReports(ctx, &buf, utils.ExportFormatCSV, &utils.ReportFilterQuery{}); err != nil {
+		t.Errorf("csv export over the PDF limit: %v", err)
+	}
+}
+
+func TestReportTimeline(t *testing.T) {
+	ctx := context.Background()
+	db := setupTestDB(t)
+	repos := repositories.New(db)
+	h := seedHierarchy(t, repos)
+	reports := NewReportService(repos)
+
+	user, err := NewUserService(repos).CreateUser(ctx, &utils.CreateUserRequest{Name: "Dana", Email: "dana@example.com"})
+	if err != nil {
+		t.Fatalf("create user: %v", err)
+	}
+	asDana := utils.WithActor(ctx, utils.Actor{UserID: user.ID, Role: models.RoleTechnician})
+	if _, err := reports.AssignUserToReport(asDana, h.report, user.ID); err != nil {
+		t.Fatalf("assign: %v", err)
+	}
+	if _, err := reports.PatchReport(asDana, h.report, []byte(`{"status": "COMPLETED", "repair_cost": 40}`)); err != nil {
+		t.Fatalf("complete: %v", err)
+	}
+	if _, err := reports.PatchReport(ctx, h.report, []byte(`{"user_id": null}`)); err != nil {
+		t.Fatalf("unassign: %v", err)
+	}
+
+	timeline, err := NewExportService(db).reportTimeline(ctx, h.report)
+	if err != nil {
+		t.Fatalf("timeline: %v", err)
+	}
+	want := []reportTimelineEvent{
+		{Actor: "system", Event: "Reported as PENDING"},
+		{Actor: "Dana", Event: "Assigned to Dana"},
+		{Actor: "Dana", Event: "Status PENDING -> COMPLETED; Updated repair_cost"},
+		{Actor: "system", Event: "Unassigned"},
+	}
+	var got []reportTimelineEvent
+	for _, event := range timeline {
+		got = append(got, reportTimelineEvent{Actor: event.Actor, Event: event.Event})
+	}
+	if len(got) < len(want) {
+		t.Fatalf("timeline = %+v, want %+v", got, want)
+	}
+	// The seed may have logged more changes, such as its tags; the last ones are ours
+	got = append(got[:1], got[len(got)-len(want)+1:]...)
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("event %d = %+v, want %+v", i, got[i], want[i])
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := NewExportService(db).ExportReportPDF(ctx, &buf, h.report); err != nil || buf.Len() == 0 {
+		t.Errorf("export report = %v with %d bytes, want a PDF", err, buf.Len())
+	}
+}
@@ -0,0 +1,85 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"incident-report/repositories"
+	"incident-report/utils"
+)
+
+func TestSparseFieldsetsNarrowColumns(t *testing.T) {
+	ctx := context.Background()
+	repos := repositories.New(setupTestDB(t))
+	h := seedHierarchy(t, repos)
+	components := NewComponentService(repos)
+
+	narrow := utils.WithFields(ctx, []string{"code", "room"})
+	component, err := components.GetComponentByID(narrow, h.component)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if component.Code != "PRJ-1" || component.Name != "" || component.RoomID == nil || component.Version != 1 {
+		t.Errorf("component = %+v, want only the code, the room, the ID and the version loaded", component)
+	}
+
+	list, _, err := components.GetAllComponents(narrow, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(list) != 1 || list[0].Name != "" || list[0].Room == nil || list[0].Room.Floor.Building.Code != "B1" {
+		t.Errorf("components = %+v, want the code and the nested room", list)
+	}
+
+	// A field computed from other columns keeps them all
+	report, err := NewReportService(repos).GetReportByID(utils.WithFields(ctx, []string{"name", "unknown"}), h.report)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if report.Name == "" || report.Status != "PENDING" {
+		t.Errorf("report = %+v, want every column loaded", report)
+	}
+
+	// The sort key of a cursor list is loaded whatever the fields
+	tags := NewTagService(repos)
+	if _, err := tags.CreateTag(ctx, &utils.CreateTagRequest{Name: "urgent"}); err != nil {
+		t.Fatal(err)
+	}
+	first, info, err := tags.GetAllTags(utils.WithFields(ctx, []string{"color"}), &utils.PaginationQuery{PageSize: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	next, _, err := tags.GetAllTags(ctx, &utils.PaginationQuery{PageSize: 1, After: info.NextCursor})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(first) != 1 || len(next) != 1 || next[0].Name != "urgent" {
+		t.Errorf("tags = %+v then %+v, want electrical then urgent", first, next)
+	}
+}
+
+func TestSelectFields(t *testing.T) {
+	page := utils.PaginatedResponse{
+		Data:     []utils.TagResponse{{ID: 1, Name: "electrical", Color: "#FF0000", Version: 2}},
+		Page:     1,
+		PageSize: 10,
+	}
+	selected, err := utils.SelectFields(page, []string{"name"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	records := selected.(utils.PaginatedResponse).Data.([]interface{})
+	if record := records[0].(map[string]interface{}); len(record) != 1 || record["name"] != "electrical" {
+		t.Errorf("record = %v, want the name only", record)
+	}
+
+	if _, err := utils.SelectFields(&utils.TagResponse{}, []string{"colour"}); err == nil {
+		t.Error("unknown field was accepted")
+	}
+	if _, err := utils.ParseFields("name,Bad-Field"); err == nil {
+		t.Error("malformed field name was accepted")
+	}
+	if fields, _ := utils.ParseFields(" name,,id,name "); len(fields) != 2 {
+		t.Errorf("fields = %v, want name and id", fields)
+	}
+}
@@ -1,32 +1,32 @@
 package services
 
 import (
+	"context"
 	"errors"
-	"incident-report/config"
 	"incident-report/models"
+	"incident-report/repositories"
 	"incident-report/utils"
-
-	"gorm.io/gorm"
 )
 
 // FloorService handles all floor-related business logic
-type FloorService struct{}
+type FloorService struct {
+	repos *repositories.Repositories
+}
 
 // NewFloorService creates a new instance of FloorService
-func NewFloorService() *FloorService {
-	return &FloorService{}
+func NewFloorService(repos *repositories.Repositories) *FloorService {
+	return &FloorService{repos: repos}
 }
 
 // CreateFloor creates a new floor in the database
-func (fs *FloorService) CreateFloor(req *utils.CreateFloorRequest) (*utils.FloorResponse, error) {
+func (fs *FloorService) CreateFloor(ctx context.Context, req *utils.CreateFloorRequest) (*utils.FloorResponse, error) {
 	if req.BuildingID == 0 || req.FloorNumber == 0 || req.Name == "" {
-		return nil, errors.New("building_id, floor_number, and name are required")
+		return nil, utils.Validation("building_id, floor_number, and name are required")
 	}
 
 	// Verify building exists
-	var building models.Building
-	if err := config.DB.First(&building, req.BuildingID).Error; err != nil {
-		return nil, errors.New("building not found")
+	if _, err := fs.repos.Buildings.FindByID(ctx, req.BuildingID); err != nil {
+		return nil, utils.InvalidField("building_id", "building not found")
 	}
 
 	floor := models.Floor{
@@ -35,10 +35,10 @@ func (fs *FloorService) CreateFloor(req *utils.CreateFloorRequest) (*utils.Floor
 		Name:       req.Name,
 	}
 
-	result := config.DB.Create(&floor)
-	if result.Error != nil {
-		return nil, result.Error
+	if err := fs.repos.Floors.Create(ctx, &floor); err != nil {
+		return nil, err
 	}
+	invalidateHierarchyTree()
 
 	return &utils.FloorResponse{
 		ID:          floor.ID,
@@ -47,19 +47,18 @@ func (fs *FloorService) CreateFloor(req *utils.CreateFloorRequest) (*utils.Floor
 		Name:        floor.Name,
 		CreatedAt:   floor.CreatedAt,
 		UpdatedAt:   floor.UpdatedAt,
+		Version:     floor.Version,
 	}, nil
 }
 
 // GetFloorByID retrieves a floor by its ID
-func (fs *FloorService) GetFloorByID(id uint) (*utils.FloorResponse, error) {
-	var floor models.Floor
-
-	result := config.DB.First(&floor, id)
-	if result.Error != nil {
-		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
-			return nil, errors.New("floor not found")
+func (fs *FloorService) GetFloorByID(ctx context.Context, id uint) (*utils.FloorResponse, error) {
+	floor, err := fs.repos.Floors.FindByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, repositories.ErrNotFound) {
+			return nil, utils.NotFound("floor")
 		}
-		return nil, result.Error
+		return nil, err
 	}
 
 	return &utils.FloorResponse{
@@ -69,29 +68,19 @@ func (fs *FloorService) GetFloorByID(id uint) (*utils.FloorResponse, error) {
 		Name:        floor.Name,
 		CreatedAt:   floor.CreatedAt,
 		UpdatedAt:   floor.UpdatedAt,
+		Version:     floor.Version,
 	}, nil
 }
 
 // GetFloorsByBuildingID retrieves all floors in a building
-func (fs *FloorService) GetFloorsByBuildingID(buildingID uint, page, pageSize int) ([]utils.FloorResponse, int64, error) {
-	if page <= 0 {
-		page = 1
+func (fs *FloorService) GetFloorsByBuildingID(ctx context.Context, buildingID uint, pagination *utils.PaginationQuery) ([]utils.FloorResponse, utils.PageInfo, error) {
+	page, err := pageOf(pagination)
+	if err != nil {
+		return nil, utils.PageInfo{}, err
 	}
-	if pageSize <= 0 || pageSize > 100 {
-		pageSize = 10
-	}
-
-	var floors []models.Floor
-	var total int64
-
-	if err := config.DB.Where("building_id = ?", buildingID).Count(&total).Error; err != nil {
-		return nil, 0, err
-	}
-
-	offset := (page - 1) * pageSize
-	result := config.DB.Where("building_id = ?", buildingID).Offset(offset).Limit(pageSize).Find(&floors)
-	if result.Error != nil {
-		return nil, 0, result.Error
+	floors, info, err := fs.repos.Floors.ListByBuilding(ctx, buildingID, page)
+	if err != nil {
+		return nil, utils.PageInfo{}, err
 	}
 
 	var responses []utils.FloorResponse
@@ -103,67 +92,44 @@ func (fs *FloorService) GetFloorsByBuildingID(buildingID uint, page, pageSize in
 			Name:        floor.Name,
 			CreatedAt:   floor.CreatedAt,
 			UpdatedAt:   floor.UpdatedAt,
+			Version:     floor.Version,
 		})
 	}
 
-	return responses, total, nil
+	return responses, info, nil
 }
 
 // GetAllFloors retrieves all floors with pagination and building info
-func (fs *FloorService) GetAllFloors(page, pageSize int) ([]utils.FloorResponse, int64, error) {
-	if page <= 0 {
-		page = 1
+func (fs *FloorService) GetAllFloors(ctx context.Context, pagination *utils.PaginationQuery) ([]utils.FloorResponse, utils.PageInfo, error) {
+	page, err := pageOf(pagination)
+	if err != nil {
+		return nil, utils.PageInfo{}, err
 	}
-	if pageSize <= 0 || pageSize > 100 {
-		pageSize = 10
-	}
-
-	var floors []models.Floor
-	var total int64
-
-	if err := config.DB.Model(&models.Floor{}).Count(&total).Error; err != nil {
-		return nil, 0, err
-	}
-
-	offset := (page - 1) * pageSize
-	result := config.DB.Preload("Building").Offset(offset).Limit(pageSize).Find(&floors)
-	if result.Error != nil {
-		return nil, 0, result.Error
+	floors, info, err := fs.repos.Floors.List(ctx, page)
+	if err != nil {
+		return nil, utils.PageInfo{}, err
 	}
 
 	var responses []utils.FloorResponse
-	for _, floor := range floors {
-		buildingResp := &utils.BuildingResponse{
-			ID:        floor.Building.ID,
-			Code:      floor.Building.Code,
-			Name:      floor.Building.Name,
-			Location:  floor.Building.Location,
-			CreatedAt: floor.Building.CreatedAt,
-		}
-		responses = append(responses, utils.FloorResponse{
-			ID:          floor.ID,
-			BuildingID:  floor.BuildingID,
-			Building:    buildingResp,
-			FloorNumber: floor.Number,
-			Name:        floor.Name,
-			CreatedAt:   floor.CreatedAt,
-			UpdatedAt:   floor.UpdatedAt,
-		})
+	for i := range floors {
+		responses = append(responses, *toFloorResponse(&floors[i]))
 	}
 
-	return responses, total, nil
+	return responses, info, nil
 }
 
 // UpdateFloor updates an existing floor
-func (fs *FloorService) UpdateFloor(id uint, req *utils.UpdateFloorRequest) (*utils.FloorResponse, error) {
-	var floor models.Floor
-
-	result := config.DB.First(&floor, id)
-	if result.Error != nil {
-		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
-			return nil, errors.New("floor not found")
+func (fs *FloorService) UpdateFloor(ctx context.Context, id uint, req *utils.UpdateFloorRequest) (*utils.FloorResponse, error) {
+	floor, err := fs.repos.Floors.FindByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, repositories.ErrNotFound) {
+			return nil, utils.NotFound("floor")
 		}
-		return nil, result.Error
+		return nil, err
+	}
+
+	if err := checkVersion(ctx, "floor", floor.Version); err != nil {
+		return nil, err
 	}
 
 	if req.FloorNumber != 0 {
@@ -173,10 +139,50 @@ func (fs *FloorService) UpdateFloor(id uint, req *utils.UpdateFloorRequest) (*ut
 		floor.Name = req.Name
 	}
 
-	if err := config.DB.Save(&floor).Error; err != nil {
+	if err := fs.repos.Floors.Save(ctx, floor); err != nil {
+		return nil, versionConflict(ctx, "floor", err)
+	}
+	invalidateHierarchyTree()
+
+	return &utils.FloorResponse{
+		ID:          floor.ID,
+		BuildingID:  floor.BuildingID,
+		FloorNumber: floor.Number,
+		Name:        floor.Name,
+		CreatedAt:   floor.CreatedAt,
+		UpdatedAt:   floor.UpdatedAt,
+		Version:     floor.Version,
+	}, nil
+}
+
+// PatchFloor applies a JSON Merge Patch to a floor
+// Unlike UpdateFloor, it tells absent fields from null ones, so optional fields can be cleared.
+func (fs *FloorService) PatchFloor(ctx context.Context, id uint, patch []byte) (*utils.FloorResponse, error) {
+	floor, err := fs.repos.Floors.FindByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, repositories.ErrNotFound) {
+			return nil, utils.NotFound("floor")
+		}
+		return nil, err
+	}
+
+	if err := checkVersion(ctx, "floor", floor.Version); err != nil {
 		return nil, err
 	}
 
+	req := utils.PatchFloorRequest{FloorNumber: floor.Number, Name: floor.Name}
+	if err := utils.ApplyMergePatch(&req, patch); err != nil {
+		return nil, err
+	}
+
+	floor.Number = req.FloorNumber
+	floor.Name = req.Name
+
+	if err := fs.repos.Floors.Save(ctx, floor); err != nil {
+		return nil, versionConflict(ctx, "floor", err)
+	}
+	invalidateHierarchyTree()
+
 	return &utils.FloorResponse{
 		ID:          floor.ID,
 		BuildingID:  floor.BuildingID,
@@ -184,36 +190,68 @@ func (fs *FloorService) UpdateFloor(id uint, req *utils.UpdateFloorRequest) (*ut
 		Name:        floor.Name,
 		CreatedAt:   floor.CreatedAt,
 		UpdatedAt:   floor.UpdatedAt,
+		Version:     floor.Version,
 	}, nil
 }
 
-// DeleteFloor performs a soft delete of a floor
-func (fs *FloorService) DeleteFloor(id uint) error {
-	var floor models.Floor
+// GetFloorDeleteImpact counts what deleting a floor would take with it
+func (fs *FloorService) GetFloorDeleteImpact(ctx context.Context, id uint) (*utils.DeleteImpactResponse, error) {
+	if _, err := fs.repos.Floors.FindByID(ctx, id); err != nil {
+		if errors.Is(err, repositories.ErrNotFound) {
+			return nil, utils.NotFound("floor")
+		}
+		return nil, err
+	}
+
+	impact, err := deleteImpact(ctx, fs.repos, "floors", id)
+	return impact, err
+}
 
-	result := config.DB.First(&floor, id)
-	if result.Error != nil {
-		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
-			return errors.New("floor not found")
+// DeleteFloor performs a soft delete of a floor
+// Its dependents are deleted with it only when cascade is set.
+func (fs *FloorService) DeleteFloor(ctx context.Context, id uint, cascade bool) error {
+	floor, err := fs.repos.Floors.FindByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, repositories.ErrNotFound) {
+			return utils.NotFound("floor")
 		}
-		return result.Error
+		return err
 	}
 
-	result = config.DB.Delete(&floor)
-	return result.Error
+	if err := checkVersion(ctx, "floor", floor.Version); err != nil {
+		return err
+	}
+
+	return cascadeDelete(ctx, fs.repos, "floors", floor.ID, floor.Version, cascade)
 }
 
 // GetFloorWithRooms retrieves a floor with all its rooms
-func (fs *FloorService) GetFloorWithRooms(id uint) (*models.Floor, error) {
-	var floor models.Floor
-
-	result := config.DB.Preload("Rooms").First(&floor, id)
-	if result.Error != nil {
-		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
-			return nil, errors.New("floor not found")
+func (fs *FloorService) GetFloorWithRooms(ctx context.Context, id uint) (*models.Floor, error) {
+	floor, err := fs.repos.Floors.FindWithRooms(ctx, id)
+	if err != nil {
+		if errors.Is(err, repositories.ErrNotFound) {
+			return nil, utils.NotFound("floor")
 		}
-		return nil, result.Error
+		return nil, err
 	}
 
-	return &floor, nil
+	return floor, nil
+}
+
+// toFloorResponse converts a floor model into its response DTO
+// The building is nested when it was loaded with the floor.
+func toFloorResponse(floor *models.Floor) *utils.FloorResponse {
+	response := &utils.FloorResponse{
+		ID:          floor.ID,
+		BuildingID:  floor.BuildingID,
+		FloorNumber: floor.Number,
+		Name:        floor.Name,
+		CreatedAt:   floor.CreatedAt,
+		UpdatedAt:   floor.UpdatedAt,
+		Version:     floor.Version,
+	}
+	if floor.Building.ID != 0 {
+		response.Building = toBuildingResponse(&floor.Building)
+	}
+	return response
 }
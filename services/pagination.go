@@ -0,0 +1,45 @@
+package services
+
+import (
+	"incident-report/repositories"
+	"incident-report/utils"
+)
+
+// pageOf converts the pagination query of a list request into the page to load
+// Without a cursor the page number selects an offset. The list is counted unless the query
+// says otherwise, except when paging by cursor, where counting is opt-in.
+func pageOf(q *utils.PaginationQuery) (repositories.Page, error) {
+	if q == nil {
+		q = &utils.PaginationQuery{}
+	}
+
+	page := repositories.Page{Limit: q.PageSize}
+	if page.Limit <= 0 || page.Limit > 100 {
+		page.Limit = 10
+	}
+	if q.Page > 1 {
+		page.Offset = (q.Page - 1) * page.Limit
+	}
+
+	var err error
+	if q.After != "" {
+		if page.After, err = utils.DecodeCursor(q.After); err != nil {
+			return page, utils.InvalidField("after", "after must be a cursor returned by an earlier page")
+		}
+	}
+	if q.Before != "" {
+		if page.Before, err = utils.DecodeCursor(q.Before); err != nil {
+			return page, utils.InvalidField("before", "before must be a cursor returned by an earlier page")
+		}
+	}
+	if page.After != nil && page.Before != nil {
+		return page, utils.Validation("after and before cannot be combined")
+	}
+
+	if q.Total != nil {
+		page.SkipTotal = !*q.Total
+	} else {
+		page.SkipTotal = q.IsCursor()
+	}
+	return page, nil
+}
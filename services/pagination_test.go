@@ -0,0 +1,184 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"testing"
+	"time"
+
+	"incident-report/repositories"
+	"incident-report/utils"
+)
+
+// lister loads a page of a list and returns the keys of its records
+type lister func(q *utils.PaginationQuery) ([]string, utils.PageInfo, error)
+
+// walkCursors pages through a list two records at a time, forward by next_cursor from the first
+// page, then back by prev_cursor from the last one. It returns the keys seen going forward and
+// the keys seen going back, which are those of every page but the last.
+func walkCursors(t *testing.T, list lister) (forward, backward []string, last int) {
+	t.Helper()
+
+	q := &utils.PaginationQuery{PageSize: 2}
+	var prev string
+	for i := 0; ; i++ {
+		keys, info, err := list(q)
+		if err != nil {
+			t.Fatalf("page %d: %v", i, err)
+		}
+		if i > 0 && info.Total != nil {
+			t.Errorf("page %d was counted, want cursor pages uncounted by default", i)
+		}
+		forward, prev, last = append(forward, keys...), info.PrevCursor, len(keys)
+		if info.NextCursor == "" {
+			break
+		}
+		q = &utils.PaginationQuery{PageSize: 2, After: info.NextCursor}
+	}
+
+	for prev != "" {
+		keys, info, err := list(&utils.PaginationQuery{PageSize: 2, Before: prev})
+		if err != nil {
+			t.Fatalf("page before %s: %v", prev, err)
+		}
+		backward, prev = append(keys, backward...), info.PrevCursor
+	}
+	return forward, backward, last
+}
+
+// allKeys loads a whole list on one offset page
+func allKeys(t *testing.T, list lister) []string {
+	t.Helper()
+
+	keys, info, err := list(&utils.PaginationQuery{PageSize: 100})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Total == nil || int(*info.Total) != len(keys) {
+		t.Errorf("total = %v, want %d", info.Total, len(keys))
+	}
+	return keys
+}
+
+func TestCursorPagination(t *testing.T) {
+	ctx := context.Background()
+	db := setupTestDB(t)
+	repos := repositories.New(db)
+	h := seedHierarchy(t, repos)
+	ids := addReports(t, repos, h, 9)
+
+	tags := NewTagService(repos)
+	for _, name := range []string{"urgent", "cabling", "lighting", "hvac"} {
+		if _, err := tags.CreateTag(ctx, &utils.CreateTagRequest{Name: name}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// Deleted in two batches, so the trash has both ties and distinct times
+	stampDeleted(t, db, time.Now(), "reports", ids[0], ids[1], ids[2])
+	stampDeleted(t, db, time.Now().Add(time.Minute), "reports", ids[3])
+	stampDeleted(t, db, time.Now().Add(time.Minute), "components", h.component)
+
+	lists := map[string]lister{
+		"reports": func(q *utils.PaginationQuery) ([]string, utils.PageInfo, error) {
+			reports, info, err := NewReportService(repos).GetAllReports(ctx, q, nil)
+			var keys []string
+			for _, report := range reports {
+				keys = append(keys, fmt.Sprintf("%s#%d", report.Name, report.ID))
+			}
+			return keys, info, err
+		},
+		"tags": func(q *utils.PaginationQuery) ([]string, utils.PageInfo, error) {
+			list, info, err := tags.GetAllTags(ctx, q)
+			var keys []string
+			for _, tag := range list {
+				keys = append(keys, tag.Name)
+			}
+			return keys, info, err
+		},
+		"audit": func(q *utils.PaginationQuery) ([]string, utils.PageInfo, error) {
+			entries, info, err := NewAuditService(repos).ListAuditLogs(ctx, &utils.AuditQuery{PaginationQuery: *q})
+			var keys []string
+			for _, entry := range entries {
+				keys = append(keys, fmt.Sprintf("%s#%d", entry.EntityType, entry.ID))
+			}
+			return keys, info, err
+		},
+		"trash": func(q *utils.PaginationQuery) ([]string, utils.PageInfo, error) {
+			items, info, err := NewTrashService(repos).ListTrash(ctx, "", q)
+			var keys []string
+			for _, item := range items {
+				keys = append(keys, fmt.Sprintf("%s#%d", item.Type, item.ID))
+			}
+			return keys, info, err
+		},
+	}
+	for name, list := range lists {
+		t.Run(name, func(t *testing.T) {
+			want := allKeys(t, list)
+			if len(want) < 5 {
+				t.Fatalf("list = %v, want at least 3 pages", want)
+			}
+			forward, backward, last := walkCursors(t, list)
+			if !slices.Equal(forward, want) {
+				t.Errorf("forward = %v, want %v", forward, want)
+			}
+			if !slices.Equal(backward, want[:len(want)-last]) {
+				t.Errorf("backward = %v, want %v", backward, want[:len(want)-last])
+			}
+		})
+	}
+
+	names, _, err := tags.GetAllTags(ctx, &utils.PaginationQuery{PageSize: 3})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(names) != 3 || names[0].Name != "cabling" || names[2].Name != "hvac" {
+		t.Errorf("first tags = %+v, want them sorted by name", names)
+	}
+}
+
+func TestCursorPaginationQuery(t *testing.T) {
+	ctx := context.Background()
+	repos := repositories.New(setupTestDB(t))
+	h := seedHierarchy(t, repos)
+	addReports(t, repos, h, 3)
+	reports := NewReportService(repos)
+
+	yes, no := true, false
+	first, info, err := reports.GetAllReports(ctx, &utils.PaginationQuery{PageSize: 2, Total: &no}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Total != nil || len(first) != 2 || info.NextCursor == "" || info.PrevCursor != "" {
+		t.Errorf("first page = %d reports, %+v; want 2 uncounted reports and a next cursor", len(first), info)
+	}
+
+	rest, info, err := reports.GetAllReports(ctx, &utils.PaginationQuery{PageSize: 2, After: info.NextCursor, Total: &yes}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Total == nil || *info.Total != 3 || len(rest) != 1 || info.NextCursor != "" || rest[0].ID <= first[1].ID {
+		t.Errorf("last page = %+v, %+v; want the third report, counted", rest, info)
+	}
+
+	// A report created while paging shows up at the end instead of shifting the pages
+	added := addReports(t, repos, h, 2)[1]
+	late, _, err := reports.GetAllReports(ctx, &utils.PaginationQuery{PageSize: 2, After: utils.Cursor{ID: rest[0].ID}.Encode()}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(late) != 1 || late[0].ID != added {
+		t.Errorf("after the last report = %+v, want the new report %d", late, added)
+	}
+
+	for _, q := range []utils.PaginationQuery{{After: "not a cursor"}, {Before: "e30"}} {
+		_, _, err := reports.GetAllReports(ctx, &q, nil)
+		var appErr *utils.AppError
+		if !errors.As(err, &appErr) || !errors.Is(err, utils.ErrValidation) {
+			t.Errorf("query %+v = %v, want a validation error", q, err)
+		}
+	}
+}
@@ -0,0 +1,122 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"incident-report/repositories"
+	"incident-report/utils"
+)
+
+func TestPatchClearsFields(t *testing.T) {
+	ctx := context.Background()
+	repos := repositories.New(setupTestDB(t))
+	h := seedHierarchy(t, repos)
+
+	components := NewComponentService(repos)
+	if _, err := components.UpdateComponent(ctx, h.component, &utils.UpdateComponentRequest{Brand: "Epson", ProcurementYear: 2019}); err != nil {
+		t.Fatalf("update component: %v", err)
+	}
+	component, err := components.PatchComponent(ctx, h.component, []byte(`{"brand": null, "name": "Projector A"}`))
+	if err != nil {
+		t.Fatalf("patch component: %v", err)
+	}
+	if component.Brand != "" || component.Name != "Projector A" || component.ProcurementYear != 2019 {
+		t.Errorf("component = brand %q, name %q, year %d; want the brand cleared, the name changed and the year kept",
+			component.Brand, component.Name, component.ProcurementYear)
+	}
+
+	floor, err := NewFloorService(repos).PatchFloor(ctx, h.floor, []byte(`{"floor_number": 0}`))
+	if err != nil {
+		t.Fatalf("patch floor: %v", err)
+	}
+	if floor.FloorNumber != 0 || floor.Name != "Ground" {
+		t.Errorf("floor = number %d, name %q; want the ground floor 0", floor.FloorNumber, floor.Name)
+	}
+
+	reports := NewReportService(repos)
+	user, err := NewUserService(repos).CreateUser(ctx, &utils.CreateUserRequest{Name: "Tech One", Email: "tech@example.com"})
+	if err != nil {
+		t.Fatalf("create user: %v", err)
+	}
+	if _, err := reports.AssignUserToReport(ctx, h.report, user.ID); err != nil {
+		t.Fatalf("assign user: %v", err)
+	}
+	report, err := reports.PatchReport(ctx, h.report, []byte(`{"user_id": null}`))
+	if err != nil {
+		t.Fatalf("patch report: %v", err)
+	}
+	if report.UserID != nil || report.Status != "PENDING" {
+		t.Errorf("report = user %v, status %s; want it unassigned and still pending", report.UserID, report.Status)
+	}
+}
+
+func TestPatchValidation(t *testing.T) {
+	ctx := context.Background()
+	repos := repositories.New(setupTestDB(t))
+	h := seedHierarchy(t, repos)
+	reports := NewReportService(repos)
+	before, err := reports.GetReportByID(ctx, h.report)
+	if err != nil {
+		t.Fatalf("get report: %v", err)
+	}
+
+	tests := []struct {
+		name  string
+		patch string
+		field string
+	}{
+		{"required field cleared", `{"name": null}`, "name"},
+		{"invalid value", `{"status": "DONE"}`, "status"},
+		{"wrong type", `{"repair_cost": "cheap"}`, "repair_cost"},
+		{"unknown field", `{"priority": "high"}`, "priority"},
+		{"missing user", `{"user_id": 999}`, "user_id"},
+		{"not an object", `["name"]`, ""},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := reports.PatchReport(ctx, h.report, []byte(tc.patch))
+			var appErr *utils.AppError
+			if !errors.As(err, &appErr) || !errors.Is(err, utils.ErrValidation) {
+				t.Fatalf("patch = %v, want a validation error", err)
+			}
+			if tc.field != "" && (len(appErr.Fields) == 0 || appErr.Fields[0].Field != tc.field) {
+				t.Errorf("fields = %+v, want %s", appErr.Fields, tc.field)
+			}
+		})
+	}
+
+	report, err := reports.GetReportByID(ctx, h.report)
+	if err != nil {
+		t.Fatalf("get report: %v", err)
+	}
+	if report.Version != before.Version {
+		t.Errorf("version = %d, want the rejected patches to leave the report at %d", report.Version, before.Version)
+	}
+}
+
+func TestMergePatch(t *testing.T) {
+	// Examples from RFC 7396, appendix A
+	tests := []struct{ doc, patch, want string }{
+		{`{"a":"b"}`, `{"a":"c"}`, `{"a":"c"}`},
+		{`{"a":"b"}`, `{"b":"c"}`, `{"a":"b","b":"c"}`},
+		{`{"a":"b"}`, `{"a":null}`, `{}`},
+		{`{"a":"b","b":"c"}`, `{"a":null}`, `{"b":"c"}`},
+		{`{"a":["b"]}`, `{"a":"c"}`, `{"a":"c"}`},
+		{`{"a":{"b":"c"}}`, `{"a":{"b":"d","c":null}}`, `{"a":{"b":"d"}}`},
+		{`{"a":[{"b":"c"}]}`, `{"a":[1]}`, `{"a":[1]}`},
+		{`{"e":null}`, `{"a":1}`, `{"a":1,"e":null}`},
+		{`[1,2]`, `{"a":"b","c":null}`, `{"a":"b"}`},
+		{`{}`, `{"a":{"bb":{"ccc":null}}}`, `{"a":{"bb":{}}}`},
+	}
+	for _, tc := range tests {
+		got, err := utils.MergePatch([]byte(tc.doc), []byte(tc.patch))
+		if err != nil {
+			t.Fatalf("merge %s into %s: %v", tc.patch, tc.doc, err)
+		}
+		if string(got) != tc.want {
+			t.Errorf("merge %s into %s = %s, want %s", tc.patch, tc.doc, got, tc.want)
+		}
+	}
+}
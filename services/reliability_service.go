@@ -0,0 +1,294 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"incident-report/models"
+	"incident-report/utils"
+	"os"
+	"sort"
+	"strconv"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// ReliabilityService computes failure metrics of components from their report history
+// Every report filed against a component counts as one failure.
+type ReliabilityService struct {
+	db *gorm.DB
+
+	// Default score weights, overridable per request
+	ageWeight     float64
+	failureWeight float64
+	costWeight    float64
+}
+
+// NewReliabilityService creates a new instance of ReliabilityService
+// Default score weights are read from REPLACEMENT_AGE_WEIGHT, REPLACEMENT_FAILURE_WEIGHT
+// and REPLACEMENT_COST_WEIGHT and fall back to 1
+func NewReliabilityService(db *gorm.DB) *ReliabilityService {
+	return &ReliabilityService{
+		db:            db,
+		ageWeight:     envWeight("REPLACEMENT_AGE_WEIGHT"),
+		failureWeight: envWeight("REPLACEMENT_FAILURE_WEIGHT"),
+		costWeight:    envWeight("REPLACEMENT_COST_WEIGHT"),
+	}
+}
+
+// envWeight reads a non-negative score weight from the environment
+func envWeight(key string) float64 {
+	weight, err := strconv.ParseFloat(os.Getenv(key), 64)
+	if err != nil || weight < 0 {
+		return 1
+	}
+	return weight
+}
+
+// Time conversions used for operating periods
+const (
+	hoursPerDay = 24
+	daysPerYear = 365.25
+)
+
+// componentFailureStats is a per-component aggregate of its reports
+type componentFailureStats struct {
+	ComponentID uint
+	Failures    int64
+	RepairCost  float64
+}
+
+// failureStats aggregates report counts and repair costs in SQL
+// for every component selected by the components query
+func failureStats(db *gorm.DB, components *gorm.DB) (map[uint]componentFailureStats, error) {
+	stats := make(map[uint]componentFailureStats)
+
+	var rows []componentFailureStats
+	err := db.Model(&models.Report{}).
+		Select("component_id, COUNT(*) AS failures, COALESCE(SUM(repair_cost), 0) AS repair_cost").
+		Where("component_id IN (?)", components.Select("id")).
+		Group("component_id").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	for _, row := range rows {
+		stats[row.ComponentID] = row
+	}
+	return stats, nil
+}
+
+// serviceStart is when a component entered service
+// The first of January of its procurement year is used, or its creation time when the year is unknown
+func serviceStart(component *models.Component) time.Time {
+	if component.ProcurementYear > 0 {
+		return time.Date(component.ProcurementYear, time.January, 1, 0, 0, 0, 0, time.Local)
+	}
+	return time.Unix(component.CreatedAt, 0)
+}
+
+// operatingDays is how long a component has been in service, at least one day
+func operatingDays(component *models.Component, now time.Time) float64 {
+	days := now.Sub(serviceStart(component)).Hours() / hoursPerDay
+	if days < 1 {
+		return 1
+	}
+	return days
+}
+
+// componentReliability combines a component with its failure statistics
+func componentReliability(component *models.Component, stats componentFailureStats, now time.Time) utils.ComponentReliability {
+	days := operatingDays(component, now)
+
+	reliability := utils.ComponentReliability{
+		ComponentID:     component.ID,
+		Code:            component.Code,
+		Name:            component.Name,
+		CategoryID:      component.CategoryID,
+		ProcurementYear: component.ProcurementYear,
+		Failures:        stats.Failures,
+		FailuresPerYear: float64(stats.Failures) / (days / daysPerYear),
+		RepairCost:      stats.RepairCost,
+	}
+
+	if component.ProcurementYear > 0 {
+		age := now.Year() - component.ProcurementYear
+		if age < 0 {
+			age = 0
+		}
+		reliability.AgeYears = &age
+	}
+
+	if stats.Failures > 0 {
+		mtbf := days / float64(stats.Failures)
+		reliability.MTBFDays = &mtbf
+	}
+
+	return reliability
+}
+
+// GetComponentReliability returns the failure metrics of a single component
+func (rs *ReliabilityService) GetComponentReliability(ctx context.Context, id uint) (*utils.ComponentReliability, error) {
+	var component models.Component
+
+	db := rs.db.WithContext(ctx)
+	result := db.First(&component, id)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, utils.NotFound("component")
+		}
+		return nil, result.Error
+	}
+
+	stats, err := failureStats(db, db.Model(&models.Component{}).Where("id = ?", component.ID))
+	if err != nil {
+		return nil, err
+	}
+
+	reliability := componentReliability(&component, stats[component.ID], time.Now())
+	return &reliability, nil
+}
+
+// GetCategoryReliability returns the aggregated failure metrics of a component category
+// MTBF is the total operating time of all components divided by their total failures
+func (rs *ReliabilityService) GetCategoryReliability(ctx context.Context, id uint) (*utils.CategoryReliability, error) {
+	var category models.ComponentCategory
+
+	db := rs.db.WithContext(ctx)
+	result := db.First(&category, id)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, utils.NotFound("component category")
+		}
+		return nil, result.Error
+	}
+
+	var components []models.Component
+	if err := db.Where("category_id = ?", id).Find(&components).Error; err != nil {
+		return nil, err
+	}
+
+	stats, err := failureStats(db, db.Model(&models.Component{}).Where("category_id = ?", id))
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	reliability := utils.CategoryReliability{
+		CategoryID: category.ID,
+		Code:       category.Code,
+		Name:       category.Name,
+		Components: int64(len(components)),
+	}
+
+	var totalDays, totalAge float64
+	var agedComponents int
+	for i := range components {
+		component := &components[i]
+		totalDays += operatingDays(component, now)
+		reliability.Failures += stats[component.ID].Failures
+		reliability.RepairCost += stats[component.ID].RepairCost
+		if component.ProcurementYear > 0 {
+			totalAge += float64(now.Year() - component.ProcurementYear)
+			agedComponents++
+		}
+	}
+
+	if agedComponents > 0 {
+		averageAge := totalAge / float64(agedComponents)
+		reliability.AverageAgeYears = &averageAge
+	}
+	if reliability.Failures > 0 {
+		mtbf := totalDays / float64(reliability.Failures)
+		reliability.MTBFDays = &mtbf
+	}
+
+	return &reliability, nil
+}
+
+// GetReplacementCandidates ranks components by a weighted score of age, failure frequency and repair cost
+// Each factor is normalized against the highest value among the ranked components before weighting
+func (rs *ReliabilityService) GetReplacementCandidates(ctx context.Context, query *utils.ReplacementCandidatesQuery) ([]utils.ReplacementCandidate, error) {
+	ageWeight := weightOrDefault(query.AgeWeight, rs.ageWeight)
+	failureWeight := weightOrDefault(query.FailureWeight, rs.failureWeight)
+	costWeight := weightOrDefault(query.CostWeight, rs.costWeight)
+	totalWeight := ageWeight + failureWeight + costWeight
+	if totalWeight == 0 {
+		return nil, utils.Validation("at least one score weight must be greater than zero")
+	}
+
+	scope := func() *gorm.DB {
+		db := rs.db.WithContext(ctx).Model(&models.Component{})
+		if query.CategoryID != 0 {
+			db = db.Where("category_id = ?", query.CategoryID)
+		}
+		return db
+	}
+
+	var components []models.Component
+	if err := scope().Find(&components).Error; err != nil {
+		return nil, err
+	}
+
+	stats, err := failureStats(rs.db.WithContext(ctx), scope())
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	candidates := make([]utils.ReplacementCandidate, len(components))
+	var maxAge, maxFrequency, maxCost float64
+	for i := range components {
+		reliability := componentReliability(&components[i], stats[components[i].ID], now)
+		candidates[i] = utils.ReplacementCandidate{ComponentReliability: reliability}
+
+		if reliability.AgeYears != nil && float64(*reliability.AgeYears) > maxAge {
+			maxAge = float64(*reliability.AgeYears)
+		}
+		if reliability.FailuresPerYear > maxFrequency {
+			maxFrequency = reliability.FailuresPerYear
+		}
+		if reliability.RepairCost > maxCost {
+			maxCost = reliability.RepairCost
+		}
+	}
+
+	for i := range candidates {
+		candidate := &candidates[i]
+		var age float64
+		if candidate.AgeYears != nil {
+			age = float64(*candidate.AgeYears)
+		}
+		score := ageWeight*normalize(age, maxAge) +
+			failureWeight*normalize(candidate.FailuresPerYear, maxFrequency) +
+			costWeight*normalize(candidate.RepairCost, maxCost)
+		candidate.Score = score / totalWeight
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].Score > candidates[j].Score
+	})
+
+	limit := topNLimit(query.Limit)
+	if len(candidates) > limit {
+		candidates = candidates[:limit]
+	}
+	return candidates, nil
+}
+
+// weightOrDefault returns the requested weight or the configured default
+func weightOrDefault(requested *float64, fallback float64) float64 {
+	if requested == nil {
+		return fallback
+	}
+	return *requested
+}
+
+// normalize scales value into [0, 1] relative to max
+func normalize(value float64, max float64) float64 {
+	if max <= 0 {
+		return 0
+	}
+	return value / max
+}
@@ -1,27 +1,30 @@
 package services
 
 import (
+	"context"
 	"errors"
-	"incident-report/config"
+	"fmt"
 	"incident-report/models"
+	"incident-report/repositories"
 	"incident-report/utils"
-
-	"gorm.io/gorm"
+	"time"
 )
 
 // ReportService handles all report-related business logic
-type ReportService struct{}
+type ReportService struct {
+	repos *repositories.Repositories
+}
 
 // NewReportService creates a new instance of ReportService
-func NewReportService() *ReportService {
-	return &ReportService{}
+func NewReportService(repos *repositories.Repositories) *ReportService {
+	return &ReportService{repos: repos}
 }
 
 // CreateReport creates a new report in the database
-func (rs *ReportService) CreateReport(req *utils.CreateReportRequest) (*utils.ReportResponse, error) {
+func (rs *ReportService) CreateReport(ctx context.Context, req *utils.CreateReportRequest) (*utils.ReportResponse, error) {
 	// Validate input
 	if req.Name == "" || req.RoomID == 0 || req.ComponentID == 0 {
-		return nil, errors.New("name, room_id, and component_id are required")
+		return nil, utils.Validation("name, room_id, and component_id are required")
 	}
 
 	// Create report model instance
@@ -30,14 +33,15 @@ func (rs *ReportService) CreateReport(req *utils.CreateReportRequest) (*utils.Re
 		RoomID:      req.RoomID,
 		UserID:      req.UserID,
 		ComponentID: req.ComponentID,
-		Status:      models.ReportStatus(req.Status),
+		RepairCost:  req.RepairCost,
 	}
+	setReportStatus(&report, models.ReportStatus(req.Status))
 
 	// Save to database
-	result := config.DB.Create(&report)
-	if result.Error != nil {
-		return nil, result.Error
+	if err := rs.repos.Reports.Create(ctx, &report); err != nil {
+		return nil, err
 	}
+	invalidateHierarchyTree()
 
 	// Return report response DTO
 	return &utils.ReportResponse{
@@ -47,22 +51,23 @@ func (rs *ReportService) CreateReport(req *utils.CreateReportRequest) (*utils.Re
 		UserID:      report.UserID,
 		ComponentID: report.ComponentID,
 		Status:      string(report.Status),
+		RepairCost:  report.RepairCost,
 		CreatedAt:   report.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
 		UpdatedAt:   report.UpdatedAt.Format("2006-01-02T15:04:05Z07:00"),
+		Version:     report.Version,
+		CompletedAt: formatOptionalTime(report.CompletedAt),
 	}, nil
 }
 
 // GetReportByID retrieves a report by their ID
-func (rs *ReportService) GetReportByID(id uint) (*utils.ReportResponse, error) {
-	var report models.Report
-
+func (rs *ReportService) GetReportByID(ctx context.Context, id uint) (*utils.ReportResponse, error) {
 	// Query database for report with given ID
-	result := config.DB.First(&report, id)
-	if result.Error != nil {
-		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
-			return nil, errors.New("report not found")
+	report, err := rs.repos.Reports.FindWithTags(ctx, id)
+	if err != nil {
+		if errors.Is(err, repositories.ErrNotFound) {
+			return nil, utils.NotFound("report")
 		}
-		return nil, result.Error
+		return nil, err
 	}
 
 	return &utils.ReportResponse{
@@ -72,36 +77,24 @@ func (rs *ReportService) GetReportByID(id uint) (*utils.ReportResponse, error) {
 		UserID:      report.UserID,
 		ComponentID: report.ComponentID,
 		Status:      string(report.Status),
+		RepairCost:  report.RepairCost,
+		Tags:        toTagResponses(report.Tags),
 		CreatedAt:   report.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
 		UpdatedAt:   report.UpdatedAt.Format("2006-01-02T15:04:05Z07:00"),
+		Version:     report.Version,
+		CompletedAt: formatOptionalTime(report.CompletedAt),
 	}, nil
 }
 
-// GetAllReports retrieves all reports with pagination support
-func (rs *ReportService) GetAllReports(page int, pageSize int) ([]utils.ReportResponse, int64, error) {
-	// Set default pagination values
-	if page <= 0 {
-		page = 1
+// GetAllReports retrieves all reports matching the filter with pagination support
+func (rs *ReportService) GetAllReports(ctx context.Context, pagination *utils.PaginationQuery, filter *utils.ReportFilterQuery) ([]utils.ReportResponse, utils.PageInfo, error) {
+	page, err := pageOf(pagination)
+	if err != nil {
+		return nil, utils.PageInfo{}, err
 	}
-	if pageSize <= 0 || pageSize > 100 {
-		pageSize = 10
-	}
-
-	var reports []models.Report
-	var total int64
-
-	// Count total records
-	if err := config.DB.Model(&models.Report{}).Count(&total).Error; err != nil {
-		return nil, 0, err
-	}
-
-	// Calculate offset for pagination
-	offset := (page - 1) * pageSize
-
-	// Fetch paginated results
-	result := config.DB.Offset(offset).Limit(pageSize).Find(&reports)
-	if result.Error != nil {
-		return nil, 0, result.Error
+	reports, info, err := rs.repos.Reports.List(ctx, filter, page)
+	if err != nil {
+		return nil, utils.PageInfo{}, err
 	}
 
 	// Convert to response DTOs
@@ -114,24 +107,31 @@ func (rs *ReportService) GetAllReports(page int, pageSize int) ([]utils.ReportRe
 			UserID:      report.UserID,
 			ComponentID: report.ComponentID,
 			Status:      string(report.Status),
+			RepairCost:  report.RepairCost,
+			Tags:        toTagResponses(report.Tags),
 			CreatedAt:   report.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
 			UpdatedAt:   report.UpdatedAt.Format("2006-01-02T15:04:05Z07:00"),
+			Version:     report.Version,
+			CompletedAt: formatOptionalTime(report.CompletedAt),
 		})
 	}
 
-	return responses, total, nil
+	return responses, info, nil
 }
 
 // UpdateReport updates an existing report's information
-func (rs *ReportService) UpdateReport(id uint, req *utils.UpdateReportRequest) (*utils.ReportResponse, error) {
+func (rs *ReportService) UpdateReport(ctx context.Context, id uint, req *utils.UpdateReportRequest) (*utils.ReportResponse, error) {
 	// Find report first
-	var report models.Report
-	result := config.DB.First(&report, id)
-	if result.Error != nil {
-		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
-			return nil, errors.New("report not found")
+	report, err := rs.repos.Reports.FindByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, repositories.ErrNotFound) {
+			return nil, utils.NotFound("report")
 		}
-		return nil, result.Error
+		return nil, err
+	}
+
+	if err := checkVersion(ctx, "report", report.Version); err != nil {
+		return nil, err
 	}
 
 	// Update only provided fields
@@ -148,14 +148,80 @@ func (rs *ReportService) UpdateReport(id uint, req *utils.UpdateReportRequest) (
 		report.ComponentID = req.ComponentID
 	}
 	if req.Status != "" {
-		report.Status = models.ReportStatus(req.Status)
+		setReportStatus(report, models.ReportStatus(req.Status))
+	}
+	if req.RepairCost != nil {
+		report.RepairCost = *req.RepairCost
 	}
 
 	// Save changes to database
-	if err := config.DB.Save(&report).Error; err != nil {
+	if err := rs.repos.Reports.Save(ctx, report); err != nil {
+		return nil, versionConflict(ctx, "report", err)
+	}
+	invalidateHierarchyTree()
+
+	return &utils.ReportResponse{
+		ID:          report.ID,
+		Name:        report.Name,
+		RoomID:      report.RoomID,
+		UserID:      report.UserID,
+		ComponentID: report.ComponentID,
+		Status:      string(report.Status),
+		RepairCost:  report.RepairCost,
+		CreatedAt:   report.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
+		UpdatedAt:   report.UpdatedAt.Format("2006-01-02T15:04:05Z07:00"),
+		Version:     report.Version,
+		CompletedAt: formatOptionalTime(report.CompletedAt),
+	}, nil
+}
+
+// PatchReport applies a JSON Merge Patch to a report
+// Unlike UpdateReport, it tells absent fields from null ones, so optional fields can be cleared.
+func (rs *ReportService) PatchReport(ctx context.Context, id uint, patch []byte) (*utils.ReportResponse, error) {
+	report, err := rs.repos.Reports.FindByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, repositories.ErrNotFound) {
+			return nil, utils.NotFound("report")
+		}
+		return nil, err
+	}
+
+	if err := checkVersion(ctx, "report", report.Version); err != nil {
 		return nil, err
 	}
 
+	req := utils.PatchReportRequest{
+		Name:        report.Name,
+		RoomID:      report.RoomID,
+		UserID:      report.UserID,
+		ComponentID: report.ComponentID,
+		Status:      string(report.Status),
+		RepairCost:  report.RepairCost,
+	}
+	if err := utils.ApplyMergePatch(&req, patch); err != nil {
+		return nil, err
+	}
+
+	if req.UserID != nil {
+		if _, err := rs.repos.Users.FindByID(ctx, *req.UserID); err != nil {
+			if errors.Is(err, repositories.ErrNotFound) {
+				return nil, utils.InvalidField("user_id", "user not found")
+			}
+			return nil, err
+		}
+	}
+	report.Name = req.Name
+	report.RoomID = req.RoomID
+	report.UserID = req.UserID
+	report.ComponentID = req.ComponentID
+	report.RepairCost = req.RepairCost
+	setReportStatus(report, models.ReportStatus(req.Status))
+
+	if err := rs.repos.Reports.Save(ctx, report); err != nil {
+		return nil, versionConflict(ctx, "report", err)
+	}
+	invalidateHierarchyTree()
+
 	return &utils.ReportResponse{
 		ID:          report.ID,
 		Name:        report.Name,
@@ -163,45 +229,56 @@ func (rs *ReportService) UpdateReport(id uint, req *utils.UpdateReportRequest) (
 		UserID:      report.UserID,
 		ComponentID: report.ComponentID,
 		Status:      string(report.Status),
+		RepairCost:  report.RepairCost,
 		CreatedAt:   report.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
 		UpdatedAt:   report.UpdatedAt.Format("2006-01-02T15:04:05Z07:00"),
+		Version:     report.Version,
+		CompletedAt: formatOptionalTime(report.CompletedAt),
 	}, nil
 }
 
-// DeleteReport deletes a report from the database
-func (rs *ReportService) DeleteReport(id uint) error {
+// DeleteReport performs a soft delete of a report; its tags stay attached for a restore
+func (rs *ReportService) DeleteReport(ctx context.Context, id uint) error {
 	// Find report first to ensure it exists
-	var report models.Report
-	result := config.DB.First(&report, id)
-	if result.Error != nil {
-		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
-			return errors.New("report not found")
+	report, err := rs.repos.Reports.FindByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, repositories.ErrNotFound) {
+			return utils.NotFound("report")
 		}
-		return result.Error
+		return err
+	}
+
+	if err := checkVersion(ctx, "report", report.Version); err != nil {
+		return err
 	}
 
-	// Perform hard delete
-	result = config.DB.Unscoped().Delete(&report)
-	return result.Error
+	if err := rs.repos.Reports.Delete(ctx, report); err != nil {
+		return versionConflict(ctx, "report", err)
+	}
+
+	invalidateHierarchyTree()
+	return nil
 }
 
 // AssignUserToReport assigns a user to an existing report
-func (rs *ReportService) AssignUserToReport(reportID uint, userID uint) (*utils.ReportResponse, error) {
+func (rs *ReportService) AssignUserToReport(ctx context.Context, reportID uint, userID uint) (*utils.ReportResponse, error) {
 	// Find report first
-	var report models.Report
-	result := config.DB.First(&report, reportID)
-	if result.Error != nil {
-		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
-			return nil, errors.New("report not found")
+	report, err := rs.repos.Reports.FindByID(ctx, reportID)
+	if err != nil {
+		if errors.Is(err, repositories.ErrNotFound) {
+			return nil, utils.NotFound("report")
 		}
-		return nil, result.Error
+		return nil, err
+	}
+
+	if err := checkVersion(ctx, "report", report.Version); err != nil {
+		return nil, err
 	}
 
 	// Check if user exists
-	var user models.User
-	if err := config.DB.First(&user, userID).Error; err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, errors.New("user not found")
+	if _, err := rs.repos.Users.FindByID(ctx, userID); err != nil {
+		if errors.Is(err, repositories.ErrNotFound) {
+			return nil, utils.InvalidField("user_id", "user not found")
 		}
 		return nil, err
 	}
@@ -210,9 +287,10 @@ func (rs *ReportService) AssignUserToReport(reportID uint, userID uint) (*utils.
 	report.UserID = &userID
 
 	// Save changes to database
-	if err := config.DB.Save(&report).Error; err != nil {
-		return nil, err
+	if err := rs.repos.Reports.Save(ctx, report); err != nil {
+		return nil, versionConflict(ctx, "report", err)
 	}
+	invalidateHierarchyTree()
 
 	return &utils.ReportResponse{
 		ID:          report.ID,
@@ -221,7 +299,205 @@ func (rs *ReportService) AssignUserToReport(reportID uint, userID uint) (*utils.
 		UserID:      report.UserID,
 		ComponentID: report.ComponentID,
 		Status:      string(report.Status),
+		RepairCost:  report.RepairCost,
 		CreatedAt:   report.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
 		UpdatedAt:   report.UpdatedAt.Format("2006-01-02T15:04:05Z07:00"),
+		Version:     report.Version,
+		CompletedAt: formatOptionalTime(report.CompletedAt),
 	}, nil
 }
+
+// AddTagsToReport attaches existing tags to a report
+// Tags that are already attached are left untouched
+func (rs *ReportService) AddTagsToReport(ctx context.Context, reportID uint, tagIDs []uint) (*utils.ReportResponse, error) {
+	report, err := rs.repos.Reports.FindByID(ctx, reportID)
+	if err != nil {
+		if errors.Is(err, repositories.ErrNotFound) {
+			return nil, utils.NotFound("report")
+		}
+		return nil, err
+	}
+
+	if err := checkVersion(ctx, "report", report.Version); err != nil {
+		return nil, err
+	}
+
+	tags, err := rs.repos.Tags.FindByIDs(ctx, tagIDs)
+	if err != nil {
+		return nil, err
+	}
+	if len(tags) != len(uniqueIDs(tagIDs)) {
+		return nil, utils.InvalidField("tag_ids", "tag not found")
+	}
+
+	if err := rs.repos.Reports.AddTags(ctx, report, tags); err != nil {
+		return nil, versionConflict(ctx, "report", err)
+	}
+
+	return rs.GetReportByID(ctx, reportID)
+}
+
+// RemoveTagFromReport detaches a tag from a report
+func (rs *ReportService) RemoveTagFromReport(ctx context.Context, reportID uint, tagID uint) (*utils.ReportResponse, error) {
+	report, err := rs.repos.Reports.FindByID(ctx, reportID)
+	if err != nil {
+		if errors.Is(err, repositories.ErrNotFound) {
+			return nil, utils.NotFound("report")
+		}
+		return nil, err
+	}
+
+	if err := checkVersion(ctx, "report", report.Version); err != nil {
+		return nil, err
+	}
+
+	tag, err := rs.repos.Tags.FindByID(ctx, tagID)
+	if err != nil {
+		if errors.Is(err, repositories.ErrNotFound) {
+			return nil, utils.NotFound("tag")
+		}
+		return nil, err
+	}
+
+	if err := rs.repos.Reports.RemoveTag(ctx, report, tag); err != nil {
+		return nil, versionConflict(ctx, "report", err)
+	}
+
+	return rs.GetReportByID(ctx, reportID)
+}
+
+// uniqueIDs removes duplicate IDs while keeping their order
+func uniqueIDs(ids []uint) []uint {
+	seen := make(map[uint]bool, len(ids))
+	var unique []uint
+	for _, id := range ids {
+		if !seen[id] {
+			seen[id] = true
+			unique = append(unique, id)
+		}
+	}
+	return unique
+}
+
+// setReportStatus changes a report's status and keeps CompletedAt in sync
+// Completing stamps the resolution time; moving back to an open status clears it
+func setReportStatus(report *models.Report, status models.ReportStatus) {
+	if status == models.ReportStatusCompleted {
+		if report.Status != models.ReportStatusCompleted || report.CompletedAt == nil {
+			now := time.Now()
+			report.CompletedAt = &now
+		}
+	} else {
+		report.CompletedAt = nil
+	}
+	report.Status = status
+}
+
+// toReportResponse converts a report model into its response DTO
+// Tags are only listed when they were loaded with the report.
+func toReportResponse(report *models.Report) *utils.ReportResponse {
+	return &utils.ReportResponse{
+		ID:          report.ID,
+		Name:        report.Name,
+		RoomID:      report.RoomID,
+		UserID:      report.UserID,
+		ComponentID: report.ComponentID,
+		Status:      string(report.Status),
+		RepairCost:  report.RepairCost,
+		Tags:        toTagResponses(report.Tags),
+		CreatedAt:   report.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
+		UpdatedAt:   report.UpdatedAt.Format("2006-01-02T15:04:05Z07:00"),
+		Version:     report.Version,
+		CompletedAt: formatOptionalTime(report.CompletedAt),
+	}
+}
+
+// formatOptionalTime formats a nullable timestamp for a response DTO
+func formatOptionalTime(t *time.Time) *string {
+	if t == nil {
+		return nil
+	}
+	formatted := t.Format("2006-01-02T15:04:05Z07:00")
+	return &formatted
+}
+
+// BulkReports applies one action to many reports, selected by ID or by filter
+// Every report goes through the same checks as the single-report endpoint; see runBulk for the modes.
+func (rs *ReportService) BulkReports(ctx context.Context, req *utils.BulkReportRequest) (*utils.BulkResponse, error) {
+	ids, err := rs.bulkReportIDs(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	var apply func(reports *ReportService, id uint) error
+	switch req.Action {
+	case utils.BulkActionAssign:
+		if req.UserID == nil {
+			return nil, utils.InvalidField("user_id", "user_id is required to assign reports")
+		}
+		if _, err := rs.repos.Users.FindByID(ctx, *req.UserID); err != nil {
+			if errors.Is(err, repositories.ErrNotFound) {
+				return nil, utils.InvalidField("user_id", "user not found")
+			}
+			return nil, err
+		}
+		apply = func(reports *ReportService, id uint) error {
+			_, err := reports.AssignUserToReport(ctx, id, *req.UserID)
+			return err
+		}
+	case utils.BulkActionUnassign:
+		// Like a merge patch of a single report that sets user_id to null
+		apply = func(reports *ReportService, id uint) error {
+			_, err := reports.PatchReport(ctx, id, []byte(`{"user_id":null}`))
+			return err
+		}
+	case utils.BulkActionTransition:
+		if req.Status == "" {
+			return nil, utils.InvalidField("status", "status is required to transition reports")
+		}
+		apply = func(reports *ReportService, id uint) error {
+			_, err := reports.UpdateReport(ctx, id, &utils.UpdateReportRequest{Status: req.Status})
+			return err
+		}
+	case utils.BulkActionTag:
+		if len(req.TagIDs) == 0 {
+			return nil, utils.InvalidField("tag_ids", "tag_ids is required to tag reports")
+		}
+		apply = func(reports *ReportService, id uint) error {
+			_, err := reports.AddTagsToReport(ctx, id, req.TagIDs)
+			return err
+		}
+	case utils.BulkActionDelete:
+		apply = func(reports *ReportService, id uint) error {
+			return reports.DeleteReport(ctx, id)
+		}
+	default:
+		return nil, utils.InvalidField("action", "unknown bulk action "+req.Action)
+	}
+
+	return runBulk(ctx, rs.repos, req.Mode, ids, func(tx *repositories.Repositories, id uint) error {
+		return apply(NewReportService(tx), id)
+	})
+}
+
+// bulkReportIDs resolves the reports a bulk request acts on
+// A filter must narrow the reports down, so that a bulk request never acts on every report by accident.
+func (rs *ReportService) bulkReportIDs(ctx context.Context, req *utils.BulkReportRequest) ([]uint, error) {
+	switch {
+	case len(req.IDs) > 0 && req.Filter != nil:
+		return nil, utils.Validation("send either ids or filter, not both")
+	case len(req.IDs) > 0:
+		return uniqueIDs(req.IDs), nil
+	case req.Filter.IsEmpty():
+		return nil, utils.InvalidField("ids", "ids or a non-empty filter is required")
+	}
+
+	ids, err := rs.repos.Reports.IDs(ctx, req.Filter, utils.MaxBulkItems+1)
+	if err != nil {
+		return nil, err
+	}
+	if len(ids) > utils.MaxBulkItems {
+		return nil, utils.InvalidField("filter", fmt.Sprintf("filter matches more than %d reports; narrow it down", utils.MaxBulkItems))
+	}
+	return ids, nil
+}
@@ -1,32 +1,36 @@
 package services
 
 import (
+	"context"
 	"errors"
-	"incident-report/config"
 	"incident-report/models"
+	"incident-report/repositories"
 	"incident-report/utils"
-
-	"gorm.io/gorm"
 )
 
 // RoomService handles all room-related business logic
-type RoomService struct{}
+type RoomService struct {
+	repos *repositories.Repositories
+}
 
 // NewRoomService creates a new instance of RoomService
-func NewRoomService() *RoomService {
-	return &RoomService{}
+func NewRoomService(repos *repositories.Repositories) *RoomService {
+	return &RoomService{repos: repos}
 }
 
 // CreateRoom creates a new room in the database
-func (rs *RoomService) CreateRoom(req *utils.CreateRoomRequest) (*utils.RoomResponse, error) {
+func (rs *RoomService) CreateRoom(ctx context.Context, req *utils.CreateRoomRequest) (*utils.RoomResponse, error) {
 	if req.FloorID == 0 || req.Code == "" || req.Name == "" {
-		return nil, errors.New("floor_id, code, and name are required")
+		return nil, utils.Validation("floor_id, code, and name are required")
 	}
 
 	// Verify floor exists
-	var floor models.Floor
-	if err := config.DB.First(&floor, req.FloorID).Error; err != nil {
-		return nil, errors.New("floor not found")
+	if _, err := rs.repos.Floors.FindByID(ctx, req.FloorID); err != nil {
+		return nil, utils.InvalidField("floor_id", "floor not found")
+	}
+
+	if err := ensureUnique(ctx, rs.repos.Rooms.CodeTaken, "code", req.Code, 0); err != nil {
+		return nil, err
 	}
 
 	room := models.Room{
@@ -35,10 +39,10 @@ func (rs *RoomService) CreateRoom(req *utils.CreateRoomRequest) (*utils.RoomResp
 		Name:    req.Name,
 	}
 
-	result := config.DB.Create(&room)
-	if result.Error != nil {
-		return nil, result.Error
+	if err := rs.repos.Rooms.Create(ctx, &room); err != nil {
+		return nil, err
 	}
+	invalidateHierarchyTree()
 
 	return &utils.RoomResponse{
 		ID:        room.ID,
@@ -47,19 +51,18 @@ func (rs *RoomService) CreateRoom(req *utils.CreateRoomRequest) (*utils.RoomResp
 		Name:      room.Name,
 		CreatedAt: room.CreatedAt,
 		UpdatedAt: room.UpdatedAt,
+		Version:   room.Version,
 	}, nil
 }
 
 // GetRoomByID retrieves a room by its ID
-func (rs *RoomService) GetRoomByID(id uint) (*utils.RoomResponse, error) {
-	var room models.Room
-
-	result := config.DB.First(&room, id)
-	if result.Error != nil {
-		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
-			return nil, errors.New("room not found")
+func (rs *RoomService) GetRoomByID(ctx context.Context, id uint) (*utils.RoomResponse, error) {
+	room, err := rs.repos.Rooms.FindByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, repositories.ErrNotFound) {
+			return nil, utils.NotFound("room")
 		}
-		return nil, result.Error
+		return nil, err
 	}
 
 	return &utils.RoomResponse{
@@ -69,29 +72,19 @@ func (rs *RoomService) GetRoomByID(id uint) (*utils.RoomResponse, error) {
 		Name:      room.Name,
 		CreatedAt: room.CreatedAt,
 		UpdatedAt: room.UpdatedAt,
+		Version:   room.Version,
 	}, nil
 }
 
 // GetRoomsByFloorID retrieves all rooms on a floor
-func (rs *RoomService) GetRoomsByFloorID(floorID uint, page, pageSize int) ([]utils.RoomResponse, int64, error) {
-	if page <= 0 {
-		page = 1
-	}
-	if pageSize <= 0 || pageSize > 100 {
-		pageSize = 10
-	}
-
-	var rooms []models.Room
-	var total int64
-
-	if err := config.DB.Where("floor_id = ?", floorID).Count(&total).Error; err != nil {
-		return nil, 0, err
+func (rs *RoomService) GetRoomsByFloorID(ctx context.Context, floorID uint, pagination *utils.PaginationQuery) ([]utils.RoomResponse, utils.PageInfo, error) {
+	page, err := pageOf(pagination)
+	if err != nil {
+		return nil, utils.PageInfo{}, err
 	}
-
-	offset := (page - 1) * pageSize
-	result := config.DB.Where("floor_id = ?", floorID).Offset(offset).Limit(pageSize).Find(&rooms)
-	if result.Error != nil {
-		return nil, 0, result.Error
+	rooms, info, err := rs.repos.Rooms.ListByFloor(ctx, floorID, page)
+	if err != nil {
+		return nil, utils.PageInfo{}, err
 	}
 
 	var responses []utils.RoomResponse
@@ -103,89 +96,105 @@ func (rs *RoomService) GetRoomsByFloorID(floorID uint, page, pageSize int) ([]ut
 			Name:      room.Name,
 			CreatedAt: room.CreatedAt,
 			UpdatedAt: room.UpdatedAt,
+			Version:   room.Version,
 		})
 	}
 
-	return responses, total, nil
+	return responses, info, nil
 }
 
 // GetAllRooms retrieves all rooms with pagination and floor/building info
-func (rs *RoomService) GetAllRooms(page, pageSize int) ([]utils.RoomResponse, int64, error) {
-	if page <= 0 {
-		page = 1
-	}
-	if pageSize <= 0 || pageSize > 100 {
-		pageSize = 10
-	}
-
-	var rooms []models.Room
-	var total int64
-
-	if err := config.DB.Model(&models.Room{}).Count(&total).Error; err != nil {
-		return nil, 0, err
+func (rs *RoomService) GetAllRooms(ctx context.Context, pagination *utils.PaginationQuery) ([]utils.RoomResponse, utils.PageInfo, error) {
+	page, err := pageOf(pagination)
+	if err != nil {
+		return nil, utils.PageInfo{}, err
 	}
-
-	offset := (page - 1) * pageSize
-	result := config.DB.Preload("Floor.Building").Offset(offset).Limit(pageSize).Find(&rooms)
-	if result.Error != nil {
-		return nil, 0, result.Error
+	rooms, info, err := rs.repos.Rooms.List(ctx, page)
+	if err != nil {
+		return nil, utils.PageInfo{}, err
 	}
 
 	var responses []utils.RoomResponse
-	for _, room := range rooms {
-		buildingResp := &utils.BuildingResponse{
-			ID:        room.Floor.Building.ID,
-			Code:      room.Floor.Building.Code,
-			Name:      room.Floor.Building.Name,
-			Location:  room.Floor.Building.Location,
-			CreatedAt: room.Floor.Building.CreatedAt,
-		}
-		floorResp := &utils.FloorResponse{
-			ID:          room.Floor.ID,
-			BuildingID:  room.Floor.BuildingID,
-			Building:    buildingResp,
-			FloorNumber: room.Floor.Number,
-			Name:        room.Floor.Name,
-			CreatedAt:   room.Floor.CreatedAt,
-			UpdatedAt:   room.Floor.UpdatedAt,
-		}
-		responses = append(responses, utils.RoomResponse{
-			ID:        room.ID,
-			FloorID:   room.FloorID,
-			Floor:     floorResp,
-			Code:      room.Code,
-			Name:      room.Name,
-			CreatedAt: room.CreatedAt,
-			UpdatedAt: room.UpdatedAt,
-		})
+	for i := range rooms {
+		responses = append(responses, *toRoomResponse(&rooms[i]))
 	}
 
-	return responses, total, nil
+	return responses, info, nil
 }
 
 // UpdateRoom updates an existing room
-func (rs *RoomService) UpdateRoom(id uint, req *utils.UpdateRoomRequest) (*utils.RoomResponse, error) {
-	var room models.Room
-
-	result := config.DB.First(&room, id)
-	if result.Error != nil {
-		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
-			return nil, errors.New("room not found")
+func (rs *RoomService) UpdateRoom(ctx context.Context, id uint, req *utils.UpdateRoomRequest) (*utils.RoomResponse, error) {
+	room, err := rs.repos.Rooms.FindByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, repositories.ErrNotFound) {
+			return nil, utils.NotFound("room")
 		}
-		return nil, result.Error
+		return nil, err
+	}
+
+	if err := checkVersion(ctx, "room", room.Version); err != nil {
+		return nil, err
 	}
 
 	if req.Code != "" {
+		if err := ensureUnique(ctx, rs.repos.Rooms.CodeTaken, "code", req.Code, room.ID); err != nil {
+			return nil, err
+		}
 		room.Code = req.Code
 	}
 	if req.Name != "" {
 		room.Name = req.Name
 	}
 
-	if err := config.DB.Save(&room).Error; err != nil {
+	if err := rs.repos.Rooms.Save(ctx, room); err != nil {
+		return nil, versionConflict(ctx, "room", err)
+	}
+	invalidateHierarchyTree()
+
+	return &utils.RoomResponse{
+		ID:        room.ID,
+		FloorID:   room.FloorID,
+		Code:      room.Code,
+		Name:      room.Name,
+		CreatedAt: room.CreatedAt,
+		UpdatedAt: room.UpdatedAt,
+		Version:   room.Version,
+	}, nil
+}
+
+// PatchRoom applies a JSON Merge Patch to a room
+// Unlike UpdateRoom, it tells absent fields from null ones, so optional fields can be cleared.
+func (rs *RoomService) PatchRoom(ctx context.Context, id uint, patch []byte) (*utils.RoomResponse, error) {
+	room, err := rs.repos.Rooms.FindByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, repositories.ErrNotFound) {
+			return nil, utils.NotFound("room")
+		}
+		return nil, err
+	}
+
+	if err := checkVersion(ctx, "room", room.Version); err != nil {
 		return nil, err
 	}
 
+	req := utils.PatchRoomRequest{Code: room.Code, Name: room.Name}
+	if err := utils.ApplyMergePatch(&req, patch); err != nil {
+		return nil, err
+	}
+
+	if req.Code != room.Code {
+		if err := ensureUnique(ctx, rs.repos.Rooms.CodeTaken, "code", req.Code, room.ID); err != nil {
+			return nil, err
+		}
+	}
+	room.Code = req.Code
+	room.Name = req.Name
+
+	if err := rs.repos.Rooms.Save(ctx, room); err != nil {
+		return nil, versionConflict(ctx, "room", err)
+	}
+	invalidateHierarchyTree()
+
 	return &utils.RoomResponse{
 		ID:        room.ID,
 		FloorID:   room.FloorID,
@@ -193,36 +202,68 @@ func (rs *RoomService) UpdateRoom(id uint, req *utils.UpdateRoomRequest) (*utils
 		Name:      room.Name,
 		CreatedAt: room.CreatedAt,
 		UpdatedAt: room.UpdatedAt,
+		Version:   room.Version,
 	}, nil
 }
 
-// DeleteRoom performs a soft delete of a room
-func (rs *RoomService) DeleteRoom(id uint) error {
-	var room models.Room
+// GetRoomDeleteImpact counts what deleting a room would take with it
+func (rs *RoomService) GetRoomDeleteImpact(ctx context.Context, id uint) (*utils.DeleteImpactResponse, error) {
+	if _, err := rs.repos.Rooms.FindByID(ctx, id); err != nil {
+		if errors.Is(err, repositories.ErrNotFound) {
+			return nil, utils.NotFound("room")
+		}
+		return nil, err
+	}
+
+	impact, err := deleteImpact(ctx, rs.repos, "rooms", id)
+	return impact, err
+}
 
-	result := config.DB.First(&room, id)
-	if result.Error != nil {
-		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
-			return errors.New("room not found")
+// DeleteRoom performs a soft delete of a room
+// Its dependents are deleted with it only when cascade is set.
+func (rs *RoomService) DeleteRoom(ctx context.Context, id uint, cascade bool) error {
+	room, err := rs.repos.Rooms.FindByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, repositories.ErrNotFound) {
+			return utils.NotFound("room")
 		}
-		return result.Error
+		return err
+	}
+
+	if err := checkVersion(ctx, "room", room.Version); err != nil {
+		return err
 	}
 
-	result = config.DB.Delete(&room)
-	return result.Error
+	return cascadeDelete(ctx, rs.repos, "rooms", room.ID, room.Version, cascade)
 }
 
 // GetRoomWithComponents retrieves a room with all its components
-func (rs *RoomService) GetRoomWithComponents(id uint) (*models.Room, error) {
-	var room models.Room
-
-	result := config.DB.Preload("Components").First(&room, id)
-	if result.Error != nil {
-		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
-			return nil, errors.New("room not found")
+func (rs *RoomService) GetRoomWithComponents(ctx context.Context, id uint) (*models.Room, error) {
+	room, err := rs.repos.Rooms.FindWithComponents(ctx, id)
+	if err != nil {
+		if errors.Is(err, repositories.ErrNotFound) {
+			return nil, utils.NotFound("room")
 		}
-		return nil, result.Error
+		return nil, err
 	}
 
-	return &room, nil
+	return room, nil
+}
+
+// toRoomResponse converts a room model into its response DTO
+// The floor, and its building, are nested when they were loaded with the room.
+func toRoomResponse(room *models.Room) *utils.RoomResponse {
+	response := &utils.RoomResponse{
+		ID:        room.ID,
+		FloorID:   room.FloorID,
+		Code:      room.Code,
+		Name:      room.Name,
+		CreatedAt: room.CreatedAt,
+		UpdatedAt: room.UpdatedAt,
+		Version:   room.Version,
+	}
+	if room.Floor.ID != 0 {
+		response.Floor = toFloorResponse(&room.Floor)
+	}
+	return response
 }
@@ -0,0 +1,53 @@
+package services
+
+import (
+	"fmt"
+	"incident-report/config"
+
+	"gorm.io/gorm"
+)
+
+// Analytics intervals
+const (
+	IntervalDay  = "day"
+	IntervalWeek = "week"
+)
+
+// dateBucketExpr returns a SQL expression that truncates a timestamp column to its day,
+// or to the Monday of its week, formatted as YYYY-MM-DD
+func dateBucketExpr(db *gorm.DB, interval string, column string) string {
+	switch db.Dialector.Name() {
+	case config.DriverPostgres:
+		if interval == IntervalWeek {
+			return fmt.Sprintf("TO_CHAR(DATE_TRUNC('week', %s), 'YYYY-MM-DD')", column)
+		}
+		return fmt.Sprintf("TO_CHAR(%s, 'YYYY-MM-DD')", column)
+
+	case config.DriverSQLite:
+		// Timestamps are stored with their offset; 'localtime' buckets them like the MySQL server does
+		if interval == IntervalWeek {
+			return fmt.Sprintf("DATE(%s, 'localtime', 'weekday 0', '-6 days')", column)
+		}
+		return fmt.Sprintf("DATE(%s, 'localtime')", column)
+
+	default:
+		if interval == IntervalWeek {
+			return fmt.Sprintf("DATE_FORMAT(DATE_SUB(%s, INTERVAL WEEKDAY(%s) DAY), '%%Y-%%m-%%d')", column, column)
+		}
+		return fmt.Sprintf("DATE_FORMAT(%s, '%%Y-%%m-%%d')", column)
+	}
+}
+
+// secondsBetweenExpr returns a SQL expression for the number of seconds between two timestamp columns
+func secondsBetweenExpr(db *gorm.DB, start string, end string) string {
+	switch db.Dialector.Name() {
+	case config.DriverPostgres:
+		return fmt.Sprintf("CAST(EXTRACT(EPOCH FROM (%s - %s)) AS BIGINT)", end, start)
+
+	case config.DriverSQLite:
+		return fmt.Sprintf("CAST(ROUND((JULIANDAY(%s) - JULIANDAY(%s)) * 86400) AS INTEGER)", end, start)
+
+	default:
+		return fmt.Sprintf("TIMESTAMPDIFF(SECOND, %s, %s)", start, end)
+	}
+}
@@ -0,0 +1,184 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"incident-report/models"
+	"incident-report/repositories"
+	"incident-report/utils"
+)
+
+// defaultTagColor is used when a tag is created without a color
+const defaultTagColor = "#808080"
+
+// TagService handles all tag-related business logic
+type TagService struct {
+	repos *repositories.Repositories
+}
+
+// NewTagService creates a new instance of TagService
+func NewTagService(repos *repositories.Repositories) *TagService {
+	return &TagService{repos: repos}
+}
+
+// CreateTag creates a new tag in the database
+func (ts *TagService) CreateTag(ctx context.Context, req *utils.CreateTagRequest) (*utils.TagResponse, error) {
+	if req.Name == "" {
+		return nil, utils.Validation("name is required")
+	}
+
+	if err := ensureUnique(ctx, ts.repos.Tags.NameTaken, "name", req.Name, 0); err != nil {
+		return nil, err
+	}
+
+	tag := models.Tag{
+		Name:  req.Name,
+		Color: req.Color,
+	}
+	if tag.Color == "" {
+		tag.Color = defaultTagColor
+	}
+
+	if err := ts.repos.Tags.Create(ctx, &tag); err != nil {
+		return nil, err
+	}
+
+	return toTagResponse(&tag), nil
+}
+
+// GetTagByID retrieves a tag by its ID
+func (ts *TagService) GetTagByID(ctx context.Context, id uint) (*utils.TagResponse, error) {
+	tag, err := ts.repos.Tags.FindByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, repositories.ErrNotFound) {
+			return nil, utils.NotFound("tag")
+		}
+		return nil, err
+	}
+
+	return toTagResponse(tag), nil
+}
+
+// GetAllTags retrieves all tags with pagination, ordered by name
+func (ts *TagService) GetAllTags(ctx context.Context, pagination *utils.PaginationQuery) ([]utils.TagResponse, utils.PageInfo, error) {
+	page, err := pageOf(pagination)
+	if err != nil {
+		return nil, utils.PageInfo{}, err
+	}
+	tags, info, err := ts.repos.Tags.List(ctx, page)
+	if err != nil {
+		return nil, utils.PageInfo{}, err
+	}
+
+	var responses []utils.TagResponse
+	for i := range tags {
+		responses = append(responses, *toTagResponse(&tags[i]))
+	}
+
+	return responses, info, nil
+}
+
+// UpdateTag updates an existing tag
+func (ts *TagService) UpdateTag(ctx context.Context, id uint, req *utils.UpdateTagRequest) (*utils.TagResponse, error) {
+	tag, err := ts.repos.Tags.FindByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, repositories.ErrNotFound) {
+			return nil, utils.NotFound("tag")
+		}
+		return nil, err
+	}
+
+	if err := checkVersion(ctx, "tag", tag.Version); err != nil {
+		return nil, err
+	}
+
+	if req.Name != "" {
+		if err := ensureUnique(ctx, ts.repos.Tags.NameTaken, "name", req.Name, tag.ID); err != nil {
+			return nil, err
+		}
+		tag.Name = req.Name
+	}
+	if req.Color != "" {
+		tag.Color = req.Color
+	}
+
+	if err := ts.repos.Tags.Save(ctx, tag); err != nil {
+		return nil, versionConflict(ctx, "tag", err)
+	}
+
+	return toTagResponse(tag), nil
+}
+
+// PatchTag applies a JSON Merge Patch to a tag
+// Unlike UpdateTag, it tells absent fields from null ones, so optional fields can be cleared.
+func (ts *TagService) PatchTag(ctx context.Context, id uint, patch []byte) (*utils.TagResponse, error) {
+	tag, err := ts.repos.Tags.FindByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, repositories.ErrNotFound) {
+			return nil, utils.NotFound("tag")
+		}
+		return nil, err
+	}
+
+	if err := checkVersion(ctx, "tag", tag.Version); err != nil {
+		return nil, err
+	}
+
+	req := utils.PatchTagRequest{Name: tag.Name, Color: tag.Color}
+	if err := utils.ApplyMergePatch(&req, patch); err != nil {
+		return nil, err
+	}
+
+	if req.Name != tag.Name {
+		if err := ensureUnique(ctx, ts.repos.Tags.NameTaken, "name", req.Name, tag.ID); err != nil {
+			return nil, err
+		}
+	}
+	tag.Name = req.Name
+	tag.Color = req.Color
+
+	if err := ts.repos.Tags.Save(ctx, tag); err != nil {
+		return nil, versionConflict(ctx, "tag", err)
+	}
+
+	return toTagResponse(tag), nil
+}
+
+// DeleteTag deletes a tag and detaches it from every report
+func (ts *TagService) DeleteTag(ctx context.Context, id uint) error {
+	tag, err := ts.repos.Tags.FindByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, repositories.ErrNotFound) {
+			return utils.NotFound("tag")
+		}
+		return err
+	}
+
+	if err := checkVersion(ctx, "tag", tag.Version); err != nil {
+		return err
+	}
+
+	return versionConflict(ctx, "tag", ts.repos.Tags.Delete(ctx, tag))
+}
+
+// toTagResponse converts a tag model into its response DTO
+func toTagResponse(tag *models.Tag) *utils.TagResponse {
+	return &utils.TagResponse{
+		ID:      tag.ID,
+		Name:    tag.Name,
+		Color:   tag.Color,
+		Version: tag.Version,
+	}
+}
+
+// toTagResponses converts loaded report tags into response DTOs
+func toTagResponses(tags []models.Tag) []utils.TagResponse {
+	if len(tags) == 0 {
+		return nil
+	}
+	responses := make([]utils.TagResponse, len(tags))
+	for i := range tags {
+		responses[i] = *toTagResponse(&tags[i])
+	}
+	return responses
+}
@@ -0,0 +1,19 @@
+package services
+
+import (
+	"testing"
+
+	"incident-report/testutil"
+
+	"gorm.io/gorm"
+)
+
+// setupTestDB opens a freshly migrated database for a single test
+// The tree cache is shared by every TreeService, so it is emptied as well
+func setupTestDB(t *testing.T) *gorm.DB {
+	t.Helper()
+
+	db := testutil.OpenDB(t)
+	invalidateHierarchyTree()
+	return db
+}
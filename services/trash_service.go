@@ -0,0 +1,131 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"incident-report/models"
+	"incident-report/repositories"
+	"incident-report/utils"
+)
+
+// trashNouns names a single record of every soft-deletable type in messages
+var trashNouns = map[string]string{
+	"buildings":            "building",
+	"floors":               "floor",
+	"rooms":                "room",
+	"component-categories": "component category",
+	"components":           "component",
+	"reports":              "report",
+	"users":                "user",
+}
+
+// TrashService handles listing, restoring and purging soft-deleted records
+type TrashService struct {
+	repos *repositories.Repositories
+}
+
+// NewTrashService creates a new instance of TrashService
+func NewTrashService(repos *repositories.Repositories) *TrashService {
+	return &TrashService{repos: repos}
+}
+
+// ListTrash retrieves deleted records of one type, or of every type, most recently deleted first
+func (ts *TrashService) ListTrash(ctx context.Context, entityType string, pagination *utils.PaginationQuery) ([]utils.TrashItemResponse, utils.PageInfo, error) {
+	page, err := pageOf(pagination)
+	if err != nil {
+		return nil, utils.PageInfo{}, err
+	}
+	items, info, err := ts.repos.Trash.List(ctx, entityType, page)
+	if err != nil {
+		return nil, utils.PageInfo{}, err
+	}
+
+	responses := make([]utils.TrashItemResponse, len(items))
+	for i, item := range items {
+		responses[i] = toTrashItemResponse(&item)
+	}
+	return responses, info, nil
+}
+
+// Restore undeletes a record together with the children that were deleted with it
+// A record cannot come back while the record it belongs to is still deleted.
+func (ts *TrashService) Restore(ctx context.Context, entityType string, id uint) (*utils.TrashItemResponse, error) {
+	noun := trashNouns[entityType]
+	item, err := ts.findDeleted(ctx, entityType, id)
+	if err != nil {
+		return nil, err
+	}
+
+	parent, err := ts.repos.Trash.DeletedParent(ctx, entityType, id)
+	if err != nil {
+		return nil, err
+	}
+	if parent != "" {
+		return nil, utils.ConflictState(fmt.Sprintf("the %s of this %s is deleted; restore it first", trashNouns[parent], noun))
+	}
+
+	if err := ts.repos.Trash.Restore(ctx, entityType, id); err != nil {
+		return nil, err
+	}
+	invalidateHierarchyTree()
+
+	item.DeletedAt = nil
+	response := toTrashItemResponse(item)
+	return &response, nil
+}
+
+// Purge permanently deletes a record from the trash
+// Only admins may purge, and only records that nothing references any more.
+func (ts *TrashService) Purge(ctx context.Context, entityType string, id uint) error {
+	if actor, ok := utils.ActorFromContext(ctx); !ok || actor.Role != models.RoleAdmin {
+		return utils.Forbidden("only admins can purge deleted records")
+	}
+
+	noun := trashNouns[entityType]
+	if _, err := ts.findDeleted(ctx, entityType, id); err != nil {
+		return err
+	}
+
+	dependents, err := ts.repos.Trash.Dependents(ctx, entityType, id)
+	if err != nil {
+		return err
+	}
+	if dependents > 0 {
+		return utils.ConflictState(fmt.Sprintf("%s is still referenced by %d records; purge them first", noun, dependents))
+	}
+
+	if err := ts.repos.Trash.Purge(ctx, entityType, id); err != nil {
+		return err
+	}
+	utils.Logger(ctx).InfoContext(ctx, "purged deleted record", "entity", entityType, "id", id)
+
+	invalidateHierarchyTree()
+	return nil
+}
+
+// findDeleted loads a record that is in the trash
+func (ts *TrashService) findDeleted(ctx context.Context, entityType string, id uint) (*repositories.TrashItem, error) {
+	noun := trashNouns[entityType]
+	item, err := ts.repos.Trash.Find(ctx, entityType, id)
+	if err != nil {
+		if errors.Is(err, repositories.ErrNotFound) {
+			return nil, utils.NotFound(noun)
+		}
+		return nil, err
+	}
+	if item.DeletedAt == nil {
+		return nil, utils.ConflictState(noun + " is not deleted")
+	}
+	return item, nil
+}
+
+// toTrashItemResponse converts a trash record into its response DTO
+func toTrashItemResponse(item *repositories.TrashItem) utils.TrashItemResponse {
+	return utils.TrashItemResponse{
+		Type:      item.Type,
+		ID:        item.ID,
+		Name:      item.Name,
+		DeletedAt: item.DeletedAt,
+	}
+}
@@ -0,0 +1,168 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"incident-report/models"
+	"incident-report/repositories"
+	"incident-report/utils"
+
+	"gorm.io/gorm"
+)
+
+// stampDeleted soft-deletes rows with one shared timestamp, as a cascading delete does
+func stampDeleted(t *testing.T, db *gorm.DB, stamp time.Time, table string, ids ...uint) {
+	t.Helper()
+
+	if err := db.Table(table).Where("id IN ?", ids).UpdateColumn("deleted_at", stamp).Error; err != nil {
+		t.Fatalf("stamp %s: %v", table, err)
+	}
+}
+
+// asAdmin returns a context whose actor is an admin
+func asAdmin(ctx context.Context) context.Context {
+	return utils.WithActor(ctx, utils.Actor{UserID: 1, Role: models.RoleAdmin})
+}
+
+func TestRestoreBringsBackSubtreeDeletedTogether(t *testing.T) {
+	ctx := context.Background()
+	db := setupTestDB(t)
+	repos := repositories.New(db)
+	h := seedHierarchy(t, repos)
+	trash := NewTrashService(repos)
+
+	// The report was deleted on its own before the building went
+	if err := NewReportService(repos).DeleteReport(ctx, h.report); err != nil {
+		t.Fatal(err)
+	}
+	stamp := time.Now().Add(time.Minute)
+	stampDeleted(t, db, stamp, "buildings", h.building)
+	stampDeleted(t, db, stamp, "floors", h.floor)
+	stampDeleted(t, db, stamp, "rooms", h.room)
+	stampDeleted(t, db, stamp, "components", h.component)
+
+	items, info, err := trash.ListTrash(ctx, "", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if *info.Total != 5 || len(items) != 5 || items[4].Type != "reports" {
+		t.Fatalf("trash = %d %+v, want 5 records with the report last", *info.Total, items)
+	}
+	if _, info, _ := trash.ListTrash(ctx, "floors", nil); *info.Total != 1 {
+		t.Errorf("floors in trash = %d, want 1", *info.Total)
+	}
+
+	if _, err := trash.Restore(ctx, "rooms", h.room); !errors.Is(err, utils.ErrConflict) {
+		t.Errorf("restoring a room of a deleted floor: err = %v, want a conflict", err)
+	}
+
+	restored, err := trash.Restore(ctx, "buildings", h.building)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if restored.DeletedAt != nil {
+		t.Errorf("restored building still has deleted_at %v", restored.DeletedAt)
+	}
+	for _, row := range []struct {
+		model any
+		id    uint
+	}{
+		{&models.Building{}, h.building},
+		{&models.Floor{}, h.floor},
+		{&models.Room{}, h.room},
+		{&models.Component{}, h.component},
+	} {
+		if countLive(t, db, row.model, "id = ?", row.id) != 1 {
+			t.Errorf("%T %d was not restored", row.model, row.id)
+		}
+	}
+	if countLive(t, db, &models.Report{}, "id = ?", h.report) != 0 {
+		t.Error("a report deleted before its building was restored with it")
+	}
+
+	if _, err := trash.Restore(ctx, "buildings", h.building); !errors.Is(err, utils.ErrConflict) {
+		t.Errorf("restoring a live building: err = %v, want a conflict", err)
+	}
+	if _, err := trash.Restore(ctx, "buildings", 999); !errors.Is(err, utils.ErrNotFound) {
+		t.Errorf("restoring an unknown building: err = %v, want not found", err)
+	}
+
+	// The report comes back on its own, tags included
+	if _, err := trash.Restore(ctx, "reports", h.report); err != nil {
+		t.Fatal(err)
+	}
+	report, err := NewReportService(repos).GetReportByID(ctx, h.report)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(report.Tags) != 1 {
+		t.Errorf("restored report has %d tags, want 1", len(report.Tags))
+	}
+}
+
+func TestRestoreRejectsReusedCode(t *testing.T) {
+	ctx := context.Background()
+	db := setupTestDB(t)
+	repos := repositories.New(db)
+	h := seedHierarchy(t, repos)
+
+	completeReport(t, repos, h.report)
+	if err := NewBuildingService(repos).DeleteBuilding(ctx, h.building, true); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := NewBuildingService(repos).CreateBuilding(ctx, &utils.CreateBuildingRequest{Code: "B1", Name: "Rebuilt"}); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := NewTrashService(repos).Restore(ctx, "buildings", h.building); !errors.Is(err, utils.ErrConflict) {
+		t.Errorf("err = %v, want a conflict on the reused code", err)
+	}
+}
+
+func TestPurge(t *testing.T) {
+	ctx := context.Background()
+	db := setupTestDB(t)
+	repos := repositories.New(db)
+	h := seedHierarchy(t, repos)
+	trash := NewTrashService(repos)
+
+	if err := NewComponentService(repos).DeleteComponent(ctx, h.component); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := trash.Purge(ctx, "components", h.component); !errors.Is(err, utils.ErrForbidden) {
+		t.Errorf("purge without an admin: err = %v, want forbidden", err)
+	}
+	if err := trash.Purge(asAdmin(ctx), "components", h.component); !errors.Is(err, utils.ErrConflict) {
+		t.Errorf("purge with a report still attached: err = %v, want a conflict", err)
+	}
+	if err := trash.Purge(asAdmin(ctx), "reports", h.report); !errors.Is(err, utils.ErrConflict) {
+		t.Errorf("purge of a live report: err = %v, want a conflict", err)
+	}
+
+	if err := NewReportService(repos).DeleteReport(ctx, h.report); err != nil {
+		t.Fatal(err)
+	}
+	if err := trash.Purge(asAdmin(ctx), "reports", h.report); err != nil {
+		t.Fatalf("purge report: %v", err)
+	}
+	if err := trash.Purge(asAdmin(ctx), "components", h.component); err != nil {
+		t.Fatalf("purge component: %v", err)
+	}
+
+	if countRows(t, db, &models.Report{}, "id = ?", h.report) != 0 {
+		t.Error("purged report is still stored")
+	}
+	if countRows(t, db.Table("report_tags"), nil, "report_id = ?", h.report) != 0 {
+		t.Error("tag links of the purged report are still stored")
+	}
+	if countRows(t, db, &models.Component{}, "id = ?", h.component) != 0 {
+		t.Error("purged component is still stored")
+	}
+	if countLive(t, db, &models.Tag{}, "id = ?", h.tag) != 1 {
+		t.Error("purging a report removed its tag")
+	}
+}
@@ -0,0 +1,301 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"incident-report/models"
+	"incident-report/utils"
+	"strings"
+	"sync"
+
+	"gorm.io/gorm"
+)
+
+// Tree depth levels
+const (
+	TreeDepthBuildings = 1
+	TreeDepthFloors    = 2
+	TreeDepthRooms     = 3
+)
+
+// TreeService builds the Building → Floor → Room hierarchy used by location pickers
+type TreeService struct {
+	db *gorm.DB
+}
+
+// NewTreeService creates a new instance of TreeService
+func NewTreeService(db *gorm.DB) *TreeService {
+	return &TreeService{db: db}
+}
+
+// TreeOptions controls the shape of a hierarchy tree
+type TreeOptions struct {
+	Depth                  int
+	IncludeComponentCount  bool
+	IncludeOpenReportCount bool
+}
+
+// ParseTreeOptions converts the tree query parameters into TreeOptions
+func ParseTreeOptions(query *utils.TreeQuery) (TreeOptions, error) {
+	options := TreeOptions{Depth: query.Depth}
+	if options.Depth == 0 {
+		options.Depth = TreeDepthRooms
+	}
+
+	for _, include := range strings.Split(query.Include, ",") {
+		switch strings.TrimSpace(include) {
+		case "":
+		case utils.TreeIncludeComponentCount:
+			options.IncludeComponentCount = true
+		case utils.TreeIncludeOpenReportCount:
+			options.IncludeOpenReportCount = true
+		default:
+			return options, utils.InvalidField("include", "unknown include option: "+include)
+		}
+	}
+
+	return options, nil
+}
+
+// hierarchyTreeCache keeps built trees until a hierarchy mutation invalidates them
+// Every invalidation increments generation, so a tree built from data read before a mutation
+// is recognised and not stored after the mutation dropped the cache.
+var hierarchyTreeCache = struct {
+	sync.RWMutex
+	entries    map[string][]utils.BuildingTreeNode
+	generation uint64
+}{entries: make(map[string][]utils.BuildingTreeNode)}
+
+// invalidateHierarchyTree drops every cached tree
+// Called after any change to buildings, floors, rooms, components or reports
+func invalidateHierarchyTree() {
+	hierarchyTreeCache.Lock()
+	hierarchyTreeCache.entries = make(map[string][]utils.BuildingTreeNode)
+	hierarchyTreeCache.generation++
+	hierarchyTreeCache.Unlock()
+}
+
+// lookupTree returns a cached tree, if any, and the cache generation it was looked up in
+func lookupTree(key string) ([]utils.BuildingTreeNode, uint64, bool) {
+	hierarchyTreeCache.RLock()
+	defer hierarchyTreeCache.RUnlock()
+	tree, ok := hierarchyTreeCache.entries[key]
+	return tree, hierarchyTreeCache.generation, ok
+}
+
+// storeTree caches a tree unless the cache was invalidated since generation
+func storeTree(key string, generation uint64, tree []utils.BuildingTreeNode) {
+	hierarchyTreeCache.Lock()
+	defer hierarchyTreeCache.Unlock()
+	if hierarchyTreeCache.generation == generation {
+		hierarchyTreeCache.entries[key] = tree
+	}
+}
+
+// GetTree returns the hierarchy of every building
+func (ts *TreeService) GetTree(ctx context.Context, options TreeOptions) ([]utils.BuildingTreeNode, error) {
+	return ts.cachedTree(ctx, 0, options)
+}
+
+// GetBuildingTree returns the hierarchy of a single building
+func (ts *TreeService) GetBuildingTree(ctx context.Context, buildingID uint, options TreeOptions) (*utils.BuildingTreeNode, error) {
+	tree, err := ts.cachedTree(ctx, buildingID, options)
+	if err != nil {
+		return nil, err
+	}
+	if len(tree) == 0 {
+		return nil, utils.NotFound("building")
+	}
+	return &tree[0], nil
+}
+
+// cachedTree serves a tree from the cache, building it on a miss
+// A buildingID of 0 means every building. Entries are keyed by connection as well,
+// so services bound to different databases never see each other's trees
+func (ts *TreeService) cachedTree(ctx context.Context, buildingID uint, options TreeOptions) ([]utils.BuildingTreeNode, error) {
+	key := fmt.Sprintf("%p|%d|%d|%t|%t", ts.db, buildingID, options.Depth, options.IncludeComponentCount, options.IncludeOpenReportCount)
+
+	tree, generation, ok := lookupTree(key)
+	if ok {
+		return tree, nil
+	}
+
+	tree, err := ts.buildTree(ctx, buildingID, options)
+	if err != nil {
+		return nil, err
+	}
+	storeTree(key, generation, tree)
+
+	return tree, nil
+}
+
+// roomCount is a per-room aggregate row
+type roomCount struct {
+	RoomID uint
+	Count  int64
+}
+
+// buildTree loads the hierarchy with one query per level and aggregates counts bottom-up
+func (ts *TreeService) buildTree(ctx context.Context, buildingID uint, options TreeOptions) ([]utils.BuildingTreeNode, error) {
+	db := ts.db.WithContext(ctx)
+
+	var buildings []models.Building
+	query := db.Order("code")
+	if buildingID != 0 {
+		query = query.Where("id = ?", buildingID)
+	}
+	if err := query.Find(&buildings).Error; err != nil {
+		return nil, err
+	}
+	if len(buildings) == 0 {
+		return []utils.BuildingTreeNode{}, nil
+	}
+
+	buildingIDs := make([]uint, len(buildings))
+	for i, building := range buildings {
+		buildingIDs[i] = building.ID
+	}
+
+	var floors []models.Floor
+	if err := db.Where("building_id IN ?", buildingIDs).Order("number").Find(&floors).Error; err != nil {
+		return nil, err
+	}
+
+	floorIDs := make([]uint, len(floors))
+	for i, floor := range floors {
+		floorIDs[i] = floor.ID
+	}
+
+	var rooms []models.Room
+	if len(floorIDs) > 0 {
+		if err := db.Where("floor_id IN ?", floorIDs).Order("code").Find(&rooms).Error; err != nil {
+			return nil, err
+		}
+	}
+
+	roomIDs := make([]uint, len(rooms))
+	for i, room := range rooms {
+		roomIDs[i] = room.ID
+	}
+
+	var componentCounts, openReportCounts map[uint]int64
+	var err error
+	if options.IncludeComponentCount {
+		componentCounts, err = countByRoom(db.Model(&models.Component{}), roomIDs)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if options.IncludeOpenReportCount {
+		openReportCounts, err = countByRoom(
+			db.Model(&models.Report{}).Where("status IN ?", models.OpenReportStatuses), roomIDs)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Assemble rooms per floor
+	roomsByFloor := make(map[uint][]utils.RoomTreeNode)
+	for _, room := range rooms {
+		node := utils.RoomTreeNode{ID: room.ID, Code: room.Code, Name: room.Name}
+		if componentCounts != nil {
+			node.ComponentCount = countPointer(componentCounts[room.ID])
+		}
+		if openReportCounts != nil {
+			node.OpenReportCount = countPointer(openReportCounts[room.ID])
+		}
+		roomsByFloor[room.FloorID] = append(roomsByFloor[room.FloorID], node)
+	}
+
+	// Assemble floors per building, summing room counts
+	floorsByBuilding := make(map[uint][]utils.FloorTreeNode)
+	for _, floor := range floors {
+		node := utils.FloorTreeNode{ID: floor.ID, FloorNumber: floor.Number, Name: floor.Name}
+		roomNodes := roomsByFloor[floor.ID]
+		node.ComponentCount, node.OpenReportCount = sumRoomCounts(roomNodes, options)
+		if options.Depth >= TreeDepthRooms {
+			node.Rooms = roomNodes
+		}
+		floorsByBuilding[floor.BuildingID] = append(floorsByBuilding[floor.BuildingID], node)
+	}
+
+	tree := make([]utils.BuildingTreeNode, 0, len(buildings))
+	for _, building := range buildings {
+		node := utils.BuildingTreeNode{
+			ID:       building.ID,
+			Code:     building.Code,
+			Name:     building.Name,
+			Location: building.Location,
+		}
+		floorNodes := floorsByBuilding[building.ID]
+		node.ComponentCount, node.OpenReportCount = sumFloorCounts(floorNodes, options)
+		if options.Depth >= TreeDepthFloors {
+			node.Floors = floorNodes
+		}
+		tree = append(tree, node)
+	}
+
+	return tree, nil
+}
+
+// countByRoom counts rows of the given model grouped by room_id
+func countByRoom(query *gorm.DB, roomIDs []uint) (map[uint]int64, error) {
+	counts := make(map[uint]int64)
+	if len(roomIDs) == 0 {
+		return counts, nil
+	}
+
+	var rows []roomCount
+	err := query.Select("room_id, COUNT(*) AS count").
+		Where("room_id IN ?", roomIDs).
+		Group("room_id").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	for _, row := range rows {
+		counts[row.RoomID] = row.Count
+	}
+	return counts, nil
+}
+
+// sumRoomCounts totals the counts of a floor's rooms
+func sumRoomCounts(rooms []utils.RoomTreeNode, options TreeOptions) (*int64, *int64) {
+	var components, openReports int64
+	for _, room := range rooms {
+		if room.ComponentCount != nil {
+			components += *room.ComponentCount
+		}
+		if room.OpenReportCount != nil {
+			openReports += *room.OpenReportCount
+		}
+	}
+	return optionalCount(options.IncludeComponentCount, components), optionalCount(options.IncludeOpenReportCount, openReports)
+}
+
+// sumFloorCounts totals the counts of a building's floors
+func sumFloorCounts(floors []utils.FloorTreeNode, options TreeOptions) (*int64, *int64) {
+	var components, openReports int64
+	for _, floor := range floors {
+		if floor.ComponentCount != nil {
+			components += *floor.ComponentCount
+		}
+		if floor.OpenReportCount != nil {
+			openReports += *floor.OpenReportCount
+		}
+	}
+	return optionalCount(options.IncludeComponentCount, components), optionalCount(options.IncludeOpenReportCount, openReports)
+}
+
+// optionalCount returns a pointer to count when it was requested
+func optionalCount(included bool, count int64) *int64 {
+	if !included {
+		return nil
+	}
+	return countPointer(count)
+}
+
+func countPointer(count int64) *int64 {
+	return &count
+}
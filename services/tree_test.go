@@ -0,0 +1,49 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"incident-report/repositories"
+	"incident-report/utils"
+
+	"gorm.io/gorm"
+)
+
+func TestTreeCacheDropsTreesBuiltBeforeInvalidation(t *testing.T) {
+	ctx := context.Background()
+	db := setupTestDB(t)
+	repos := repositories.New(db)
+	h := seedHierarchy(t, repos)
+	tree := NewTreeService(db)
+
+	// Rename the building while the tree is being built, after its buildings were read
+	renamed := false
+	err := db.Callback().Query().After("gorm:query").Register("test:rename_during_build", func(query *gorm.DB) {
+		if query.Statement.Table != "buildings" || renamed {
+			return
+		}
+		renamed = true
+		_, err := NewBuildingService(repos).UpdateBuilding(ctx, h.building, &utils.UpdateBuildingRequest{Name: "Renamed Building"})
+		if err != nil {
+			t.Errorf("rename building: %v", err)
+		}
+	})
+	if err != nil {
+		t.Fatalf("register callback: %v", err)
+	}
+	t.Cleanup(func() { db.Callback().Query().Remove("test:rename_during_build") })
+
+	invalidateHierarchyTree()
+	if _, err := tree.GetTree(ctx, TreeOptions{}); err != nil {
+		t.Fatalf("get tree: %v", err)
+	}
+
+	nodes, err := tree.GetTree(ctx, TreeOptions{})
+	if err != nil {
+		t.Fatalf("get tree: %v", err)
+	}
+	if len(nodes) != 1 || nodes[0].Name != "Renamed Building" {
+		t.Errorf("tree after the rename = %+v, want the renamed building", nodes)
+	}
+}
@@ -0,0 +1,20 @@
+package services
+
+import (
+	"context"
+	"incident-report/utils"
+)
+
+// ensureUnique rejects value when another live record already holds it
+// The unique index still has the final word under concurrent writes; this check turns the
+// common case into a clean conflict before anything is written.
+func ensureUnique(ctx context.Context, taken func(ctx context.Context, value string, exceptID uint) (bool, error), field, value string, exceptID uint) error {
+	exists, err := taken(ctx, value, exceptID)
+	if err != nil {
+		return err
+	}
+	if exists {
+		return utils.Conflict(field, field+" already exists")
+	}
+	return nil
+}
@@ -0,0 +1,120 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"incident-report/models"
+	"incident-report/repositories"
+	"incident-report/utils"
+)
+
+// TestUniqueIndexConflicts inserts duplicates past the service pre-checks, so the unique
+// indexes themselves must reject them and the driver error must name the field
+func TestUniqueIndexConflicts(t *testing.T) {
+	ctx := context.Background()
+	db := setupTestDB(t)
+	repos := repositories.New(db)
+	h := seedHierarchy(t, repos)
+
+	var floorID uint
+	if err := db.Model(&models.Room{}).Select("floor_id").Where("id = ?", h.room).Scan(&floorID).Error; err != nil {
+		t.Fatal(err)
+	}
+	var categoryID uint
+	if err := db.Model(&models.Component{}).Select("category_id").Where("id = ?", h.component).Scan(&categoryID).Error; err != nil {
+		t.Fatal(err)
+	}
+	if err := repos.Users.Create(ctx, &models.User{Name: "Alice", Email: "alice@example.com"}); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name   string
+		field  string
+		insert func() error
+	}{
+		{"user", "email", func() error {
+			return repos.Users.Create(ctx, &models.User{Name: "Alice Again", Email: "alice@example.com"})
+		}},
+		{"building", "code", func() error {
+			return repos.Buildings.Create(ctx, &models.Building{Code: "B1", Name: "Copy"})
+		}},
+		{"room", "code", func() error {
+			return repos.Rooms.Create(ctx, &models.Room{FloorID: floorID, Code: "R101", Name: "Copy"})
+		}},
+		{"category", "code", func() error {
+			return repos.ComponentCategories.Create(ctx, &models.ComponentCategory{Code: "PRJ", Name: "Copy"})
+		}},
+		{"component", "code", func() error {
+			return repos.Components.Create(ctx, &models.Component{CategoryID: categoryID, Code: "PRJ-1", Name: "Copy"})
+		}},
+		{"tag", "name", func() error {
+			return repos.Tags.Create(ctx, &models.Tag{Name: "electrical", Color: defaultTagColor})
+		}},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.insert()
+			if !errors.Is(err, utils.ErrConflict) {
+				t.Fatalf("err = %v, want a conflict", err)
+			}
+			var appErr *utils.AppError
+			if !errors.As(err, &appErr) || len(appErr.Fields) != 1 || appErr.Fields[0].Field != tc.field {
+				t.Errorf("conflict = %+v, want it on %s", appErr, tc.field)
+			}
+		})
+	}
+}
+
+func TestSoftDeletedCodesCanBeReused(t *testing.T) {
+	ctx := context.Background()
+	db := setupTestDB(t)
+	repos := repositories.New(db)
+	h := seedHierarchy(t, repos)
+
+	if err := NewComponentService(repos).DeleteComponent(ctx, h.component); err != nil {
+		t.Fatal(err)
+	}
+	if err := NewReportService(repos).DeleteReport(ctx, h.report); err != nil {
+		t.Fatal(err)
+	}
+	if err := NewRoomService(repos).DeleteRoom(ctx, h.room, false); err != nil {
+		t.Fatal(err)
+	}
+	if err := NewFloorService(repos).DeleteFloor(ctx, h.floor, false); err != nil {
+		t.Fatal(err)
+	}
+	if err := NewBuildingService(repos).DeleteBuilding(ctx, h.building, false); err != nil {
+		t.Fatal(err)
+	}
+
+	building, err := NewBuildingService(repos).CreateBuilding(ctx, &utils.CreateBuildingRequest{Code: "B1", Name: "Rebuilt"})
+	if err != nil {
+		t.Fatalf("reuse building code: %v", err)
+	}
+	floor, err := NewFloorService(repos).CreateFloor(ctx, &utils.CreateFloorRequest{BuildingID: building.ID, FloorNumber: 1, Name: "Ground"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	room, err := NewRoomService(repos).CreateRoom(ctx, &utils.CreateRoomRequest{FloorID: floor.ID, Code: "R101", Name: "Lab 101"})
+	if err != nil {
+		t.Fatalf("reuse room code: %v", err)
+	}
+	category, err := NewComponentCategoryService(repos).CreateComponentCategory(ctx, &utils.CreateComponentCategoryRequest{Code: "LGT", Name: "Lighting"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := NewComponentService(repos).CreateComponent(ctx, &utils.CreateComponentRequest{
+		RoomID: &room.ID, CategoryID: category.ID, Code: "PRJ-1", Name: "Projector 1",
+	}); err != nil {
+		t.Fatalf("reuse component code: %v", err)
+	}
+
+	// A second live record with the reused code is still rejected
+	if _, err := NewBuildingService(repos).CreateBuilding(ctx, &utils.CreateBuildingRequest{Code: "B1", Name: "Copy"}); !errors.Is(err, utils.ErrConflict) {
+		t.Errorf("err = %v, want a conflict", err)
+	}
+}
@@ -1,122 +1,115 @@
 package services
 
 import (
+	"context"
 	"errors"
-	"gorm.io/gorm"
-	"incident-report/config"
 	"incident-report/models"
+	"incident-report/repositories"
 	"incident-report/utils"
 )
 
 // UserService handles all user-related business logic
-type UserService struct{}
+type UserService struct {
+	repos *repositories.Repositories
+}
 
 // NewUserService creates a new instance of UserService
-func NewUserService() *UserService {
-	return &UserService{}
+func NewUserService(repos *repositories.Repositories) *UserService {
+	return &UserService{repos: repos}
 }
 
 // CreateUser creates a new user in the database
-func (us *UserService) CreateUser(req *utils.CreateUserRequest) (*utils.UserResponse, error) {
+func (us *UserService) CreateUser(ctx context.Context, req *utils.CreateUserRequest) (*utils.UserResponse, error) {
 	// Validate input
 	if req.Name == "" || req.Email == "" {
-		return nil, errors.New("name and email are required")
+		return nil, utils.Validation("name and email are required")
+	}
+
+	if err := ensureUnique(ctx, us.repos.Users.EmailTaken, "email", req.Email, 0); err != nil {
+		return nil, err
 	}
 
 	// Create user model instance
 	user := models.User{
 		Name:  req.Name,
 		Email: req.Email,
+		Role:  models.RoleTechnician,
 	}
 
 	// Save to database
-	result := config.DB.Create(&user)
-	if result.Error != nil {
-		// Check for duplicate email error
-		if result.Error.Error() == "UNIQUE constraint failed: users.email" {
-			return nil, errors.New("email already exists")
-		}
-		return nil, result.Error
+	if err := us.repos.Users.Create(ctx, &user); err != nil {
+		return nil, err
 	}
 
 	// Return user response DTO
 	return &utils.UserResponse{
-		ID:    user.ID,
-		Name:  user.Name,
-		Email: user.Email,
+		ID:      user.ID,
+		Name:    user.Name,
+		Email:   user.Email,
+		Role:    user.Role,
+		Version: user.Version,
 	}, nil
 }
 
 // GetUserByID retrieves a user by their ID
-func (us *UserService) GetUserByID(id uint) (*utils.UserResponse, error) {
-	var user models.User
-
+func (us *UserService) GetUserByID(ctx context.Context, id uint) (*utils.UserResponse, error) {
 	// Query database for user with given ID
-	result := config.DB.First(&user, id)
-	if result.Error != nil {
-		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
-			return nil, errors.New("user not found")
+	user, err := us.repos.Users.FindByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, repositories.ErrNotFound) {
+			return nil, utils.NotFound("user")
 		}
-		return nil, result.Error
+		return nil, err
 	}
 
 	return &utils.UserResponse{
-		ID:    user.ID,
-		Name:  user.Name,
-		Email: user.Email,
+		ID:      user.ID,
+		Name:    user.Name,
+		Email:   user.Email,
+		Role:    user.Role,
+		Version: user.Version,
 	}, nil
 }
 
 // GetAllUsers retrieves all users with pagination support
-func (us *UserService) GetAllUsers(page int, pageSize int) ([]utils.UserResponse, int64, error) {
-	// Set default pagination values
-	if page <= 0 {
-		page = 1
-	}
-	if pageSize <= 0 || pageSize > 100 {
-		pageSize = 10
-	}
-
-	var users []models.User
-	var total int64
-
-	// Count total records
-	if err := config.DB.Model(&models.User{}).Count(&total).Error; err != nil {
-		return nil, 0, err
+func (us *UserService) GetAllUsers(ctx context.Context, pagination *utils.PaginationQuery) ([]utils.UserResponse, utils.PageInfo, error) {
+	page, err := pageOf(pagination)
+	if err != nil {
+		return nil, utils.PageInfo{}, err
 	}
-
-	// Calculate offset for pagination
-	offset := (page - 1) * pageSize
-
-	// Fetch paginated results
-	result := config.DB.Offset(offset).Limit(pageSize).Find(&users)
-	if result.Error != nil {
-		return nil, 0, result.Error
+	users, info, err := us.repos.Users.List(ctx, page)
+	if err != nil {
+		return nil, utils.PageInfo{}, err
 	}
 
 	// Convert to response DTOs
 	var responses []utils.UserResponse
 	for _, user := range users {
 		responses = append(responses, utils.UserResponse{
-			ID:    user.ID,
-			Name:  user.Name,
-			Email: user.Email,
+			ID:      user.ID,
+			Name:    user.Name,
+			Email:   user.Email,
+			Role:    user.Role,
+			Version: user.Version,
 		})
 	}
 
-	return responses, total, nil
+	return responses, info, nil
 }
 
 // UpdateUser updates an existing user's information
-func (us *UserService) UpdateUser(id uint, req *utils.UpdateUserRequest) (*utils.UserResponse, error) {
+func (us *UserService) UpdateUser(ctx context.Context, id uint, req *utils.UpdateUserRequest) (*utils.UserResponse, error) {
 	// Find user first
-	var user models.User
-	result := config.DB.First(&user, id)
-	if result.Error != nil {
-		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
-			return nil, errors.New("user not found")
+	user, err := us.repos.Users.FindByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, repositories.ErrNotFound) {
+			return nil, utils.NotFound("user")
 		}
-		return nil, result.Error
+		return nil, err
+	}
+
+	if err := checkVersion(ctx, "user", user.Version); err != nil {
+		return nil, err
 	}
 
 	// Update only provided fields
@@ -124,34 +117,105 @@ func (us *UserService) UpdateUser(id uint, req *utils.UpdateUserRequest) (*utils
 		user.Name = req.Name
 	}
 	if req.Email != "" {
+		if err := ensureUnique(ctx, us.repos.Users.EmailTaken, "email", req.Email, user.ID); err != nil {
+			return nil, err
+		}
 		user.Email = req.Email
 	}
+	if req.Role != "" && req.Role != user.Role {
+		if actor, ok := utils.ActorFromContext(ctx); !ok || actor.Role != models.RoleAdmin {
+			return nil, utils.Forbidden("only admins can change roles")
+		}
+		user.Role = req.Role
+	}
 
 	// Save changes to database
-	if err := config.DB.Save(&user).Error; err != nil {
+	if err := us.repos.Users.Save(ctx, user); err != nil {
+		return nil, versionConflict(ctx, "user", err)
+	}
+
+	return &utils.UserResponse{
+		ID:      user.ID,
+		Name:    user.Name,
+		Email:   user.Email,
+		Role:    user.Role,
+		Version: user.Version,
+	}, nil
+}
+
+// PatchUser applies a JSON Merge Patch to a user
+// Unlike UpdateUser, it tells absent fields from null ones, so optional fields can be cleared.
+func (us *UserService) PatchUser(ctx context.Context, id uint, patch []byte) (*utils.UserResponse, error) {
+	user, err := us.repos.Users.FindByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, repositories.ErrNotFound) {
+			return nil, utils.NotFound("user")
+		}
+		return nil, err
+	}
+
+	if err := checkVersion(ctx, "user", user.Version); err != nil {
+		return nil, err
+	}
+
+	req := utils.PatchUserRequest{Name: user.Name, Email: user.Email, Role: user.Role}
+	if err := utils.ApplyMergePatch(&req, patch); err != nil {
 		return nil, err
 	}
 
+	if req.Email != user.Email {
+		if err := ensureUnique(ctx, us.repos.Users.EmailTaken, "email", req.Email, user.ID); err != nil {
+			return nil, err
+		}
+	}
+	if req.Role != user.Role {
+		if actor, ok := utils.ActorFromContext(ctx); !ok || actor.Role != models.RoleAdmin {
+			return nil, utils.Forbidden("only admins can change roles")
+		}
+	}
+	user.Name = req.Name
+	user.Email = req.Email
+	user.Role = req.Role
+
+	if err := us.repos.Users.Save(ctx, user); err != nil {
+		return nil, versionConflict(ctx, "user", err)
+	}
+
 	return &utils.UserResponse{
-		ID:    user.ID,
-		Name:  user.Name,
-		Email: user.Email,
+		ID:      user.ID,
+		Name:    user.Name,
+		Email:   user.Email,
+		Role:    user.Role,
+		Version: user.Version,
 	}, nil
 }
 
 // DeleteUser performs a soft delete of a user (marks as deleted, doesn't remove from DB)
-func (us *UserService) DeleteUser(id uint) error {
+func (us *UserService) DeleteUser(ctx context.Context, id uint) error {
 	// Find user first to ensure it exists
-	var user models.User
-	result := config.DB.First(&user, id)
-	if result.Error != nil {
-		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
-			return errors.New("user not found")
+	user, err := us.repos.Users.FindByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, repositories.ErrNotFound) {
+			return utils.NotFound("user")
 		}
-		return result.Error
+		return err
+	}
+
+	if err := checkVersion(ctx, "user", user.Version); err != nil {
+		return err
 	}
 
 	// Perform soft delete (sets deleted_at timestamp)
-	result = config.DB.Delete(&user)
-	return result.Error
+	return versionConflict(ctx, "user", us.repos.Users.Delete(ctx, user))
+}
+
+// toUserResponse converts a user model into its response DTO
+func toUserResponse(user *models.User) *utils.UserResponse {
+	return &utils.UserResponse{
+		ID:      user.ID,
+		Name:    user.Name,
+		Email:   user.Email,
+		Role:    user.Role,
+		Version: user.Version,
+	}
 }
@@ -0,0 +1,31 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"incident-report/repositories"
+	"incident-report/utils"
+)
+
+// checkVersion enforces the If-Match precondition of the request, if it has one
+func checkVersion(ctx context.Context, noun string, current uint) error {
+	expected, ok := utils.ExpectedVersionFromContext(ctx)
+	if ok && expected != current {
+		return utils.PreconditionFailed(fmt.Sprintf("%s has changed; the current version is %d", noun, current))
+	}
+	return nil
+}
+
+// versionConflict translates a write that lost a race against another request
+// It is a failed precondition when the request named a version with If-Match, and a
+// conflict otherwise, since the client could not know that it was out of date.
+func versionConflict(ctx context.Context, noun string, err error) error {
+	if !errors.Is(err, repositories.ErrStaleVersion) {
+		return err
+	}
+	if _, ok := utils.ExpectedVersionFromContext(ctx); ok {
+		return utils.PreconditionFailed(noun + " has changed")
+	}
+	return utils.ConflictState(noun + " was changed by another request; reload it and try again")
+}
@@ -0,0 +1,145 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"incident-report/repositories"
+	"incident-report/utils"
+)
+
+func TestUpdateIncrementsVersion(t *testing.T) {
+	ctx := context.Background()
+	repos := repositories.New(setupTestDB(t))
+	svc := NewTagService(repos)
+
+	tag, err := svc.CreateTag(ctx, &utils.CreateTagRequest{Name: "electrical"})
+	if err != nil {
+		t.Fatalf("create tag: %v", err)
+	}
+	if tag.Version != 1 {
+		t.Fatalf("version after create = %d, want 1", tag.Version)
+	}
+
+	for want := uint(2); want <= 3; want++ {
+		tag, err = svc.UpdateTag(ctx, tag.ID, &utils.UpdateTagRequest{Color: "#FF8800"})
+		if err != nil {
+			t.Fatalf("update tag: %v", err)
+		}
+		if tag.Version != want {
+			t.Errorf("version after update = %d, want %d", tag.Version, want)
+		}
+	}
+}
+
+func TestIfMatchPrecondition(t *testing.T) {
+	ctx := context.Background()
+	repos := repositories.New(setupTestDB(t))
+	svc := NewTagService(repos)
+
+	tag, err := svc.CreateTag(ctx, &utils.CreateTagRequest{Name: "electrical"})
+	if err != nil {
+		t.Fatalf("create tag: %v", err)
+	}
+
+	stale := utils.WithExpectedVersion(ctx, tag.Version+1)
+	_, err = svc.UpdateTag(stale, tag.ID, &utils.UpdateTagRequest{Color: "#FF8800"})
+	if !errors.Is(err, utils.ErrStale) {
+		t.Errorf("update with a stale version = %v, want a failed precondition", err)
+	}
+	if err := svc.DeleteTag(stale, tag.ID); !errors.Is(err, utils.ErrStale) {
+		t.Errorf("delete with a stale version = %v, want a failed precondition", err)
+	}
+
+	current := utils.WithExpectedVersion(ctx, tag.Version)
+	updated, err := svc.UpdateTag(current, tag.ID, &utils.UpdateTagRequest{Color: "#FF8800"})
+	if err != nil {
+		t.Fatalf("update with the current version: %v", err)
+	}
+	if err := svc.DeleteTag(utils.WithExpectedVersion(ctx, updated.Version), tag.ID); err != nil {
+		t.Errorf("delete with the current version: %v", err)
+	}
+}
+
+func TestConcurrentSaveLosesRace(t *testing.T) {
+	ctx := context.Background()
+	repos := repositories.New(setupTestDB(t))
+	h := seedHierarchy(t, repos)
+
+	// Both requests read version 1; the second write must not overwrite the first
+	first, err := repos.Rooms.FindByID(ctx, h.room)
+	if err != nil {
+		t.Fatalf("find room: %v", err)
+	}
+	second, err := repos.Rooms.FindByID(ctx, h.room)
+	if err != nil {
+		t.Fatalf("find room: %v", err)
+	}
+
+	first.Name = "Lab 101A"
+	if err := repos.Rooms.Save(ctx, first); err != nil {
+		t.Fatalf("first save: %v", err)
+	}
+	second.Name = "Lab 101B"
+	if err := repos.Rooms.Save(ctx, second); !errors.Is(err, repositories.ErrStaleVersion) {
+		t.Fatalf("second save = %v, want ErrStaleVersion", err)
+	}
+	if second.Version != 1 {
+		t.Errorf("version of the rejected record = %d, want it left at 1", second.Version)
+	}
+
+	stored, err := repos.Rooms.FindByID(ctx, h.room)
+	if err != nil {
+		t.Fatalf("find room: %v", err)
+	}
+	if stored.Name != "Lab 101A" || stored.Version != 2 {
+		t.Errorf("stored room = %q at version %d, want %q at version 2", stored.Name, stored.Version, "Lab 101A")
+	}
+
+	err = versionConflict(ctx, "room", repositories.ErrStaleVersion)
+	if !errors.Is(err, utils.ErrConflict) {
+		t.Errorf("lost race without If-Match = %v, want a conflict", err)
+	}
+	err = versionConflict(utils.WithExpectedVersion(ctx, 1), "room", repositories.ErrStaleVersion)
+	if !errors.Is(err, utils.ErrStale) {
+		t.Errorf("lost race with If-Match = %v, want a failed precondition", err)
+	}
+}
+
+func TestTagChangesIncrementReportVersion(t *testing.T) {
+	ctx := context.Background()
+	repos := repositories.New(setupTestDB(t))
+	h := seedHierarchy(t, repos)
+	svc := NewReportService(repos)
+
+	report, err := svc.GetReportByID(ctx, h.report)
+	if err != nil {
+		t.Fatalf("get report: %v", err)
+	}
+
+	stale := utils.WithExpectedVersion(ctx, report.Version+1)
+	if _, err := svc.AddTagsToReport(stale, h.report, []uint{h.tag}); !errors.Is(err, utils.ErrStale) {
+		t.Errorf("add tags with a stale version = %v, want a failed precondition", err)
+	}
+	if _, err := svc.RemoveTagFromReport(stale, h.report, h.tag); !errors.Is(err, utils.ErrStale) {
+		t.Errorf("remove tag with a stale version = %v, want a failed precondition", err)
+	}
+
+	added, err := svc.AddTagsToReport(utils.WithExpectedVersion(ctx, report.Version), h.report, []uint{h.tag})
+	if err != nil {
+		t.Fatalf("add tags with the current version: %v", err)
+	}
+	if added.Version != report.Version+1 {
+		t.Errorf("version after adding a tag = %d, want %d", added.Version, report.Version+1)
+	}
+
+	removed, err := svc.RemoveTagFromReport(ctx, h.report, h.tag)
+	if err != nil {
+		t.Fatalf("remove tag: %v", err)
+	}
+	if removed.Version != added.Version+1 || len(removed.Tags) != 0 {
+		t.Errorf("report after removing the tag = version %d with %d tags, want version %d without tags",
+			removed.Version, len(removed.Tags), added.Version+1)
+	}
+}
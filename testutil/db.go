@@ -0,0 +1,56 @@
+// Package testutil holds helpers shared by the test suites of several packages
+package testutil
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"incident-report/config"
+	"incident-report/migrations"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// OpenDB opens a freshly migrated database for a single test and closes it when the test ends
+// Tests run on a throwaway SQLite file by default. Set TEST_DB_DRIVER and TEST_DB_DSN
+// to run them against a scratch MySQL or PostgreSQL database instead; its schema is
+// dropped and recreated for every test.
+func OpenDB(t testing.TB) *gorm.DB {
+	t.Helper()
+
+	driver := os.Getenv("TEST_DB_DRIVER")
+	dsn := os.Getenv("TEST_DB_DSN")
+	if driver == "" || driver == config.DriverSQLite {
+		driver = config.DriverSQLite
+		if dsn == "" {
+			dsn = filepath.Join(t.TempDir(), "test.db") + "?_pragma=foreign_keys(1)&_pragma=busy_timeout(5000)"
+		}
+	}
+	t.Setenv("DB_DRIVER", driver)
+	t.Setenv("DB_DSN", dsn)
+
+	dialector, err := config.Dialector()
+	if err != nil {
+		t.Fatalf("configure database: %v", err)
+	}
+	db, err := gorm.Open(dialector, &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	if err != nil {
+		t.Fatalf("open database: %v", err)
+	}
+
+	if _, err := migrations.Down(db, len(migrations.All())); err != nil {
+		t.Fatalf("reset schema: %v", err)
+	}
+	if _, err := migrations.Up(db, 0); err != nil {
+		t.Fatalf("migrate: %v", err)
+	}
+
+	t.Cleanup(func() {
+		if sqlDB, err := db.DB(); err == nil {
+			sqlDB.Close()
+		}
+	})
+	return db
+}
@@ -0,0 +1,23 @@
+package utils
+
+import "context"
+
+// Actor is the user on whose behalf a request runs
+type Actor struct {
+	UserID uint
+	Role   string
+}
+
+// actorKey is the context key of the current Actor
+type actorKey struct{}
+
+// WithActor returns a copy of ctx that carries the actor
+func WithActor(ctx context.Context, actor Actor) context.Context {
+	return context.WithValue(ctx, actorKey{}, actor)
+}
+
+// ActorFromContext returns the actor of the request, if the caller identified itself
+func ActorFromContext(ctx context.Context) (Actor, bool) {
+	actor, ok := ctx.Value(actorKey{}).(Actor)
+	return actor, ok
+}
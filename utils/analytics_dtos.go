@@ -0,0 +1,90 @@
+package utils
+
+// ===== Analytics DTOs =====
+
+// AnalyticsQuery represents the filters shared by every analytics endpoint
+// From and To are inclusive calendar dates (YYYY-MM-DD).
+type AnalyticsQuery struct {
+	From       string `form:"from" binding:"omitempty,datetime=2006-01-02"`
+	To         string `form:"to" binding:"omitempty,datetime=2006-01-02"`
+	BuildingID uint   `form:"building_id" binding:"omitempty"`
+}
+
+// VolumeQuery represents the query parameters of the report volume endpoint
+type VolumeQuery struct {
+	AnalyticsQuery
+	Interval string `form:"interval" binding:"omitempty,oneof=day week"`
+}
+
+// TopNQuery represents the query parameters of ranking endpoints
+type TopNQuery struct {
+	AnalyticsQuery
+	Limit int `form:"limit" binding:"omitempty,min=1,max=100"`
+}
+
+// VolumeBucket represents the number of reports opened and closed in a period
+type VolumeBucket struct {
+	Period string `json:"period"`
+	Opened int64  `json:"opened"`
+	Closed int64  `json:"closed"`
+}
+
+// ResolutionTimeResponse represents mean and median time to resolve in seconds
+type ResolutionTimeResponse struct {
+	Resolved      int64   `json:"resolved"`
+	MeanSeconds   float64 `json:"mean_seconds"`
+	MedianSeconds float64 `json:"median_seconds"`
+}
+
+// ComponentFailureCount represents how often a component was reported
+type ComponentFailureCount struct {
+	ComponentID   uint   `json:"component_id"`
+	ComponentCode string `json:"component_code"`
+	ComponentName string `json:"component_name"`
+	Reports       int64  `json:"reports"`
+}
+
+// CategoryFailureCount represents how often components of a category were reported
+type CategoryFailureCount struct {
+	CategoryID   uint   `json:"category_id"`
+	CategoryCode string `json:"category_code"`
+	CategoryName string `json:"category_name"`
+	Reports      int64  `json:"reports"`
+}
+
+// BuildingHotspot represents the report load of a building
+type BuildingHotspot struct {
+	BuildingID   uint   `json:"building_id"`
+	BuildingCode string `json:"building_code"`
+	BuildingName string `json:"building_name"`
+	Reports      int64  `json:"reports"`
+	OpenReports  int64  `json:"open_reports"`
+}
+
+// FloorHotspot represents the report load of a floor
+type FloorHotspot struct {
+	FloorID     uint   `json:"floor_id"`
+	FloorNumber int    `json:"floor_number"`
+	FloorName   string `json:"floor_name"`
+	BuildingID  uint   `json:"building_id"`
+	Reports     int64  `json:"reports"`
+	OpenReports int64  `json:"open_reports"`
+}
+
+// TechnicianThroughput represents the workload and output of an assigned user
+type TechnicianThroughput struct {
+	UserID             uint    `json:"user_id"`
+	UserName           string  `json:"user_name"`
+	Assigned           int64   `json:"assigned"`
+	Completed          int64   `json:"completed"`
+	MeanResolveSeconds float64 `json:"mean_resolve_seconds"`
+}
+
+// TagCount represents how many reports carry a tag
+type TagCount struct {
+	TagID       uint   `json:"tag_id"`
+	TagName     string `json:"tag_name"`
+	TagColor    string `json:"tag_color"`
+	Reports     int64  `json:"reports"`
+	OpenReports int64  `json:"open_reports"`
+}
@@ -0,0 +1,33 @@
+package utils
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// ===== Audit DTOs =====
+
+// AuditQuery represents the filters of the audit log
+// From and To are inclusive calendar dates (YYYY-MM-DD).
+type AuditQuery struct {
+	PaginationQuery
+	Entity   string `form:"entity" binding:"omitempty,oneof=users buildings floors rooms component-categories components reports tags"`
+	EntityID uint   `form:"entity_id"`
+	Actor    uint   `form:"actor"`
+	From     string `form:"from" binding:"omitempty,datetime=2006-01-02"`
+	To       string `form:"to" binding:"omitempty,datetime=2006-01-02"`
+}
+
+// AuditLogResponse represents an audit log entry in API responses
+// Changes maps every changed column to its before and after value.
+type AuditLogResponse struct {
+	ID         uint            `json:"id"`
+	ActorID    *uint           `json:"actor_id"`
+	EntityType string          `json:"entity_type"`
+	EntityID   uint            `json:"entity_id"`
+	Action     string          `json:"action"`
+	Changes    json.RawMessage `json:"changes"`
+	IP         string          `json:"ip"`
+	RequestID  string          `json:"request_id"`
+	CreatedAt  time.Time       `json:"created_at"`
+}
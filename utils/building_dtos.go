@@ -16,6 +16,13 @@ type UpdateBuildingRequest struct {
 	Location string `json:"location" binding:"omitempty,max=500"`
 }
 
+// PatchBuildingRequest is the patchable state of a building, which a merge patch is applied to
+type PatchBuildingRequest struct {
+	Code     string `json:"code" binding:"required,min=1,max=100"`
+	Name     string `json:"name" binding:"required,min=2,max=255"`
+	Location string `json:"location" binding:"max=500"`
+}
+
 // BuildingResponse represents building response
 type BuildingResponse struct {
 	ID        uint   `json:"id"`
@@ -24,6 +31,7 @@ type BuildingResponse struct {
 	Location  string `json:"location"`
 	CreatedAt int64  `json:"created_at"`
 	UpdatedAt int64  `json:"updated_at"`
+	Version   uint   `json:"version"`
 }
 
 // ===== Floor DTOs =====
@@ -41,6 +49,12 @@ type UpdateFloorRequest struct {
 	Name        string `json:"name" binding:"omitempty,min=2,max=255"`
 }
 
+// PatchFloorRequest is the patchable state of a floor; unlike an update, it can set floor number 0
+type PatchFloorRequest struct {
+	FloorNumber int    `json:"floor_number"`
+	Name        string `json:"name" binding:"required,min=2,max=255"`
+}
+
 // FloorResponse represents floor response
 type FloorResponse struct {
 	ID          uint              `json:"id"`
@@ -50,6 +64,7 @@ type FloorResponse struct {
 	Name        string            `json:"name"`
 	CreatedAt   int64             `json:"created_at"`
 	UpdatedAt   int64             `json:"updated_at"`
+	Version     uint              `json:"version"`
 }
 
 // ===== Room DTOs =====
@@ -67,6 +82,12 @@ type UpdateRoomRequest struct {
 	Name string `json:"name" binding:"omitempty,min=2,max=255"`
 }
 
+// PatchRoomRequest is the patchable state of a room
+type PatchRoomRequest struct {
+	Code string `json:"code" binding:"required,min=1,max=100"`
+	Name string `json:"name" binding:"required,min=2,max=255"`
+}
+
 // RoomResponse represents room response
 type RoomResponse struct {
 	ID        uint           `json:"id"`
@@ -76,6 +97,7 @@ type RoomResponse struct {
 	Name      string         `json:"name"`
 	CreatedAt int64          `json:"created_at"`
 	UpdatedAt int64          `json:"updated_at"`
+	Version   uint           `json:"version"`
 }
 
 // ===== ComponentCategory DTOs =====
@@ -94,6 +116,13 @@ type UpdateComponentCategoryRequest struct {
 	Description string `json:"description" binding:"omitempty"`
 }
 
+// PatchComponentCategoryRequest is the patchable state of a component category
+type PatchComponentCategoryRequest struct {
+	Code        string `json:"code" binding:"required,min=1,max=100"`
+	Name        string `json:"name" binding:"required,min=2,max=255"`
+	Description string `json:"description"`
+}
+
 // ComponentCategoryResponse represents component category response
 type ComponentCategoryResponse struct {
 	ID          uint   `json:"id"`
@@ -102,6 +131,7 @@ type ComponentCategoryResponse struct {
 	Description string `json:"description"`
 	CreatedAt   int64  `json:"created_at"`
 	UpdatedAt   int64  `json:"updated_at"`
+	Version     uint   `json:"version"`
 }
 
 // ===== Component DTOs =====
@@ -126,6 +156,16 @@ type UpdateComponentRequest struct {
 	ProcurementYear int    `json:"procurement_year" binding:"omitempty"`
 }
 
+// PatchComponentRequest is the patchable state of a component
+// The room is changed with assign-room, which checks that it exists.
+type PatchComponentRequest struct {
+	Code            string `json:"code" binding:"required,min=1,max=100"`
+	Name            string `json:"name" binding:"required,min=2,max=255"`
+	Brand           string `json:"brand" binding:"max=255"`
+	Specification   string `json:"specification"`
+	ProcurementYear int    `json:"procurement_year" binding:"min=0"`
+}
+
 // ComponentResponse represents component response
 type ComponentResponse struct {
 	ID              uint          `json:"id"`
@@ -139,9 +179,62 @@ type ComponentResponse struct {
 	ProcurementYear int           `json:"procurement_year"`
 	CreatedAt       int64         `json:"created_at"`
 	UpdatedAt       int64         `json:"updated_at"`
+	Version         uint          `json:"version"`
 }
 
 // AssignRoomRequest represents the request payload for assigning a room to a component
 type AssignRoomRequest struct {
 	RoomID uint `json:"room_id" binding:"required"`
 }
+
+// ComponentFilterQuery represents the filters accepted by the component list and export endpoints
+type ComponentFilterQuery struct {
+	RoomID     uint `form:"room_id" binding:"omitempty"`
+	CategoryID uint `form:"category_id" binding:"omitempty"`
+}
+
+// ===== Hierarchy Tree DTOs =====
+
+// Tree include options
+const (
+	TreeIncludeComponentCount  = "component_count"
+	TreeIncludeOpenReportCount = "open_report_count"
+)
+
+// TreeQuery represents the query parameters of the hierarchy tree endpoints
+// Depth 1 returns buildings only, 2 adds floors and 3 (default) adds rooms.
+// Include is a comma separated list of tree include options.
+type TreeQuery struct {
+	Depth   int    `form:"depth" binding:"omitempty,min=1,max=3"`
+	Include string `form:"include" binding:"omitempty"`
+}
+
+// BuildingTreeNode represents a building with its floors in the hierarchy tree
+type BuildingTreeNode struct {
+	ID              uint            `json:"id"`
+	Code            string          `json:"code"`
+	Name            string          `json:"name"`
+	Location        string          `json:"location"`
+	ComponentCount  *int64          `json:"component_count,omitempty"`
+	OpenReportCount *int64          `json:"open_report_count,omitempty"`
+	Floors          []FloorTreeNode `json:"floors,omitempty"`
+}
+
+// FloorTreeNode represents a floor with its rooms in the hierarchy tree
+type FloorTreeNode struct {
+	ID              uint           `json:"id"`
+	FloorNumber     int            `json:"floor_number"`
+	Name            string         `json:"name"`
+	ComponentCount  *int64         `json:"component_count,omitempty"`
+	OpenReportCount *int64         `json:"open_report_count,omitempty"`
+	Rooms           []RoomTreeNode `json:"rooms,omitempty"`
+}
+
+// RoomTreeNode represents a room leaf in the hierarchy tree
+type RoomTreeNode struct {
+	ID              uint   `json:"id"`
+	Code            string `json:"code"`
+	Name            string `json:"name"`
+	ComponentCount  *int64 `json:"component_count,omitempty"`
+	OpenReportCount *int64 `json:"open_report_count,omitempty"`
+}
@@ -0,0 +1,67 @@
+package utils
+
+// ===== Bulk DTOs =====
+
+// MaxBulkItems is the most records a single bulk request may change
+const MaxBulkItems = 500
+
+// Bulk modes
+// An atomic bulk request changes every record or none; a best-effort one keeps the changes that succeeded.
+const (
+	BulkModeAtomic     = "atomic"
+	BulkModeBestEffort = "best_effort"
+)
+
+// Bulk report actions
+const (
+	BulkActionAssign     = "assign"
+	BulkActionUnassign   = "unassign"
+	BulkActionTransition = "transition"
+	BulkActionTag        = "tag"
+	BulkActionDelete     = "delete"
+)
+
+// Outcomes of a single record of a bulk request
+// Records that succeeded in an atomic request that failed elsewhere are rolled back.
+const (
+	BulkItemOK         = "ok"
+	BulkItemFailed     = "failed"
+	BulkItemRolledBack = "rolled_back"
+)
+
+// BulkReportRequest represents a bulk action on reports, selected by ID or by filter
+// Assign takes user_id, transition takes status and tag takes tag_ids; unassign and delete take nothing.
+type BulkReportRequest struct {
+	Action string             `json:"action" binding:"required,oneof=assign unassign transition tag delete"`
+	IDs    []uint             `json:"ids" binding:"omitempty,max=500"`
+	Filter *ReportFilterQuery `json:"filter"`
+	Mode   string             `json:"mode" binding:"omitempty,oneof=atomic best_effort"`
+	UserID *uint              `json:"user_id"`
+	Status string             `json:"status" binding:"omitempty,oneof=PENDING IN_PROGRESS COMPLETED"`
+	TagIDs []uint             `json:"tag_ids"`
+}
+
+// BulkMoveComponentsRequest represents moving many components to one room
+type BulkMoveComponentsRequest struct {
+	IDs    []uint `json:"ids" binding:"required,min=1,max=500"`
+	RoomID uint   `json:"room_id" binding:"required"`
+	Mode   string `json:"mode" binding:"omitempty,oneof=atomic best_effort"`
+}
+
+// BulkItemResult reports the outcome of a bulk request for a single record
+type BulkItemResult struct {
+	ID     uint   `json:"id"`
+	Status string `json:"status"`
+	Code   string `json:"code,omitempty"`
+	Error  string `json:"error,omitempty"`
+}
+
+// BulkResponse reports the outcome of a bulk request
+// Committed is false when an atomic request was rolled back.
+type BulkResponse struct {
+	Mode      string           `json:"mode"`
+	Committed bool             `json:"committed"`
+	Succeeded int              `json:"succeeded"`
+	Failed    int              `json:"failed"`
+	Results   []BulkItemResult `json:"results"`
+}
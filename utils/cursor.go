@@ -0,0 +1,44 @@
+package utils
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"time"
+)
+
+// Cursor marks the position of a record in a sorted list
+// It holds the record's sort key: its ID and, for lists that are not sorted by ID alone, the
+// sorted name or time. Clients get and send it as an opaque string, see Encode.
+type Cursor struct {
+	ID   uint       `json:"id"`
+	Key  string     `json:"key,omitempty"`
+	Time *time.Time `json:"time,omitempty"`
+}
+
+// PageInfo describes where a page sits in its list
+type PageInfo struct {
+	Total      *int64 // nil when the list was not counted
+	NextCursor string // empty on the last page
+	PrevCursor string // empty on the first page
+}
+
+// Encode returns the opaque string form of the cursor
+func (c Cursor) Encode() string {
+	data, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// DecodeCursor parses a cursor returned by Encode
+func DecodeCursor(s string) (*Cursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, errors.New("malformed cursor")
+	}
+
+	var cursor Cursor
+	if err := json.Unmarshal(data, &cursor); err != nil || cursor.ID == 0 {
+		return nil, errors.New("malformed cursor")
+	}
+	return &cursor, nil
+}
@@ -7,64 +7,177 @@ type CreateUserRequest struct {
 }
 
 // UpdateUserRequest represents the request payload for updating a user
+// Changing the role is reserved to admins.
 type UpdateUserRequest struct {
 	Name  string `json:"name" binding:"omitempty,min=2,max=255"`
 	Email string `json:"email" binding:"omitempty,email"`
+	Role  string `json:"role" binding:"omitempty,oneof=technician admin"`
+}
+
+// PatchUserRequest is the patchable state of a user; changing the role is reserved to admins
+type PatchUserRequest struct {
+	Name  string `json:"name" binding:"required,min=2,max=255"`
+	Email string `json:"email" binding:"required,email"`
+	Role  string `json:"role" binding:"required,oneof=technician admin"`
 }
 
 // UserResponse represents the response payload for a user
 type UserResponse struct {
-	ID    uint   `json:"id"`
-	Name  string `json:"name"`
-	Email string `json:"email"`
+	ID      uint   `json:"id"`
+	Name    string `json:"name"`
+	Email   string `json:"email"`
+	Role    string `json:"role"`
+	Version uint   `json:"version"`
 }
 
 // PaginationQuery represents pagination parameters
+// A list is paged either by page number or by the after/before cursors of an earlier page.
+// Total turns counting the matching records on or off; it defaults to on for page numbers and
+// to off for cursors.
 type PaginationQuery struct {
-	Page     int `form:"page" binding:"omitempty,min=1"`
-	PageSize int `form:"page_size" binding:"omitempty,min=1,max=100"`
+	Page     int    `form:"page" binding:"omitempty,min=1,excluded_with=After Before"`
+	PageSize int    `form:"page_size" binding:"omitempty,min=1,max=100"`
+	After    string `form:"after" binding:"omitempty,excluded_with=Before"`
+	Before   string `form:"before" binding:"omitempty"`
+	Total    *bool  `form:"total" binding:"omitempty"`
+}
+
+// IsCursor reports whether the query pages by cursor rather than by page number
+func (q *PaginationQuery) IsCursor() bool {
+	return q != nil && (q.After != "" || q.Before != "")
 }
 
 // PaginatedResponse represents a paginated response
+// Page is only set when paging by page number, Total and TotalPage only when the list was
+// counted. NextCursor and PrevCursor lead to the neighbouring pages, when there are any.
 type PaginatedResponse struct {
-	Data      interface{} `json:"data"`
-	Page      int         `json:"page"`
-	PageSize  int         `json:"page_size"`
-	Total     int64       `json:"total"`
-	TotalPage int         `json:"total_page"`
+	Data       interface{} `json:"data"`
+	Page       int         `json:"page,omitempty"`
+	PageSize   int         `json:"page_size"`
+	Total      *int64      `json:"total,omitempty"`
+	TotalPage  int         `json:"total_page,omitempty"`
+	NextCursor string      `json:"next_cursor,omitempty"`
+	PrevCursor string      `json:"prev_cursor,omitempty"`
+}
+
+// NewPaginatedResponse wraps a page of a list loaded for the query
+func NewPaginatedResponse(data interface{}, q *PaginationQuery, info PageInfo) PaginatedResponse {
+	response := PaginatedResponse{
+		Data:       data,
+		PageSize:   q.PageSize,
+		Total:      info.Total,
+		NextCursor: info.NextCursor,
+		PrevCursor: info.PrevCursor,
+	}
+	if !q.IsCursor() {
+		response.Page = q.Page
+	}
+	if info.Total != nil && q.PageSize > 0 {
+		response.TotalPage = (int(*info.Total) + q.PageSize - 1) / q.PageSize
+	}
+	return response
 }
 
 // CreateReportRequest represents the request payload for creating a report
 type CreateReportRequest struct {
-	Name        string `json:"name" binding:"required"`
-	RoomID      uint   `json:"room_id" binding:"required"`
-	UserID      *uint  `json:"user_id,omitempty"`
-	ComponentID uint   `json:"component_id" binding:"required"`
-	Status      string `json:"status" binding:"required,oneof=PENDING IN_PROGRESS COMPLETED"`
+	Name        string  `json:"name" binding:"required"`
+	RoomID      uint    `json:"room_id" binding:"required"`
+	UserID      *uint   `json:"user_id,omitempty"`
+	ComponentID uint    `json:"component_id" binding:"required"`
+	Status      string  `json:"status" binding:"required,oneof=PENDING IN_PROGRESS COMPLETED"`
+	RepairCost  float64 `json:"repair_cost" binding:"omitempty,min=0"`
 }
 
 // UpdateReportRequest represents the request payload for updating a report
 type UpdateReportRequest struct {
-	Name        string `json:"name" binding:"omitempty"`
-	RoomID      uint   `json:"room_id" binding:"omitempty"`
-	UserID      *uint  `json:"user_id" binding:"omitempty"`
-	ComponentID uint   `json:"component_id" binding:"omitempty"`
-	Status      string `json:"status" binding:"omitempty,oneof=PENDING IN_PROGRESS COMPLETED"`
+	Name        string   `json:"name" binding:"omitempty"`
+	RoomID      uint     `json:"room_id" binding:"omitempty"`
+	UserID      *uint    `json:"user_id" binding:"omitempty"`
+	ComponentID uint     `json:"component_id" binding:"omitempty"`
+	Status      string   `json:"status" binding:"omitempty,oneof=PENDING IN_PROGRESS COMPLETED"`
+	RepairCost  *float64 `json:"repair_cost" binding:"omitempty,min=0"`
+}
+
+// PatchReportRequest is the patchable state of a report; a null user_id unassigns it
+type PatchReportRequest struct {
+	Name        string  `json:"name" binding:"required"`
+	RoomID      uint    `json:"room_id" binding:"required"`
+	UserID      *uint   `json:"user_id"`
+	ComponentID uint    `json:"component_id" binding:"required"`
+	Status      string  `json:"status" binding:"required,oneof=PENDING IN_PROGRESS COMPLETED"`
+	RepairCost  float64 `json:"repair_cost" binding:"min=0"`
 }
 
 // ReportResponse represents the response payload for a report
 type ReportResponse struct {
-	ID          uint   `json:"id"`
-	Name        string `json:"name"`
-	RoomID      uint   `json:"room_id"`
-	UserID      *uint  `json:"user_id,omitempty"`
-	ComponentID uint   `json:"component_id"`
-	Status      string `json:"status"`
-	CreatedAt   string `json:"created_at"`
-	UpdatedAt   string `json:"updated_at"`
+	ID          uint          `json:"id"`
+	Name        string        `json:"name"`
+	RoomID      uint          `json:"room_id"`
+	UserID      *uint         `json:"user_id,omitempty"`
+	ComponentID uint          `json:"component_id"`
+	Status      string        `json:"status"`
+	RepairCost  float64       `json:"repair_cost"`
+	Tags        []TagResponse `json:"tags,omitempty"`
+	CreatedAt   string        `json:"created_at"`
+	UpdatedAt   string        `json:"updated_at"`
+	CompletedAt *string       `json:"completed_at,omitempty"`
+	Version     uint          `json:"version"`
 }
 
 // AssignUserRequest represents the request payload for assigning a user to a report
 type AssignUserRequest struct {
 	UserID uint `json:"user_id" binding:"required"`
 }
+
+// ReportFilterQuery represents the filters accepted by the report list and export endpoints
+// Tags is a comma separated list of tag names; TagMatch selects reports having any (default) or all of them.
+// The bulk endpoint takes the same filters in its JSON body.
+type ReportFilterQuery struct {
+	Status      string `form:"status" json:"status" binding:"omitempty,oneof=PENDING IN_PROGRESS COMPLETED"`
+	RoomID      uint   `form:"room_id" json:"room_id" binding:"omitempty"`
+	UserID      uint   `form:"user_id" json:"user_id" binding:"omitempty"`
+	ComponentID uint   `form:"component_id" json:"component_id" binding:"omitempty"`
+	Tags        string `form:"tags" json:"tags" binding:"omitempty"`
+	TagMatch    string `form:"tag_match" json:"tag_match" binding:"omitempty,oneof=any all"`
+}
+
+// IsEmpty reports whether the filter selects every report
+func (f *ReportFilterQuery) IsEmpty() bool {
+	return f == nil || (f.Status == "" && f.RoomID == 0 && f.UserID == 0 && f.ComponentID == 0 && f.Tags == "")
+}
+
+// ExportQuery represents the optional export format of a list or detail endpoint
+type ExportQuery struct {
+	Format string `form:"format" binding:"omitempty,oneof=json csv xlsx pdf"`
+}
+
+// CreateTagRequest represents the request payload for creating a tag
+type CreateTagRequest struct {
+	Name  string `json:"name" binding:"required,min=1,max=100"`
+	Color string `json:"color" binding:"omitempty,hexcolor"`
+}
+
+// UpdateTagRequest represents the request payload for updating a tag
+type UpdateTagRequest struct {
+	Name  string `json:"name" binding:"omitempty,min=1,max=100"`
+	Color string `json:"color" binding:"omitempty,hexcolor"`
+}
+
+// PatchTagRequest is the patchable state of a tag
+type PatchTagRequest struct {
+	Name  string `json:"name" binding:"required,min=1,max=100"`
+	Color string `json:"color" binding:"required,hexcolor"`
+}
+
+// TagResponse represents the response payload for a tag
+type TagResponse struct {
+	ID      uint   `json:"id"`
+	Name    string `json:"name"`
+	Color   string `json:"color"`
+	Version uint   `json:"version"`
+}
+
+// ReportTagsRequest represents the request payload for attaching tags to a report
+type ReportTagsRequest struct {
+	TagIDs []uint `json:"tag_ids" binding:"required,min=1"`
+}
@@ -0,0 +1,164 @@
+package utils
+
+import (
+	"errors"
+	"net/http"
+)
+
+// Error codes returned in the code field of every error response
+// Clients should branch on these rather than on the human-readable message.
+const (
+	CodeBadRequest    = "BAD_REQUEST"
+	CodeValidation    = "VALIDATION_FAILED"
+	CodeUnauthorized  = "UNAUTHORIZED"
+	CodeForbidden     = "FORBIDDEN"
+	CodeNotFound      = "NOT_FOUND"
+	CodeConflict      = "CONFLICT"
+	CodePrecondition  = "PRECONDITION_FAILED"
+	CodeMediaType     = "UNSUPPORTED_MEDIA_TYPE"
+	CodeUnprocessable = "UNPROCESSABLE_ENTITY"
+	CodeInternal      = "INTERNAL_ERROR"
+)
+
+// Error kinds of AppError, to be matched with errors.Is
+var (
+	ErrNotFound      = errors.New("not found")
+	ErrConflict      = errors.New("conflict")
+	ErrValidation    = errors.New("validation failed")
+	ErrForbidden     = errors.New("forbidden")
+	ErrStale         = errors.New("precondition failed")
+	ErrUnprocessable = errors.New("unprocessable")
+)
+
+// FieldError describes why a single request field was rejected
+type FieldError struct {
+	Field   string `json:"field"`
+	Rule    string `json:"rule,omitempty"`
+	Message string `json:"message"`
+}
+
+// AppError is a domain error returned by the services
+// Its kind decides the HTTP status; Fields carries per-field details for validation and conflict errors.
+type AppError struct {
+	Kind    error
+	Message string
+	Fields  []FieldError
+	Err     error
+}
+
+// Error returns the human-readable message
+func (e *AppError) Error() string {
+	return e.Message
+}
+
+// Is reports whether target is the kind of this error
+func (e *AppError) Is(target error) bool {
+	return target == e.Kind
+}
+
+// Unwrap returns the underlying cause, if any
+func (e *AppError) Unwrap() error {
+	return e.Err
+}
+
+// NotFound reports that the requested resource does not exist
+func NotFound(resource string) *AppError {
+	return &AppError{Kind: ErrNotFound, Message: resource + " not found"}
+}
+
+// Conflict reports that a field clashes with existing data
+func Conflict(field string, message string) *AppError {
+	return &AppError{
+		Kind:    ErrConflict,
+		Message: message,
+		Fields:  []FieldError{{Field: field, Message: message}},
+	}
+}
+
+// ConflictState reports that the current state of a record does not allow the operation
+func ConflictState(message string) *AppError {
+	return &AppError{Kind: ErrConflict, Message: message}
+}
+
+// Validation reports that the request is invalid
+func Validation(message string, fields ...FieldError) *AppError {
+	return &AppError{Kind: ErrValidation, Message: message, Fields: fields}
+}
+
+// InvalidField reports that a single request field is invalid
+// It is also used when a referenced record such as room_id does not exist.
+func InvalidField(field string, message string) *AppError {
+	return Validation(message, FieldError{Field: field, Message: message})
+}
+
+// Forbidden reports that the caller may not perform the operation
+func Forbidden(message string) *AppError {
+	return &AppError{Kind: ErrForbidden, Message: message}
+}
+
+// PreconditionFailed reports that the record no longer has the version named in If-Match
+func PreconditionFailed(message string) *AppError {
+	return &AppError{Kind: ErrStale, Message: message}
+}
+
+// Unprocessable reports that a valid request cannot be carried out as asked, e.g. an export that is too large
+func Unprocessable(message string) *AppError {
+	return &AppError{Kind: ErrUnprocessable, Message: message}
+}
+
+// HTTPStatus returns the HTTP status an error maps to
+// Errors without a known kind are unexpected and map to 500.
+func HTTPStatus(err error) int {
+	switch {
+	case errors.Is(err, ErrNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, ErrConflict):
+		return http.StatusConflict
+	case errors.Is(err, ErrValidation):
+		return http.StatusBadRequest
+	case errors.Is(err, ErrForbidden):
+		return http.StatusForbidden
+	case errors.Is(err, ErrStale):
+		return http.StatusPreconditionFailed
+	case errors.Is(err, ErrUnprocessable):
+		return http.StatusUnprocessableEntity
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// ErrorCode returns the code an error is reported with, for responses that report several errors
+func ErrorCode(err error) string {
+	return errorCode(HTTPStatus(err), err)
+}
+
+// CodeForStatus returns the error code of a response status, for responses that do not carry one
+func CodeForStatus(status int) string {
+	return errorCode(status, nil)
+}
+
+// errorCode returns the error code of a response status
+func errorCode(status int, err error) string {
+	switch {
+	case errors.Is(err, ErrValidation):
+		return CodeValidation
+	case status == http.StatusUnauthorized:
+		return CodeUnauthorized
+	case status == http.StatusForbidden:
+		return CodeForbidden
+	case status == http.StatusNotFound:
+		return CodeNotFound
+	case status == http.StatusConflict:
+		return CodeConflict
+	case status == http.StatusPreconditionFailed:
+		return CodePrecondition
+	case status == http.StatusUnsupportedMediaType:
+		return CodeMediaType
+	case status == http.StatusUnprocessableEntity:
+		return CodeUnprocessable
+	case status >= http.StatusInternalServerError:
+		return CodeInternal
+	default:
+		return CodeBadRequest
+	}
+}
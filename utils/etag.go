@@ -0,0 +1,48 @@
+package utils
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ETag formats the version of a record as an entity tag
+func ETag(version uint) string {
+	return `"` + strconv.FormatUint(uint64(version), 10) + `"`
+}
+
+// ParseETag reads the version back from an entity tag made by ETag
+// Weak tags (W/"3") are accepted, since a version identifies the record's content either way.
+func ParseETag(tag string) (uint, error) {
+	tag = strings.TrimPrefix(strings.TrimSpace(tag), "W/")
+	if len(tag) < 2 || tag[0] != '"' || tag[len(tag)-1] != '"' {
+		return 0, errors.New("entity tag must be quoted")
+	}
+	version, err := strconv.ParseUint(tag[1:len(tag)-1], 10, 32)
+	if err != nil || version == 0 {
+		return 0, errors.New("entity tag is not a record version")
+	}
+	return uint(version), nil
+}
+
+// SetETag sets the ETag header of the response to the version of the returned record
+func SetETag(c *gin.Context, version uint) {
+	c.Header("ETag", ETag(version))
+}
+
+// expectedVersionKey is the context key of the version named by If-Match
+type expectedVersionKey struct{}
+
+// WithExpectedVersion returns a copy of ctx that requires the record to be at version
+func WithExpectedVersion(ctx context.Context, version uint) context.Context {
+	return context.WithValue(ctx, expectedVersionKey{}, version)
+}
+
+// ExpectedVersionFromContext returns the version the request requires, if it sent If-Match
+func ExpectedVersionFromContext(ctx context.Context) (uint, bool) {
+	version, ok := ctx.Value(expectedVersionKey{}).(uint)
+	return version, ok
+}
@@ -0,0 +1,85 @@
+package utils
+
+import (
+	"mime"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Supported export formats
+const (
+	ExportFormatJSON = "json"
+	ExportFormatCSV  = "csv"
+	ExportFormatXLSX = "xlsx"
+	ExportFormatPDF  = "pdf"
+)
+
+// MaxPDFExportRows is the most rows a PDF list export may hold
+// A PDF is assembled in memory before it is written, unlike CSV and XLSX exports, which stream;
+// larger result sets are refused with 422 and should be narrowed by filter or exported as CSV or XLSX.
+const MaxPDFExportRows = 5000
+
+// exportContentTypes maps each export format to its MIME type
+var exportContentTypes = map[string]string{
+	ExportFormatCSV:  "text/csv",
+	ExportFormatXLSX: "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet",
+	ExportFormatPDF:  "application/pdf",
+}
+
+// acceptableFormats lists the formats an Accept header can ask for
+var acceptableFormats = []string{ExportFormatJSON, ExportFormatCSV, ExportFormatXLSX, ExportFormatPDF}
+
+// ResolveExportFormat determines the requested export format
+// The format query parameter wins; otherwise the Accept header is inspected. The supported type
+// with the highest q-value is picked, and of several with the same q-value the one listed first.
+// JSON is returned when neither asks for a file export.
+func ResolveExportFormat(c *gin.Context, requested string) string {
+	if requested != "" {
+		return requested
+	}
+
+	best, bestQuality := ExportFormatJSON, 0.0
+	for _, entry := range strings.Split(c.GetHeader("Accept"), ",") {
+		mediaType, params, err := mime.ParseMediaType(entry)
+		if err != nil {
+			continue
+		}
+		quality := 1.0
+		if q, ok := params["q"]; ok {
+			if quality, err = strconv.ParseFloat(q, 64); err != nil {
+				continue
+			}
+		}
+		if quality <= bestQuality {
+			continue
+		}
+		for _, format := range acceptableFormats {
+			if mediaType == mediaTypeOf(format) {
+				best, bestQuality = format, quality
+			}
+		}
+	}
+
+	return best
+}
+
+// mediaTypeOf returns the MIME type a format is asked for by
+func mediaTypeOf(format string) string {
+	if format == ExportFormatJSON {
+		return "application/json"
+	}
+	return exportContentTypes[format]
+}
+
+// ExportContentType returns the MIME type for an export format
+func ExportContentType(format string) string {
+	return exportContentTypes[format]
+}
+
+// SetExportHeaders prepares the response headers for a file download
+func SetExportHeaders(c *gin.Context, format string, baseName string) {
+	c.Header("Content-Type", ExportContentType(format))
+	c.Header("Content-Disposition", "attachment; filename=\""+baseName+"."+format+"\"")
+}
@@ -0,0 +1,135 @@
+package utils
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
+)
+
+// fieldsKey is the context key of the response fields a request asked for
+type fieldsKey struct{}
+
+// fieldName matches the name of a JSON field of a response
+var fieldName = regexp.MustCompile(`^[a-z][a-z0-9_]*$`)
+
+// WithFields returns a copy of ctx that asks for the given top-level response fields only
+func WithFields(ctx context.Context, fields []string) context.Context {
+	return context.WithValue(ctx, fieldsKey{}, fields)
+}
+
+// FieldsFromContext returns the response fields the request asked for, if it sent fields=
+func FieldsFromContext(ctx context.Context) ([]string, bool) {
+	fields, ok := ctx.Value(fieldsKey{}).([]string)
+	return fields, ok && len(fields) > 0
+}
+
+// ParseFields parses a comma separated list of response field names
+func ParseFields(s string) ([]string, error) {
+	var fields []string
+	seen := map[string]bool{}
+	for _, name := range strings.Split(s, ",") {
+		name = strings.TrimSpace(name)
+		if name == "" || seen[name] {
+			continue
+		}
+		if !fieldName.MatchString(name) {
+			return nil, InvalidField("fields", fmt.Sprintf("%q is not a field name", name))
+		}
+		seen[name] = true
+		fields = append(fields, name)
+	}
+	return fields, nil
+}
+
+// SelectFields keeps only the given top-level fields of a response
+// A paginated response keeps its envelope and narrows every record of the page. Fields the
+// response type doesn't have are rejected; nested objects are kept or dropped as a whole.
+func SelectFields(data interface{}, fields []string) (interface{}, error) {
+	switch page := data.(type) {
+	case PaginatedResponse:
+		selected, err := SelectFields(page.Data, fields)
+		page.Data = selected
+		return page, err
+	case *PaginatedResponse:
+		narrowed := *page
+		return SelectFields(narrowed, fields)
+	}
+
+	if names, ok := jsonFieldNames(reflect.TypeOf(data)); ok {
+		for _, field := range fields {
+			if !names[field] {
+				return nil, InvalidField("fields", fmt.Sprintf("unknown field %q", field))
+			}
+		}
+	}
+
+	raw, err := json.Marshal(data)
+	if err != nil {
+		return nil, err
+	}
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	decoder.UseNumber()
+	var value interface{}
+	if err := decoder.Decode(&value); err != nil {
+		return nil, err
+	}
+
+	keep := make(map[string]bool, len(fields))
+	for _, field := range fields {
+		keep[field] = true
+	}
+	switch v := value.(type) {
+	case map[string]interface{}:
+		pruneFields(v, keep)
+	case []interface{}:
+		for _, item := range v {
+			if record, ok := item.(map[string]interface{}); ok {
+				pruneFields(record, keep)
+			}
+		}
+	}
+	return value, nil
+}
+
+// pruneFields deletes the fields of a record that are not kept
+func pruneFields(record map[string]interface{}, keep map[string]bool) {
+	for name := range record {
+		if !keep[name] {
+			delete(record, name)
+		}
+	}
+}
+
+// jsonFieldNames lists the JSON field names of a struct, or of the elements of a slice of structs
+// It reports false for any other type, whose fields are only known once it is encoded.
+func jsonFieldNames(t reflect.Type) (map[string]bool, bool) {
+	for t != nil && (t.Kind() == reflect.Pointer || t.Kind() == reflect.Slice || t.Kind() == reflect.Array) {
+		t = t.Elem()
+	}
+	if t == nil || t.Kind() != reflect.Struct {
+		return nil, false
+	}
+
+	names := map[string]bool{}
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		switch {
+		case name == "-" || !field.IsExported():
+		case field.Anonymous && name == "":
+			embedded, _ := jsonFieldNames(field.Type)
+			for n := range embedded {
+				names[n] = true
+			}
+		case name == "":
+			names[field.Name] = true
+		default:
+			names[name] = true
+		}
+	}
+	return names, true
+}
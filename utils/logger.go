@@ -0,0 +1,23 @@
+package utils
+
+import (
+	"context"
+	"log/slog"
+)
+
+// loggerKey is the context key of the request-scoped logger
+type loggerKey struct{}
+
+// WithLogger returns a copy of ctx that carries the logger
+func WithLogger(ctx context.Context, logger *slog.Logger) context.Context {
+	return context.WithValue(ctx, loggerKey{}, logger)
+}
+
+// Logger returns the logger of the request ctx belongs to, tagged with its request ID and user
+// Outside of a request it returns the default logger.
+func Logger(ctx context.Context) *slog.Logger {
+	if logger, ok := ctx.Value(loggerKey{}).(*slog.Logger); ok {
+		return logger
+	}
+	return slog.Default()
+}
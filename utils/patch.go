@@ -0,0 +1,94 @@
+package utils
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"strings"
+)
+
+// MergePatchContentType is the media type of a JSON Merge Patch (RFC 7396)
+const MergePatchContentType = "application/merge-patch+json"
+
+// IsMergePatch reports whether a request body of the content type can be applied as a merge patch
+// Plain JSON is accepted too, since most clients send it without thinking about patch formats.
+func IsMergePatch(contentType string) bool {
+	return contentType == MergePatchContentType || contentType == "application/json"
+}
+
+// MergePatch applies a JSON Merge Patch to a JSON document
+// Members of the patch replace those of the document, objects are merged recursively,
+// and a null member removes the member from the document.
+func MergePatch(doc, patch []byte) ([]byte, error) {
+	var target, changes any
+	if err := json.Unmarshal(doc, &target); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(patch, &changes); err != nil {
+		return nil, err
+	}
+	return json.Marshal(mergeValue(target, changes))
+}
+
+// mergeValue is the MergePatch algorithm of RFC 7396 on decoded JSON
+func mergeValue(target, patch any) any {
+	changes, ok := patch.(map[string]any)
+	if !ok {
+		return patch
+	}
+
+	merged, ok := target.(map[string]any)
+	if !ok {
+		merged = map[string]any{}
+	}
+	for name, value := range changes {
+		if value == nil {
+			delete(merged, name)
+			continue
+		}
+		merged[name] = mergeValue(merged[name], value)
+	}
+	return merged
+}
+
+// ApplyMergePatch merges a patch into the request struct holding the current state of a record
+// A member set to null resets the field to its zero value, so optional fields can be cleared;
+// members the patch leaves out keep their current value. The result is validated with the
+// struct's binding rules, like a bound request body.
+func ApplyMergePatch(req any, patch []byte) error {
+	var changes map[string]any
+	if err := json.Unmarshal(patch, &changes); err != nil || changes == nil {
+		return Validation("patch must be a JSON object")
+	}
+
+	current, err := json.Marshal(req)
+	if err != nil {
+		return err
+	}
+	merged, err := MergePatch(current, patch)
+	if err != nil {
+		return err
+	}
+
+	// Decode into a zero value, so that removed members do not keep their current value
+	patched := reflect.New(reflect.TypeOf(req).Elem())
+	decoder := json.NewDecoder(bytes.NewReader(merged))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(patched.Interface()); err != nil {
+		if field, ok := strings.CutPrefix(err.Error(), `json: unknown field "`); ok {
+			field = strings.TrimSuffix(field, `"`)
+			return Validation(err.Error(), FieldError{Field: field, Rule: "unknown", Message: "cannot be changed"})
+		}
+		if fields := bindingFieldErrors(err); len(fields) > 0 {
+			return Validation(err.Error(), fields...)
+		}
+		return Validation(err.Error())
+	}
+
+	if err := Validate(patched.Interface()); err != nil {
+		return err
+	}
+
+	reflect.ValueOf(req).Elem().Set(patched.Elem())
+	return nil
+}
@@ -0,0 +1,47 @@
+package utils
+
+// ===== Reliability DTOs =====
+
+// ComponentReliability represents the failure history of a single component
+// A failure is any report filed against the component.
+type ComponentReliability struct {
+	ComponentID     uint     `json:"component_id"`
+	Code            string   `json:"code"`
+	Name            string   `json:"name"`
+	CategoryID      uint     `json:"category_id"`
+	ProcurementYear int      `json:"procurement_year"`
+	AgeYears        *int     `json:"age_years"`
+	Failures        int64    `json:"failures"`
+	FailuresPerYear float64  `json:"failures_per_year"`
+	MTBFDays        *float64 `json:"mtbf_days"`
+	RepairCost      float64  `json:"repair_cost"`
+}
+
+// CategoryReliability represents the aggregated failure history of a component category
+type CategoryReliability struct {
+	CategoryID      uint     `json:"category_id"`
+	Code            string   `json:"code"`
+	Name            string   `json:"name"`
+	Components      int64    `json:"components"`
+	AverageAgeYears *float64 `json:"average_age_years"`
+	Failures        int64    `json:"failures"`
+	MTBFDays        *float64 `json:"mtbf_days"`
+	RepairCost      float64  `json:"repair_cost"`
+}
+
+// ReplacementCandidatesQuery represents the query parameters of the replacement candidates endpoint
+// The weights control how much age, failure frequency and repair cost contribute to the score.
+type ReplacementCandidatesQuery struct {
+	CategoryID    uint     `form:"category_id" binding:"omitempty"`
+	Limit         int      `form:"limit" binding:"omitempty,min=1,max=100"`
+	AgeWeight     *float64 `form:"age_weight" binding:"omitempty,min=0"`
+	FailureWeight *float64 `form:"failure_weight" binding:"omitempty,min=0"`
+	CostWeight    *float64 `form:"cost_weight" binding:"omitempty,min=0"`
+}
+
+// ReplacementCandidate represents a component ranked for replacement
+// Score is between 0 and 1; higher means the component should be replaced sooner.
+type ReplacementCandidate struct {
+	ComponentReliability
+	Score float64 `json:"score"`
+}
@@ -0,0 +1,23 @@
+package utils
+
+import "context"
+
+// RequestInfo identifies the HTTP request a change was made in
+type RequestInfo struct {
+	ID string
+	IP string
+}
+
+// requestInfoKey is the context key of the current RequestInfo
+type requestInfoKey struct{}
+
+// WithRequestInfo returns a copy of ctx that carries the request info
+func WithRequestInfo(ctx context.Context, info RequestInfo) context.Context {
+	return context.WithValue(ctx, requestInfoKey{}, info)
+}
+
+// RequestInfoFromContext returns the request info, if ctx belongs to an HTTP request
+func RequestInfoFromContext(ctx context.Context) (RequestInfo, bool) {
+	info, ok := ctx.Value(requestInfoKey{}).(RequestInfo)
+	return info, ok
+}
@@ -1,19 +1,37 @@
 package utils
 
 import (
+	"errors"
+	"net/http"
+
 	"github.com/gin-gonic/gin"
 )
 
 // ResponseData represents a standard API response structure
+// Error responses carry a stable Code and, for validation and conflict errors, per-field Details.
 type ResponseData struct {
-	Success bool        `json:"success"`
-	Message string      `json:"message"`
-	Data    interface{} `json:"data,omitempty"`
-	Error   string      `json:"error,omitempty"`
+	Success bool         `json:"success"`
+	Message string       `json:"message"`
+	Data    interface{}  `json:"data,omitempty"`
+	Code    string       `json:"code,omitempty"`
+	Error   string       `json:"error,omitempty"`
+	Details []FieldError `json:"details,omitempty"`
 }
 
 // SuccessResponse returns a success response with data
+// When the request asked for a sparse fieldset (fields=), the data keeps only those fields.
 func SuccessResponse(c *gin.Context, statusCode int, message string, data interface{}) {
+	if c.Request != nil {
+		if fields, ok := FieldsFromContext(c.Request.Context()); ok {
+			selected, err := SelectFields(data, fields)
+			if err != nil {
+				HandleError(c, "Invalid fields", err)
+				return
+			}
+			data = selected
+		}
+	}
+
 	c.JSON(statusCode, ResponseData{
 		Success: true,
 		Message: message,
@@ -22,10 +40,64 @@ func SuccessResponse(c *gin.Context, statusCode int, message string, data interf
 }
 
 // ErrorResponse returns an error response with an error message
+// The error code is derived from the status code.
 func ErrorResponse(c *gin.Context, statusCode int, message string, err string) {
 	c.JSON(statusCode, ResponseData{
 		Success: false,
 		Message: message,
+		Code:    errorCode(statusCode, nil),
 		Error:   err,
 	})
 }
+
+// HandleError returns the error response for an error returned by a service
+// The status and code follow the error's kind, see HTTPStatus. Unexpected errors are attached to
+// the request, so the request log line names them.
+func HandleError(c *gin.Context, message string, err error) {
+	status := HTTPStatus(err)
+	if status >= http.StatusInternalServerError {
+		_ = c.Error(err)
+	}
+	response := ResponseData{
+		Success: false,
+		Message: message,
+		Code:    errorCode(status, err),
+		Error:   err.Error(),
+	}
+
+	var appErr *AppError
+	if errors.As(err, &appErr) {
+		response.Details = appErr.Fields
+	}
+
+	c.JSON(status, response)
+}
+
+// BulkResultResponse returns the per-record outcome of a bulk request
+// A rolled back atomic request is a 409 conflict that still carries the outcome, so the client
+// can see which records failed.
+func BulkResultResponse(c *gin.Context, message string, result *BulkResponse) {
+	if result.Committed {
+		SuccessResponse(c, http.StatusOK, message, result)
+		return
+	}
+
+	c.JSON(http.StatusConflict, ResponseData{
+		Success: false,
+		Message: message,
+		Data:    result,
+		Code:    CodeConflict,
+		Error:   "the bulk request was rolled back because some records failed",
+	})
+}
+
+// BindingError returns a 400 response for a request body or query that failed to bind
+// Validator failures list every rejected field; malformed input is reported as a bad request.
+func BindingError(c *gin.Context, message string, err error) {
+	if fields := bindingFieldErrors(err); len(fields) > 0 {
+		HandleError(c, message, Validation(err.Error(), fields...))
+		return
+	}
+
+	ErrorResponse(c, http.StatusBadRequest, message, err.Error())
+}
@@ -0,0 +1,36 @@
+package utils
+
+import "time"
+
+// ===== Trash DTOs =====
+
+// TrashQuery represents the query parameters of the trash listing
+// Without a type, deleted records of every type are listed together.
+type TrashQuery struct {
+	PaginationQuery
+	Type string `form:"type" binding:"omitempty,oneof=buildings floors rooms component-categories components reports users"`
+}
+
+// TrashItemResponse represents a deleted record in the trash
+// DeletedAt is empty once the record has been restored.
+type TrashItemResponse struct {
+	Type      string     `json:"type"`
+	ID        uint       `json:"id"`
+	Name      string     `json:"name"`
+	DeletedAt *time.Time `json:"deleted_at,omitempty"`
+}
+
+// DeleteQuery represents the query parameters of a delete that may cascade
+type DeleteQuery struct {
+	Cascade bool `form:"cascade"`
+}
+
+// DeleteImpactResponse counts the records a cascading delete would take with it
+// OpenReports are included in Reports; while there are any, the delete is refused.
+type DeleteImpactResponse struct {
+	Floors      int   `json:"floors"`
+	Rooms       int   `json:"rooms"`
+	Components  int   `json:"components"`
+	Reports     int   `json:"reports"`
+	OpenReports int64 `json:"open_reports"`
+}